@@ -19,7 +19,8 @@ func main() {
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "http://localhost:3000, https://wastetrack-staging.netlify.app",
 		AllowMethods:     "GET,POST,HEAD,PUT,DELETE,PATCH,OPTIONS",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,Access-Control-Request-Method,Access-Control-Request-Headers",
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,Access-Control-Request-Method,Access-Control-Request-Headers,Idempotency-Key",
+		ExposeHeaders:    "Idempotent-Replayed",
 		AllowCredentials: true,
 	}))
 
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_waste_drop_request_status_history_request_id;
+DROP TABLE IF EXISTS waste_drop_request_status_history;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS waste_drop_request_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    request_id UUID NOT NULL REFERENCES waste_drop_requests(id) ON DELETE CASCADE,
+    from_status request_status,
+    to_status request_status NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    actor_role TEXT,
+    reason TEXT,
+    location GEOGRAPHY(Point, 4326),
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_waste_drop_request_status_history_request_id ON waste_drop_request_status_history(request_id, created_at);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS ledger_drifts;
+DROP TRIGGER IF EXISTS trg_ledger_lines_immutable ON ledger_lines;
+DROP TRIGGER IF EXISTS trg_ledger_entries_immutable ON ledger_entries;
+DROP FUNCTION IF EXISTS prevent_ledger_mutation();
+DROP TABLE IF EXISTS ledger_lines;
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_accounts;
+
+DROP TYPE IF EXISTS ledger_direction;
+DROP TYPE IF EXISTS ledger_asset;
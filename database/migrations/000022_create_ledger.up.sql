@@ -0,0 +1,113 @@
+DO $$ 
+BEGIN
+    -- Ledger assets
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'ledger_asset') THEN
+        CREATE TYPE ledger_asset AS ENUM ('points', 'rupiah');
+    END IF;
+
+    -- Ledger line directions
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'ledger_direction') THEN
+        CREATE TYPE ledger_direction AS ENUM ('debit', 'credit');
+    END IF;
+END $$;
+
+-- One account per user per asset, system accounts are identified by code instead of user
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    code TEXT,
+    asset ledger_asset NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK ((user_id IS NULL) <> (code IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_accounts_user_asset ON ledger_accounts(user_id, asset) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_accounts_code_asset ON ledger_accounts(code, asset) WHERE code IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    entry_type TEXT NOT NULL,
+    reference_type TEXT,
+    reference_id UUID,
+    description TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_reference ON ledger_entries(reference_type, reference_id);
+
+CREATE TABLE IF NOT EXISTS ledger_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    entry_id UUID NOT NULL REFERENCES ledger_entries(id) ON DELETE RESTRICT,
+    account_id UUID NOT NULL REFERENCES ledger_accounts(id) ON DELETE RESTRICT,
+    direction ledger_direction NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_lines_account ON ledger_lines(account_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_ledger_lines_entry ON ledger_lines(entry_id);
+
+-- Journal entries and their lines are immutable, corrections are posted as new entries
+CREATE OR REPLACE FUNCTION prevent_ledger_mutation() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger records are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_ledger_entries_immutable ON ledger_entries;
+CREATE TRIGGER trg_ledger_entries_immutable BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION prevent_ledger_mutation();
+
+DROP TRIGGER IF EXISTS trg_ledger_lines_immutable ON ledger_lines;
+CREATE TRIGGER trg_ledger_lines_immutable BEFORE UPDATE OR DELETE ON ledger_lines
+    FOR EACH ROW EXECUTE FUNCTION prevent_ledger_mutation();
+
+-- Drift between users.points / users.balance and the ledger, flagged by the reconciliation job
+CREATE TABLE IF NOT EXISTS ledger_drifts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    asset ledger_asset NOT NULL,
+    cached_balance BIGINT NOT NULL,
+    ledger_balance BIGINT NOT NULL,
+    is_resolved BOOLEAN DEFAULT FALSE,
+    detected_at TIMESTAMPTZ DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_drifts_user_asset ON ledger_drifts(user_id, asset, is_resolved);
+
+-- Opening balances so the ledger matches the existing cached columns
+INSERT INTO ledger_accounts (code, asset) VALUES ('opening_balance', 'points'), ('opening_balance', 'rupiah')
+ON CONFLICT DO NOTHING;
+
+DO $$
+DECLARE
+    u RECORD;
+    opening_points UUID;
+    opening_rupiah UUID;
+    user_account UUID;
+    new_entry UUID;
+BEGIN
+    SELECT id INTO opening_points FROM ledger_accounts WHERE code = 'opening_balance' AND asset = 'points';
+    SELECT id INTO opening_rupiah FROM ledger_accounts WHERE code = 'opening_balance' AND asset = 'rupiah';
+
+    FOR u IN SELECT id, COALESCE(points, 0) AS points, COALESCE(balance, 0) AS balance FROM users LOOP
+        IF u.points <> 0 THEN
+            INSERT INTO ledger_accounts (user_id, asset) VALUES (u.id, 'points') RETURNING id INTO user_account;
+            INSERT INTO ledger_entries (entry_type, reference_type, reference_id, description)
+                VALUES ('opening_balance', 'user', u.id, 'Opening points balance') RETURNING id INTO new_entry;
+            INSERT INTO ledger_lines (entry_id, account_id, direction, amount) VALUES
+                (new_entry, user_account, CASE WHEN u.points > 0 THEN 'credit' ELSE 'debit' END::ledger_direction, ABS(u.points)),
+                (new_entry, opening_points, CASE WHEN u.points > 0 THEN 'debit' ELSE 'credit' END::ledger_direction, ABS(u.points));
+        END IF;
+
+        IF u.balance <> 0 THEN
+            INSERT INTO ledger_accounts (user_id, asset) VALUES (u.id, 'rupiah') RETURNING id INTO user_account;
+            INSERT INTO ledger_entries (entry_type, reference_type, reference_id, description)
+                VALUES ('opening_balance', 'user', u.id, 'Opening rupiah balance') RETURNING id INTO new_entry;
+            INSERT INTO ledger_lines (entry_id, account_id, direction, amount) VALUES
+                (new_entry, user_account, CASE WHEN u.balance > 0 THEN 'credit' ELSE 'debit' END::ledger_direction, ABS(u.balance)),
+                (new_entry, opening_rupiah, CASE WHEN u.balance > 0 THEN 'debit' ELSE 'credit' END::ledger_direction, ABS(u.balance));
+        END IF;
+    END LOOP;
+END $$;
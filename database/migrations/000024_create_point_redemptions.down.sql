@@ -0,0 +1,51 @@
+DO $$ 
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'conversion_status') THEN
+        CREATE TYPE conversion_status AS ENUM ('pending','completed', 'rejected');
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS point_conversions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    status conversion_status DEFAULT 'pending',
+    is_deleted BOOLEAN DEFAULT FALSE
+);
+
+-- Redemptions with a waste bank go back to salary transactions, the rest to point conversions
+INSERT INTO salary_transactions (id, sender_id, receiver_id, transaction_type, amount, created_at, status, notes, is_deleted)
+SELECT
+    id,
+    user_id,
+    waste_bank_id,
+    'point_conversion',
+    points,
+    created_at,
+    CASE status WHEN 'approved' THEN 'completed' WHEN 'pending' THEN 'pending' ELSE 'failed' END::transaction_status,
+    notes,
+    is_deleted
+FROM point_redemptions
+WHERE waste_bank_id IS NOT NULL
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO point_conversions (id, user_id, amount, created_at, status, is_deleted)
+SELECT
+    id,
+    user_id,
+    points,
+    created_at,
+    CASE status WHEN 'approved' THEN 'completed' WHEN 'pending' THEN 'pending' ELSE 'rejected' END::conversion_status,
+    is_deleted
+FROM point_redemptions
+WHERE waste_bank_id IS NULL
+ON CONFLICT (id) DO NOTHING;
+
+DROP INDEX IF EXISTS idx_point_redemptions_bank_status;
+DROP INDEX IF EXISTS idx_point_redemptions_user;
+DROP TABLE IF EXISTS point_redemptions;
+DROP INDEX IF EXISTS idx_point_conversion_rates_bank;
+DROP TABLE IF EXISTS point_conversion_rates;
+DROP TYPE IF EXISTS redemption_limit_period;
+DROP TYPE IF EXISTS redemption_status;
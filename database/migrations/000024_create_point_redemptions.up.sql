@@ -0,0 +1,100 @@
+DO $$ 
+BEGIN
+    -- Redemption status
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'redemption_status') THEN
+        CREATE TYPE redemption_status AS ENUM ('pending', 'approved', 'rejected', 'cancelled');
+    END IF;
+
+    -- Period a redemption limit applies to
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'redemption_limit_period') THEN
+        CREATE TYPE redemption_limit_period AS ENUM ('day', 'week', 'month');
+    END IF;
+END $$;
+
+-- A rate without waste_bank_id is the default for banks that have none of their own
+CREATE TABLE IF NOT EXISTS point_conversion_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    waste_bank_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    rupiah_per_point NUMERIC(12, 4) NOT NULL CHECK (rupiah_per_point > 0),
+    min_points BIGINT NOT NULL DEFAULT 1 CHECK (min_points > 0),
+    max_points BIGINT CHECK (max_points IS NULL OR max_points >= min_points),
+    period_limit_points BIGINT CHECK (period_limit_points IS NULL OR period_limit_points > 0),
+    limit_period redemption_limit_period NOT NULL DEFAULT 'month',
+    effective_from TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_point_conversion_rates_bank ON point_conversion_rates(waste_bank_id, effective_from DESC);
+
+CREATE TABLE IF NOT EXISTS point_redemptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    waste_bank_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    rate_id UUID REFERENCES point_conversion_rates(id) ON DELETE SET NULL,
+    points BIGINT NOT NULL CHECK (points > 0),
+    rupiah_per_point NUMERIC(12, 4) NOT NULL,
+    rupiah_amount BIGINT NOT NULL,
+    status redemption_status NOT NULL DEFAULT 'pending',
+    reason TEXT,
+    notes TEXT,
+    hold_entry_id UUID REFERENCES ledger_entries(id) ON DELETE RESTRICT,
+    decided_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    decided_at TIMESTAMPTZ,
+    legacy_source TEXT,
+    is_deleted BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_point_redemptions_user ON point_redemptions(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_point_redemptions_bank_status ON point_redemptions(waste_bank_id, status);
+
+-- Both legacy flows converted points to rupiah one to one
+INSERT INTO point_conversion_rates (rupiah_per_point, min_points)
+SELECT 1, 1
+WHERE NOT EXISTS (SELECT 1 FROM point_conversion_rates WHERE waste_bank_id IS NULL);
+
+-- Migrate legacy records, keeping their IDs so existing ledger references still resolve
+INSERT INTO point_redemptions (id, user_id, waste_bank_id, points, rupiah_per_point, rupiah_amount, status, decided_at, legacy_source, is_deleted, created_at, updated_at)
+SELECT
+    id,
+    user_id,
+    NULL,
+    amount,
+    1,
+    amount,
+    CASE status::TEXT WHEN 'completed' THEN 'approved' WHEN 'rejected' THEN 'rejected' ELSE 'pending' END::redemption_status,
+    CASE WHEN status::TEXT <> 'pending' THEN created_at END,
+    'point_conversions',
+    COALESCE(is_deleted, FALSE),
+    created_at,
+    created_at
+FROM point_conversions
+WHERE amount > 0
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO point_redemptions (id, user_id, waste_bank_id, points, rupiah_per_point, rupiah_amount, status, notes, decided_at, legacy_source, is_deleted, created_at, updated_at)
+SELECT
+    id,
+    sender_id,
+    receiver_id,
+    amount,
+    1,
+    amount,
+    CASE status::TEXT WHEN 'completed' THEN 'approved' WHEN 'failed' THEN 'rejected' ELSE 'pending' END::redemption_status,
+    notes,
+    CASE WHEN status::TEXT <> 'pending' THEN created_at END,
+    'salary_transactions',
+    COALESCE(is_deleted, FALSE),
+    created_at,
+    created_at
+FROM salary_transactions
+WHERE transaction_type = 'point_conversion' AND amount > 0
+ON CONFLICT (id) DO NOTHING;
+
+DELETE FROM salary_transactions WHERE transaction_type = 'point_conversion';
+
+DROP TABLE IF EXISTS point_conversions;
+DROP TYPE IF EXISTS conversion_status;
@@ -0,0 +1,3 @@
+ALTER TABLE waste_drop_request_items DROP COLUMN IF EXISTS price_version_id;
+DROP INDEX IF EXISTS idx_waste_bank_price_versions_lookup;
+DROP TABLE IF EXISTS waste_bank_price_versions;
@@ -0,0 +1,33 @@
+-- Versions keep their bank and type so history survives deleting the priced type
+CREATE TABLE IF NOT EXISTS waste_bank_price_versions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    priced_type_id UUID REFERENCES waste_bank_priced_types(id) ON DELETE SET NULL,
+    waste_bank_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    waste_type_id UUID NOT NULL REFERENCES waste_types(id) ON DELETE CASCADE,
+    price_per_kgs BIGINT NOT NULL CHECK (price_per_kgs >= 0),
+    effective_from TIMESTAMPTZ NOT NULL,
+    effective_to TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (effective_to IS NULL OR effective_to > effective_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_waste_bank_price_versions_lookup ON waste_bank_price_versions(waste_bank_id, waste_type_id, effective_from DESC);
+
+-- Current prices become the first version
+INSERT INTO waste_bank_price_versions (priced_type_id, waste_bank_id, waste_type_id, price_per_kgs, effective_from)
+SELECT id, waste_bank_id, waste_type_id, COALESCE(custom_price_per_kgs, 0), COALESCE(created_at, NOW())
+FROM waste_bank_priced_types;
+
+ALTER TABLE waste_drop_request_items
+    ADD COLUMN IF NOT EXISTS price_version_id UUID REFERENCES waste_bank_price_versions(id) ON DELETE SET NULL;
+
+-- Link items of open requests to the version their price came from
+UPDATE waste_drop_request_items i
+SET price_version_id = v.id
+FROM waste_drop_requests r, waste_bank_price_versions v
+WHERE i.request_id = r.id
+  AND v.waste_bank_id = r.waste_bank_id
+  AND v.waste_type_id = i.waste_type_id
+  AND v.price_per_kgs = i.verified_price_per_kgs
+  AND i.price_version_id IS NULL;
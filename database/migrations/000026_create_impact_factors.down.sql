@@ -0,0 +1,5 @@
+ALTER TABLE customer_profiles DROP COLUMN IF EXISTS energy_saved;
+DROP INDEX IF EXISTS idx_impact_factors_default;
+DROP INDEX IF EXISTS idx_impact_factors_category;
+DROP INDEX IF EXISTS idx_impact_factors_type;
+DROP TABLE IF EXISTS impact_factors;
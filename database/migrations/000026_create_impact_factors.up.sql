@@ -0,0 +1,28 @@
+-- Per kg coefficients. A factor is matched by waste type first, then by category, then the default with neither
+CREATE TABLE IF NOT EXISTS impact_factors (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    waste_type_id UUID REFERENCES waste_types(id) ON DELETE CASCADE,
+    waste_category_id UUID REFERENCES waste_categories(id) ON DELETE CASCADE,
+    co2e_per_kg NUMERIC(12, 4) NOT NULL DEFAULT 0 CHECK (co2e_per_kg >= 0),
+    water_liters_per_kg NUMERIC(12, 4) NOT NULL DEFAULT 0 CHECK (water_liters_per_kg >= 0),
+    energy_kwh_per_kg NUMERIC(12, 4) NOT NULL DEFAULT 0 CHECK (energy_kwh_per_kg >= 0),
+    trees_per_kg NUMERIC(12, 6) NOT NULL DEFAULT 0 CHECK (trees_per_kg >= 0),
+    source TEXT,
+    -- NULL until the change has been applied to historical customer totals
+    applied_at TIMESTAMPTZ,
+    is_deleted BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (waste_type_id IS NULL OR waste_category_id IS NULL)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_impact_factors_type ON impact_factors(waste_type_id) WHERE waste_type_id IS NOT NULL AND is_deleted = FALSE;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_impact_factors_category ON impact_factors(waste_category_id) WHERE waste_category_id IS NOT NULL AND is_deleted = FALSE;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_impact_factors_default ON impact_factors((TRUE)) WHERE waste_type_id IS NULL AND waste_category_id IS NULL AND is_deleted = FALSE;
+
+-- The default keeps the coefficients that used to be hard-coded
+INSERT INTO impact_factors (co2e_per_kg, water_liters_per_kg, energy_kwh_per_kg, trees_per_kg, source, applied_at)
+SELECT 2.5, 1000, 0, 0.1, 'Legacy default', NOW()
+WHERE NOT EXISTS (SELECT 1 FROM impact_factors WHERE waste_type_id IS NULL AND waste_category_id IS NULL);
+
+ALTER TABLE customer_profiles ADD COLUMN IF NOT EXISTS energy_saved BIGINT DEFAULT 0;
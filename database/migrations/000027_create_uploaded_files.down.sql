@@ -0,0 +1,6 @@
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_thumbnail_url;
+ALTER TABLE waste_transfer_requests DROP COLUMN IF EXISTS image_thumbnail_url;
+ALTER TABLE waste_drop_requests DROP COLUMN IF EXISTS image_thumbnail_url;
+
+DROP TABLE IF EXISTS uploaded_files;
+DROP TYPE IF EXISTS file_owner_type;
@@ -0,0 +1,32 @@
+DO $$ 
+BEGIN
+    -- Record an uploaded file is attached to
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'file_owner_type') THEN
+        CREATE TYPE file_owner_type AS ENUM ('waste_drop_request', 'waste_transfer_request', 'user_avatar');
+    END IF;
+END $$;
+
+-- Files stored through the file storage backend. A file whose owner is deleted or no longer
+-- points at its url is orphaned and removed by the cleanup job.
+CREATE TABLE IF NOT EXISTS uploaded_files (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    owner_type file_owner_type NOT NULL,
+    owner_id UUID NOT NULL,
+    object_name TEXT NOT NULL,
+    url TEXT NOT NULL,
+    thumbnail_object_name TEXT,
+    thumbnail_url TEXT,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    width INT NOT NULL DEFAULT 0,
+    height INT NOT NULL DEFAULT 0,
+    uploaded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploaded_files_owner ON uploaded_files(owner_type, owner_id);
+CREATE INDEX IF NOT EXISTS idx_uploaded_files_created_at ON uploaded_files(created_at);
+
+ALTER TABLE waste_drop_requests ADD COLUMN IF NOT EXISTS image_thumbnail_url TEXT;
+ALTER TABLE waste_transfer_requests ADD COLUMN IF NOT EXISTS image_thumbnail_url TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_thumbnail_url TEXT;
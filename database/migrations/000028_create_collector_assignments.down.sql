@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_waste_drop_request_status_history_actor;
+DROP TABLE IF EXISTS collector_assignments;
+
+ALTER TABLE collector_managements DROP COLUMN IF EXISTS daily_capacity;
+
+ALTER TABLE waste_bank_profiles
+    DROP COLUMN IF EXISTS auto_assign_max_distance_km,
+    DROP COLUMN IF EXISTS auto_assign_collectors;
@@ -0,0 +1,32 @@
+-- Waste banks opt in to automatic collector assignment for pickups
+ALTER TABLE waste_bank_profiles
+    ADD COLUMN IF NOT EXISTS auto_assign_collectors BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS auto_assign_max_distance_km NUMERIC(8, 2) CHECK (auto_assign_max_distance_km IS NULL OR auto_assign_max_distance_km > 0);
+
+-- Requests a collector may take per appointment day, counted across all of their banks
+ALTER TABLE collector_managements
+    ADD COLUMN IF NOT EXISTS daily_capacity INT NOT NULL DEFAULT 10 CHECK (daily_capacity > 0);
+
+-- One row per automatic assignment, recording the inputs that made the collector win
+CREATE TABLE IF NOT EXISTS collector_assignments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    request_id UUID NOT NULL REFERENCES waste_drop_requests(id) ON DELETE CASCADE,
+    waste_bank_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    collector_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    trigger VARCHAR(20) NOT NULL CHECK (trigger IN ('create', 'sweep', 'manual')),
+    score NUMERIC(12, 4) NOT NULL,
+    distance_meters NUMERIC(12, 2),
+    location_source VARCHAR(30),
+    window_load INT NOT NULL DEFAULT 0,
+    day_load INT NOT NULL DEFAULT 0,
+    daily_capacity INT NOT NULL,
+    candidate_count INT NOT NULL DEFAULT 0,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_collector_assignments_request ON collector_assignments(request_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_collector_assignments_bank ON collector_assignments(waste_bank_id, created_at DESC);
+
+-- Used to find a collector's last reported location
+CREATE INDEX IF NOT EXISTS idx_waste_drop_request_status_history_actor ON waste_drop_request_status_history(actor_id, created_at DESC) WHERE location IS NOT NULL;
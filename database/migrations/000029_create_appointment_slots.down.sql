@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_waste_drop_requests_bank_slot;
+DROP TABLE IF EXISTS waste_bank_schedule_exceptions;
+
+ALTER TABLE waste_drop_requests DROP COLUMN IF EXISTS is_waitlisted;
+
+ALTER TABLE waste_bank_profiles
+    DROP COLUMN IF EXISTS slot_overbooking_policy,
+    DROP COLUMN IF EXISTS pickup_slot_capacity,
+    DROP COLUMN IF EXISTS dropoff_slot_capacity,
+    DROP COLUMN IF EXISTS slot_duration_minutes;
+
+DROP TYPE IF EXISTS slot_overbooking_policy;
@@ -0,0 +1,35 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'slot_overbooking_policy') THEN
+        CREATE TYPE slot_overbooking_policy AS ENUM ('reject', 'waitlist');
+    END IF;
+END$$;
+
+-- Bookable slots are generated from open_time/close_time in steps of slot_duration_minutes
+ALTER TABLE waste_bank_profiles
+    ADD COLUMN IF NOT EXISTS slot_duration_minutes INT NOT NULL DEFAULT 60 CHECK (slot_duration_minutes BETWEEN 15 AND 480),
+    ADD COLUMN IF NOT EXISTS dropoff_slot_capacity INT NOT NULL DEFAULT 10 CHECK (dropoff_slot_capacity >= 0),
+    ADD COLUMN IF NOT EXISTS pickup_slot_capacity INT NOT NULL DEFAULT 5 CHECK (pickup_slot_capacity >= 0),
+    ADD COLUMN IF NOT EXISTS slot_overbooking_policy slot_overbooking_policy NOT NULL DEFAULT 'reject';
+
+-- Waitlisted requests do not take up slot capacity until a booking in their slot is cancelled
+ALTER TABLE waste_drop_requests
+    ADD COLUMN IF NOT EXISTS is_waitlisted BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Closures and holidays, or different opening hours on a single date
+CREATE TABLE IF NOT EXISTS waste_bank_schedule_exceptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    waste_bank_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    is_closed BOOLEAN NOT NULL DEFAULT TRUE,
+    open_time TIME,
+    close_time TIME,
+    reason VARCHAR(255),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (waste_bank_id, date),
+    CHECK (is_closed OR (open_time IS NOT NULL AND close_time IS NOT NULL AND open_time < close_time))
+);
+
+CREATE INDEX IF NOT EXISTS idx_waste_drop_requests_bank_slot ON waste_drop_requests(waste_bank_id, appointment_date, delivery_type)
+    WHERE is_deleted = FALSE AND status <> 'cancelled';
@@ -0,0 +1,9 @@
+ALTER TABLE waste_drop_requests DROP COLUMN IF EXISTS subscription_id;
+
+DROP TABLE IF EXISTS pickup_subscription_occurrences;
+DROP TABLE IF EXISTS pickup_subscription_items;
+DROP TABLE IF EXISTS pickup_subscriptions;
+
+DROP TYPE IF EXISTS subscription_occurrence_status;
+DROP TYPE IF EXISTS subscription_frequency;
+DROP TYPE IF EXISTS subscription_status;
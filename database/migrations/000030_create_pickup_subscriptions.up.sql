@@ -0,0 +1,64 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'subscription_status') THEN
+        CREATE TYPE subscription_status AS ENUM ('active', 'paused', 'cancelled');
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'subscription_frequency') THEN
+        CREATE TYPE subscription_frequency AS ENUM ('weekly', 'biweekly', 'monthly');
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'subscription_occurrence_status') THEN
+        CREATE TYPE subscription_occurrence_status AS ENUM ('generating', 'generated', 'skipped', 'failed');
+    END IF;
+END$$;
+
+-- A standing pickup, e.g. every Saturday 08:00-10:00, materialized into waste drop requests ahead of time
+CREATE TABLE IF NOT EXISTS pickup_subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    customer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    waste_bank_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    frequency subscription_frequency NOT NULL,
+    day_of_week INT CHECK (day_of_week BETWEEN 0 AND 6), -- 0 is Sunday, used by weekly and biweekly
+    day_of_month INT CHECK (day_of_month BETWEEN 1 AND 28), -- Used by monthly
+    start_time TIMETZ NOT NULL,
+    end_time TIMETZ NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    appointment_location GEOGRAPHY(POINT, 4326),
+    user_phone_number VARCHAR(20),
+    notes TEXT,
+    status subscription_status NOT NULL DEFAULT 'active',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (start_time < end_time),
+    CHECK (end_date IS NULL OR end_date >= start_date),
+    CHECK ((frequency = 'monthly' AND day_of_month IS NOT NULL) OR (frequency <> 'monthly' AND day_of_week IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_pickup_subscriptions_customer ON pickup_subscriptions(customer_id);
+CREATE INDEX IF NOT EXISTS idx_pickup_subscriptions_active ON pickup_subscriptions(status) WHERE status = 'active';
+
+-- Default items copied into each generated request
+CREATE TABLE IF NOT EXISTS pickup_subscription_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subscription_id UUID NOT NULL REFERENCES pickup_subscriptions(id) ON DELETE CASCADE,
+    waste_type_id UUID NOT NULL REFERENCES waste_types(id),
+    quantity BIGINT NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pickup_subscription_items_subscription ON pickup_subscription_items(subscription_id);
+
+-- One row per date of a subscription, so each date is generated at most once and skips are remembered
+CREATE TABLE IF NOT EXISTS pickup_subscription_occurrences (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subscription_id UUID NOT NULL REFERENCES pickup_subscriptions(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    status subscription_occurrence_status NOT NULL,
+    request_id UUID REFERENCES waste_drop_requests(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (subscription_id, date)
+);
+
+ALTER TABLE waste_drop_requests
+    ADD COLUMN IF NOT EXISTS subscription_id UUID REFERENCES pickup_subscriptions(id) ON DELETE SET NULL;
@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_waste_transfer_requests_open;
+DROP INDEX IF EXISTS idx_waste_drop_requests_open;
+DROP INDEX IF EXISTS idx_waste_transfer_requests_cancellation_reason;
+DROP INDEX IF EXISTS idx_waste_drop_requests_cancellation_reason;
+
+ALTER TABLE waste_transfer_requests
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS cancellation_reason_code;
+
+ALTER TABLE waste_drop_requests
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS cancellation_reason_code;
+
+ALTER TABLE waste_bank_profiles
+    DROP COLUMN IF EXISTS pending_expiry_hours;
+
+DROP TYPE IF EXISTS cancellation_reason_code;
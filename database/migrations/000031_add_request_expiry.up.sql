@@ -0,0 +1,25 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'cancellation_reason_code') THEN
+        CREATE TYPE cancellation_reason_code AS ENUM ('customer_cancelled', 'no_show', 'expired', 'bank_rejected');
+    END IF;
+END$$;
+
+-- Pending requests are expired after this many hours without action, NULL uses the server default
+ALTER TABLE waste_bank_profiles
+    ADD COLUMN IF NOT EXISTS pending_expiry_hours INT CHECK (pending_expiry_hours > 0);
+
+ALTER TABLE waste_drop_requests
+    ADD COLUMN IF NOT EXISTS cancellation_reason_code cancellation_reason_code,
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ;
+
+ALTER TABLE waste_transfer_requests
+    ADD COLUMN IF NOT EXISTS cancellation_reason_code cancellation_reason_code,
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_waste_drop_requests_cancellation_reason ON waste_drop_requests(cancellation_reason_code) WHERE cancellation_reason_code IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_waste_transfer_requests_cancellation_reason ON waste_transfer_requests(cancellation_reason_code) WHERE cancellation_reason_code IS NOT NULL;
+
+-- The expiry job scans open requests by status
+CREATE INDEX IF NOT EXISTS idx_waste_drop_requests_open ON waste_drop_requests(status, created_at) WHERE status IN ('pending', 'assigned') AND is_deleted = FALSE;
+CREATE INDEX IF NOT EXISTS idx_waste_transfer_requests_open ON waste_transfer_requests(status, created_at) WHERE status IN ('pending', 'assigned') AND is_deleted = FALSE;
@@ -0,0 +1,12 @@
+ALTER TABLE waste_drop_requests
+    DROP COLUMN IF EXISTS estimated_total_price;
+
+ALTER TABLE waste_drop_request_items
+    DROP COLUMN IF EXISTS is_added_at_completion,
+    DROP COLUMN IF EXISTS gross_subtotal,
+    DROP COLUMN IF EXISTS moisture_percent,
+    DROP COLUMN IF EXISTS contamination_percent,
+    DROP COLUMN IF EXISTS grade_multiplier,
+    DROP COLUMN IF EXISTS grade;
+
+DROP TYPE IF EXISTS quality_grade;
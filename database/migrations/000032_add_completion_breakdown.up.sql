@@ -0,0 +1,19 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'quality_grade') THEN
+        CREATE TYPE quality_grade AS ENUM ('A', 'B', 'C');
+    END IF;
+END$$;
+
+-- Per item breakdown of how the verified subtotal was reached at completion
+ALTER TABLE waste_drop_request_items
+    ADD COLUMN IF NOT EXISTS grade quality_grade NOT NULL DEFAULT 'A',
+    ADD COLUMN IF NOT EXISTS grade_multiplier NUMERIC(4, 2) NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS contamination_percent NUMERIC(5, 2) NOT NULL DEFAULT 0 CHECK (contamination_percent >= 0 AND contamination_percent <= 100),
+    ADD COLUMN IF NOT EXISTS moisture_percent NUMERIC(5, 2) NOT NULL DEFAULT 0 CHECK (moisture_percent >= 0 AND moisture_percent <= 100),
+    ADD COLUMN IF NOT EXISTS gross_subtotal BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS is_added_at_completion BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Total the customer was quoted before completion replaced total_price with the verified payout
+ALTER TABLE waste_drop_requests
+    ADD COLUMN IF NOT EXISTS estimated_total_price BIGINT;
@@ -0,0 +1,13 @@
+ALTER TABLE waste_drop_requests
+    DROP COLUMN IF EXISTS pickup_fee,
+    DROP COLUMN IF EXISTS quote_id;
+
+DROP TABLE IF EXISTS waste_drop_quote_items;
+DROP TABLE IF EXISTS waste_drop_quotes;
+DROP INDEX IF EXISTS idx_waste_bank_promotions_active;
+DROP TABLE IF EXISTS waste_bank_promotions;
+
+ALTER TABLE waste_bank_profiles
+    DROP COLUMN IF EXISTS pickup_free_radius_km,
+    DROP COLUMN IF EXISTS pickup_fee_per_km,
+    DROP COLUMN IF EXISTS pickup_base_fee;
@@ -0,0 +1,58 @@
+-- Pickup fee charged against the payout: a base fee plus a fee per km beyond the free radius
+ALTER TABLE waste_bank_profiles
+    ADD COLUMN IF NOT EXISTS pickup_base_fee BIGINT NOT NULL DEFAULT 0 CHECK (pickup_base_fee >= 0),
+    ADD COLUMN IF NOT EXISTS pickup_fee_per_km BIGINT NOT NULL DEFAULT 0 CHECK (pickup_fee_per_km >= 0),
+    ADD COLUMN IF NOT EXISTS pickup_free_radius_km NUMERIC(6, 2) NOT NULL DEFAULT 0 CHECK (pickup_free_radius_km >= 0);
+
+-- Price bonus a waste bank offers for a waste type, or for every type when waste_type_id is NULL
+CREATE TABLE IF NOT EXISTS waste_bank_promotions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    waste_bank_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    waste_type_id UUID REFERENCES waste_types(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    bonus_percent NUMERIC(5, 2) NOT NULL CHECK (bonus_percent > 0 AND bonus_percent <= 100),
+    starts_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ends_at TIMESTAMPTZ,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_waste_bank_promotions_active ON waste_bank_promotions(waste_bank_id, waste_type_id) WHERE is_active = TRUE;
+
+CREATE TABLE IF NOT EXISTS waste_drop_quotes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    customer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    waste_bank_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    delivery_type delivery_type NOT NULL,
+    appointment_location GEOGRAPHY(POINT, 4326),
+    distance_km NUMERIC(10, 3),
+    items_total BIGINT NOT NULL DEFAULT 0,
+    promotion_bonus BIGINT NOT NULL DEFAULT 0,
+    pickup_fee BIGINT NOT NULL DEFAULT 0,
+    total_price BIGINT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    -- Set once a drop request is created from the quote, a quote is used only once
+    request_id UUID REFERENCES waste_drop_requests(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_waste_drop_quotes_customer ON waste_drop_quotes(customer_id, created_at);
+
+CREATE TABLE IF NOT EXISTS waste_drop_quote_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    quote_id UUID NOT NULL REFERENCES waste_drop_quotes(id) ON DELETE CASCADE,
+    waste_type_id UUID NOT NULL REFERENCES waste_types(id),
+    estimated_weight NUMERIC(10, 2) NOT NULL CHECK (estimated_weight > 0),
+    price_per_kgs BIGINT NOT NULL,
+    promotion_id UUID REFERENCES waste_bank_promotions(id) ON DELETE SET NULL,
+    bonus_percent NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    quoted_price_per_kgs BIGINT NOT NULL,
+    subtotal BIGINT NOT NULL,
+    UNIQUE (quote_id, waste_type_id)
+);
+
+ALTER TABLE waste_drop_requests
+    ADD COLUMN IF NOT EXISTS quote_id UUID REFERENCES waste_drop_quotes(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS pickup_fee BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,7 @@
+ALTER TABLE waste_drop_requests
+    DROP COLUMN IF EXISTS is_outside_service_area;
+
+ALTER TABLE waste_bank_profiles
+    DROP COLUMN IF EXISTS reject_outside_service_area;
+
+DROP TABLE IF EXISTS service_areas;
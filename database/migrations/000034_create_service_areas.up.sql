@@ -0,0 +1,20 @@
+-- Areas a waste bank or collector serves, replacing the fixed search radius for users that define them
+CREATE TABLE IF NOT EXISTS service_areas (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    area GEOGRAPHY(POLYGON, 4326) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_service_areas_user_id ON service_areas(user_id) WHERE is_active = TRUE;
+CREATE INDEX IF NOT EXISTS idx_service_areas_area ON service_areas USING GIST(area);
+
+-- Pickups outside every service area are flagged, or rejected when the waste bank asks for it
+ALTER TABLE waste_bank_profiles
+    ADD COLUMN IF NOT EXISTS reject_outside_service_area BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE waste_drop_requests
+    ADD COLUMN IF NOT EXISTS is_outside_service_area BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,18 @@
+DROP INDEX IF EXISTS idx_waste_transfer_requests_region_code;
+DROP INDEX IF EXISTS idx_waste_drop_requests_region_code;
+DROP INDEX IF EXISTS idx_users_region_code;
+
+ALTER TABLE waste_transfer_requests
+    DROP COLUMN IF EXISTS region_code;
+
+ALTER TABLE waste_drop_requests
+    DROP COLUMN IF EXISTS region_code;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS region_code;
+
+DROP TABLE IF EXISTS region_aliases;
+
+DROP TABLE IF EXISTS regions;
+
+DROP TYPE IF EXISTS region_level;
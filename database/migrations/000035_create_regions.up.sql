@@ -0,0 +1,42 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'region_level') THEN
+        CREATE TYPE region_level AS ENUM ('province', 'regency', 'district', 'village');
+    END IF;
+END$$;
+
+-- Administrative regions keyed by their Kemendagri code, e.g. 35 (provinsi), 35.78 (kabupaten/kota),
+-- 35.78.01 (kecamatan) and 35.78.01.1001 (kelurahan/desa). Codes are prefixes of the codes inside them.
+CREATE TABLE IF NOT EXISTS regions (
+    code VARCHAR(13) PRIMARY KEY,
+    parent_code VARCHAR(13) REFERENCES regions(code) ON DELETE CASCADE,
+    level region_level NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    boundary GEOGRAPHY(MULTIPOLYGON, 4326),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_regions_parent_code ON regions(parent_code);
+CREATE INDEX IF NOT EXISTS idx_regions_level ON regions(level);
+CREATE INDEX IF NOT EXISTS idx_regions_boundary ON regions USING GIST(boundary);
+
+-- Lowercase spelling variants matched against free text addresses, e.g. 'jatim' for Jawa Timur
+CREATE TABLE IF NOT EXISTS region_aliases (
+    alias VARCHAR(255) PRIMARY KEY CHECK (alias = LOWER(alias)),
+    region_code VARCHAR(13) NOT NULL REFERENCES regions(code) ON DELETE CASCADE
+);
+
+-- Most specific region known for a user or request, pattern ops allow filtering by a code prefix of any level
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS region_code VARCHAR(13) REFERENCES regions(code) ON DELETE SET NULL;
+
+ALTER TABLE waste_drop_requests
+    ADD COLUMN IF NOT EXISTS region_code VARCHAR(13) REFERENCES regions(code) ON DELETE SET NULL;
+
+ALTER TABLE waste_transfer_requests
+    ADD COLUMN IF NOT EXISTS region_code VARCHAR(13) REFERENCES regions(code) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_region_code ON users(region_code varchar_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_waste_drop_requests_region_code ON waste_drop_requests(region_code varchar_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_waste_transfer_requests_region_code ON waste_transfer_requests(region_code varchar_pattern_ops);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS daily_waste_rollups;
+
+DROP TYPE IF EXISTS rollup_flow;
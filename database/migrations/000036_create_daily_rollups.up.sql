@@ -0,0 +1,31 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'rollup_flow') THEN
+        CREATE TYPE rollup_flow AS ENUM ('drop', 'waste_bank_transfer', 'industry_transfer');
+    END IF;
+END$$;
+
+-- Verified weight of completed requests per day, receiver, sender, waste type and region, so dashboards
+-- do not scan the item tables. Rows are recomputed per flow, receiver and day whenever a request
+-- completes or is deleted, and rebuilt from scratch by the rollup backfill.
+CREATE TABLE IF NOT EXISTS daily_waste_rollups (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    day DATE NOT NULL, -- Request creation date in Asia/Jakarta, matching the dashboard month filters
+    flow rollup_flow NOT NULL,
+    receiver_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- Waste bank of a drop, destination of a transfer
+    receiver_role user_role NOT NULL,
+    receiver_region_code VARCHAR(13),
+    sender_id UUID REFERENCES users(id) ON DELETE CASCADE, -- Source of a transfer, NULL for drops
+    region_code VARCHAR(13), -- Region of the request
+    waste_type_id UUID NOT NULL REFERENCES waste_types(id) ON DELETE CASCADE,
+    request_count INTEGER NOT NULL DEFAULT 0,
+    weight DECIMAL(14, 3) NOT NULL DEFAULT 0,
+    amount BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_waste_rollups_day ON daily_waste_rollups(day, flow);
+CREATE INDEX IF NOT EXISTS idx_daily_waste_rollups_receiver ON daily_waste_rollups(receiver_id, day);
+CREATE INDEX IF NOT EXISTS idx_daily_waste_rollups_sender ON daily_waste_rollups(sender_id, day) WHERE sender_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_daily_waste_rollups_region_code ON daily_waste_rollups(region_code varchar_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_daily_waste_rollups_receiver_region_code ON daily_waste_rollups(receiver_region_code varchar_pattern_ops);
@@ -0,0 +1 @@
+ALTER TABLE waste_transfer_items DROP COLUMN IF EXISTS recycled_at;
@@ -0,0 +1,3 @@
+-- When the destination industry last reported the recycled output of a transfer item. Items without it
+-- have not been processed yet and are left out of recycling yield.
+ALTER TABLE waste_transfer_items ADD COLUMN IF NOT EXISTS recycled_at TIMESTAMPTZ;
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg_stock_movements_immutable ON stock_movements;
+DROP FUNCTION IF EXISTS prevent_stock_movement_mutation();
+DROP TABLE IF EXISTS stock_movements;
+
+DROP TYPE IF EXISTS stock_movement_type;
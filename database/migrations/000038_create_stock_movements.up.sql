@@ -0,0 +1,59 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'stock_movement_type') THEN
+        -- A transfer leaves the source storage when it is reserved at assignment and is released back if
+        -- the transfer is cancelled, it only enters the destination storage on completion
+        CREATE TYPE stock_movement_type AS ENUM (
+            'drop_inbound',
+            'transfer_reservation',
+            'transfer_release',
+            'transfer_inbound',
+            'manual_adjustment',
+            'recycling_consumption'
+        );
+    END IF;
+END$$;
+
+-- Every change of storage_items.weight_kgs. Movements are keyed by storage and waste type rather than by
+-- storage item, as an item is deleted when its stock runs out and created again on the next inbound.
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    storage_id UUID NOT NULL REFERENCES storage(id) ON DELETE CASCADE,
+    waste_type_id UUID NOT NULL REFERENCES waste_types(id) ON DELETE CASCADE,
+    movement_type stock_movement_type NOT NULL,
+    weight_kgs DECIMAL NOT NULL, -- Signed, negative when stock leaves the storage
+    balance_kgs DECIMAL NOT NULL, -- Weight of the storage item after the movement
+    reference_type TEXT,
+    reference_id UUID,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    notes TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_storage_waste_type ON stock_movements(storage_id, waste_type_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_reference ON stock_movements(reference_type, reference_id);
+
+-- Movements are append-only, corrections are new manual adjustments. Cascades from deleted storages and
+-- users still go through, they run one trigger level down.
+CREATE OR REPLACE FUNCTION prevent_stock_movement_mutation() RETURNS TRIGGER AS $$
+BEGIN
+    IF pg_trigger_depth() > 1 THEN
+        IF TG_OP = 'DELETE' THEN
+            RETURN OLD;
+        END IF;
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'stock movements are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_stock_movements_immutable ON stock_movements;
+CREATE TRIGGER trg_stock_movements_immutable BEFORE UPDATE OR DELETE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION prevent_stock_movement_mutation();
+
+-- Opening movements so the journal adds up to the current stock
+INSERT INTO stock_movements (storage_id, waste_type_id, movement_type, weight_kgs, balance_kgs, notes, created_at)
+SELECT storage_id, waste_type_id, 'manual_adjustment', SUM(weight_kgs), SUM(weight_kgs), 'Opening stock', NOW()
+FROM storage_items
+WHERE COALESCE(weight_kgs, 0) <> 0
+GROUP BY storage_id, waste_type_id;
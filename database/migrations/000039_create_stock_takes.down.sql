@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS stock_take_lines;
+DROP TABLE IF EXISTS stock_takes;
+DROP TYPE IF EXISTS shrinkage_reason;
+DROP TYPE IF EXISTS stock_take_status;
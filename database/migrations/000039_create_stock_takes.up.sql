@@ -0,0 +1,48 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'stock_take_status') THEN
+        CREATE TYPE stock_take_status AS ENUM ('open', 'posted', 'cancelled');
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'shrinkage_reason') THEN
+        CREATE TYPE shrinkage_reason AS ENUM ('moisture_loss', 'theft', 'sorting_error', 'other');
+    END IF;
+END$$;
+
+-- A physical count of a storage. Counts are entered while the stock take is open and only touch
+-- storage_items once it is posted.
+CREATE TABLE IF NOT EXISTS stock_takes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    storage_id UUID NOT NULL REFERENCES storage(id) ON DELETE CASCADE,
+    status stock_take_status NOT NULL DEFAULT 'open',
+    notes TEXT,
+    opened_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    posted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    opened_at TIMESTAMPTZ DEFAULT NOW(),
+    posted_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_takes_storage_id ON stock_takes(storage_id);
+CREATE INDEX IF NOT EXISTS idx_stock_takes_status ON stock_takes(status);
+-- One count at a time per storage
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_takes_one_open ON stock_takes(storage_id) WHERE status = 'open';
+
+-- system_weight_kgs is the stock when the line was counted and stays frozen, so movements between the count
+-- and posting are kept and only the variance found by the count is adjusted
+CREATE TABLE IF NOT EXISTS stock_take_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    stock_take_id UUID NOT NULL REFERENCES stock_takes(id) ON DELETE CASCADE,
+    waste_type_id UUID NOT NULL REFERENCES waste_types(id) ON DELETE CASCADE,
+    system_weight_kgs DECIMAL NOT NULL DEFAULT 0,
+    counted_weight_kgs DECIMAL CHECK (counted_weight_kgs >= 0), -- NULL until counted
+    shrinkage_reason shrinkage_reason,
+    notes TEXT,
+    counted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (stock_take_id, waste_type_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_take_lines_waste_type_id ON stock_take_lines(waste_type_id);
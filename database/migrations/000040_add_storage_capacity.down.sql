@@ -0,0 +1,3 @@
+ALTER TABLE storage DROP COLUMN IF EXISTS reject_overflow;
+ALTER TABLE storage DROP COLUMN IF EXISTS capacity_alert_percent;
+ALTER TABLE waste_types DROP COLUMN IF EXISTS bulk_density_kgs_per_m3;
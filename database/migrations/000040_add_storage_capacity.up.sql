@@ -0,0 +1,9 @@
+-- Loose bulk density of a waste type, used to estimate the volume its stock occupies. Types without one fall
+-- back to a default density in the application.
+ALTER TABLE waste_types ADD COLUMN IF NOT EXISTS bulk_density_kgs_per_m3 DECIMAL CHECK (bulk_density_kgs_per_m3 > 0);
+
+-- Utilization at which a storage is flagged for an outbound transfer, and whether inbound drops that would
+-- overflow it are rejected instead of completed with a warning
+ALTER TABLE storage ADD COLUMN IF NOT EXISTS capacity_alert_percent DECIMAL NOT NULL DEFAULT 80
+    CHECK (capacity_alert_percent > 0 AND capacity_alert_percent <= 100);
+ALTER TABLE storage ADD COLUMN IF NOT EXISTS reject_overflow BOOLEAN NOT NULL DEFAULT FALSE;
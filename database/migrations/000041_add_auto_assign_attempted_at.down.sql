@@ -0,0 +1 @@
+ALTER TABLE waste_drop_requests DROP COLUMN IF EXISTS auto_assign_attempted_at;
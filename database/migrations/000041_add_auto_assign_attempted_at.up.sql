@@ -0,0 +1,2 @@
+-- Last time the assignment sweep tried and failed to find a collector, so the sweep moves on to other requests
+ALTER TABLE waste_drop_requests ADD COLUMN IF NOT EXISTS auto_assign_attempted_at TIMESTAMPTZ;
@@ -0,0 +1 @@
+ALTER TABLE waste_drop_quote_items DROP COLUMN IF EXISTS price_version_id;
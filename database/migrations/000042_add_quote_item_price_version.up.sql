@@ -0,0 +1,3 @@
+-- Price version a quote item was priced from, copied to the drop request items booked with the quote
+ALTER TABLE waste_drop_quote_items
+    ADD COLUMN IF NOT EXISTS price_version_id UUID REFERENCES waste_bank_price_versions(id) ON DELETE SET NULL;
@@ -0,0 +1,16 @@
+-- Planned visiting order of a collector's stops for a day, shared by every server process so the order
+-- stays stable no matter which one serves the request. plan_key holds the departure time and start point
+-- the route was planned from.
+CREATE TABLE IF NOT EXISTS collector_route_plans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    collector_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    route_date DATE NOT NULL,
+    plan_key TEXT NOT NULL,
+    plan JSONB NOT NULL, -- Stop keys, time windows, distance matrix and visiting order
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collector_route_plans_key ON collector_route_plans(collector_id, route_date, plan_key);
+CREATE INDEX IF NOT EXISTS idx_collector_route_plans_expires_at ON collector_route_plans(expires_at);
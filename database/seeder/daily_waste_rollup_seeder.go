@@ -0,0 +1,26 @@
+package seeder
+
+import (
+	"log"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// SeedDailyWasteRollups rebuilds the dashboard rollups from the completed requests
+func SeedDailyWasteRollups(db *gorm.DB) error {
+	var rows int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		rows, err = repository.NewDailyWasteRollupRepository(logrus.New()).Rebuild(tx, nil)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error rebuilding daily waste rollups: %v", err)
+		return err
+	}
+
+	log.Printf("Built %d daily waste rollup rows", rows)
+	return nil
+}
@@ -0,0 +1,68 @@
+package seeder
+
+import (
+	"log"
+	"time"
+
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// SeedImpactFactors seeds the default impact factor along with a few per category overrides
+func SeedImpactFactors(db *gorm.DB) error {
+	now := time.Now()
+
+	var existing int64
+	if err := db.Model(&entity.ImpactFactor{}).Where("waste_type_id IS NULL AND waste_category_id IS NULL AND is_deleted = ?", false).Count(&existing).Error; err != nil {
+		return err
+	}
+	if existing == 0 {
+		// Same coefficients the profile totals used before factors were configurable
+		factor := entity.ImpactFactor{
+			Co2ePerKg:        2.5,
+			WaterLitersPerKg: 1000,
+			EnergyKwhPerKg:   0,
+			TreesPerKg:       0.1,
+			Source:           "Legacy default",
+			AppliedAt:        &now,
+		}
+		if err := db.Create(&factor).Error; err != nil {
+			log.Printf("Error creating default impact factor: %v", err)
+			return err
+		}
+	}
+
+	categoryFactors := map[string]entity.ImpactFactor{
+		"Plastic": {Co2ePerKg: 1.8, WaterLitersPerKg: 40, EnergyKwhPerKg: 5.8, TreesPerKg: 0.02},
+		"Paper":   {Co2ePerKg: 1.0, WaterLitersPerKg: 26, EnergyKwhPerKg: 4.0, TreesPerKg: 0.017},
+		"Metal":   {Co2ePerKg: 4.0, WaterLitersPerKg: 40, EnergyKwhPerKg: 14, TreesPerKg: 0},
+	}
+
+	count := 0
+	for name, factor := range categoryFactors {
+		var category entity.WasteCategory
+		if err := db.Where("name = ?", name).First(&category).Error; err != nil {
+			log.Printf("Waste category %s not found, skipping impact factor", name)
+			continue
+		}
+
+		if err := db.Model(&entity.ImpactFactor{}).Where("waste_category_id = ? AND is_deleted = ?", category.ID, false).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			continue
+		}
+
+		factor.WasteCategoryID = &category.ID
+		factor.Source = "Seed data"
+		factor.AppliedAt = &now
+		if err := db.Create(&factor).Error; err != nil {
+			log.Printf("Error creating impact factor for %s: %v", name, err)
+			return err
+		}
+		count++
+	}
+
+	log.Printf("Successfully seeded %d category impact factors", count)
+	return nil
+}
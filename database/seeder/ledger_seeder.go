@@ -0,0 +1,73 @@
+package seeder
+
+import (
+	"log"
+
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// SeedLedgerOpeningBalances posts opening entries for seeded users so the ledger
+// matches their points/balance columns
+func SeedLedgerOpeningBalances(db *gorm.DB) error {
+	var users []entity.User
+	if err := db.Where("points <> 0 OR balance <> 0").Find(&users).Error; err != nil {
+		return err
+	}
+
+	count := 0
+	for _, user := range users {
+		for asset, amount := range map[string]int64{"points": user.Points, "rupiah": user.Balance} {
+			if amount == 0 {
+				continue
+			}
+
+			var existing int64
+			if err := db.Model(&entity.LedgerAccount{}).Where("user_id = ? AND asset = ?", user.ID, asset).Count(&existing).Error; err != nil {
+				return err
+			}
+			if existing > 0 {
+				continue
+			}
+
+			code := "opening_balance"
+			opening := entity.LedgerAccount{}
+			if err := db.Where("code = ? AND asset = ?", code, asset).
+				Attrs(entity.LedgerAccount{Code: &code, Asset: asset}).
+				FirstOrCreate(&opening).Error; err != nil {
+				return err
+			}
+
+			userID := user.ID
+			account := entity.LedgerAccount{UserID: &userID, Asset: asset}
+			if err := db.Create(&account).Error; err != nil {
+				return err
+			}
+
+			userDirection, openingDirection := "credit", "debit"
+			if amount < 0 {
+				userDirection, openingDirection = "debit", "credit"
+				amount = -amount
+			}
+
+			entry := entity.LedgerEntry{
+				EntryType:     "opening_balance",
+				ReferenceType: "user",
+				ReferenceID:   &userID,
+				Description:   "Opening " + asset + " balance",
+				Lines: []entity.LedgerLine{
+					{AccountID: account.ID, Direction: userDirection, Amount: amount},
+					{AccountID: opening.ID, Direction: openingDirection, Amount: amount},
+				},
+			}
+			if err := db.Create(&entry).Error; err != nil {
+				log.Printf("Error creating opening balance for %s: %v", user.Email, err)
+				return err
+			}
+			count++
+		}
+	}
+
+	log.Printf("Successfully seeded %d ledger opening balances", count)
+	return nil
+}
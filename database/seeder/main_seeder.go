@@ -14,7 +14,10 @@ func RunAllSeeders(db *gorm.DB) error {
 	seeders := []func(*gorm.DB) error{
 		SeedWasteCategories,
 		SeedWasteTypes,
+		SeedImpactFactors,
+		SeedRegions,
 		SeedUsers,
+		SeedLedgerOpeningBalances,
 		SeedCustomerProfiles,
 		SeedGovernmentProfiles,
 		SeedIndustryProfiles,
@@ -29,6 +32,9 @@ func RunAllSeeders(db *gorm.DB) error {
 		SeedWasteTransferRequests,
 		SeedWasteTransferItemOfferings,
 		SeedSalaryTransactions,
+		SeedPointConversionRates,
+		SeedRegionCodes,
+		SeedDailyWasteRollups,
 	}
 
 	for i, seeder := range seeders {
@@ -44,23 +50,47 @@ func RunAllSeeders(db *gorm.DB) error {
 
 // ClearAllData clears all data from tables (useful for testing)
 func ClearAllData(db *gorm.DB) error {
+	// Ledger rows and stock movements reject DELETE, so they are truncated instead along with the redemptions holding them
+	if err := db.Exec("TRUNCATE point_redemptions, ledger_drifts, ledger_lines, ledger_entries, ledger_accounts, stock_movements").Error; err != nil {
+		return err
+	}
+
 	tables := []string{
+		"daily_waste_rollups",
 		"salary_transactions",
+		"point_conversion_rates",
 		"waste_transfer_items",
 		"waste_transfer_requests",
+		"collector_assignments",
+		"pickup_subscription_occurrences",
+		"pickup_subscription_items",
 		"waste_drop_request_items",
 		"waste_drop_requests",
+		"waste_drop_quote_items",
+		"waste_drop_quotes",
+		"waste_bank_promotions",
+		"pickup_subscriptions",
+		"stock_take_lines",
+		"stock_takes",
 		"storage_items",
 		"storage",
+		"waste_bank_price_versions",
 		"waste_bank_priced_types",
+		"impact_factors",
+		"waste_bank_schedule_exceptions",
+		"service_areas",
 		"collector_managements",
 		"waste_collector_profiles",
 		"waste_bank_profiles",
 		"industry_profiles",
 		"government_profiles",
 		"customer_profiles",
+		"idempotency_keys",
+		"uploaded_files",
 		"refresh_tokens",
 		"users",
+		"region_aliases",
+		"regions",
 		"waste_types",
 		"waste_categories",
 	}
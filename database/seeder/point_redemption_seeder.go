@@ -0,0 +1,37 @@
+package seeder
+
+import (
+	"log"
+	"time"
+
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// SeedPointConversionRates seeds the default points to rupiah rate used by banks without their own
+func SeedPointConversionRates(db *gorm.DB) error {
+	var existing int64
+	if err := db.Model(&entity.PointConversionRate{}).Where("waste_bank_id IS NULL").Count(&existing).Error; err != nil {
+		return err
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	periodLimit := int64(100000)
+	rate := entity.PointConversionRate{
+		RupiahPerPoint:    1,
+		MinPoints:         1000,
+		PeriodLimitPoints: &periodLimit,
+		LimitPeriod:       "month",
+		EffectiveFrom:     time.Now().AddDate(0, -1, 0),
+		IsActive:          true,
+	}
+	if err := db.Create(&rate).Error; err != nil {
+		log.Printf("Error creating default point conversion rate: %v", err)
+		return err
+	}
+
+	log.Println("Successfully seeded default point conversion rate")
+	return nil
+}
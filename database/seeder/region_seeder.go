@@ -0,0 +1,285 @@
+package seeder
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SeedRegions seeds the provinces and the regencies used by the seed data, using Kemendagri codes.
+// This is only a partial registry: the full code list down to villages is loaded with ImportRegionCodes
+// and the boundaries with ImportRegions, see the readme.
+func SeedRegions(db *gorm.DB) error {
+	provinces := []struct {
+		Code    string
+		Name    string
+		Aliases []string
+	}{
+		{"11", "Aceh", []string{"nad", "nanggroe aceh darussalam"}},
+		{"12", "Sumatera Utara", []string{"sumut", "north sumatra"}},
+		{"13", "Sumatera Barat", []string{"sumbar", "west sumatra"}},
+		{"14", "Riau", nil},
+		{"15", "Jambi", nil},
+		{"16", "Sumatera Selatan", []string{"sumsel", "south sumatra"}},
+		{"17", "Bengkulu", nil},
+		{"18", "Lampung", nil},
+		{"19", "Kepulauan Bangka Belitung", []string{"babel", "bangka belitung"}},
+		{"21", "Kepulauan Riau", []string{"kepri", "riau islands"}},
+		{"31", "DKI Jakarta", []string{"jakarta", "dki"}},
+		{"32", "Jawa Barat", []string{"jabar", "west java"}},
+		{"33", "Jawa Tengah", []string{"jateng", "central java"}},
+		{"34", "DI Yogyakarta", []string{"diy", "yogyakarta", "jogja", "jogjakarta"}},
+		{"35", "Jawa Timur", []string{"jatim", "east java"}},
+		{"36", "Banten", nil},
+		{"51", "Bali", nil},
+		{"52", "Nusa Tenggara Barat", []string{"ntb", "west nusa tenggara"}},
+		{"53", "Nusa Tenggara Timur", []string{"ntt", "east nusa tenggara"}},
+		{"61", "Kalimantan Barat", []string{"kalbar", "west kalimantan"}},
+		{"62", "Kalimantan Tengah", []string{"kalteng", "central kalimantan"}},
+		{"63", "Kalimantan Selatan", []string{"kalsel", "south kalimantan"}},
+		{"64", "Kalimantan Timur", []string{"kaltim", "east kalimantan"}},
+		{"65", "Kalimantan Utara", []string{"kaltara", "north kalimantan"}},
+		{"71", "Sulawesi Utara", []string{"sulut", "north sulawesi"}},
+		{"72", "Sulawesi Tengah", []string{"sulteng", "central sulawesi"}},
+		{"73", "Sulawesi Selatan", []string{"sulsel", "south sulawesi"}},
+		{"74", "Sulawesi Tenggara", []string{"sultra", "southeast sulawesi"}},
+		{"75", "Gorontalo", nil},
+		{"76", "Sulawesi Barat", []string{"sulbar", "west sulawesi"}},
+		{"81", "Maluku", nil},
+		{"82", "Maluku Utara", []string{"malut", "north maluku"}},
+		{"91", "Papua", nil},
+		{"92", "Papua Barat", []string{"west papua"}},
+		{"93", "Papua Selatan", []string{"south papua"}},
+		{"94", "Papua Tengah", []string{"central papua"}},
+		{"95", "Papua Pegunungan", []string{"highland papua"}},
+		{"96", "Papua Barat Daya", []string{"southwest papua"}},
+	}
+
+	regencies := []struct {
+		Code string
+		Name string
+	}{
+		{"35.15", "Kabupaten Sidoarjo"},
+		{"35.25", "Kabupaten Gresik"},
+		{"35.73", "Kota Malang"},
+		{"35.78", "Kota Surabaya"},
+		{"31.71", "Kota Jakarta Selatan"},
+		{"31.73", "Kota Jakarta Pusat"},
+		{"32.73", "Kota Bandung"},
+	}
+
+	regions := make([]entity.Region, 0, len(provinces)+len(regencies))
+	aliases := make([]entity.RegionAlias, 0)
+	for _, province := range provinces {
+		regions = append(regions, entity.Region{Code: province.Code, Level: "province", Name: province.Name})
+		for _, alias := range province.Aliases {
+			aliases = append(aliases, entity.RegionAlias{Alias: alias, RegionCode: province.Code})
+		}
+	}
+	for _, regency := range regencies {
+		parent := regionParentCode(regency.Code)
+		regions = append(regions, entity.Region{Code: regency.Code, ParentCode: &parent, Level: "regency", Name: regency.Name})
+	}
+
+	if err := db.Omit("boundary").Clauses(clause.OnConflict{DoNothing: true}).Create(&regions).Error; err != nil {
+		log.Printf("Error creating regions: %v", err)
+		return err
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&aliases).Error; err != nil {
+		log.Printf("Error creating region aliases: %v", err)
+		return err
+	}
+
+	log.Printf("Seeded %d regions and %d aliases", len(regions), len(aliases))
+	return nil
+}
+
+// SeedRegionCodes fills the region codes of the seeded users and requests. Without boundaries only the
+// province and city names of the seed data are matched.
+func SeedRegionCodes(db *gorm.DB) error {
+	regions := repository.NewRegionRepository(logrus.New())
+
+	boundaries, err := regions.CountWithBoundary(db)
+	if err != nil {
+		return err
+	}
+	if boundaries == 0 {
+		log.Printf("WARNING: no region boundaries are loaded, regions are assigned from province and city names only. Import them with -regions")
+	}
+
+	users, err := regions.BackfillUsers(db, false)
+	if err != nil {
+		return err
+	}
+	drops, err := regions.BackfillWasteDropRequests(db, false)
+	if err != nil {
+		return err
+	}
+	transfers, err := regions.BackfillWasteTransferRequests(db, false)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Assigned regions to %d users, %d drop requests and %d transfer requests", users, drops, transfers)
+	return nil
+}
+
+// ImportRegionCodes loads the Kemendagri code list from a CSV file of code and name columns, e.g.
+// "35.78.01.1001,Wonokromo", with an optional header row. The level and parent are taken from the dotted
+// code like in ImportRegions. Existing regions are renamed and keep their boundaries.
+func ImportRegionCodes(db *gorm.DB, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var regions []entity.Region
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(record) < 2 {
+			return fmt.Errorf("line %d needs a code and a name", line)
+		}
+
+		code, name := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		level := regionLevel(code)
+		if line == 1 && (level == "" || strings.ContainsAny(code, "abcdefghijklmnopqrstuvwxyz")) {
+			continue // Header row
+		}
+		if code == "" || level == "" || name == "" {
+			return fmt.Errorf("line %d has an invalid code %q or missing name", line, record[0])
+		}
+
+		region := entity.Region{Code: code, Level: level, Name: name}
+		if level != "province" {
+			parent := regionParentCode(code)
+			region.ParentCode = &parent
+		}
+		regions = append(regions, region)
+	}
+
+	// Parents are written before their children so the parent_code foreign key holds
+	sort.SliceStable(regions, func(i, j int) bool {
+		return strings.Count(regions[i].Code, ".") < strings.Count(regions[j].Code, ".")
+	})
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		return tx.Omit("boundary").Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "code"}},
+			DoUpdates: clause.AssignmentColumns([]string{"parent_code", "level", "name", "updated_at"}),
+		}).CreateInBatches(&regions, 1000).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Imported %d region codes from %s", len(regions), path)
+	return nil
+}
+
+// ImportRegions loads regions with their boundaries from a GeoJSON FeatureCollection. Every feature needs
+// "code" and "name" properties, the level and parent are taken from the dotted code, e.g. 35.78.01.1001
+// is a village in district 35.78.01. Existing regions are updated.
+func ImportRegions(db *gorm.DB, path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Properties struct {
+				Code string `json:"code"`
+				Name string `json:"name"`
+			} `json:"properties"`
+			Geometry json.RawMessage `json:"geometry"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(raw, &collection); err != nil {
+		return err
+	}
+	if collection.Type != "FeatureCollection" {
+		return fmt.Errorf("expected a FeatureCollection, got %q", collection.Type)
+	}
+
+	// Parents are written before their children so the parent_code foreign key holds
+	features := collection.Features
+	sort.SliceStable(features, func(i, j int) bool {
+		return strings.Count(features[i].Properties.Code, ".") < strings.Count(features[j].Properties.Code, ".")
+	})
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i, feature := range features {
+			code := strings.TrimSpace(feature.Properties.Code)
+			level := regionLevel(code)
+			if code == "" || level == "" || feature.Properties.Name == "" {
+				return fmt.Errorf("feature %d has an invalid code %q or missing name", i, feature.Properties.Code)
+			}
+
+			var parent *string
+			if level != "province" {
+				parentCode := regionParentCode(code)
+				parent = &parentCode
+			}
+
+			var geometry *string
+			if len(feature.Geometry) > 0 && string(feature.Geometry) != "null" {
+				value := string(feature.Geometry)
+				geometry = &value
+			}
+
+			err := tx.Exec(`INSERT INTO regions (code, parent_code, level, name, boundary, created_at, updated_at)
+				VALUES (?, ?, ?, ?, CASE WHEN ?::text IS NULL THEN NULL ELSE ST_Multi(ST_GeomFromGeoJSON(?::text))::geography END, NOW(), NOW())
+				ON CONFLICT (code) DO UPDATE SET parent_code = EXCLUDED.parent_code, level = EXCLUDED.level,
+					name = EXCLUDED.name, boundary = COALESCE(EXCLUDED.boundary, regions.boundary), updated_at = NOW()`,
+				code, parent, level, feature.Properties.Name, geometry, geometry).Error
+			if err != nil {
+				return fmt.Errorf("feature %d (%s): %w", i, code, err)
+			}
+		}
+
+		log.Printf("Imported %d regions from %s", len(features), path)
+		return nil
+	})
+}
+
+// regionLevel maps the number of segments in a Kemendagri code to its level
+func regionLevel(code string) string {
+	switch strings.Count(code, ".") {
+	case 0:
+		return "province"
+	case 1:
+		return "regency"
+	case 2:
+		return "district"
+	case 3:
+		return "village"
+	}
+	return ""
+}
+
+func regionParentCode(code string) string {
+	return code[:strings.LastIndex(code, ".")]
+}
@@ -121,7 +121,7 @@ func SeedSalaryTransactions(db *gorm.DB) error {
 				SenderID:        wasteBanks[2].ID, // Central bank
 				ReceiverID:      collectors[i].ID,
 				Amount:          300000,
-				TransactionType: "points_conversion",
+				TransactionType: "salary",
 				CreatedAt:       lastWeek,
 				Status:          "completed",
 				Notes:           "Bonus for cooperation with central coordination",
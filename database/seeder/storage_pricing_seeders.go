@@ -2,6 +2,7 @@ package seeder
 
 import (
 	"log"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/wastetrack/wastetrack-backend/internal/entity"
@@ -105,6 +106,20 @@ func SeedWasteBankPricedTypes(db *gorm.DB) error {
 						wasteType.Name, wasteBank.Username, err)
 					return err
 				}
+
+				// Every price needs a version for drop requests to lock in
+				version := entity.WasteBankPriceVersion{
+					PricedTypeID:  &pricedType.ID,
+					WasteBankID:   pricedType.WasteBankID,
+					WasteTypeID:   pricedType.WasteTypeID,
+					PricePerKgs:   finalPrice,
+					EffectiveFrom: time.Now().AddDate(0, -1, 0),
+				}
+				if err := db.Create(&version).Error; err != nil {
+					log.Printf("Error creating price version for %s at %s: %v",
+						wasteType.Name, wasteBank.Username, err)
+					return err
+				}
 				log.Printf("Created pricing for %s at %s: Rp %d/kg",
 					wasteType.Name, wasteBank.Username, finalPrice)
 			}
@@ -215,7 +230,7 @@ func SeedStorages(db *gorm.DB) error {
 				log.Printf("Error creating storage: %v", err)
 				return err
 			}
-			log.Printf("Created storage facility: %dx%dx%d cm", storage.Length, storage.Width, storage.Height)
+			log.Printf("Created storage facility: %.2fx%.2fx%.2f cm", storage.Length, storage.Width, storage.Height)
 		}
 	}
 
@@ -386,6 +401,18 @@ func SeedStorageItems(db *gorm.DB) error {
 					log.Printf("Error creating storage item: %v", err)
 					return err
 				}
+				// Opening movement so the stock journal adds up to the seeded weight
+				if err := db.Create(&entity.StockMovement{
+					StorageID:    storageItem.StorageID,
+					WasteTypeID:  storageItem.WasteTypeID,
+					MovementType: "manual_adjustment",
+					WeightKgs:    finalWeight,
+					BalanceKgs:   finalWeight,
+					Notes:        "Opening stock",
+				}).Error; err != nil {
+					log.Printf("Error creating stock movement: %v", err)
+					return err
+				}
 				log.Printf("Created storage item: %.1f kg of %s for %s",
 					finalWeight, wasteType.Name, user.Username)
 			}
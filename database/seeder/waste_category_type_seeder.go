@@ -72,46 +72,48 @@ func SeedWasteTypes(db *gorm.DB) error {
 		categoryMap[cat.Name] = cat.ID
 	}
 
+	// Densities are rough loose (unbaled) figures in kg/m3
 	wasteTypes := []struct {
 		CategoryName string
 		Name         string
 		Description  string
+		BulkDensity  float64
 	}{
 		// Plastic types
-		{"Plastic", "PET Bottles", "Polyethylene terephthalate bottles"},
-		{"Plastic", "HDPE Containers", "High-density polyethylene containers"},
-		{"Plastic", "Plastic Bags", "Various plastic bags and films"},
-		{"Plastic", "Styrofoam", "Expanded polystyrene foam"},
+		{"Plastic", "PET Bottles", "Polyethylene terephthalate bottles", 30},
+		{"Plastic", "HDPE Containers", "High-density polyethylene containers", 40},
+		{"Plastic", "Plastic Bags", "Various plastic bags and films", 25},
+		{"Plastic", "Styrofoam", "Expanded polystyrene foam", 15},
 
 		// Paper types
-		{"Paper", "Newspaper", "Daily newspapers and newsprint"},
-		{"Paper", "Cardboard", "Corrugated cardboard boxes"},
-		{"Paper", "Office Paper", "White and colored office paper"},
-		{"Paper", "Magazines", "Glossy magazines and catalogs"},
+		{"Paper", "Newspaper", "Daily newspapers and newsprint", 250},
+		{"Paper", "Cardboard", "Corrugated cardboard boxes", 50},
+		{"Paper", "Office Paper", "White and colored office paper", 300},
+		{"Paper", "Magazines", "Glossy magazines and catalogs", 350},
 
 		// Metal types
-		{"Metal", "Aluminum Cans", "Beverage aluminum cans"},
-		{"Metal", "Steel Cans", "Food steel cans"},
-		{"Metal", "Copper Wire", "Electrical copper wiring"},
-		{"Metal", "Iron Scrap", "Various iron and steel scrap"},
+		{"Metal", "Aluminum Cans", "Beverage aluminum cans", 35},
+		{"Metal", "Steel Cans", "Food steel cans", 90},
+		{"Metal", "Copper Wire", "Electrical copper wiring", 600},
+		{"Metal", "Iron Scrap", "Various iron and steel scrap", 500},
 
 		// Glass types
-		{"Glass", "Clear Glass Bottles", "Transparent glass bottles"},
-		{"Glass", "Colored Glass Bottles", "Brown and green glass bottles"},
-		{"Glass", "Glass Jars", "Food and beverage jars"},
+		{"Glass", "Clear Glass Bottles", "Transparent glass bottles", 300},
+		{"Glass", "Colored Glass Bottles", "Brown and green glass bottles", 300},
+		{"Glass", "Glass Jars", "Food and beverage jars", 300},
 
 		// Organic types
-		{"Organic", "Food Waste", "Kitchen and food scraps"},
-		{"Organic", "Garden Waste", "Leaves, branches, and yard trimmings"},
+		{"Organic", "Food Waste", "Kitchen and food scraps", 500},
+		{"Organic", "Garden Waste", "Leaves, branches, and yard trimmings", 150},
 
 		// Electronic types
-		{"Electronic", "Mobile Phones", "Old smartphones and feature phones"},
-		{"Electronic", "Computers", "Desktop and laptop computers"},
-		{"Electronic", "Televisions", "CRT and LCD televisions"},
+		{"Electronic", "Mobile Phones", "Old smartphones and feature phones", 400},
+		{"Electronic", "Computers", "Desktop and laptop computers", 200},
+		{"Electronic", "Televisions", "CRT and LCD televisions", 250},
 
 		// Textile types
-		{"Textile", "Cotton Clothing", "Used cotton garments"},
-		{"Textile", "Synthetic Clothing", "Polyester and synthetic garments"},
+		{"Textile", "Cotton Clothing", "Used cotton garments", 150},
+		{"Textile", "Synthetic Clothing", "Polyester and synthetic garments", 150},
 	}
 
 	for _, wt := range wasteTypes {
@@ -120,11 +122,13 @@ func SeedWasteTypes(db *gorm.DB) error {
 			continue
 		}
 
+		bulkDensity := wt.BulkDensity
 		wasteType := entity.WasteType{
-			ID:          uuid.New(),
-			CategoryID:  categoryID,
-			Name:        wt.Name,
-			Description: wt.Description,
+			ID:                  uuid.New(),
+			CategoryID:          categoryID,
+			Name:                wt.Name,
+			Description:         wt.Description,
+			BulkDensityKgsPerM3: &bulkDensity,
 		}
 
 		var existing entity.WasteType
@@ -37,15 +37,40 @@ func Bootstrap(config *BootstrapConfig) {
 	wasteCategoryRepository := repository.NewWasteCategoryRepository(config.Log)
 	wasteTypeRepository := repository.NewWasteTypeRepository(config.Log)
 	wasteBankPricedTypeRepository := repository.NewWasteBankPricedTypeRepository(config.Log)
+	wasteBankPriceVersionRepository := repository.NewWasteBankPriceVersionRepository(config.Log)
 	wasteDropRequestRepository := repository.NewWasteDropRequestRepository(config.Log)
 	wasteDropRequesItemRepository := repository.NewWasteDropRequestItemRepository(config.Log)
 	wasteTransferRequestRepository := repository.NewWasteTransferRequestRepository(config.Log)
 	wasteTransferItemOfferingRepository := repository.NewWasteTransferItemOfferingRepository(config.Log)
 	collectorManagementRepository := repository.NewCollectorManagementRepository(config.Log)
 	salaryTransactionRepository := repository.NewSalaryTransactionRepository(config.Log)
-	pointConversionRepository := repository.NewPointConversionRepository(config.Log)
+	pointRedemptionRepository := repository.NewPointRedemptionRepository(config.Log)
+	pointConversionRateRepository := repository.NewPointConversionRateRepository(config.Log)
 	storageRepository := repository.NewStorageRepository(config.Log)
 	storageItemRepository := repository.NewStorageItemRepository(config.Log)
+	wasteDropRequestStatusHistoryRepository := repository.NewWasteDropRequestStatusHistoryRepository(config.Log)
+	ledgerAccountRepository := repository.NewLedgerAccountRepository(config.Log)
+	ledgerEntryRepository := repository.NewLedgerEntryRepository(config.Log)
+	stockMovementRepository := repository.NewStockMovementRepository(config.Log)
+	stockTakeRepository := repository.NewStockTakeRepository(config.Log)
+	stockTakeLineRepository := repository.NewStockTakeLineRepository(config.Log)
+	ledgerDriftRepository := repository.NewLedgerDriftRepository(config.Log)
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepository(config.Log)
+	impactFactorRepository := repository.NewImpactFactorRepository(config.Log)
+	uploadedFileRepository := repository.NewUploadedFileRepository(config.Log)
+	collectorAssignmentRepository := repository.NewCollectorAssignmentRepository(config.Log)
+	collectorRouteRepository := repository.NewCollectorRouteRepository(config.Log)
+	wasteBankScheduleExceptionRepository := repository.NewWasteBankScheduleExceptionRepository(config.Log)
+	pickupSubscriptionRepository := repository.NewPickupSubscriptionRepository(config.Log)
+	pickupSubscriptionItemRepository := repository.NewPickupSubscriptionItemRepository(config.Log)
+	pickupSubscriptionOccurrenceRepository := repository.NewPickupSubscriptionOccurrenceRepository(config.Log)
+	wasteBankPromotionRepository := repository.NewWasteBankPromotionRepository(config.Log)
+	wasteDropQuoteRepository := repository.NewWasteDropQuoteRepository(config.Log)
+	wasteDropQuoteItemRepository := repository.NewWasteDropQuoteItemRepository(config.Log)
+	serviceAreaRepository := repository.NewServiceAreaRepository(config.Log)
+	regionRepository := repository.NewRegionRepository(config.Log)
+	dailyWasteRollupRepository := repository.NewDailyWasteRollupRepository(config.Log)
+	fileStorage := NewFileStorage(config.Config, config.App, config.Log)
 
 	// Setup Helper
 	jwtHelper := helper.NewJWTHelper(
@@ -76,27 +101,57 @@ func Bootstrap(config *BootstrapConfig) {
 		industryRepository,
 		collectorManagementRepository,
 		storageRepository,
+		regionRepository,
 		jwtHelper,
 		emailHelper,
 		config.Config.GetString("app.base_url"), // Base URL for email links
 	)
+	ledgerUseCase := usecase.NewLedgerUsecase(config.DB, config.Log, config.Validate, ledgerAccountRepository, ledgerEntryRepository, ledgerDriftRepository, userRepository)
 	customerUseCase := usecase.NewCustomerUseCase(config.DB, config.Log, config.Validate, customerRepository)
 	wasteBankUseCase := usecase.NewWasteBankUseCase(config.DB, config.Log, config.Validate, wasteBankRepository)
 	wasteCollectorUseCase := usecase.NewWasteCollectorUseCase(config.DB, config.Log, config.Validate, wasteCollectorRepository)
 	industryUseCase := usecase.NewIndustryUseCase(config.DB, config.Log, config.Validate, industryRepository)
 	wasteCategoryUseCase := usecase.NewWasteCategoryUsecase(config.DB, config.Log, config.Validate, wasteCategoryRepository)
 	wasteTypeUseCase := usecase.NewWasteTypeUsecase(config.DB, config.Log, config.Validate, wasteCategoryRepository, wasteTypeRepository)
-	wasteBankPricedTypeUseCase := usecase.NewWasteBankPricedTypeUsecase(config.DB, config.Log, config.Validate, wasteBankPricedTypeRepository, wasteTypeRepository)
-	wasteDropRequestUseCase := usecase.NewWasteDropRequestUsecase(config.DB, config.Log, config.Validate, wasteDropRequestRepository, userRepository, wasteTypeRepository, wasteDropRequesItemRepository, wasteBankPricedTypeRepository, customerRepository, wasteBankRepository, wasteCollectorRepository, storageRepository, storageItemRepository)
+	wasteBankPricedTypeUseCase := usecase.NewWasteBankPricedTypeUsecase(config.DB, config.Log, config.Validate, wasteBankPricedTypeRepository, wasteTypeRepository, wasteBankPriceVersionRepository)
+	appointmentSlotUseCase := usecase.NewAppointmentSlotUsecase(config.DB, config.Log, config.Validate, wasteBankRepository, wasteBankScheduleExceptionRepository, wasteDropRequestRepository)
+	collectorAssignmentUseCase := usecase.NewCollectorAssignmentUsecase(config.DB, config.Log, config.Validate, collectorAssignmentRepository, wasteDropRequestRepository, wasteDropRequestStatusHistoryRepository, wasteBankRepository)
+	wasteDropRequestUseCase := usecase.NewWasteDropRequestUsecase(config.DB, config.Log, config.Validate, wasteDropRequestRepository, userRepository, wasteTypeRepository, wasteDropRequesItemRepository, wasteBankPricedTypeRepository, wasteBankPriceVersionRepository, customerRepository, wasteBankRepository, wasteCollectorRepository, storageRepository, storageItemRepository, wasteDropRequestStatusHistoryRepository, impactFactorRepository, ledgerUseCase, collectorAssignmentUseCase, appointmentSlotUseCase, wasteDropQuoteRepository, serviceAreaRepository, regionRepository, dailyWasteRollupRepository, stockMovementRepository)
+	wasteBankPromotionUseCase := usecase.NewWasteBankPromotionUsecase(config.DB, config.Log, config.Validate, wasteBankPromotionRepository, wasteBankRepository, wasteTypeRepository)
+	wasteDropQuoteUseCase := usecase.NewWasteDropQuoteUsecase(config.DB, config.Log, config.Validate, wasteDropQuoteRepository, wasteDropQuoteItemRepository, wasteBankPriceVersionRepository, wasteBankPromotionRepository, wasteBankRepository, wasteTypeRepository, userRepository)
+	regionUseCase := usecase.NewRegionUsecase(config.DB, config.Log, config.Validate, regionRepository)
+	dailyWasteRollupUseCase := usecase.NewDailyWasteRollupUsecase(config.DB, config.Log, config.Validate, dailyWasteRollupRepository)
+	wasteBankDashboardUseCase := usecase.NewWasteBankDashboardUsecase(config.DB, config.Log, config.Validate, userRepository, dailyWasteRollupRepository, storageItemRepository, wasteDropRequestRepository)
+	industryDashboardUseCase := usecase.NewIndustryDashboardUsecase(config.DB, config.Log, config.Validate, userRepository, dailyWasteRollupRepository, wasteTransferItemOfferingRepository)
+	customerStatementUseCase := usecase.NewCustomerStatementUsecase(config.DB, config.Log, config.Validate, userRepository, wasteDropRequestRepository, ledgerAccountRepository, ledgerEntryRepository)
+	serviceAreaUseCase := usecase.NewServiceAreaUsecase(config.DB, config.Log, config.Validate, serviceAreaRepository, userRepository)
+	wasteBankRecommendationUseCase := usecase.NewWasteBankRecommendationUsecase(config.DB, config.Log, config.Validate, wasteBankRepository, wasteBankPriceVersionRepository, userRepository, appointmentSlotUseCase)
+	pickupSubscriptionUseCase := usecase.NewPickupSubscriptionUsecase(config.DB, config.Log, config.Validate, pickupSubscriptionRepository, pickupSubscriptionItemRepository, pickupSubscriptionOccurrenceRepository, wasteDropRequestRepository, userRepository, wasteTypeRepository, wasteDropRequestUseCase, appointmentSlotUseCase)
 	wasteDropRequestItemUseCase := usecase.NewWasteDropRequestItemUsecase(config.DB, config.Log, config.Validate, wasteDropRequesItemRepository, wasteDropRequestRepository, wasteTypeRepository)
-	wasteTransferRequestUseCase := usecase.NewWasteTransferRequestUsecase(config.DB, config.Log, config.Validate, wasteTransferRequestRepository, wasteTransferItemOfferingRepository, userRepository, wasteTypeRepository, storageRepository, storageItemRepository, industryRepository, wasteBankRepository, salaryTransactionRepository)
+	wasteTransferRequestUseCase := usecase.NewWasteTransferRequestUsecase(config.DB, config.Log, config.Validate, wasteTransferRequestRepository, wasteTransferItemOfferingRepository, userRepository, wasteTypeRepository, storageRepository, storageItemRepository, industryRepository, wasteBankRepository, salaryTransactionRepository, regionRepository, dailyWasteRollupRepository, stockMovementRepository)
+	requestExpiryUseCase := usecase.NewRequestExpiryUsecase(config.DB, config.Log, wasteDropRequestRepository, wasteTransferRequestRepository, wasteDropRequestUseCase, wasteTransferRequestUseCase, config.Config.GetInt("request_expiry.pending_hours"))
 	wasteTransferItemOfferingUseCase := usecase.NewWasteTransferItemOfferingUsecase(config.DB, config.Log, config.Validate, wasteTransferItemOfferingRepository, wasteTransferRequestRepository, wasteTypeRepository)
 	collectorManagementUseCase := usecase.NewCollectorManagementUsecase(config.DB, config.Log, config.Validate, collectorManagementRepository, userRepository)
-	salaryTransactionUseCase := usecase.NewSalaryTransactionUsecase(config.DB, config.Log, config.Validate, salaryTransactionRepository, userRepository)
-	pointConversionUseCase := usecase.NewPointConversionUsecase(config.DB, config.Log, config.Validate, pointConversionRepository, userRepository)
-	storageUseCase := usecase.NewStorageUsecase(config.DB, config.Log, config.Validate, storageRepository, userRepository)
-	storageItemUseCase := usecase.NewStorageItemUsecase(config.DB, config.Log, config.Validate, storageRepository, storageItemRepository, wasteTypeRepository)
-	governmentUseCase := usecase.NewGovernmentUseCase(config.DB, config.Log, config.Validate, userRepository, wasteDropRequesItemRepository, wasteTransferItemOfferingRepository, wasteTransferRequestRepository, storageRepository)
+	salaryTransactionUseCase := usecase.NewSalaryTransactionUsecase(config.DB, config.Log, config.Validate, salaryTransactionRepository, userRepository, ledgerUseCase)
+	pointRedemptionUseCase := usecase.NewPointRedemptionUsecase(config.DB, config.Log, config.Validate, pointRedemptionRepository, pointConversionRateRepository, userRepository, ledgerUseCase)
+	storageUseCase := usecase.NewStorageUsecase(config.DB, config.Log, config.Validate, storageRepository, userRepository, storageItemRepository, wasteBankPricedTypeRepository)
+	storageItemUseCase := usecase.NewStorageItemUsecase(config.DB, config.Log, config.Validate, storageRepository, storageItemRepository, wasteTypeRepository, stockMovementRepository)
+	stockTakeUseCase := usecase.NewStockTakeUsecase(config.DB, config.Log, config.Validate, userRepository, storageRepository, storageItemRepository, wasteTypeRepository, stockTakeRepository, stockTakeLineRepository, stockMovementRepository)
+	governmentUseCase := usecase.NewGovernmentUseCase(config.DB, config.Log, config.Validate, userRepository, wasteDropRequesItemRepository, wasteTransferItemOfferingRepository, wasteTransferRequestRepository, storageRepository, impactFactorRepository, regionRepository, wasteDropRequestRepository, dailyWasteRollupRepository)
+	impactFactorUseCase := usecase.NewImpactFactorUsecase(config.DB, config.Log, config.Validate, impactFactorRepository, wasteTypeRepository, wasteCategoryRepository)
+	collectorRouteUseCase := usecase.NewCollectorRouteUsecase(config.DB, config.Log, config.Validate, collectorRouteRepository, collectorManagementRepository, userRepository, collectorRouteOptions(config.Config))
+	fileUseCase := usecase.NewFileUsecase(
+		config.DB,
+		config.Log,
+		config.Validate,
+		fileStorage,
+		uploadedFileRepository,
+		userRepository,
+		wasteDropRequestRepository,
+		wasteTransferRequestRepository,
+		maxUploadSizeMB(config.Config)*1024*1024, // Upload size limit in bytes
+		thumbnailSize(config.Config),
+	)
 
 	// Setup controllers
 	userController := http.NewUserController(
@@ -116,15 +171,33 @@ func Bootstrap(config *BootstrapConfig) {
 	wasteTransferItemOfferingController := http.NewWasteTransferItemOfferingController(wasteTransferItemOfferingUseCase, config.Log)
 	collectorManagementController := http.NewCollectorManagementController(collectorManagementUseCase, config.Log)
 	salaryTransactionController := http.NewSalaryTransactionController(salaryTransactionUseCase, config.Log)
-	pointConversionController := http.NewPointConversionController(pointConversionUseCase, config.Log)
+	pointRedemptionController := http.NewPointRedemptionController(pointRedemptionUseCase, config.Log)
 	storageController := http.NewStorageController(storageUseCase, config.Log)
 	storageItemController := http.NewStorageItemController(storageItemUseCase, config.Log)
 	governmentController := http.NewGovernmentController(governmentUseCase, config.Log)
+	ledgerController := http.NewLedgerController(ledgerUseCase, config.Log)
+	impactFactorController := http.NewImpactFactorController(impactFactorUseCase, config.Log)
+	fileController := http.NewFileController(fileUseCase, config.Log)
+	collectorAssignmentController := http.NewCollectorAssignmentController(collectorAssignmentUseCase, config.Log)
+	collectorRouteController := http.NewCollectorRouteController(collectorRouteUseCase, config.Log)
+	appointmentSlotController := http.NewAppointmentSlotController(appointmentSlotUseCase, config.Log)
+	pickupSubscriptionController := http.NewPickupSubscriptionController(pickupSubscriptionUseCase, config.Log)
+	wasteBankPromotionController := http.NewWasteBankPromotionController(wasteBankPromotionUseCase, config.Log)
+	wasteDropQuoteController := http.NewWasteDropQuoteController(wasteDropQuoteUseCase, config.Log)
+	regionController := http.NewRegionController(regionUseCase, config.Log)
+	dailyWasteRollupController := http.NewDailyWasteRollupController(dailyWasteRollupUseCase, config.Log)
+	wasteBankDashboardController := http.NewWasteBankDashboardController(wasteBankDashboardUseCase, config.Log)
+	industryDashboardController := http.NewIndustryDashboardController(industryDashboardUseCase, config.Log)
+	customerStatementController := http.NewCustomerStatementController(customerStatementUseCase, config.Log)
+	stockTakeController := http.NewStockTakeController(stockTakeUseCase, config.Log)
+	serviceAreaController := http.NewServiceAreaController(serviceAreaUseCase, config.Log)
+	wasteBankRecommendationController := http.NewWasteBankRecommendationController(wasteBankRecommendationUseCase, config.Log)
 
 	// Setup middlewares
 	authMiddleware := middleware.NewJWTAuth(
 		jwtHelper,
 	)
+	idempotencyMiddleware := middleware.NewIdempotency(config.DB, idempotencyKeyRepository, config.Log)
 
 	routeConfig := route.RouteConfig{
 		App:                                 config.App,
@@ -142,13 +215,45 @@ func Bootstrap(config *BootstrapConfig) {
 		WasteTransferItemOfferingController: wasteTransferItemOfferingController,
 		CollectorManagementController:       collectorManagementController,
 		SalaryTransactionController:         salaryTransactionController,
-		PointConversionController:           pointConversionController,
+		PointRedemptionController:           pointRedemptionController,
 		StorageController:                   storageController,
 		StorageItemController:               storageItemController,
 		GovernmentController:                governmentController,
+		LedgerController:                    ledgerController,
+		ImpactFactorController:              impactFactorController,
+		FileController:                      fileController,
+		CollectorAssignmentController:       collectorAssignmentController,
+		CollectorRouteController:            collectorRouteController,
+		AppointmentSlotController:           appointmentSlotController,
+		PickupSubscriptionController:        pickupSubscriptionController,
+		WasteBankPromotionController:        wasteBankPromotionController,
+		WasteDropQuoteController:            wasteDropQuoteController,
+		WasteBankRecommendationController:   wasteBankRecommendationController,
+		ServiceAreaController:               serviceAreaController,
+		RegionController:                    regionController,
+		DailyWasteRollupController:          dailyWasteRollupController,
+		WasteBankDashboardController:        wasteBankDashboardController,
+		IndustryDashboardController:         industryDashboardController,
+		CustomerStatementController:         customerStatementController,
+		StockTakeController:                 stockTakeController,
 		AuthMiddleware:                      authMiddleware,
+		IdempotencyMiddleware:               idempotencyMiddleware,
 	}
 
 	routeConfig.Setup()
 	job.StartTokenCleanupJob(config.DB, jwtHelper)
+	job.StartLedgerReconciliationJob(ledgerUseCase)
+	job.StartIdempotencyKeyCleanupJob(config.DB, idempotencyKeyRepository)
+	job.StartPriceVersionSyncJob(wasteBankPricedTypeUseCase)
+	job.StartImpactRecomputeJob(impactFactorUseCase)
+	job.StartOrphanFileCleanupJob(fileUseCase)
+	// Prefork children would each run the sweep and race on the same requests, only the parent sweeps
+	if !fiber.IsChild() {
+		job.StartCollectorAssignmentSweepJob(collectorAssignmentUseCase)
+	}
+	job.StartPickupSubscriptionJob(pickupSubscriptionUseCase)
+	// Same as the sweep, one expiry run per tick rather than one per prefork child
+	if !fiber.IsChild() {
+		job.StartRequestExpiryJob(requestExpiryUseCase)
+	}
 }
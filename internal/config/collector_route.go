@@ -0,0 +1,28 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/wastetrack/wastetrack-backend/internal/helper"
+)
+
+// collectorRouteOptions reads the routing section used to estimate collector arrival times.
+// Defaults are 20 km/h average speed, 10 minutes per stop and departure at 08:00.
+func collectorRouteOptions(config *viper.Viper) helper.RouteOptions {
+	options := helper.RouteOptions{
+		DepartureMinute: 8 * 60,
+		SpeedKmh:        20,
+		ServiceMinutes:  10,
+	}
+	if speed := config.GetFloat64("routing.average_speed_kmh"); speed > 0 {
+		options.SpeedKmh = speed
+	}
+	if minutes := config.GetInt("routing.service_minutes"); minutes > 0 {
+		options.ServiceMinutes = minutes
+	}
+	if departure, err := time.Parse("15:04", config.GetString("routing.departure_time")); err == nil {
+		options.DepartureMinute = departure.Hour()*60 + departure.Minute()
+	}
+	return options
+}
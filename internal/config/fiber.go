@@ -10,6 +10,8 @@ func NewFiber(config *viper.Viper) *fiber.App {
 		AppName:      config.GetString("app.name"),
 		ErrorHandler: NewErrorHandler(),
 		Prefork:      config.GetBool("web.prefork"),
+		// Leaves room for the multipart overhead around the largest allowed upload
+		BodyLimit: int(maxUploadSizeMB(config)+1) * 1024 * 1024,
 	})
 
 	return app
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"context"
+	"net/url"
+
+	"cloud.google.com/go/storage"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+)
+
+// NewFileStorage picks the storage backend from storage.driver, "gcs" or "local" (the default).
+// The local directory is served statically under the path of its base URL.
+func NewFileStorage(config *viper.Viper, app *fiber.App, log *logrus.Logger) repository.FileStorage {
+	switch config.GetString("storage.driver") {
+	case "gcs":
+		// Credentials come from the environment (GOOGLE_APPLICATION_CREDENTIALS or the metadata server)
+		client, err := storage.NewClient(context.Background())
+		if err != nil {
+			log.Fatalf("Failed to create GCS client: %v", err)
+		}
+		return repository.NewGCSFileRepository(client, config.GetString("storage.gcs.bucket"))
+	default:
+		dir := config.GetString("storage.local.dir")
+		if dir == "" {
+			dir = "./uploads"
+		}
+		baseURL := config.GetString("storage.local.base_url")
+		if baseURL == "" {
+			baseURL = "/uploads"
+		}
+
+		routePath := "/uploads"
+		if parsed, err := url.Parse(baseURL); err == nil && parsed.Path != "" {
+			routePath = parsed.Path
+		}
+		app.Static(routePath, dir)
+
+		return repository.NewLocalFileRepository(dir, baseURL)
+	}
+}
+
+// maxUploadSizeMB reads storage.max_upload_size_mb, defaulting to 5 MB
+func maxUploadSizeMB(config *viper.Viper) int64 {
+	if size := config.GetInt64("storage.max_upload_size_mb"); size > 0 {
+		return size
+	}
+	return 5
+}
+
+// thumbnailSize reads storage.thumbnail_size, the longest side of thumbnails in pixels, defaulting to 320
+func thumbnailSize(config *viper.Viper) int {
+	if size := config.GetInt("storage.thumbnail_size"); size > 0 {
+		return size
+	}
+	return 320
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type AppointmentSlotController struct {
+	Log                    *logrus.Logger
+	AppointmentSlotUsecase *usecase.AppointmentSlotUsecase
+}
+
+func NewAppointmentSlotController(usecase *usecase.AppointmentSlotUsecase, logger *logrus.Logger) *AppointmentSlotController {
+	return &AppointmentSlotController{
+		Log:                    logger,
+		AppointmentSlotUsecase: usecase,
+	}
+}
+
+func (c *AppointmentSlotController) ListSlots(ctx *fiber.Ctx) error {
+	request := &model.SearchAppointmentSlotRequest{
+		WasteBankID:   ctx.Query("waste_bank_id"),
+		StartDate:     ctx.Query("start_date"),
+		EndDate:       ctx.Query("end_date"),
+		DeliveryType:  ctx.Query("delivery_type"),
+		OnlyAvailable: ctx.QueryBool("only_available", false),
+	}
+
+	responses, err := c.AppointmentSlotUsecase.ListSlots(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to list appointment slots: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.AppointmentDayResponse]{Data: responses})
+}
+
+func (c *AppointmentSlotController) CreateException(ctx *fiber.Ctx) error {
+	request := new(model.ScheduleExceptionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	auth := middleware.GetUser(ctx)
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	response, err := c.AppointmentSlotUsecase.CreateException(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create schedule exception: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ScheduleExceptionResponse]{Data: response})
+}
+
+func (c *AppointmentSlotController) ListExceptions(ctx *fiber.Ctx) error {
+	request := &model.SearchScheduleExceptionRequest{
+		WasteBankID: ctx.Query("waste_bank_id"),
+		StartDate:   ctx.Query("start_date"),
+		EndDate:     ctx.Query("end_date"),
+		Page:        ctx.QueryInt("page", 1),
+		Size:        ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.AppointmentSlotUsecase.SearchExceptions(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search schedule exceptions")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.ScheduleExceptionResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *AppointmentSlotController) DeleteException(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.DeleteScheduleExceptionRequest{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	response, err := c.AppointmentSlotUsecase.DeleteException(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to delete schedule exception: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ScheduleExceptionResponse]{Data: response})
+}
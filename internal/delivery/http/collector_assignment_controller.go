@@ -0,0 +1,86 @@
+package http
+
+import (
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type CollectorAssignmentController struct {
+	Log                        *logrus.Logger
+	CollectorAssignmentUsecase *usecase.CollectorAssignmentUsecase
+}
+
+func NewCollectorAssignmentController(usecase *usecase.CollectorAssignmentUsecase, logger *logrus.Logger) *CollectorAssignmentController {
+	return &CollectorAssignmentController{
+		Log:                        logger,
+		CollectorAssignmentUsecase: usecase,
+	}
+}
+
+func (c *CollectorAssignmentController) AutoAssign(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.AutoAssignCollectorRequest{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	response, err := c.CollectorAssignmentUsecase.AssignRequest(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to auto-assign collector: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CollectorAssignmentResponse]{Data: response})
+}
+
+func (c *CollectorAssignmentController) ListByRequest(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchCollectorAssignmentRequest{
+		RequestID: ctx.Params("id"),
+		Page:      ctx.QueryInt("page", 1),
+		Size:      ctx.QueryInt("size", 10),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+	return c.search(ctx, request)
+}
+
+func (c *CollectorAssignmentController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchCollectorAssignmentRequest{
+		RequestID:   ctx.Query("request_id"),
+		WasteBankID: ctx.Query("waste_bank_id"),
+		CollectorID: ctx.Query("collector_id"),
+		Page:        ctx.QueryInt("page", 1),
+		Size:        ctx.QueryInt("size", 10),
+		ActorID:     auth.ID,
+		ActorRole:   auth.Role,
+	}
+	return c.search(ctx, request)
+}
+
+func (c *CollectorAssignmentController) search(ctx *fiber.Ctx, request *model.SearchCollectorAssignmentRequest) error {
+	responses, total, err := c.CollectorAssignmentUsecase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search collector assignments")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.CollectorAssignmentResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type CollectorRouteController struct {
+	Log                   *logrus.Logger
+	CollectorRouteUsecase *usecase.CollectorRouteUsecase
+}
+
+func NewCollectorRouteController(usecase *usecase.CollectorRouteUsecase, logger *logrus.Logger) *CollectorRouteController {
+	return &CollectorRouteController{
+		Log:                   logger,
+		CollectorRouteUsecase: usecase,
+	}
+}
+
+func (c *CollectorRouteController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetCollectorRouteRequest{
+		CollectorID: ctx.Query("collector_id", auth.ID), // Waste banks and admins pick the collector
+		Date:        ctx.Query("date"),
+		StartTime:   ctx.Query("start_time"),
+		ActorID:     auth.ID,
+		ActorRole:   auth.Role,
+	}
+
+	// Optional start location, defaults to the collector's profile location
+	if ctx.Query("lat") != "" && ctx.Query("lng") != "" {
+		request.Start = &model.LocationRequest{
+			Latitude:  ctx.QueryFloat("lat"),
+			Longitude: ctx.QueryFloat("lng"),
+		}
+	}
+
+	response, err := c.CollectorRouteUsecase.GetRoute(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get collector route: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CollectorRouteResponse]{Data: response})
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type CustomerStatementController struct {
+	Log                      *logrus.Logger
+	CustomerStatementUsecase *usecase.CustomerStatementUsecase
+}
+
+func NewCustomerStatementController(usecase *usecase.CustomerStatementUsecase, logger *logrus.Logger) *CustomerStatementController {
+	return &CustomerStatementController{
+		Log:                      logger,
+		CustomerStatementUsecase: usecase,
+	}
+}
+
+// Get handles GET /api/customer/statement, format=csv or format=pdf downloads the statement as a file
+func (c *CustomerStatementController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.CustomerStatementRequest{
+		CustomerID: ctx.Query("customer_id"),
+		StartMonth: ctx.Query("start_month"),
+		EndMonth:   ctx.Query("end_month"),
+		Format:     ctx.Query("format"),
+		ActorID:    auth.ID,
+		ActorRole:  auth.Role,
+	}
+
+	response, err := c.CustomerStatementUsecase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get customer statement: %v", err)
+		return err
+	}
+
+	switch request.Format {
+	case "csv":
+		body, err := c.CustomerStatementUsecase.StatementToCSV(response)
+		if err != nil {
+			return err
+		}
+		ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="statement-%s.csv"`, response.CustomerID))
+		return ctx.Send(body)
+	case "pdf":
+		ctx.Set(fiber.HeaderContentType, "application/pdf")
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="statement-%s.pdf"`, response.CustomerID))
+		return ctx.Send(c.CustomerStatementUsecase.StatementToPDF(response))
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CustomerStatementResponse]{Data: response})
+}
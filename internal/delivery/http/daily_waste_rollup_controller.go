@@ -0,0 +1,34 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type DailyWasteRollupController struct {
+	Log                     *logrus.Logger
+	DailyWasteRollupUsecase *usecase.DailyWasteRollupUsecase
+}
+
+func NewDailyWasteRollupController(usecase *usecase.DailyWasteRollupUsecase, logger *logrus.Logger) *DailyWasteRollupController {
+	return &DailyWasteRollupController{
+		Log:                     logger,
+		DailyWasteRollupUsecase: usecase,
+	}
+}
+
+func (c *DailyWasteRollupController) Rebuild(ctx *fiber.Ctx) error {
+	request := &model.RebuildDailyWasteRollupRequest{
+		Since: ctx.Query("since"),
+	}
+
+	response, err := c.DailyWasteRollupUsecase.Rebuild(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to rebuild daily waste rollups: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.RebuildDailyWasteRollupResponse]{Data: response})
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type FileController struct {
+	Log         *logrus.Logger
+	FileUsecase *usecase.FileUsecase
+}
+
+func NewFileController(usecase *usecase.FileUsecase, logger *logrus.Logger) *FileController {
+	return &FileController{
+		Log:         logger,
+		FileUsecase: usecase,
+	}
+}
+
+// Helper method to build an upload request from the multipart "file" field
+func (c *FileController) parseUpload(ctx *fiber.Ctx, ownerID string) (*model.UploadFileRequest, error) {
+	auth := middleware.GetUser(ctx)
+
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		c.Log.Warnf("Failed to read uploaded file: %v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Multipart field \"file\" is required")
+	}
+
+	return &model.UploadFileRequest{
+		OwnerID:   ownerID,
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+		File:      file,
+	}, nil
+}
+
+func (c *FileController) UploadWasteDropRequestImage(ctx *fiber.Ctx) error {
+	request, err := c.parseUpload(ctx, ctx.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	response, err := c.FileUsecase.UploadWasteDropRequestImage(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to upload waste drop request image: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.UploadedFileResponse]{Data: response})
+}
+
+func (c *FileController) UploadWasteTransferRequestImage(ctx *fiber.Ctx) error {
+	request, err := c.parseUpload(ctx, ctx.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	response, err := c.FileUsecase.UploadWasteTransferRequestImage(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to upload waste transfer request image: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.UploadedFileResponse]{Data: response})
+}
+
+func (c *FileController) UploadAvatar(ctx *fiber.Ctx) error {
+	request, err := c.parseUpload(ctx, middleware.GetUser(ctx).ID)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.FileUsecase.UploadAvatar(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to upload avatar: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.UploadedFileResponse]{Data: response})
+}
@@ -26,6 +26,7 @@ func (c *GovernmentController) GetDashboard(ctx *fiber.Ctx) error {
 		EndMonth:   ctx.Query("end_month"),
 		Province:   ctx.Query("province"),
 		City:       ctx.Query("city"),
+		RegionCode: ctx.Query("region_code"),
 	}
 
 	response, err := c.GovernmentUsecase.GetDashboard(request)
@@ -38,3 +39,52 @@ func (c *GovernmentController) GetDashboard(ctx *fiber.Ctx) error {
 		Data: response,
 	})
 }
+
+// GetRegionBreakdown handles GET /api/government/dashboard/regions
+func (c *GovernmentController) GetRegionBreakdown(ctx *fiber.Ctx) error {
+	request := &model.RegionBreakdownRequest{
+		StartMonth: ctx.Query("start_month"),
+		EndMonth:   ctx.Query("end_month"),
+		ParentCode: ctx.Query("parent_code"),
+		Format:     ctx.Query("format"),
+	}
+
+	response, err := c.GovernmentUsecase.GetRegionBreakdown(request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to get region breakdown")
+		return err
+	}
+
+	if request.Format == "geojson" {
+		return ctx.JSON(c.GovernmentUsecase.RegionBreakdownToGeoJSON(response), "application/geo+json")
+	}
+	return ctx.JSON(model.WebResponse[*model.RegionBreakdownResponse]{
+		Data: response,
+	})
+}
+
+// GetDropHeatmap handles GET /api/government/dashboard/heatmap
+func (c *GovernmentController) GetDropHeatmap(ctx *fiber.Ctx) error {
+	request := &model.DropHeatmapRequest{
+		StartMonth: ctx.Query("start_month"),
+		EndMonth:   ctx.Query("end_month"),
+		RegionCode: ctx.Query("region_code"),
+		Mode:       ctx.Query("mode"),
+		CellSize:   ctx.QueryInt("cell_size", 0),
+		Precision:  ctx.QueryInt("precision", 0),
+		Format:     ctx.Query("format"),
+	}
+
+	response, err := c.GovernmentUsecase.GetDropHeatmap(request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to get drop request heatmap")
+		return err
+	}
+
+	if request.Format == "geojson" {
+		return ctx.JSON(c.GovernmentUsecase.DropHeatmapToGeoJSON(response), "application/geo+json")
+	}
+	return ctx.JSON(model.WebResponse[*model.DropHeatmapResponse]{
+		Data: response,
+	})
+}
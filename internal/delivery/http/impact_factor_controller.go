@@ -0,0 +1,122 @@
+package http
+
+import (
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/helper"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type ImpactFactorController struct {
+	Log                 *logrus.Logger
+	ImpactFactorUsecase *usecase.ImpactFactorUsecase
+}
+
+func NewImpactFactorController(usecase *usecase.ImpactFactorUsecase, logger *logrus.Logger) *ImpactFactorController {
+	return &ImpactFactorController{
+		Log:                 logger,
+		ImpactFactorUsecase: usecase,
+	}
+}
+
+func (c *ImpactFactorController) Create(ctx *fiber.Ctx) error {
+	request := new(model.ImpactFactorRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.ImpactFactorUsecase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create impact factor: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ImpactFactorResponse]{Data: response})
+}
+
+func (c *ImpactFactorController) Get(ctx *fiber.Ctx) error {
+	request := &model.GetImpactFactorRequest{
+		ID: ctx.Params("id"),
+	}
+
+	response, err := c.ImpactFactorUsecase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get impact factor: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ImpactFactorResponse]{Data: response})
+}
+
+func (c *ImpactFactorController) List(ctx *fiber.Ctx) error {
+	request := &model.SearchImpactFactorRequest{
+		WasteTypeID:     ctx.Query("waste_type_id"),
+		WasteCategoryID: ctx.Query("waste_category_id"),
+		IsDeleted:       helper.ParseBoolQuery(ctx, "is_deleted"),
+		Page:            ctx.QueryInt("page", 1),
+		Size:            ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.ImpactFactorUsecase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search impact factors")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.ImpactFactorResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *ImpactFactorController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateImpactFactorRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+
+	response, err := c.ImpactFactorUsecase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to update impact factor: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ImpactFactorResponse]{Data: response})
+}
+
+func (c *ImpactFactorController) Delete(ctx *fiber.Ctx) error {
+	request := &model.DeleteImpactFactorRequest{
+		ID: ctx.Params("id"),
+	}
+
+	if err := c.ImpactFactorUsecase.Delete(ctx.UserContext(), request); err != nil {
+		c.Log.Warnf("Failed to delete impact factor: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+// Recompute applies the current factors to customer impact totals without waiting for the job
+func (c *ImpactFactorController) Recompute(ctx *fiber.Ctx) error {
+	updated, err := c.ImpactFactorUsecase.RecomputeCustomerImpact(ctx.UserContext(), true)
+	if err != nil {
+		c.Log.Warnf("Failed to recompute customer impact: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.RecomputeImpactResponse]{Data: &model.RecomputeImpactResponse{UpdatedProfiles: updated}})
+}
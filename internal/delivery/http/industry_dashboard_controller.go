@@ -0,0 +1,41 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type IndustryDashboardController struct {
+	Log                      *logrus.Logger
+	IndustryDashboardUsecase *usecase.IndustryDashboardUsecase
+}
+
+func NewIndustryDashboardController(usecase *usecase.IndustryDashboardUsecase, logger *logrus.Logger) *IndustryDashboardController {
+	return &IndustryDashboardController{
+		Log:                      logger,
+		IndustryDashboardUsecase: usecase,
+	}
+}
+
+// Get handles GET /api/industry/dashboard
+func (c *IndustryDashboardController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.IndustryDashboardRequest{
+		IndustryID: ctx.Query("industry_id"),
+		StartMonth: ctx.Query("start_month"),
+		EndMonth:   ctx.Query("end_month"),
+		ActorID:    auth.ID,
+		ActorRole:  auth.Role,
+	}
+
+	response, err := c.IndustryDashboardUsecase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get industry dashboard: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.IndustryDashboardResponse]{Data: response})
+}
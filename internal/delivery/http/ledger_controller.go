@@ -0,0 +1,100 @@
+package http
+
+import (
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/helper"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type LedgerController struct {
+	Log           *logrus.Logger
+	LedgerUsecase *usecase.LedgerUsecase
+}
+
+func NewLedgerController(usecase *usecase.LedgerUsecase, logger *logrus.Logger) *LedgerController {
+	return &LedgerController{
+		Log:           logger,
+		LedgerUsecase: usecase,
+	}
+}
+
+// GetStatement handles GET /api/ledger/statement
+func (c *LedgerController) GetStatement(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.LedgerStatementRequest{
+		UserID:    auth.ID,
+		Asset:     ctx.Query("asset", "points"),
+		StartDate: ctx.Query("start_date"),
+		EndDate:   ctx.Query("end_date"),
+		Page:      ctx.QueryInt("page", 1),
+		Size:      ctx.QueryInt("size", 10),
+	}
+
+	// Admins may look at any user's statement
+	if userID := ctx.Query("user_id"); userID != "" && auth.Role == "admin" {
+		request.UserID = userID
+	}
+
+	response, total, err := c.LedgerUsecase.GetStatement(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get ledger statement: %v", err)
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[*model.LedgerStatementResponse]{
+		Data:   response,
+		Paging: paging,
+	})
+}
+
+func (c *LedgerController) ListDrifts(ctx *fiber.Ctx) error {
+	request := &model.SearchLedgerDriftRequest{
+		UserID:     ctx.Query("user_id"),
+		Asset:      ctx.Query("asset"),
+		IsResolved: helper.ParseBoolQuery(ctx, "is_resolved"),
+		Page:       ctx.QueryInt("page", 1),
+		Size:       ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.LedgerUsecase.SearchDrifts(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search ledger drifts")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.LedgerDriftResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+// Reconcile runs the reconciliation on demand instead of waiting for the daily job
+func (c *LedgerController) Reconcile(ctx *fiber.Ctx) error {
+	drifts, err := c.LedgerUsecase.Reconcile(ctx.UserContext())
+	if err != nil {
+		c.Log.Warnf("Failed to reconcile ledger: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[map[string]int]{Data: map[string]int{"open_drifts": drifts}})
+}
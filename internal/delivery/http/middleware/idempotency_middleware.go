@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// Stored responses are replayed for this long, after which the key may be reused
+	IdempotencyKeyTTL = 24 * time.Hour
+)
+
+// NewIdempotency replays the stored response when a request is retried with the same
+// Idempotency-Key header. It must run after the JWT middleware since keys are scoped per user.
+func NewIdempotency(db *gorm.DB, idempotencyKeyRepository *repository.IdempotencyKeyRepository, log *logrus.Logger) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		key := ctx.Get(IdempotencyKeyHeader)
+		if key == "" {
+			return ctx.Next()
+		}
+		if len(key) > 255 {
+			return fiber.NewError(fiber.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+		}
+
+		auth := GetUser(ctx)
+		if auth == nil {
+			return fiber.ErrUnauthorized
+		}
+		userID, err := uuid.Parse(auth.ID)
+		if err != nil {
+			return fiber.ErrUnauthorized
+		}
+
+		// Fingerprint covers the route and the body, so the same key cannot be used for another request
+		hash := sha256.New()
+		hash.Write([]byte(ctx.Method()))
+		hash.Write([]byte(ctx.OriginalURL()))
+		hash.Write(ctx.Body())
+		fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+		tx := db.WithContext(ctx.UserContext())
+
+		record := &entity.IdempotencyKey{
+			Key:         key,
+			UserID:      userID,
+			Method:      ctx.Method(),
+			Path:        ctx.OriginalURL(),
+			RequestHash: fingerprint,
+		}
+		reserved, err := idempotencyKeyRepository.Reserve(tx, record)
+		if err != nil {
+			log.Warnf("Failed to reserve idempotency key: %+v", err)
+			return fiber.ErrInternalServerError
+		}
+
+		if !reserved {
+			existing := new(entity.IdempotencyKey)
+			if err := idempotencyKeyRepository.FindByUserAndKey(tx, existing, auth.ID, key); err != nil {
+				log.Warnf("Failed to find idempotency key: %+v", err)
+				return fiber.ErrInternalServerError
+			}
+
+			// An expired key is released and the request runs as new
+			if time.Since(existing.CreatedAt) > IdempotencyKeyTTL {
+				if err := idempotencyKeyRepository.Delete(tx, existing); err != nil {
+					log.Warnf("Failed to release expired idempotency key: %+v", err)
+					return fiber.ErrInternalServerError
+				}
+				if reserved, err = idempotencyKeyRepository.Reserve(tx, record); err != nil {
+					log.Warnf("Failed to reserve idempotency key: %+v", err)
+					return fiber.ErrInternalServerError
+				}
+			}
+
+			if !reserved {
+				if existing.RequestHash != fingerprint {
+					return fiber.NewError(fiber.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request")
+				}
+				if existing.CompletedAt == nil {
+					return fiber.NewError(fiber.StatusConflict, "A request with this Idempotency-Key is still being processed")
+				}
+
+				ctx.Set("Idempotent-Replayed", "true")
+				if existing.ContentType != "" {
+					ctx.Set(fiber.HeaderContentType, existing.ContentType)
+				}
+				return ctx.Status(existing.StatusCode).Send(existing.ResponseBody)
+			}
+		}
+
+		// Failed requests release the key so the client can retry them
+		if err := ctx.Next(); err != nil {
+			if deleteErr := idempotencyKeyRepository.Delete(tx, record); deleteErr != nil {
+				log.Warnf("Failed to release idempotency key: %+v", deleteErr)
+			}
+			return err
+		}
+
+		if ctx.Response().StatusCode() >= fiber.StatusInternalServerError {
+			if err := idempotencyKeyRepository.Delete(tx, record); err != nil {
+				log.Warnf("Failed to release idempotency key: %+v", err)
+			}
+			return nil
+		}
+
+		now := time.Now()
+		record.StatusCode = ctx.Response().StatusCode()
+		record.ContentType = string(ctx.Response().Header.ContentType())
+		record.ResponseBody = append([]byte(nil), ctx.Response().Body()...)
+		record.CompletedAt = &now
+		if err := idempotencyKeyRepository.SaveResponse(tx, record); err != nil {
+			log.Warnf("Failed to store idempotent response: %+v", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type PickupSubscriptionController struct {
+	Log                       *logrus.Logger
+	PickupSubscriptionUsecase *usecase.PickupSubscriptionUsecase
+}
+
+func NewPickupSubscriptionController(usecase *usecase.PickupSubscriptionUsecase, logger *logrus.Logger) *PickupSubscriptionController {
+	return &PickupSubscriptionController{
+		Log:                       logger,
+		PickupSubscriptionUsecase: usecase,
+	}
+}
+
+func (c *PickupSubscriptionController) Create(ctx *fiber.Ctx) error {
+	request := new(model.PickupSubscriptionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	auth := middleware.GetUser(ctx)
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	response, err := c.PickupSubscriptionUsecase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create pickup subscription: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PickupSubscriptionResponse]{Data: response})
+}
+
+func (c *PickupSubscriptionController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetPickupSubscriptionRequest{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	response, err := c.PickupSubscriptionUsecase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get pickup subscription: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PickupSubscriptionResponse]{Data: response})
+}
+
+func (c *PickupSubscriptionController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchPickupSubscriptionRequest{
+		CustomerID:  ctx.Query("customer_id"),
+		WasteBankID: ctx.Query("waste_bank_id"),
+		Status:      ctx.Query("status"),
+		Page:        ctx.QueryInt("page", 1),
+		Size:        ctx.QueryInt("size", 10),
+		ActorID:     auth.ID,
+		ActorRole:   auth.Role,
+	}
+
+	responses, total, err := c.PickupSubscriptionUsecase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search pickup subscriptions")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.PickupSubscriptionResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *PickupSubscriptionController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdatePickupSubscriptionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	auth := middleware.GetUser(ctx)
+	request.ID = ctx.Params("id")
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	response, err := c.PickupSubscriptionUsecase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to update pickup subscription: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PickupSubscriptionResponse]{Data: response})
+}
+
+func (c *PickupSubscriptionController) Pause(ctx *fiber.Ctx) error {
+	response, err := c.PickupSubscriptionUsecase.Pause(ctx.UserContext(), c.actionRequest(ctx))
+	if err != nil {
+		c.Log.Warnf("Failed to pause pickup subscription: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PickupSubscriptionResponse]{Data: response})
+}
+
+func (c *PickupSubscriptionController) Resume(ctx *fiber.Ctx) error {
+	response, err := c.PickupSubscriptionUsecase.Resume(ctx.UserContext(), c.actionRequest(ctx))
+	if err != nil {
+		c.Log.Warnf("Failed to resume pickup subscription: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PickupSubscriptionResponse]{Data: response})
+}
+
+func (c *PickupSubscriptionController) Cancel(ctx *fiber.Ctx) error {
+	response, err := c.PickupSubscriptionUsecase.Cancel(ctx.UserContext(), c.actionRequest(ctx))
+	if err != nil {
+		c.Log.Warnf("Failed to cancel pickup subscription: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PickupSubscriptionResponse]{Data: response})
+}
+
+func (c *PickupSubscriptionController) Skip(ctx *fiber.Ctx) error {
+	request := new(model.SkipPickupSubscriptionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	auth := middleware.GetUser(ctx)
+	request.ID = ctx.Params("id")
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	response, err := c.PickupSubscriptionUsecase.Skip(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to skip pickup subscription date: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PickupSubscriptionOccurrenceResponse]{Data: response})
+}
+
+func (c *PickupSubscriptionController) actionRequest(ctx *fiber.Ctx) *model.PickupSubscriptionActionRequest {
+	auth := middleware.GetUser(ctx)
+	return &model.PickupSubscriptionActionRequest{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+}
@@ -0,0 +1,248 @@
+package http
+
+import (
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/helper"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type PointRedemptionController struct {
+	Log                    *logrus.Logger
+	PointRedemptionUsecase *usecase.PointRedemptionUsecase
+}
+
+func NewPointRedemptionController(usecase *usecase.PointRedemptionUsecase, logger *logrus.Logger) *PointRedemptionController {
+	return &PointRedemptionController{
+		Log:                    logger,
+		PointRedemptionUsecase: usecase,
+	}
+}
+
+func (c *PointRedemptionController) Create(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.PointRedemptionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// Set UserID from authenticated user
+	request.UserID = auth.ID
+	response, err := c.PointRedemptionUsecase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create point redemption: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PointRedemptionSimpleResponse]{Data: response})
+}
+
+func (c *PointRedemptionController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.GetPointRedemptionRequest{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	response, err := c.PointRedemptionUsecase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get point redemption: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PointRedemptionResponse]{Data: response})
+}
+
+func (c *PointRedemptionController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.SearchPointRedemptionRequest{
+		UserID:      ctx.Query("user_id"),
+		WasteBankID: ctx.Query("waste_bank_id"),
+		Status:      ctx.Query("status"),
+		IsDeleted:   helper.ParseBoolQuery(ctx, "is_deleted"),
+		OrderBy:     ctx.Query("order_by"),
+		OrderDir:    ctx.Query("order_dir"),
+		Page:        ctx.QueryInt("page", 1),
+		Size:        ctx.QueryInt("size", 10),
+	}
+
+	// Customers only see their own redemptions, waste banks the ones addressed to them
+	switch auth.Role {
+	case "admin":
+	case "customer":
+		request.UserID = auth.ID
+	case "waste_bank_unit", "waste_bank_central":
+		request.WasteBankID = auth.ID
+	default:
+		return fiber.NewError(fiber.StatusForbidden, "You are not allowed to list redemptions")
+	}
+
+	responses, total, err := c.PointRedemptionUsecase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search point redemptions")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.PointRedemptionSimpleResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+// Helper method to build an approve/reject/cancel request from the route and body
+func (c *PointRedemptionController) parseDecision(ctx *fiber.Ctx) (*model.DecidePointRedemptionRequest, error) {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.DecidePointRedemptionRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			c.Log.Warnf("Failed to parse request body: %v", err)
+			return nil, fiber.ErrBadRequest
+		}
+	}
+	request.ID = ctx.Params("id")
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+	return request, nil
+}
+
+func (c *PointRedemptionController) Approve(ctx *fiber.Ctx) error {
+	request, err := c.parseDecision(ctx)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.PointRedemptionUsecase.Approve(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to approve point redemption: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PointRedemptionSimpleResponse]{Data: response})
+}
+
+func (c *PointRedemptionController) Reject(ctx *fiber.Ctx) error {
+	request, err := c.parseDecision(ctx)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.PointRedemptionUsecase.Reject(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to reject point redemption: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PointRedemptionSimpleResponse]{Data: response})
+}
+
+func (c *PointRedemptionController) Cancel(ctx *fiber.Ctx) error {
+	request, err := c.parseDecision(ctx)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.PointRedemptionUsecase.Cancel(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to cancel point redemption: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PointRedemptionSimpleResponse]{Data: response})
+}
+
+func (c *PointRedemptionController) Delete(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	response, err := c.PointRedemptionUsecase.Delete(ctx.UserContext(), id)
+	if err != nil {
+		c.Log.Warnf("Failed to delete point redemption: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PointRedemptionSimpleResponse]{Data: response})
+}
+
+func (c *PointRedemptionController) ListRates(ctx *fiber.Ctx) error {
+	request := &model.SearchPointConversionRateRequest{
+		WasteBankID: ctx.Query("waste_bank_id"),
+		IsActive:    helper.ParseBoolQuery(ctx, "is_active"),
+		Page:        ctx.QueryInt("page", 1),
+		Size:        ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.PointRedemptionUsecase.SearchRates(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search point conversion rates")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.PointConversionRateResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *PointRedemptionController) CreateRate(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.PointConversionRateRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	response, err := c.PointRedemptionUsecase.CreateRate(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create point conversion rate: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PointConversionRateResponse]{Data: response})
+}
+
+func (c *PointRedemptionController) UpdateRate(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.UpdatePointConversionRateRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	response, err := c.PointRedemptionUsecase.UpdateRate(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to update point conversion rate: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PointConversionRateResponse]{Data: response})
+}
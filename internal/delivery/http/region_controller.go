@@ -0,0 +1,78 @@
+package http
+
+import (
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type RegionController struct {
+	Log           *logrus.Logger
+	RegionUsecase *usecase.RegionUsecase
+}
+
+func NewRegionController(usecase *usecase.RegionUsecase, logger *logrus.Logger) *RegionController {
+	return &RegionController{
+		Log:           logger,
+		RegionUsecase: usecase,
+	}
+}
+
+func (c *RegionController) List(ctx *fiber.Ctx) error {
+	request := &model.SearchRegionRequest{
+		Level:      ctx.Query("level"),
+		ParentCode: ctx.Query("parent_code"),
+		Name:       ctx.Query("name"),
+		Page:       ctx.QueryInt("page", 1),
+		Size:       ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.RegionUsecase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search regions")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.RegionResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *RegionController) Get(ctx *fiber.Ctx) error {
+	request := &model.GetRegionRequest{
+		Code: ctx.Params("code"),
+	}
+
+	response, err := c.RegionUsecase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get region: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.RegionResponse]{Data: response})
+}
+
+func (c *RegionController) Backfill(ctx *fiber.Ctx) error {
+	request := &model.BackfillRegionRequest{
+		Overwrite: ctx.QueryBool("overwrite", false),
+	}
+
+	response, err := c.RegionUsecase.Backfill(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to backfill regions: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.BackfillRegionResponse]{Data: response})
+}
@@ -22,11 +22,29 @@ type RouteConfig struct {
 	WasteTransferItemOfferingController *http.WasteTransferItemOfferingController
 	CollectorManagementController       *http.CollectorManagementController
 	SalaryTransactionController         *http.SalaryTransactionController
-	PointConversionController           *http.PointConversionController
+	PointRedemptionController           *http.PointRedemptionController
 	StorageController                   *http.StorageController
 	StorageItemController               *http.StorageItemController
 	GovernmentController                *http.GovernmentController
+	LedgerController                    *http.LedgerController
+	ImpactFactorController              *http.ImpactFactorController
+	FileController                      *http.FileController
+	CollectorAssignmentController       *http.CollectorAssignmentController
+	CollectorRouteController            *http.CollectorRouteController
+	AppointmentSlotController           *http.AppointmentSlotController
+	PickupSubscriptionController        *http.PickupSubscriptionController
+	WasteBankPromotionController        *http.WasteBankPromotionController
+	WasteDropQuoteController            *http.WasteDropQuoteController
+	WasteBankRecommendationController   *http.WasteBankRecommendationController
+	ServiceAreaController               *http.ServiceAreaController
+	RegionController                    *http.RegionController
+	DailyWasteRollupController          *http.DailyWasteRollupController
+	WasteBankDashboardController        *http.WasteBankDashboardController
+	IndustryDashboardController         *http.IndustryDashboardController
+	CustomerStatementController         *http.CustomerStatementController
+	StockTakeController                 *http.StockTakeController
 	AuthMiddleware                      fiber.Handler
+	IdempotencyMiddleware               fiber.Handler
 }
 
 func (c *RouteConfig) Setup() {
@@ -55,12 +73,29 @@ func (c *RouteConfig) SetupAuthRoute() {
 	// Authenticated user endpoints
 	// Auth
 	auth.Get("/users/current", c.UserController.Current)
+	auth.Post("/users/current/avatar", c.FileController.UploadAvatar)
 	auth.Post("/auth/logout", c.UserController.Logout)
 	auth.Post("/auth/logout-all-devices", c.UserController.LogoutAllDevices)
 	auth.Post("/auth/request-email-change", c.UserController.RequestEmailChange)
 	// Profiles
 	// Waste Bank
 	auth.Get("/waste-bank/profiles/:user_id", c.WasteBankController.Get)
+	// Appointment Slots
+	auth.Get("/appointment-slots", c.AppointmentSlotController.ListSlots)
+	auth.Get("/schedule-exceptions", c.AppointmentSlotController.ListExceptions)
+	auth.Get("/pickup-subscriptions", c.PickupSubscriptionController.List)
+	auth.Get("/pickup-subscriptions/:id", c.PickupSubscriptionController.Get)
+	auth.Get("/promotions", c.WasteBankPromotionController.List)
+	auth.Get("/waste-drop-quotes/:id", c.WasteDropQuoteController.Get)
+	// Service Areas, managed by waste banks and collectors
+	auth.Get("/service-areas", c.ServiceAreaController.List)
+	auth.Post("/service-areas", c.ServiceAreaController.Create)
+	auth.Post("/service-areas/upload", c.ServiceAreaController.Upload)
+	auth.Put("/service-areas/:id", c.ServiceAreaController.Update)
+	auth.Delete("/service-areas/:id", c.ServiceAreaController.Delete)
+	// Regions
+	auth.Get("/regions", c.RegionController.List)
+	auth.Get("/regions/:code", c.RegionController.Get)
 	// Waste Categories
 	auth.Get("/waste-categories", c.WasteCategoryController.List)
 	auth.Get("/waste-categories/:id", c.WasteCategoryController.Get)
@@ -69,48 +104,78 @@ func (c *RouteConfig) SetupAuthRoute() {
 	auth.Get("/waste-types/:id", c.WasteTypeController.Get)
 	// Waste Type Prices
 	auth.Get("/waste-type-prices", c.WasteBankPricedTypeController.List)
+	auth.Get("/waste-type-prices/history", c.WasteBankPricedTypeController.History)
 	auth.Get("/waste-type-prices/:id", c.WasteBankPricedTypeController.Get)
 	// Waste Drop Requests
 	auth.Get("/waste-drop-requests", c.WasteDropRequestController.List)
 	auth.Get("/waste-drop-requests/:id", c.WasteDropRequestController.Get)
+	auth.Get("/waste-drop-requests/:id/history", c.WasteDropRequestController.GetHistory)
+	auth.Get("/waste-drop-requests/:id/breakdown", c.WasteDropRequestController.GetBreakdown)
+	auth.Post("/waste-drop-requests/:id/image", c.FileController.UploadWasteDropRequestImage)
 	// Waste Drop Request Items
 	auth.Get("/waste-drop-request-items", c.WasteDropRequestItemController.List)
 	auth.Get("/waste-drop-request-items/:id", c.WasteDropRequestItemController.Get)
 	// Waste Transfer Requests
 	auth.Get("/waste-transfer-requests", c.WasteTransferController.List)
 	auth.Get("/waste-transfer-requests/:id", c.WasteTransferController.Get)
+	auth.Post("/waste-transfer-requests/:id/image", c.FileController.UploadWasteTransferRequestImage)
 	// Waste Transfer Item Offerings
 	auth.Get("/waste-transfer-item-offerings", c.WasteTransferItemOfferingController.List)
 	auth.Get("/waste-transfer-item-offerings/:id", c.WasteTransferItemOfferingController.Get)
 	// Salary Transactions
 	auth.Get("/salary-transactions", c.SalaryTransactionController.List)
 	auth.Get("/salary-transactions/:id", c.SalaryTransactionController.Get)
-	// Point Conversions
-	auth.Get("/point-conversions", c.PointConversionController.List)
-	auth.Get("/point-conversions/:id", c.PointConversionController.Get)
+	// Point Redemptions
+	auth.Get("/point-redemptions", c.PointRedemptionController.List)
+	auth.Get("/point-redemptions/:id", c.PointRedemptionController.Get)
+	auth.Get("/point-conversion-rates", c.PointRedemptionController.ListRates)
+	// Impact Factors
+	auth.Get("/impact-factors", c.ImpactFactorController.List)
+	auth.Get("/impact-factors/:id", c.ImpactFactorController.Get)
 	// Storage
 	auth.Get("/storages", c.StorageController.List)
+	auth.Get("/storages/utilization", c.StorageController.ListUtilization)
 	auth.Get("/storages/:id", c.StorageController.Get)
+	auth.Get("/storages/:id/utilization", c.StorageController.GetUtilization)
 	// Storage Items
 	auth.Get("/storage-items", c.StorageItemController.List)
 	auth.Get("/storage-items/:id", c.StorageItemController.Get)
+	auth.Get("/storage-items/:id/movements", c.StorageItemController.ListMovements)
+	// Ledger
+	auth.Get("/ledger/statement", c.LedgerController.GetStatement)
 
 	// Customer endpoints
 	customerOnly := c.App.Group("/api/customer", c.AuthMiddleware, middleware.RequireRoles("admin", "customer"))
 	// Profiles
 	customerOnly.Get("/profiles/:user_id", c.CustomerController.Get)
 	customerOnly.Put("/profiles/:id", c.CustomerController.Update)
+	customerOnly.Get("/statement", c.CustomerStatementController.Get)
+	customerOnly.Get("/waste-bank-recommendations", c.WasteBankRecommendationController.List)
 	// Waste Drop Requests
+	customerOnly.Post("/waste-drop-requests/quote", c.WasteDropQuoteController.Create)
 	customerOnly.Post("/waste-drop-requests", c.WasteDropRequestController.Create)
 	customerOnly.Put("/waste-drop-requests/:id", c.WasteDropRequestController.UpdateStatus)
-	// Point Conversions
-	customerOnly.Post("/point-conversion-requests", c.SalaryTransactionController.CreatePointConversion)
-	customerOnly.Post("/point-conversions", c.PointConversionController.Create)
+	customerOnly.Post("/pickup-subscriptions", c.PickupSubscriptionController.Create)
+	customerOnly.Put("/pickup-subscriptions/:id", c.PickupSubscriptionController.Update)
+	customerOnly.Put("/pickup-subscriptions/:id/pause", c.PickupSubscriptionController.Pause)
+	customerOnly.Put("/pickup-subscriptions/:id/resume", c.PickupSubscriptionController.Resume)
+	customerOnly.Put("/pickup-subscriptions/:id/cancel", c.PickupSubscriptionController.Cancel)
+	customerOnly.Post("/pickup-subscriptions/:id/skip", c.PickupSubscriptionController.Skip)
+	// Point Redemptions
+	customerOnly.Post("/point-redemptions", c.IdempotencyMiddleware, c.PointRedemptionController.Create)
+	customerOnly.Put("/point-redemptions/:id/cancel", c.IdempotencyMiddleware, c.PointRedemptionController.Cancel)
 
 	// WasteBank endpoints
 	wasteBankOnly := c.App.Group("/api/waste-bank", c.AuthMiddleware, middleware.RequireRoles("admin", "waste_bank_unit", "waste_bank_central"))
 	// Profiles
 	wasteBankOnly.Put("/profiles/:id", c.WasteBankController.Update)
+	// Dashboard
+	wasteBankOnly.Get("/dashboard", c.WasteBankDashboardController.Get)
+	// Schedule Exceptions
+	wasteBankOnly.Post("/schedule-exceptions", c.AppointmentSlotController.CreateException)
+	wasteBankOnly.Delete("/schedule-exceptions/:id", c.AppointmentSlotController.DeleteException)
+	wasteBankOnly.Post("/promotions", c.WasteBankPromotionController.Create)
+	wasteBankOnly.Put("/promotions/:id/deactivate", c.WasteBankPromotionController.Deactivate)
 	// Waste Type Prices
 	wasteBankOnly.Post("/batch-waste-type-prices", c.WasteBankPricedTypeController.CreateBatch)
 	wasteBankOnly.Post("/waste-type-prices", c.WasteBankPricedTypeController.Create)
@@ -119,23 +184,29 @@ func (c *RouteConfig) SetupAuthRoute() {
 	// Waste Drop Requests
 	wasteBankOnly.Put("/waste-drop-requests/:id", c.WasteDropRequestController.UpdateStatus)
 	wasteBankOnly.Put("/waste-drop-requests/:id/assign-collector", c.WasteDropRequestController.AssignCollector)
-	wasteBankOnly.Put("/waste-drop-requests/:id/complete", c.WasteDropRequestController.Complete)
+	wasteBankOnly.Put("/waste-drop-requests/:id/complete", c.IdempotencyMiddleware, c.WasteDropRequestController.Complete)
+	wasteBankOnly.Post("/waste-drop-requests/:id/auto-assign", c.CollectorAssignmentController.AutoAssign)
+	wasteBankOnly.Get("/waste-drop-requests/:id/assignments", c.CollectorAssignmentController.ListByRequest)
 	// Waste Transfer
 	wasteBankOnly.Post("/waste-transfer-requests", c.WasteTransferController.Create)
 	wasteBankOnly.Put("/waste-transfer-requests/:id/assign-collector", c.WasteTransferController.AssignCollectorByWasteType)
 	wasteBankOnly.Put("/waste-transfer-requests/:id", c.WasteTransferController.UpdateStatus)
-	wasteBankOnly.Put("/waste-transfer-requests/:id/complete", c.WasteTransferController.CompleteRequest)
+	wasteBankOnly.Put("/waste-transfer-requests/:id/complete", c.IdempotencyMiddleware, c.WasteTransferController.CompleteRequest)
 	// Collector Management
 	wasteBankOnly.Get("/collector-management", c.CollectorManagementController.List)
 	wasteBankOnly.Get("/collector-management/:id", c.CollectorManagementController.Get)
 	wasteBankOnly.Post("/collector-management", c.CollectorManagementController.Create)
 	wasteBankOnly.Put("/collector-management/:id", c.CollectorManagementController.Update)
 	wasteBankOnly.Delete("/collector-management/:id", c.CollectorManagementController.Delete)
+	wasteBankOnly.Get("/collector-assignments", c.CollectorAssignmentController.List)
 	// Salary Transactions
-	wasteBankOnly.Post("/salary-transactions", c.SalaryTransactionController.Create)
+	wasteBankOnly.Post("/salary-transactions", c.IdempotencyMiddleware, c.SalaryTransactionController.Create)
 	wasteBankOnly.Put("/salary-transactions/:id", c.SalaryTransactionController.Update)
-	// Point Conversions
-	wasteBankOnly.Post("/point-conversions", c.SalaryTransactionController.CompletePointConversion)
+	// Point Redemptions
+	wasteBankOnly.Put("/point-redemptions/:id/approve", c.IdempotencyMiddleware, c.PointRedemptionController.Approve)
+	wasteBankOnly.Put("/point-redemptions/:id/reject", c.IdempotencyMiddleware, c.PointRedemptionController.Reject)
+	wasteBankOnly.Post("/point-conversion-rates", c.PointRedemptionController.CreateRate)
+	wasteBankOnly.Put("/point-conversion-rates/:id", c.PointRedemptionController.UpdateRate)
 	// Storage
 	wasteBankOnly.Put("/storages/:id", c.StorageController.Update)
 	// Storage Items
@@ -143,6 +214,14 @@ func (c *RouteConfig) SetupAuthRoute() {
 	wasteBankOnly.Put("/storage-items/:id", c.StorageItemController.Update)
 	wasteBankOnly.Put("/storage-items/:id/deduct-weight", c.StorageItemController.DeductStorageItem)
 	wasteBankOnly.Delete("/storage-items/:id", c.StorageItemController.Delete)
+	// Stock Takes
+	wasteBankOnly.Get("/stock-takes", c.StockTakeController.List)
+	wasteBankOnly.Get("/stock-takes/shrinkage", c.StockTakeController.GetShrinkage)
+	wasteBankOnly.Get("/stock-takes/:id", c.StockTakeController.Get)
+	wasteBankOnly.Post("/stock-takes", c.StockTakeController.Open)
+	wasteBankOnly.Put("/stock-takes/:id/counts", c.StockTakeController.RecordCounts)
+	wasteBankOnly.Put("/stock-takes/:id/post", c.StockTakeController.Post)
+	wasteBankOnly.Put("/stock-takes/:id/cancel", c.StockTakeController.Cancel)
 
 	// WasteCollector endpoints
 	wasteCollectorOnly := c.App.Group("/api/waste-collector", c.AuthMiddleware, middleware.RequireRoles("admin", "waste_collector_unit", "waste_collector_central", "waste_bank_unit", "waste_bank_central"))
@@ -151,17 +230,22 @@ func (c *RouteConfig) SetupAuthRoute() {
 	wasteCollectorOnly.Put("/profiles/:id", c.WasteCollectorController.Update)
 	// Waste Drop Requests
 	wasteCollectorOnly.Put("/waste-drop-requests/:id", c.WasteDropRequestController.UpdateStatus)
-	wasteCollectorOnly.Put("/waste-drop-requests/:id/complete", c.WasteDropRequestController.Complete)
+	wasteCollectorOnly.Put("/waste-drop-requests/:id/complete", c.IdempotencyMiddleware, c.WasteDropRequestController.Complete)
+	// Routes
+	wasteCollectorOnly.Get("/routes", c.CollectorRouteController.Get)
 
 	// Industry endpoints
 	industryOnly := c.App.Group("/api/industry", c.AuthMiddleware, middleware.RequireRoles("admin", "industry"))
 	// Profiles
 	industryOnly.Get("/profiles/:user_id", c.IndustryController.Get)
 	industryOnly.Put("/profiles/:id", c.IndustryController.Update)
+	// Dashboard
+	industryOnly.Get("/dashboard", c.IndustryDashboardController.Get)
 	// Recycle Waste Transfer
 	industryOnly.Put("/waste-transfer-requests/:id", c.WasteTransferController.UpdateStatus)
-	industryOnly.Put("/waste-transfer-requests/:id/complete", c.WasteTransferController.CompleteRequest)
+	industryOnly.Put("/waste-transfer-requests/:id/complete", c.IdempotencyMiddleware, c.WasteTransferController.CompleteRequest)
 	industryOnly.Put("waste-transfer-requests/:id/assign-collector", c.WasteTransferController.AssignCollectorByWasteType)
+	industryOnly.Put("/waste-transfer-requests/:id/recycle", c.WasteTransferController.RecycleRequest)
 	// Storage
 	industryOnly.Put("/storages/:id", c.StorageController.Update)
 	// Storage Items
@@ -174,6 +258,8 @@ func (c *RouteConfig) SetupAuthRoute() {
 	governmentOnly := c.App.Group("/api/government", c.AuthMiddleware, middleware.RequireRoles("admin", "government"))
 	// Dashboard
 	governmentOnly.Get("/dashboard", c.GovernmentController.GetDashboard)
+	governmentOnly.Get("/dashboard/regions", c.GovernmentController.GetRegionBreakdown)
+	governmentOnly.Get("/dashboard/heatmap", c.GovernmentController.GetDropHeatmap)
 	// Admin endpoints
 	adminOnly := c.App.Group("/api/admin", c.AuthMiddleware, middleware.RequireRoles("admin"))
 	// Customer profiles
@@ -200,11 +286,26 @@ func (c *RouteConfig) SetupAuthRoute() {
 	adminOnly.Delete("/waste-transfer-requests/:id", c.WasteTransferController.Delete)
 	// Salary Transactions
 	adminOnly.Delete("/salary-transactions/:id", c.SalaryTransactionController.Delete)
-	// Point Conversions
-	adminOnly.Put("/point-conversions/:id", c.PointConversionController.Update)
-	adminOnly.Delete("/point-conversions/:id", c.PointConversionController.Delete)
+	// Point Redemptions
+	adminOnly.Delete("/point-redemptions/:id", c.PointRedemptionController.Delete)
+	adminOnly.Post("/point-conversion-rates", c.PointRedemptionController.CreateRate)
+	adminOnly.Put("/point-conversion-rates/:id", c.PointRedemptionController.UpdateRate)
 	// Storage
 	adminOnly.Delete("/storages/:id", c.StorageController.Delete)
+	// Stock Takes
+	adminOnly.Get("/stock-takes/shrinkage", c.StockTakeController.GetShrinkage)
+	// Ledger
+	adminOnly.Get("/ledger/drifts", c.LedgerController.ListDrifts)
+	adminOnly.Post("/ledger/reconcile", c.LedgerController.Reconcile)
+	// Impact Factors
+	adminOnly.Post("/impact-factors", c.ImpactFactorController.Create)
+	adminOnly.Post("/impact-factors/recompute", c.ImpactFactorController.Recompute)
+	adminOnly.Put("/impact-factors/:id", c.ImpactFactorController.Update)
+	adminOnly.Delete("/impact-factors/:id", c.ImpactFactorController.Delete)
+	// Regions
+	adminOnly.Post("/regions/backfill", c.RegionController.Backfill)
+	// Dashboard rollups
+	adminOnly.Post("/rollups/rebuild", c.DailyWasteRollupController.Rebuild)
 
 }
 
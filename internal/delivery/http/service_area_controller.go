@@ -0,0 +1,154 @@
+package http
+
+import (
+	"io"
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/helper"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+// Largest GeoJSON file accepted by the upload endpoint
+const maxGeoJSONUploadBytes = 5 << 20
+
+type ServiceAreaController struct {
+	Log                *logrus.Logger
+	ServiceAreaUsecase *usecase.ServiceAreaUsecase
+}
+
+func NewServiceAreaController(usecase *usecase.ServiceAreaUsecase, logger *logrus.Logger) *ServiceAreaController {
+	return &ServiceAreaController{
+		Log:                logger,
+		ServiceAreaUsecase: usecase,
+	}
+}
+
+func (c *ServiceAreaController) Create(ctx *fiber.Ctx) error {
+	request := new(model.ServiceAreaRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	auth := middleware.GetUser(ctx)
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	responses, err := c.ServiceAreaUsecase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create service areas: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.ServiceAreaResponse]{Data: responses})
+}
+
+// Upload creates service areas from a GeoJSON file sent in the multipart field "file"
+func (c *ServiceAreaController) Upload(ctx *fiber.Ctx) error {
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		c.Log.Warnf("Failed to read uploaded file: %v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "Multipart field \"file\" is required")
+	}
+	if file.Size > maxGeoJSONUploadBytes {
+		return fiber.NewError(fiber.StatusRequestEntityTooLarge, "GeoJSON file is too large")
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		c.Log.Warnf("Failed to open uploaded file: %v", err)
+		return fiber.ErrBadRequest
+	}
+	defer content.Close()
+
+	geoJSON, err := io.ReadAll(content)
+	if err != nil {
+		c.Log.Warnf("Failed to read uploaded file: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	auth := middleware.GetUser(ctx)
+	request := &model.ServiceAreaRequest{
+		UserID:    ctx.FormValue("user_id"),
+		Name:      ctx.FormValue("name"),
+		GeoJSON:   geoJSON,
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	responses, err := c.ServiceAreaUsecase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create service areas: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.ServiceAreaResponse]{Data: responses})
+}
+
+func (c *ServiceAreaController) List(ctx *fiber.Ctx) error {
+	request := &model.SearchServiceAreaRequest{
+		UserID:   ctx.Query("user_id"),
+		IsActive: helper.ParseBoolQuery(ctx, "is_active"),
+		Page:     ctx.QueryInt("page", 1),
+		Size:     ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.ServiceAreaUsecase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search service areas")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.ServiceAreaResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *ServiceAreaController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateServiceAreaRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	auth := middleware.GetUser(ctx)
+	request.ID = ctx.Params("id")
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	response, err := c.ServiceAreaUsecase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to update service area: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.ServiceAreaResponse]{Data: response})
+}
+
+func (c *ServiceAreaController) Delete(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.DeleteServiceAreaRequest{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	if err := c.ServiceAreaUsecase.Delete(ctx.UserContext(), request); err != nil {
+		c.Log.Warnf("Failed to delete service area: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type StockTakeController struct {
+	Log              *logrus.Logger
+	StockTakeUsecase *usecase.StockTakeUsecase
+}
+
+func NewStockTakeController(usecase *usecase.StockTakeUsecase, logger *logrus.Logger) *StockTakeController {
+	return &StockTakeController{
+		Log:              logger,
+		StockTakeUsecase: usecase,
+	}
+}
+
+func (c *StockTakeController) Open(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.OpenStockTakeRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	response, err := c.StockTakeUsecase.Open(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to open stock take: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.StockTakeResponse]{Data: response})
+}
+
+func (c *StockTakeController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetStockTakeRequest{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	response, err := c.StockTakeUsecase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get stock take: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.StockTakeResponse]{Data: response})
+}
+
+func (c *StockTakeController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchStockTakeRequest{
+		StorageID: ctx.Query("storage_id"),
+		Status:    ctx.Query("status"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+		Page:      ctx.QueryInt("page", 1),
+		Size:      ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.StockTakeUsecase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search stock takes")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.StockTakeSimpleResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *StockTakeController) RecordCounts(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.RecordStockTakeCountsRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	response, err := c.StockTakeUsecase.RecordCounts(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to record stock take counts: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.StockTakeResponse]{Data: response})
+}
+
+func (c *StockTakeController) Post(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.DecideStockTakeRequest{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	response, err := c.StockTakeUsecase.Post(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to post stock take: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.StockTakeResponse]{Data: response})
+}
+
+func (c *StockTakeController) Cancel(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.DecideStockTakeRequest{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	response, err := c.StockTakeUsecase.Cancel(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to cancel stock take: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.StockTakeSimpleResponse]{Data: response})
+}
+
+// GetShrinkage handles GET /api/waste-bank/stock-takes/shrinkage and GET /api/admin/stock-takes/shrinkage
+func (c *StockTakeController) GetShrinkage(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.StockTakeShrinkageRequest{
+		WasteBankID: ctx.Query("waste_bank_id"),
+		StartMonth:  ctx.Query("start_month"),
+		EndMonth:    ctx.Query("end_month"),
+		ActorID:     auth.ID,
+		ActorRole:   auth.Role,
+	}
+
+	response, err := c.StockTakeUsecase.GetShrinkage(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get stock take shrinkage: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.StockTakeShrinkageResponse]{Data: response})
+}
@@ -139,3 +139,51 @@ func (c *StorageController) Delete(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(model.WebResponse[*model.StorageSimpleResponse]{Data: response})
 }
+
+func (c *StorageController) GetUtilization(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetStorageUtilizationRequest{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	response, err := c.StorageUsecase.GetUtilization(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get storage utilization: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.StorageUtilizationResponse]{Data: response})
+}
+
+// ListUtilization handles GET /api/storages/utilization, above_alert=true lists the storages to transfer out of
+func (c *StorageController) ListUtilization(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchStorageUtilizationRequest{
+		UserID:     ctx.Query("user_id"),
+		AboveAlert: ctx.QueryBool("above_alert", false),
+		ActorID:    auth.ID,
+		ActorRole:  auth.Role,
+		Page:       ctx.QueryInt("page", 1),
+		Size:       ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.StorageUsecase.SearchUtilization(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search storage utilization")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.StorageUtilizationResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
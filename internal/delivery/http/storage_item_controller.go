@@ -28,6 +28,7 @@ func (c *StorageItemController) Create(ctx *fiber.Ctx) error {
 		c.Log.Warnf("Failed to parse request body: %v", err)
 		return fiber.ErrBadRequest
 	}
+	request.ActorID = middleware.GetUser(ctx).ID
 
 	response, err := c.StorageItemUsecase.Create(ctx.UserContext(), request)
 	if err != nil {
@@ -140,3 +141,33 @@ func (c *StorageItemController) Delete(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(model.WebResponse[*model.StorageItemSimpleResponse]{Data: response})
 }
+
+// ListMovements handles GET /api/storage-items/:id/movements
+func (c *StorageItemController) ListMovements(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchStockMovementRequest{
+		StorageItemID: ctx.Params("id"),
+		ActorID:       auth.ID,
+		ActorRole:     auth.Role,
+		Page:          ctx.QueryInt("page", 1),
+		Size:          ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.StorageItemUsecase.ListMovements(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to list stock movements: %v", err)
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.StockMovementResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
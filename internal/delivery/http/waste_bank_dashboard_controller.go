@@ -0,0 +1,41 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type WasteBankDashboardController struct {
+	Log                       *logrus.Logger
+	WasteBankDashboardUsecase *usecase.WasteBankDashboardUsecase
+}
+
+func NewWasteBankDashboardController(usecase *usecase.WasteBankDashboardUsecase, logger *logrus.Logger) *WasteBankDashboardController {
+	return &WasteBankDashboardController{
+		Log:                       logger,
+		WasteBankDashboardUsecase: usecase,
+	}
+}
+
+// Get handles GET /api/waste-bank/dashboard
+func (c *WasteBankDashboardController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.WasteBankDashboardRequest{
+		WasteBankID: ctx.Query("waste_bank_id"),
+		StartMonth:  ctx.Query("start_month"),
+		EndMonth:    ctx.Query("end_month"),
+		ActorID:     auth.ID,
+		ActorRole:   auth.Role,
+	}
+
+	response, err := c.WasteBankDashboardUsecase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get waste bank dashboard: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.WasteBankDashboardResponse]{Data: response})
+}
@@ -94,6 +94,35 @@ func (c *WasteBankPricedTypeController) List(ctx *fiber.Ctx) error {
 	})
 }
 
+// History handles GET /api/waste-type-prices/history
+func (c *WasteBankPricedTypeController) History(ctx *fiber.Ctx) error {
+	request := &model.SearchWasteBankPriceHistoryRequest{
+		WasteBankID: ctx.Query("waste_bank_id"),
+		WasteTypeID: ctx.Query("waste_type_id"),
+		At:          ctx.Query("at"),
+		Page:        ctx.QueryInt("page", 1),
+		Size:        ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.WasteBankPricedTypeUsecase.SearchHistory(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search price history")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.WasteBankPriceVersionResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
 func (c *WasteBankPricedTypeController) Get(ctx *fiber.Ctx) error {
 	request := &model.GetWasteBankPricedTypeRequest{
 		ID: ctx.Params("id"),
@@ -109,12 +138,15 @@ func (c *WasteBankPricedTypeController) Get(ctx *fiber.Ctx) error {
 }
 
 func (c *WasteBankPricedTypeController) Update(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
 	request := new(model.UpdateWasteBankPricedTypeRequest)
 	request.ID = ctx.Params("id")
 	if err := ctx.BodyParser(request); err != nil {
 		c.Log.Warnf("Failed to parse update request: %v", err)
 		return fiber.ErrBadRequest
 	}
+	request.ActorID = auth.ID
 
 	result, err := c.WasteBankPricedTypeUsecase.Update(ctx.UserContext(), request)
 	if err != nil {
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type WasteBankPromotionController struct {
+	Log                       *logrus.Logger
+	WasteBankPromotionUsecase *usecase.WasteBankPromotionUsecase
+}
+
+func NewWasteBankPromotionController(usecase *usecase.WasteBankPromotionUsecase, logger *logrus.Logger) *WasteBankPromotionController {
+	return &WasteBankPromotionController{
+		Log:                       logger,
+		WasteBankPromotionUsecase: usecase,
+	}
+}
+
+func (c *WasteBankPromotionController) Create(ctx *fiber.Ctx) error {
+	request := new(model.WasteBankPromotionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	auth := middleware.GetUser(ctx)
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	response, err := c.WasteBankPromotionUsecase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create promotion: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.WasteBankPromotionResponse]{Data: response})
+}
+
+func (c *WasteBankPromotionController) List(ctx *fiber.Ctx) error {
+	request := &model.SearchWasteBankPromotionRequest{
+		WasteBankID: ctx.Query("waste_bank_id"),
+		WasteTypeID: ctx.Query("waste_type_id"),
+		OnlyRunning: ctx.QueryBool("only_running", false),
+		Page:        ctx.QueryInt("page", 1),
+		Size:        ctx.QueryInt("size", 10),
+	}
+
+	responses, total, err := c.WasteBankPromotionUsecase.Search(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to search promotions")
+		return err
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.WasteBankPromotionResponse]{
+		Data:   responses,
+		Paging: paging,
+	})
+}
+
+func (c *WasteBankPromotionController) Deactivate(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.DeactivateWasteBankPromotionRequest{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	response, err := c.WasteBankPromotionUsecase.Deactivate(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to deactivate promotion: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.WasteBankPromotionResponse]{Data: response})
+}
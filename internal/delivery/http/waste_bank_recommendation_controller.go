@@ -0,0 +1,76 @@
+package http
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type WasteBankRecommendationController struct {
+	Log                            *logrus.Logger
+	WasteBankRecommendationUsecase *usecase.WasteBankRecommendationUsecase
+}
+
+func NewWasteBankRecommendationController(usecase *usecase.WasteBankRecommendationUsecase, logger *logrus.Logger) *WasteBankRecommendationController {
+	return &WasteBankRecommendationController{
+		Log:                            logger,
+		WasteBankRecommendationUsecase: usecase,
+	}
+}
+
+func (c *WasteBankRecommendationController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchWasteBankRecommendationRequest{
+		DeliveryType: ctx.Query("delivery_type", ""),
+		Date:         ctx.Query("date", ""),
+		RadiusMeters: ctx.QueryInt("radius_meters", 0),
+		Size:         ctx.QueryInt("size", 10),
+		ActorID:      auth.ID,
+	}
+
+	if latStr := ctx.Query("latitude"); latStr != "" {
+		lat, err := strconv.ParseFloat(latStr, 64)
+		if err != nil {
+			c.Log.Warnf("Invalid latitude parameter: %s", latStr)
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid latitude parameter")
+		}
+		request.Latitude = &lat
+	}
+
+	if lngStr := ctx.Query("longitude"); lngStr != "" {
+		lng, err := strconv.ParseFloat(lngStr, 64)
+		if err != nil {
+			c.Log.Warnf("Invalid longitude parameter: %s", lngStr)
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid longitude parameter")
+		}
+		request.Longitude = &lng
+	}
+
+	// Waste types and their estimated weights are passed as comma separated lists
+	if wasteTypeIDs := ctx.Query("waste_type_ids"); wasteTypeIDs != "" {
+		request.WasteTypeIDs = strings.Split(wasteTypeIDs, ",")
+	}
+	if weights := ctx.Query("weights"); weights != "" {
+		for _, weightStr := range strings.Split(weights, ",") {
+			weight, err := strconv.ParseFloat(weightStr, 64)
+			if err != nil {
+				c.Log.Warnf("Invalid weights parameter: %s", weights)
+				return fiber.NewError(fiber.StatusBadRequest, "Invalid weights parameter")
+			}
+			request.Weights = append(request.Weights, weight)
+		}
+	}
+
+	responses, err := c.WasteBankRecommendationUsecase.Recommend(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to recommend waste banks: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.WasteBankRecommendationResponse]{Data: responses})
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+type WasteDropQuoteController struct {
+	Log                   *logrus.Logger
+	WasteDropQuoteUsecase *usecase.WasteDropQuoteUsecase
+}
+
+func NewWasteDropQuoteController(usecase *usecase.WasteDropQuoteUsecase, logger *logrus.Logger) *WasteDropQuoteController {
+	return &WasteDropQuoteController{
+		Log:                   logger,
+		WasteDropQuoteUsecase: usecase,
+	}
+}
+
+func (c *WasteDropQuoteController) Create(ctx *fiber.Ctx) error {
+	request := new(model.WasteDropQuoteRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	auth := middleware.GetUser(ctx)
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	response, err := c.WasteDropQuoteUsecase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create quote: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.WasteDropQuoteResponse]{Data: response})
+}
+
+func (c *WasteDropQuoteController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetWasteDropQuoteRequest{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	response, err := c.WasteDropQuoteUsecase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get quote: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.WasteDropQuoteResponse]{Data: response})
+}
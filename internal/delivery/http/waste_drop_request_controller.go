@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
 	"github.com/wastetrack/wastetrack-backend/internal/helper"
 	"github.com/wastetrack/wastetrack-backend/internal/model"
 	"github.com/wastetrack/wastetrack-backend/internal/usecase"
@@ -79,6 +80,7 @@ func (c *WasteDropRequestController) List(ctx *fiber.Ctx) error {
 		WasteBankID:          ctx.Query("waste_bank_id"),
 		AssignedCollectorID:  ctx.Query("assigned_collector_id"),
 		Status:               ctx.Query("status"),
+		CancellationReason:   ctx.Query("cancellation_reason_code"),
 		AppointmentDate:      ctx.Query("appointment_date"),
 		AppointmentStartTime: ctx.Query("appointment_start_time"),
 		AppointmentEndTime:   ctx.Query("appointment_end_time"),
@@ -150,6 +152,10 @@ func (c *WasteDropRequestController) Update(ctx *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
+	auth := middleware.GetUser(ctx)
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
 	response, err := c.WasteDropRequestUsecase.Update(ctx.UserContext(), request)
 	if err != nil {
 		c.Log.Warnf("Failed to update waste drop request: %v", err)
@@ -175,6 +181,8 @@ func (c *WasteDropRequestController) Delete(ctx *fiber.Ctx) error {
 
 // Additional controller methods for specific operations
 func (c *WasteDropRequestController) UpdateStatus(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
 	request := &model.UpdateWasteDropRequest{
 		ID:     ctx.Params("id"),
 		Status: ctx.Query("status"),
@@ -185,9 +193,19 @@ func (c *WasteDropRequestController) UpdateStatus(ctx *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
+	location, err := c.parseLocationQuery(ctx)
+	if err != nil {
+		return err
+	}
+
 	updateRequest := &model.UpdateWasteDropRequest{
-		ID:     request.ID,
-		Status: request.Status,
+		ID:         request.ID,
+		Status:     request.Status,
+		Reason:     ctx.Query("reason"),
+		ReasonCode: ctx.Query("reason_code"),
+		Location:   location,
+		ActorID:    auth.ID,
+		ActorRole:  auth.Role,
 	}
 
 	response, err := c.WasteDropRequestUsecase.Update(ctx.UserContext(), updateRequest)
@@ -199,6 +217,63 @@ func (c *WasteDropRequestController) UpdateStatus(ctx *fiber.Ctx) error {
 	return ctx.JSON(model.WebResponse[*model.WasteDropRequestSimpleResponse]{Data: response})
 }
 
+func (c *WasteDropRequestController) GetHistory(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.GetWasteDropRequestHistory{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	responses, err := c.WasteDropRequestUsecase.GetHistory(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get waste drop request history: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.WasteDropRequestStatusHistoryResponse]{Data: responses})
+}
+
+func (c *WasteDropRequestController) GetBreakdown(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &model.GetWasteDropRequestBreakdown{
+		ID:        ctx.Params("id"),
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
+	}
+
+	response, err := c.WasteDropRequestUsecase.GetBreakdown(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get waste drop request breakdown: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.WasteDropRequestBreakdownResponse]{Data: response})
+}
+
+// Helper method to parse the optional geo-stamp of a status change
+func (c *WasteDropRequestController) parseLocationQuery(ctx *fiber.Ctx) (*model.LocationRequest, error) {
+	latStr, lngStr := ctx.Query("latitude"), ctx.Query("longitude")
+	if latStr == "" && lngStr == "" {
+		return nil, nil
+	}
+
+	lat, err := strconv.ParseFloat(latStr, 64)
+	if err != nil {
+		c.Log.Warnf("Invalid latitude parameter: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+	lng, err := strconv.ParseFloat(lngStr, 64)
+	if err != nil {
+		c.Log.Warnf("Invalid longitude parameter: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	return &model.LocationRequest{Latitude: lat, Longitude: lng}, nil
+}
+
 // UPDATED COMPLETE METHOD - Now handles item verification
 func (c *WasteDropRequestController) Complete(ctx *fiber.Ctx) error {
 	request := new(model.CompleteWasteDropRequest)
@@ -209,6 +284,10 @@ func (c *WasteDropRequestController) Complete(ctx *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
+	auth := middleware.GetUser(ctx)
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
 	response, err := c.WasteDropRequestUsecase.Complete(ctx.UserContext(), request)
 	if err != nil {
 		c.Log.Warnf("Failed to complete waste drop request: %v", err)
@@ -229,10 +308,14 @@ func (c *WasteDropRequestController) AssignCollector(ctx *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
+	auth := middleware.GetUser(ctx)
 	updateRequest := &model.UpdateWasteDropRequest{
 		ID:                  request.ID,
 		AssignedCollectorID: request.AssignedCollectorID,
 		Status:              "assigned",
+		Reason:              ctx.Query("reason"),
+		ActorID:             auth.ID,
+		ActorRole:           auth.Role,
 	}
 
 	response, err := c.WasteDropRequestUsecase.Update(ctx.UserContext(), updateRequest)
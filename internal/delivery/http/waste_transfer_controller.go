@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
 	"github.com/wastetrack/wastetrack-backend/internal/helper"
 	"github.com/wastetrack/wastetrack-backend/internal/model"
 	"github.com/wastetrack/wastetrack-backend/internal/usecase"
@@ -79,6 +80,7 @@ func (c *WasteTransferRequestController) List(ctx *fiber.Ctx) error {
 		AssignedCollectorID:  ctx.Query("assigned_collector_id"), // NEW: Support filtering by assigned collector
 		FormType:             ctx.Query("form_type"),
 		Status:               ctx.Query("status"),
+		CancellationReason:   ctx.Query("cancellation_reason_code"),
 		AppointmentDate:      ctx.Query("appointment_date"),
 		AppointmentStartTime: ctx.Query("appointment_start_time"),
 		AppointmentEndTime:   ctx.Query("appointment_end_time"),
@@ -135,6 +137,7 @@ func (c *WasteTransferRequestController) List(ctx *fiber.Ctx) error {
 }
 
 func (c *WasteTransferRequestController) Update(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
 	request := new(model.UpdateWasteTransferRequest)
 	request.ID = ctx.Params("id")
 
@@ -142,6 +145,8 @@ func (c *WasteTransferRequestController) Update(ctx *fiber.Ctx) error {
 		c.Log.Warnf("Failed to parse request body: %v", err)
 		return fiber.ErrBadRequest
 	}
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
 
 	response, err := c.WasteTransferRequestUsecase.Update(ctx.UserContext(), request)
 	if err != nil {
@@ -181,9 +186,13 @@ func (c *WasteTransferRequestController) UpdateStatus(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "only collecting and cancelled status are allowed")
 	}
 
+	auth := middleware.GetUser(ctx)
 	updateRequest := &model.UpdateWasteTransferRequest{
-		ID:     request.ID,
-		Status: request.Status,
+		ID:         request.ID,
+		Status:     request.Status,
+		ReasonCode: ctx.Query("reason_code"),
+		ActorID:    auth.ID,
+		ActorRole:  auth.Role,
 	}
 
 	response, err := c.WasteTransferRequestUsecase.Update(ctx.UserContext(), updateRequest)
@@ -210,9 +219,12 @@ func (c *WasteTransferRequestController) UpdateStatusRecycle(ctx *fiber.Ctx) err
 		return fiber.NewError(fiber.StatusBadRequest, "only recycling_in_process and recycle_cancelled status are allowed")
 	}
 
+	auth := middleware.GetUser(ctx)
 	updateRequest := &model.UpdateWasteTransferRequest{
-		ID:     request.ID,
-		Status: request.Status,
+		ID:        request.ID,
+		Status:    request.Status,
+		ActorID:   auth.ID,
+		ActorRole: auth.Role,
 	}
 
 	response, err := c.WasteTransferRequestUsecase.Update(ctx.UserContext(), updateRequest)
@@ -265,3 +277,25 @@ func (c *WasteTransferRequestController) CompleteRequest(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(model.WebResponse[*model.WasteTransferRequestSimpleResponse]{Data: response})
 }
+
+func (c *WasteTransferRequestController) RecycleRequest(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.RecycleWasteTransferRequest)
+
+	if err := helper.ParseBody(ctx, request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	request.ID = ctx.Params("id")
+	request.ActorID = auth.ID
+	request.ActorRole = auth.Role
+
+	response, err := c.WasteTransferRequestUsecase.RecycleRequest(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to recycle waste transfer request: %v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.WasteTransferRequestSimpleResponse]{Data: response})
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CollectorAssignment struct {
+	ID             uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	RequestID      uuid.UUID `gorm:"column:request_id;not null"`
+	WasteBankID    uuid.UUID `gorm:"column:waste_bank_id;not null"`
+	CollectorID    uuid.UUID `gorm:"column:collector_id;not null"`
+	Collector      *User     `gorm:"foreignKey:CollectorID"`
+	Trigger        string    `gorm:"column:trigger;not null"` // create, sweep or manual
+	Score          float64   `gorm:"column:score"`
+	DistanceMeters *float64  `gorm:"column:distance_meters"` // Nullable when the collector has no known location
+	LocationSource string    `gorm:"column:location_source"`
+	WindowLoad     int       `gorm:"column:window_load"`
+	DayLoad        int       `gorm:"column:day_load"`
+	DailyCapacity  int       `gorm:"column:daily_capacity"`
+	CandidateCount int       `gorm:"column:candidate_count"`
+	Reason         string    `gorm:"column:reason;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// CollectorCandidate is an active collector of the bank with the inputs used to score them
+type CollectorCandidate struct {
+	CollectorID    uuid.UUID `gorm:"column:collector_id"`
+	DailyCapacity  int       `gorm:"column:daily_capacity"`
+	DistanceMeters *float64  `gorm:"column:distance_meters"`
+	LocationSource string    `gorm:"column:location_source"`
+	WindowLoad     int       `gorm:"column:window_load"`
+	DayLoad        int       `gorm:"column:day_load"`
+}
@@ -11,4 +11,6 @@ type CollectorManagement struct {
 	CollectorID uuid.UUID `gorm:"column:collector_id;not null"`
 	Collector   User      `gorm:"foreignKey:CollectorID"`
 	Status      string    `gorm:"column:status;default:'active'"`
+	// Requests the collector may take per appointment day across all banks
+	DailyCapacity int `gorm:"column:daily_capacity;default:10"`
 }
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+)
+
+// CollectorRouteStop is a drop or transfer request assigned to a collector for a given day
+type CollectorRouteStop struct {
+	RequestID   uuid.UUID      `gorm:"column:request_id"`
+	RequestType string         `gorm:"column:request_type"` // waste_drop_request or waste_transfer_request
+	Status      string         `gorm:"column:status"`
+	Location    *types.Point   `gorm:"column:location"`
+	StartTime   types.TimeOnly `gorm:"column:start_time"`
+	EndTime     types.TimeOnly `gorm:"column:end_time"`
+}
+
+// DistanceMatrixCell is the distance in meters between two points of a distance matrix
+type DistanceMatrixCell struct {
+	FromIndex int     `gorm:"column:from_index"`
+	ToIndex   int     `gorm:"column:to_index"`
+	Meters    float64 `gorm:"column:meters"`
+}
+
+// CollectorRoutePlan is a planned route of a collector for a day, shared between server processes
+type CollectorRoutePlan struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CollectorID uuid.UUID `gorm:"column:collector_id;not null"`
+	RouteDate   time.Time `gorm:"column:route_date;type:date;not null"`
+	PlanKey     string    `gorm:"column:plan_key;not null"` // Departure time and start point the route was planned from
+	Plan        string    `gorm:"column:plan;type:jsonb;not null"`
+	ExpiresAt   time.Time `gorm:"column:expires_at;not null"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
@@ -10,6 +10,7 @@ type CustomerProfile struct {
 	User          User      `gorm:"foreignKey:UserID"`
 	CarbonDeficit int64     `gorm:"column:carbon_deficit;default:0"`
 	WaterSaved    int64     `gorm:"column:water_saved;default:0"`
+	EnergySaved   int64     `gorm:"column:energy_saved;default:0"`
 	BagsStored    int64     `gorm:"column:bags_stored;default:0"`
 	Trees         int64     `gorm:"column:trees;default:0"`
 }
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DailyWasteRollup is the verified weight of completed requests for one day, receiver, sender, waste type and region
+type DailyWasteRollup struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Day                time.Time  `gorm:"column:day;type:date;not null"`
+	Flow               string     `gorm:"column:flow;type:rollup_flow;not null"` // ENUM: drop, waste_bank_transfer, industry_transfer
+	ReceiverID         uuid.UUID  `gorm:"column:receiver_id;not null"`
+	ReceiverRole       string     `gorm:"column:receiver_role;type:user_role;not null"`
+	ReceiverRegionCode *string    `gorm:"column:receiver_region_code"`
+	SenderID           *uuid.UUID `gorm:"column:sender_id"` // Nullable for drops
+	RegionCode         *string    `gorm:"column:region_code"`
+	WasteTypeID        uuid.UUID  `gorm:"column:waste_type_id;not null"`
+	RequestCount       int64      `gorm:"column:request_count;default:0"`
+	Weight             float64    `gorm:"column:weight;default:0"` // DECIMAL
+	Amount             int64      `gorm:"column:amount;default:0"`
+	UpdatedAt          time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
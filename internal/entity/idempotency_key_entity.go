@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IdempotencyKey struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Key         string    `gorm:"column:key;not null"`
+	UserID      uuid.UUID `gorm:"column:user_id;not null"`
+	Method      string    `gorm:"column:method;not null"`
+	Path        string    `gorm:"column:path;not null"`
+	RequestHash string    `gorm:"column:request_hash;not null"`
+
+	// Response is empty while the original request is still being processed
+	StatusCode   int        `gorm:"column:status_code"`
+	ContentType  string     `gorm:"column:content_type"`
+	ResponseBody []byte     `gorm:"column:response_body"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime"`
+	CompletedAt  *time.Time `gorm:"column:completed_at"`
+}
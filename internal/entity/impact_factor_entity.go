@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImpactFactor struct {
+	ID               uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	WasteTypeID      *uuid.UUID     `gorm:"column:waste_type_id"` // Nullable
+	WasteType        *WasteType     `gorm:"foreignKey:WasteTypeID"`
+	WasteCategoryID  *uuid.UUID     `gorm:"column:waste_category_id"` // Nullable
+	WasteCategory    *WasteCategory `gorm:"foreignKey:WasteCategoryID"`
+	Co2ePerKg        float64        `gorm:"column:co2e_per_kg;type:numeric(12,4)"`
+	WaterLitersPerKg float64        `gorm:"column:water_liters_per_kg;type:numeric(12,4)"`
+	EnergyKwhPerKg   float64        `gorm:"column:energy_kwh_per_kg;type:numeric(12,4)"`
+	TreesPerKg       float64        `gorm:"column:trees_per_kg;type:numeric(12,6)"`
+	Source           string         `gorm:"column:source"`
+	AppliedAt        *time.Time     `gorm:"column:applied_at"`
+	IsDeleted        bool           `gorm:"column:is_deleted;default:false"`
+	CreatedAt        time.Time      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt        time.Time      `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// EnvironmentalImpact is the impact of a verified weight, computed from the matching factors
+type EnvironmentalImpact struct {
+	Co2e        float64 `gorm:"column:co2e"`
+	WaterLiters float64 `gorm:"column:water_liters"`
+	EnergyKwh   float64 `gorm:"column:energy_kwh"`
+	Trees       float64 `gorm:"column:trees"`
+	Weight      float64 `gorm:"column:weight"`
+}
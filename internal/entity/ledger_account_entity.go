@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LedgerAccount struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID    *uuid.UUID `gorm:"column:user_id"` // Nullable for system accounts
+	User      *User      `gorm:"foreignKey:UserID"`
+	Code      *string    `gorm:"column:code"`                             // Nullable for user accounts
+	Asset     string     `gorm:"column:asset;type:ledger_asset;not null"` // ENUM: points, rupiah
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
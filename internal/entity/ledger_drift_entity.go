@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LedgerDrift struct {
+	ID            uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID        uuid.UUID  `gorm:"column:user_id;not null"`
+	User          User       `gorm:"foreignKey:UserID"`
+	Asset         string     `gorm:"column:asset;type:ledger_asset;not null"`
+	CachedBalance int64      `gorm:"column:cached_balance;not null"`
+	LedgerBalance int64      `gorm:"column:ledger_balance;not null"`
+	IsResolved    bool       `gorm:"column:is_resolved;default:false"`
+	DetectedAt    time.Time  `gorm:"column:detected_at;autoCreateTime"`
+	ResolvedAt    *time.Time `gorm:"column:resolved_at"`
+}
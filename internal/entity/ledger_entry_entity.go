@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LedgerEntry struct {
+	ID            uuid.UUID    `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	EntryType     string       `gorm:"column:entry_type;not null"`
+	ReferenceType string       `gorm:"column:reference_type"`
+	ReferenceID   *uuid.UUID   `gorm:"column:reference_id"`
+	Description   string       `gorm:"column:description"`
+	CreatedAt     time.Time    `gorm:"column:created_at;autoCreateTime"`
+	Lines         []LedgerLine `gorm:"foreignKey:EntryID"`
+}
+
+type LedgerLine struct {
+	ID        uuid.UUID     `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	EntryID   uuid.UUID     `gorm:"column:entry_id;not null"`
+	Entry     *LedgerEntry  `gorm:"foreignKey:EntryID"`
+	AccountID uuid.UUID     `gorm:"column:account_id;not null"`
+	Account   LedgerAccount `gorm:"foreignKey:AccountID"`
+	Direction string        `gorm:"column:direction;type:ledger_direction;not null"` // ENUM: debit, credit
+	Amount    int64         `gorm:"column:amount;not null"`
+	CreatedAt time.Time     `gorm:"column:created_at;autoCreateTime"`
+
+	// Running balance of the account after this line (not stored in DB, populated by statement queries)
+	BalanceAfter int64 `gorm:"->"`
+}
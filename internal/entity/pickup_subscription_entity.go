@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+)
+
+type PickupSubscription struct {
+	ID uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+
+	CustomerID  uuid.UUID `gorm:"column:customer_id;not null"`
+	Customer    User      `gorm:"foreignKey:CustomerID"`
+	WasteBankID uuid.UUID `gorm:"column:waste_bank_id;not null"`
+	WasteBank   User      `gorm:"foreignKey:WasteBankID"`
+
+	// Recurrence rule
+	Frequency  string         `gorm:"column:frequency;type:subscription_frequency;not null"` // ENUM: weekly, biweekly, monthly
+	DayOfWeek  *int           `gorm:"column:day_of_week"`                                    // 0 is Sunday, for weekly and biweekly
+	DayOfMonth *int           `gorm:"column:day_of_month"`                                   // 1-28, for monthly
+	StartTime  types.TimeOnly `gorm:"column:start_time;type:timetz"`
+	EndTime    types.TimeOnly `gorm:"column:end_time;type:timetz"`
+	StartDate  time.Time      `gorm:"column:start_date;type:date"`
+	EndDate    *time.Time     `gorm:"column:end_date;type:date"` // Nullable, runs until cancelled
+
+	AppointmentLocation *types.Point `gorm:"column:appointment_location;type:geography(POINT,4326)"`
+	UserPhoneNumber     string       `gorm:"column:user_phone_number"`
+	Notes               string       `gorm:"column:notes"`
+	Status              string       `gorm:"column:status;type:subscription_status;default:'active'"` // ENUM: active, paused, cancelled
+	CreatedAt           time.Time    `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt           time.Time    `gorm:"column:updated_at;autoUpdateTime"`
+
+	Items []PickupSubscriptionItem `gorm:"foreignKey:SubscriptionID"`
+}
+
+type PickupSubscriptionItem struct {
+	ID             uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	SubscriptionID uuid.UUID `gorm:"column:subscription_id;not null"`
+	WasteTypeID    uuid.UUID `gorm:"column:waste_type_id;not null"`
+	WasteType      WasteType `gorm:"foreignKey:WasteTypeID"`
+	Quantity       int64     `gorm:"column:quantity;not null"`
+}
+
+// PickupSubscriptionOccurrence records what happened to one date of a subscription
+type PickupSubscriptionOccurrence struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	SubscriptionID uuid.UUID  `gorm:"column:subscription_id;not null"`
+	Date           time.Time  `gorm:"column:date;type:date;not null"`
+	Status         string     `gorm:"column:status;type:subscription_occurrence_status;not null"` // ENUM: generating, generated, skipped, failed
+	RequestID      *uuid.UUID `gorm:"column:request_id"`
+	Reason         string     `gorm:"column:reason"`
+	CreatedAt      time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
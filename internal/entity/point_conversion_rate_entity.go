@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PointConversionRate struct {
+	ID                uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	WasteBankID       *uuid.UUID `gorm:"column:waste_bank_id"` // Nullable, nil is the default rate
+	WasteBank         *User      `gorm:"foreignKey:WasteBankID"`
+	RupiahPerPoint    float64    `gorm:"column:rupiah_per_point;type:numeric(12,4);not null"`
+	MinPoints         int64      `gorm:"column:min_points;default:1"`
+	MaxPoints         *int64     `gorm:"column:max_points"`          // Nullable, nil means no maximum
+	PeriodLimitPoints *int64     `gorm:"column:period_limit_points"` // Nullable, nil means no limit
+	LimitPeriod       string     `gorm:"column:limit_period;type:redemption_limit_period;default:'month'"`
+	EffectiveFrom     time.Time  `gorm:"column:effective_from"`
+	IsActive          bool       `gorm:"column:is_active;default:true"`
+	CreatedAt         time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt         time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
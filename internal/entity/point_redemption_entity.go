@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PointRedemption struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID         uuid.UUID  `gorm:"column:user_id;not null"`
+	User           User       `gorm:"foreignKey:UserID"`
+	WasteBankID    *uuid.UUID `gorm:"column:waste_bank_id"` // Nullable for migrated records
+	WasteBank      *User      `gorm:"foreignKey:WasteBankID"`
+	RateID         *uuid.UUID `gorm:"column:rate_id"`
+	Points         int64      `gorm:"column:points;not null"`
+	RupiahPerPoint float64    `gorm:"column:rupiah_per_point;type:numeric(12,4);not null"`
+	RupiahAmount   int64      `gorm:"column:rupiah_amount;not null"`
+	Status         string     `gorm:"column:status;type:redemption_status;default:'pending'"` // ENUM
+	Reason         string     `gorm:"column:reason"`
+	Notes          string     `gorm:"column:notes"`
+	// Ledger entry that moved the points on hold, nil for migrated records
+	HoldEntryID  *uuid.UUID `gorm:"column:hold_entry_id"`
+	DecidedByID  *uuid.UUID `gorm:"column:decided_by"`
+	DecidedAt    *time.Time `gorm:"column:decided_at"`
+	LegacySource string     `gorm:"column:legacy_source"`
+	IsDeleted    bool       `gorm:"column:is_deleted;default:false"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
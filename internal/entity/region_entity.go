@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// Region is an Indonesian administrative region keyed by its Kemendagri code
+type Region struct {
+	Code       string  `gorm:"column:code;primaryKey"`
+	ParentCode *string `gorm:"column:parent_code"`                      // Nullable for provinces
+	Level      string  `gorm:"column:level;type:region_level;not null"` // ENUM: province, regency, district, village
+	Name       string  `gorm:"column:name;not null"`
+	// EWKB of the boundary, only written by the importer and read by spatial queries
+	Boundary  *string   `gorm:"column:boundary;type:geography(MULTIPOLYGON,4326)" json:"-"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// RegionAlias is a lowercase spelling variant of a region name
+type RegionAlias struct {
+	Alias      string `gorm:"column:alias;primaryKey"`
+	RegionCode string `gorm:"column:region_code;not null"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+)
+
+// ServiceArea is a boundary a waste bank or collector serves, such as a kelurahan or RW
+type ServiceArea struct {
+	ID        uuid.UUID     `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID    uuid.UUID     `gorm:"column:user_id;not null"`
+	User      *User         `gorm:"foreignKey:UserID"`
+	Name      string        `gorm:"column:name;not null"`
+	Area      types.Polygon `gorm:"column:area;type:geography(POLYGON,4326);not null"`
+	IsActive  bool          `gorm:"column:is_active;default:true"`
+	CreatedAt time.Time     `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time     `gorm:"column:updated_at;autoUpdateTime"`
+}
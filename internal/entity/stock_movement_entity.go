@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StockMovement is an append-only journal line of a storage item weight change
+type StockMovement struct {
+	ID            uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	StorageID     uuid.UUID  `gorm:"column:storage_id;not null"`
+	Storage       Storage    `gorm:"foreignKey:StorageID"`
+	WasteTypeID   uuid.UUID  `gorm:"column:waste_type_id;not null"`
+	WasteType     WasteType  `gorm:"foreignKey:WasteTypeID"`
+	MovementType  string     `gorm:"column:movement_type;type:stock_movement_type;not null"` // ENUM
+	WeightKgs     float64    `gorm:"column:weight_kgs;not null"`                             // Negative when stock leaves
+	BalanceKgs    float64    `gorm:"column:balance_kgs;not null"`
+	ReferenceType string     `gorm:"column:reference_type"`
+	ReferenceID   *uuid.UUID `gorm:"column:reference_id"`
+	ActorID       *uuid.UUID `gorm:"column:actor_id"`
+	Notes         string     `gorm:"column:notes"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
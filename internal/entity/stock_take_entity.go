@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StockTake is a physical count of a storage, reconciled against storage_items when posted
+type StockTake struct {
+	ID          uuid.UUID       `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	StorageID   uuid.UUID       `gorm:"column:storage_id;not null"`
+	Storage     Storage         `gorm:"foreignKey:StorageID"`
+	Status      string          `gorm:"column:status;type:stock_take_status;default:'open'"` // ENUM
+	Notes       string          `gorm:"column:notes"`
+	OpenedByID  *uuid.UUID      `gorm:"column:opened_by"`
+	PostedByID  *uuid.UUID      `gorm:"column:posted_by"`
+	OpenedAt    time.Time       `gorm:"column:opened_at;autoCreateTime"`
+	PostedAt    *time.Time      `gorm:"column:posted_at"`
+	CancelledAt *time.Time      `gorm:"column:cancelled_at"`
+	Lines       []StockTakeLine `gorm:"foreignKey:StockTakeID"`
+	CreatedAt   time.Time       `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time       `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+type StockTakeLine struct {
+	ID               uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	StockTakeID      uuid.UUID  `gorm:"column:stock_take_id;not null"`
+	WasteTypeID      uuid.UUID  `gorm:"column:waste_type_id;not null"`
+	WasteType        WasteType  `gorm:"foreignKey:WasteTypeID"`
+	SystemWeightKgs  float64    `gorm:"column:system_weight_kgs;not null;default:0"`
+	CountedWeightKgs *float64   `gorm:"column:counted_weight_kgs"`                     // Nil until counted
+	ShrinkageReason  *string    `gorm:"column:shrinkage_reason;type:shrinkage_reason"` // ENUM
+	Notes            string     `gorm:"column:notes"`
+	CountedAt        *time.Time `gorm:"column:counted_at"`
+	CreatedAt        time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt        time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
@@ -10,6 +10,8 @@ type Storage struct {
 	Width                 float64   `gorm:"column:width"`
 	Height                float64   `gorm:"column:height"`
 	IsForRecycledMaterial bool      `gorm:"column:is_for_recycled_material;default:false"`
+	CapacityAlertPercent  float64   `gorm:"column:capacity_alert_percent;default:80"`
+	RejectOverflow        bool      `gorm:"column:reject_overflow;default:false"`
 }
 
 func (Storage) TableName() string {
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UploadedFile struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	OwnerType           string     `gorm:"column:owner_type;type:file_owner_type;not null"` // ENUM: waste_drop_request, waste_transfer_request, user_avatar
+	OwnerID             uuid.UUID  `gorm:"column:owner_id;not null"`
+	ObjectName          string     `gorm:"column:object_name;not null"`
+	URL                 string     `gorm:"column:url;not null"`
+	ThumbnailObjectName string     `gorm:"column:thumbnail_object_name"`
+	ThumbnailURL        string     `gorm:"column:thumbnail_url"`
+	ContentType         string     `gorm:"column:content_type;not null"`
+	SizeBytes           int64      `gorm:"column:size_bytes"`
+	Width               int        `gorm:"column:width"`
+	Height              int        `gorm:"column:height"`
+	UploadedByID        *uuid.UUID `gorm:"column:uploaded_by"`
+	CreatedAt           time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
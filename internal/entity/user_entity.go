@@ -1,4 +1,3 @@
-// TODO: Add Avatar URL
 package entity
 
 import (
@@ -22,6 +21,10 @@ type User struct {
 	Province    string    `gorm:"column:province"`
 	Points      int64     `gorm:"column:points;default:0"`
 	Balance     int64     `gorm:"column:balance;default:0"`
+	// Set together with AvatarURL when the avatar is uploaded
+	AvatarThumbnailURL string `gorm:"column:avatar_thumbnail_url"`
+	// Most specific administrative region known, see Region
+	RegionCode *string `gorm:"column:region_code"`
 	// Using custom Point type that handles PostGIS geography
 	Location               *types.Point `gorm:"type:geography(POINT,4326);"`
 	IsEmailVerified        bool         `gorm:"column:is_email_verified;default:false"`
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WasteBankPriceVersion struct {
+	ID            uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	PricedTypeID  *uuid.UUID `gorm:"column:priced_type_id"` // Nullable once the priced type is deleted
+	WasteBankID   uuid.UUID  `gorm:"column:waste_bank_id;not null"`
+	WasteTypeID   uuid.UUID  `gorm:"column:waste_type_id;not null"`
+	WasteType     WasteType  `gorm:"foreignKey:WasteTypeID"`
+	PricePerKgs   int64      `gorm:"column:price_per_kgs;not null"`
+	EffectiveFrom time.Time  `gorm:"column:effective_from;not null"`
+	EffectiveTo   *time.Time `gorm:"column:effective_to"` // Nullable, nil while open ended
+	CreatedByID   *uuid.UUID `gorm:"column:created_by"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
@@ -13,4 +13,23 @@ type WasteBankProfile struct {
 	OpenTime         types.TimeOnly `gorm:"column:open_time;type:time"`
 	CloseTime        types.TimeOnly `gorm:"column:close_time;type:time"`
 	User             User           `gorm:"foreignKey:UserID"`
+	// Pending pickups are assigned a collector automatically when enabled
+	AutoAssignCollectors    bool     `gorm:"column:auto_assign_collectors;default:false"`
+	AutoAssignMaxDistanceKm *float64 `gorm:"column:auto_assign_max_distance_km"`
+	// Appointment slots generated from the opening hours, with capacity per delivery type
+	SlotDurationMinutes   int    `gorm:"column:slot_duration_minutes;default:60"`
+	DropoffSlotCapacity   int    `gorm:"column:dropoff_slot_capacity;default:10"`
+	PickupSlotCapacity    int    `gorm:"column:pickup_slot_capacity;default:5"`
+	SlotOverbookingPolicy string `gorm:"column:slot_overbooking_policy;type:slot_overbooking_policy;default:'reject'"` // ENUM: reject, waitlist
+	// Pending requests expire after this many hours, nil uses the server default
+	PendingExpiryHours *int `gorm:"column:pending_expiry_hours"`
+	// Pickup fee charged against the payout, the per km fee applies beyond the free radius
+	PickupBaseFee      int64   `gorm:"column:pickup_base_fee;default:0"`
+	PickupFeePerKm     int64   `gorm:"column:pickup_fee_per_km;default:0"`
+	PickupFreeRadiusKm float64 `gorm:"column:pickup_free_radius_km;default:0"`
+	// Pickups outside every service area are rejected instead of flagged when enabled
+	RejectOutsideServiceArea bool `gorm:"column:reject_outside_service_area;default:false"`
+
+	// Distance in meters to a searched point, populated by nearby queries only
+	Distance *float64 `gorm:"->" json:"-"`
 }
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WasteBankPromotion raises a waste bank's price by a percentage while it runs
+type WasteBankPromotion struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	WasteBankID uuid.UUID  `gorm:"column:waste_bank_id;not null"`
+	WasteTypeID *uuid.UUID `gorm:"column:waste_type_id"` // Nullable, applies to every waste type
+	WasteType   *WasteType `gorm:"foreignKey:WasteTypeID"`
+	Name        string     `gorm:"column:name;not null"`
+	// Added on top of the price per kg, 10 means 10% more
+	BonusPercent float64    `gorm:"column:bonus_percent;not null"`
+	StartsAt     time.Time  `gorm:"column:starts_at;not null"`
+	EndsAt       *time.Time `gorm:"column:ends_at"` // Nullable, runs until deactivated
+	IsActive     bool       `gorm:"column:is_active;default:true"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+)
+
+// WasteBankScheduleException closes a waste bank on a date or replaces its opening hours for that date
+type WasteBankScheduleException struct {
+	ID          uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	WasteBankID uuid.UUID      `gorm:"column:waste_bank_id;not null"`
+	Date        time.Time      `gorm:"column:date;type:date;not null"`
+	IsClosed    bool           `gorm:"column:is_closed;default:true"`
+	OpenTime    types.TimeOnly `gorm:"column:open_time;type:time"` // Only used when the bank is open
+	CloseTime   types.TimeOnly `gorm:"column:close_time;type:time"`
+	Reason      string         `gorm:"column:reason"`
+	CreatedAt   time.Time      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// SlotBookingCount is the number of booked requests starting at a time on a date
+type SlotBookingCount struct {
+	WasteBankID     uuid.UUID      `gorm:"column:waste_bank_id"` // Only set when counting several banks at once
+	AppointmentDate time.Time      `gorm:"column:appointment_date"`
+	StartTime       types.TimeOnly `gorm:"column:start_time"`
+	DeliveryType    string         `gorm:"column:delivery_type"`
+	Total           int            `gorm:"column:total"`
+}
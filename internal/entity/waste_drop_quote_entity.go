@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+)
+
+// WasteDropQuote is a payout estimate a customer can book with before it expires
+type WasteDropQuote struct {
+	ID uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+
+	CustomerID  uuid.UUID `gorm:"column:customer_id;not null"`
+	WasteBankID uuid.UUID `gorm:"column:waste_bank_id;not null"`
+
+	DeliveryType        string       `gorm:"column:delivery_type;type:delivery_type;not null"` // ENUM: pickup, dropoff
+	AppointmentLocation *types.Point `gorm:"column:appointment_location;type:geography(POINT,4326)"`
+	DistanceKm          *float64     `gorm:"column:distance_km"` // Nullable when either location is unknown
+
+	// TotalPrice = ItemsTotal - PickupFee, ItemsTotal already includes PromotionBonus
+	ItemsTotal     int64 `gorm:"column:items_total;default:0"`
+	PromotionBonus int64 `gorm:"column:promotion_bonus;default:0"`
+	PickupFee      int64 `gorm:"column:pickup_fee;default:0"`
+	TotalPrice     int64 `gorm:"column:total_price;default:0"`
+
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null"`
+	RequestID *uuid.UUID `gorm:"column:request_id"` // Set once a drop request is created from the quote
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"`
+
+	Items []WasteDropQuoteItem `gorm:"foreignKey:QuoteID"`
+}
+
+type WasteDropQuoteItem struct {
+	ID              uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	QuoteID         uuid.UUID `gorm:"column:quote_id;not null"`
+	WasteTypeID     uuid.UUID `gorm:"column:waste_type_id;not null"`
+	WasteType       WasteType `gorm:"foreignKey:WasteTypeID"`
+	EstimatedWeight float64   `gorm:"column:estimated_weight"`
+	PricePerKgs     int64     `gorm:"column:price_per_kgs"`
+	// Price version PricePerKgs was taken from, copied to the booked request items
+	PriceVersionID *uuid.UUID `gorm:"column:price_version_id"`
+	// Promotion applied to PricePerKgs, if any
+	PromotionID       *uuid.UUID `gorm:"column:promotion_id"`
+	BonusPercent      float64    `gorm:"column:bonus_percent;default:0"`
+	QuotedPricePerKgs int64      `gorm:"column:quoted_price_per_kgs"`
+	Subtotal          int64      `gorm:"column:subtotal"`
+}
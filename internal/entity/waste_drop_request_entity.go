@@ -24,11 +24,31 @@ type WasteDropRequest struct {
 	TotalPrice int64  `gorm:"column:total_price;default:0"`
 	ImageURL   string `gorm:"column:image_url"`
 	Status     string `gorm:"type:request_status;default:'pending'"` // ENUM
+	// Set together with ImageURL when the photo is uploaded
+	ImageThumbnailURL string `gorm:"column:image_thumbnail_url"`
 
 	AppointmentLocation  *types.Point   `gorm:"type:geography(POINT,4326);"` // Requires custom handling for GEOGRAPHY(Point,4326)
 	AppointmentDate      time.Time      `gorm:"type:date"`
 	AppointmentStartTime types.TimeOnly `gorm:"type:timetz"`
 	AppointmentEndTime   types.TimeOnly `gorm:"type:timetz"`
+	// Set when the slot was full, the request then waits for a cancellation in its slot
+	IsWaitlisted bool `gorm:"column:is_waitlisted;default:false"`
+	// Last failed attempt of the assignment sweep, retried after a while
+	AutoAssignAttemptedAt *time.Time `gorm:"column:auto_assign_attempted_at"`
+	// Set when the request was generated from a recurring pickup subscription
+	SubscriptionID *uuid.UUID `gorm:"column:subscription_id"`
+	// Why and when the request was cancelled
+	CancellationReasonCode *string    `gorm:"column:cancellation_reason_code;type:cancellation_reason_code"` // ENUM: customer_cancelled, no_show, expired, bank_rejected
+	CancelledAt            *time.Time `gorm:"column:cancelled_at"`
+	// Quoted total kept when completion replaces TotalPrice with the verified payout
+	EstimatedTotalPrice *int64 `gorm:"column:estimated_total_price"`
+	// Set when booked from a quote, whose prices and pickup fee are locked in
+	QuoteID   *uuid.UUID `gorm:"column:quote_id"`
+	PickupFee int64      `gorm:"column:pickup_fee;default:0"`
+	// Set when the pickup location is outside every service area of the waste bank
+	IsOutsideServiceArea bool `gorm:"column:is_outside_service_area;default:false"`
+	// Region of the appointment location, or of the customer without one
+	RegionCode *string `gorm:"column:region_code"`
 
 	Notes     string    `gorm:"column:notes"`
 	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
@@ -16,6 +16,16 @@ type WasteDropRequestItem struct {
 	Quantity            int64   `gorm:"column:quantity"`        // BIGINT
 	VerifiedWeight      float64 `gorm:"column:verified_weight"` // DECIMAL
 	VerifiedPricePerKgs int64   `gorm:"column:verified_price_per_kgs"`
-	VerifiedSubtotal    int64   `gorm:"column:verified_subtotal"` // BIGINT
-	IsDeleted           bool    `gorm:"column:is_deleted;default:false"`
+	// Price version locked in when the request was created
+	PriceVersionID   *uuid.UUID `gorm:"column:price_version_id"`
+	VerifiedSubtotal int64      `gorm:"column:verified_subtotal"` // BIGINT
+	// Completion breakdown, verified subtotal = gross subtotal x grade multiplier less the deductions
+	Grade                string  `gorm:"column:grade;type:quality_grade;default:'A'"` // ENUM: A, B, C
+	GradeMultiplier      float64 `gorm:"column:grade_multiplier;default:1"`
+	ContaminationPercent float64 `gorm:"column:contamination_percent;default:0"`
+	MoisturePercent      float64 `gorm:"column:moisture_percent;default:0"`
+	GrossSubtotal        int64   `gorm:"column:gross_subtotal;default:0"`
+	// Set when the waste type was handed over without being on the request
+	IsAddedAtCompletion bool `gorm:"column:is_added_at_completion;default:false"`
+	IsDeleted           bool `gorm:"column:is_deleted;default:false"`
 }
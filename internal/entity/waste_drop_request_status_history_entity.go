@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+)
+
+type WasteDropRequestStatusHistory struct {
+	ID        uuid.UUID        `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	RequestID uuid.UUID        `gorm:"column:request_id;not null"`
+	Request   WasteDropRequest `gorm:"foreignKey:RequestID"`
+
+	FromStatus *string `gorm:"column:from_status;type:request_status"` // Nullable for the initial status
+	ToStatus   string  `gorm:"column:to_status;type:request_status;not null"`
+
+	ActorID   *uuid.UUID `gorm:"column:actor_id"` // Nullable for system transitions
+	Actor     *User      `gorm:"foreignKey:ActorID"`
+	ActorRole string     `gorm:"column:actor_role"`
+
+	Reason    string       `gorm:"column:reason"`
+	Location  *types.Point `gorm:"column:location;type:geography(POINT,4326)"`
+	CreatedAt time.Time    `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (WasteDropRequestStatusHistory) TableName() string {
+	return "waste_drop_request_status_history"
+}
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -24,7 +26,8 @@ type WasteTransferItemOffering struct {
 	VerifiedWeight float64 `gorm:"column:verified_weight;default:0"` // DECIMAL - verified weight
 
 	// Recycling process
-	RecycledWeight float64 `gorm:"column:recycled_weight;default:0"` // DECIMAL - weight of actual recycled material
+	RecycledWeight float64    `gorm:"column:recycled_weight;default:0"` // DECIMAL - weight of actual recycled material
+	RecycledAt     *time.Time `gorm:"column:recycled_at"`               // Set once the industry reports the recycled weight
 }
 
 func (WasteTransferItemOffering) TableName() string {
@@ -29,12 +29,19 @@ type WasteTransferRequest struct {
 	Notes                  string  `gorm:"column:notes"`
 	SourcePhoneNumber      string  `gorm:"column:source_phone_number"`
 	DestinationPhoneNumber string  `gorm:"column:destination_phone_number"`
+	// Set together with ImageURL when the photo is uploaded
+	ImageThumbnailURL string `gorm:"column:image_thumbnail_url"`
+	// Why and when the request was cancelled
+	CancellationReasonCode *string    `gorm:"column:cancellation_reason_code;type:cancellation_reason_code"` // ENUM: customer_cancelled, no_show, expired, bank_rejected
+	CancelledAt            *time.Time `gorm:"column:cancelled_at"`
 
 	AppointmentDate      time.Time      `gorm:"type:date"`
 	AppointmentStartTime types.TimeOnly `gorm:"type:timetz"`
 	AppointmentEndTime   types.TimeOnly `gorm:"type:timetz"`
 	AppointmentLocation  *types.Point   `gorm:"type:geography(POINT,4326);"`
 	IsDeleted            bool           `gorm:"column:is_deleted;default:false"`
+	// Region of the appointment location, or of the source user without one
+	RegionCode *string `gorm:"column:region_code"`
 
 	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
@@ -3,9 +3,10 @@ package entity
 import "github.com/google/uuid"
 
 type WasteType struct {
-	ID            uuid.UUID     `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	CategoryID    uuid.UUID     `gorm:"column:category_id;not null"`
-	Name          string        `gorm:"column:name;not null"`
-	Description   string        `gorm:"column:description"`
-	WasteCategory WasteCategory `gorm:"foreignKey:CategoryID"`
+	ID                  uuid.UUID     `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CategoryID          uuid.UUID     `gorm:"column:category_id;not null"`
+	Name                string        `gorm:"column:name;not null"`
+	Description         string        `gorm:"column:description"`
+	BulkDensityKgsPerM3 *float64      `gorm:"column:bulk_density_kgs_per_m3"` // Nil falls back to the default density
+	WasteCategory       WasteCategory `gorm:"foreignKey:CategoryID"`
 }
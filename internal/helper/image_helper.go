@@ -0,0 +1,220 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+const (
+	// Decoding is refused above this many pixels so a small file cannot exhaust memory
+	MaxImagePixels = 40_000_000
+	jpegQuality    = 85
+)
+
+var (
+	ErrUnsupportedImage = errors.New("only JPEG and PNG images are supported")
+	ErrImageTooLarge    = errors.New("image dimensions are too large")
+)
+
+type ProcessedImage struct {
+	ContentType string
+	Extension   string
+	Data        []byte
+	Width       int
+	Height      int
+	Thumbnail   []byte
+}
+
+// ProcessImage sniffs the content type and re-encodes the image, which drops EXIF and any other
+// metadata. JPEG orientation is applied to the pixels first so the photo still displays upright.
+// The thumbnail fits within thumbnailSize on its longest side.
+func ProcessImage(data []byte, thumbnailSize int) (*ProcessedImage, error) {
+	processed := &ProcessedImage{ContentType: http.DetectContentType(data)}
+	switch processed.ContentType {
+	case "image/jpeg":
+		processed.Extension = ".jpg"
+	case "image/png":
+		processed.Extension = ".png"
+	default:
+		return nil, ErrUnsupportedImage
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if processed.ContentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	bounds := img.Bounds()
+	processed.Width, processed.Height = bounds.Dx(), bounds.Dy()
+	if processed.Data, err = encodeImage(img, processed.ContentType); err != nil {
+		return nil, err
+	}
+
+	thumbnail := img
+	if longest := max(processed.Width, processed.Height); longest > thumbnailSize {
+		width := max(1, processed.Width*thumbnailSize/longest)
+		height := max(1, processed.Height*thumbnailSize/longest)
+		thumbnail = resizeImage(img, width, height)
+	}
+	if processed.Thumbnail, err = encodeImage(thumbnail, processed.ContentType); err != nil {
+		return nil, err
+	}
+
+	return processed, nil
+}
+
+func encodeImage(img image.Image, contentType string) ([]byte, error) {
+	var buffer bytes.Buffer
+	var err error
+	if contentType == "image/png" {
+		err = png.Encode(&buffer, img)
+	} else {
+		err = jpeg.Encode(&buffer, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	return buffer.Bytes(), err
+}
+
+// jpegOrientation reads the EXIF orientation tag, returning 1 (upright) when there is none
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		// Start of scan or end of image, the metadata segments are all before it
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && len(segment) >= 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if value := int(order.Uint16(tiff[entry+8:])); value >= 1 && value <= 8 {
+				return value
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// applyOrientation rotates and flips the pixels so orientation 1 describes the result
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// resizeImage downscales by averaging the source pixels covered by each destination pixel
+func resizeImage(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
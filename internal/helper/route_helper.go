@@ -0,0 +1,252 @@
+package helper
+
+import "math"
+
+const (
+	MinutesPerDay = 24 * 60
+	// Every minute past a stop's window costs as much as this many meters of extra driving
+	routeLatenessPenaltyMeters = 1000.0
+	routeMaxImprovementPasses  = 50
+)
+
+// RouteStop is a place to visit with an optional time window in minutes since midnight
+type RouteStop struct {
+	WindowStart int
+	WindowEnd   int
+}
+
+// RouteOptions controls how travel time is estimated from distances
+type RouteOptions struct {
+	DepartureMinute int     // When the collector leaves the start location
+	SpeedKmh        float64 // Average travel speed
+	ServiceMinutes  int     // Time spent at each stop
+}
+
+// RouteVisit is the schedule of one stop in a planned route
+type RouteVisit struct {
+	Stop           int // Index into the stops passed to PlanRoute
+	DistanceMeters float64
+	ArrivalMinute  int
+	WaitMinutes    int
+	LateMinutes    int
+	DepartMinute   int
+}
+
+// RoutePlan is an ordered visit list with its totals
+type RoutePlan struct {
+	Visits              []RouteVisit
+	TotalDistanceMeters float64
+	FinishMinute        int
+	LateStops           int
+}
+
+// PlanRoute orders the stops with a time-window-aware nearest-neighbour and earliest-deadline start,
+// improved by 2-opt and relocation moves. matrix[0] is the start location and matrix[i+1] is stops[i];
+// when hasStart is false the route starts at the first visited stop.
+func PlanRoute(stops []RouteStop, matrix [][]float64, hasStart bool, options RouteOptions) RoutePlan {
+	if len(stops) == 0 {
+		return RoutePlan{FinishMinute: options.DepartureMinute}
+	}
+
+	candidates := [][]int{
+		earliestDeadlineOrder(stops),
+		nearestNeighbourOrder(stops, matrix, hasStart, options),
+	}
+
+	var best []int
+	bestCost := math.Inf(1)
+	for _, order := range candidates {
+		order = improveRoute(order, stops, matrix, hasStart, options)
+		if cost := routeCost(order, stops, matrix, hasStart, options); cost < bestCost {
+			best, bestCost = order, cost
+		}
+	}
+
+	return ScheduleRoute(best, stops, matrix, hasStart, options)
+}
+
+// ScheduleRoute computes arrival times for a fixed visit order, which is enough when stops are only removed
+func ScheduleRoute(order []int, stops []RouteStop, matrix [][]float64, hasStart bool, options RouteOptions) RoutePlan {
+	plan := RoutePlan{Visits: make([]RouteVisit, 0, len(order))}
+
+	now := float64(options.DepartureMinute)
+	previous := -1
+	for _, stop := range order {
+		visit := RouteVisit{Stop: stop}
+		if previous >= 0 || hasStart {
+			visit.DistanceMeters = matrix[previous+1][stop+1]
+			now += travelMinutes(visit.DistanceMeters, options.SpeedKmh)
+		}
+
+		window := stops[stop]
+		if now < float64(window.WindowStart) {
+			// A route starting at the first stop simply leaves later instead of waiting
+			if previous < 0 && !hasStart {
+				now = float64(window.WindowStart)
+			} else {
+				visit.WaitMinutes = int(math.Round(float64(window.WindowStart) - now))
+				now = float64(window.WindowStart)
+			}
+		}
+		visit.ArrivalMinute = int(math.Round(now))
+		if now > float64(window.WindowEnd) {
+			visit.LateMinutes = int(math.Ceil(now - float64(window.WindowEnd)))
+			plan.LateStops++
+		}
+		now += float64(options.ServiceMinutes)
+		visit.DepartMinute = int(math.Round(now))
+
+		plan.TotalDistanceMeters += visit.DistanceMeters
+		plan.Visits = append(plan.Visits, visit)
+		previous = stop
+	}
+	plan.FinishMinute = int(math.Round(now))
+
+	return plan
+}
+
+func travelMinutes(meters float64, speedKmh float64) float64 {
+	return meters / 1000 / speedKmh * 60
+}
+
+// routeCost is the driven distance plus a penalty for every minute a window is missed
+func routeCost(order []int, stops []RouteStop, matrix [][]float64, hasStart bool, options RouteOptions) float64 {
+	now := float64(options.DepartureMinute)
+	cost := 0.0
+	previous := -1
+	for _, stop := range order {
+		if previous >= 0 || hasStart {
+			distance := matrix[previous+1][stop+1]
+			cost += distance
+			now += travelMinutes(distance, options.SpeedKmh)
+		}
+		window := stops[stop]
+		if now < float64(window.WindowStart) {
+			now = float64(window.WindowStart)
+		}
+		if now > float64(window.WindowEnd) {
+			cost += (now - float64(window.WindowEnd)) * routeLatenessPenaltyMeters
+		}
+		now += float64(options.ServiceMinutes)
+		previous = stop
+	}
+	return cost
+}
+
+func earliestDeadlineOrder(stops []RouteStop) []int {
+	order := make([]int, len(stops))
+	for i := range order {
+		order[i] = i
+	}
+	// Insertion sort keeps equal windows in their original order
+	for i := 1; i < len(order); i++ {
+		for j := i; j > 0 && routeStopBefore(stops[order[j]], stops[order[j-1]]); j-- {
+			order[j], order[j-1] = order[j-1], order[j]
+		}
+	}
+	return order
+}
+
+func routeStopBefore(a, b RouteStop) bool {
+	if a.WindowEnd != b.WindowEnd {
+		return a.WindowEnd < b.WindowEnd
+	}
+	return a.WindowStart < b.WindowStart
+}
+
+// nearestNeighbourOrder always drives to the stop that can be served soonest, counting waiting time
+func nearestNeighbourOrder(stops []RouteStop, matrix [][]float64, hasStart bool, options RouteOptions) []int {
+	visited := make([]bool, len(stops))
+	order := make([]int, 0, len(stops))
+
+	now := float64(options.DepartureMinute)
+	previous := -1
+	for len(order) < len(stops) {
+		next := -1
+		nextReady := math.Inf(1)
+		nextDeadline := 0
+		for stop, window := range stops {
+			if visited[stop] {
+				continue
+			}
+			ready := now
+			if previous >= 0 || hasStart {
+				ready += travelMinutes(matrix[previous+1][stop+1], options.SpeedKmh)
+			}
+			ready = math.Max(ready, float64(window.WindowStart))
+			if ready < nextReady || (ready == nextReady && window.WindowEnd < nextDeadline) {
+				next, nextReady, nextDeadline = stop, ready, window.WindowEnd
+			}
+		}
+
+		visited[next] = true
+		order = append(order, next)
+		now = nextReady + float64(options.ServiceMinutes)
+		previous = next
+	}
+	return order
+}
+
+// improveRoute applies 2-opt reversals and single stop relocations until neither lowers the cost
+func improveRoute(order []int, stops []RouteStop, matrix [][]float64, hasStart bool, options RouteOptions) []int {
+	best := append([]int(nil), order...)
+	bestCost := routeCost(best, stops, matrix, hasStart, options)
+	candidate := make([]int, len(best))
+
+	for pass := 0; pass < routeMaxImprovementPasses; pass++ {
+		improved := false
+
+		for i := 0; i < len(best)-1; i++ {
+			for j := i + 1; j < len(best); j++ {
+				copy(candidate, best)
+				for a, b := i, j; a < b; a, b = a+1, b-1 {
+					candidate[a], candidate[b] = candidate[b], candidate[a]
+				}
+				if cost := routeCost(candidate, stops, matrix, hasStart, options); cost < bestCost-1e-6 {
+					copy(best, candidate)
+					bestCost = cost
+					improved = true
+				}
+			}
+		}
+
+		for i := 0; i < len(best); i++ {
+			for j := 0; j < len(best); j++ {
+				if i == j {
+					continue
+				}
+				relocateStop(candidate, best, i, j)
+				if cost := routeCost(candidate, stops, matrix, hasStart, options); cost < bestCost-1e-6 {
+					copy(best, candidate)
+					bestCost = cost
+					improved = true
+				}
+			}
+		}
+
+		if !improved {
+			break
+		}
+	}
+	return best
+}
+
+// relocateStop writes order into dst with the stop at position from moved to position to
+func relocateStop(dst []int, order []int, from int, to int) {
+	stop := order[from]
+	k := 0
+	for i, s := range order {
+		if i == from {
+			continue
+		}
+		if k == to {
+			dst[k] = stop
+			k++
+		}
+		dst[k] = s
+		k++
+	}
+	if k == to {
+		dst[k] = stop
+	}
+}
@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+func StartCollectorAssignmentSweepJob(collectorAssignmentUsecase *usecase.CollectorAssignmentUsecase) {
+	ticker := time.NewTicker(10 * time.Minute) // Run every 10 minutes so pickups are not left waiting long
+	go func() {
+		for range ticker.C {
+			assigned, err := collectorAssignmentUsecase.Sweep(context.Background())
+			if err != nil {
+				fmt.Println("Error auto-assigning collectors:", err)
+				continue
+			}
+			if assigned > 0 {
+				fmt.Printf("Auto-assigned collectors to %d waste drop requests\n", assigned)
+			}
+		}
+	}()
+}
@@ -0,0 +1,21 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wastetrack/wastetrack-backend/internal/delivery/http/middleware"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+func StartIdempotencyKeyCleanupJob(db *gorm.DB, idempotencyKeyRepository *repository.IdempotencyKeyRepository) {
+	ticker := time.NewTicker(time.Hour * 24) // Run Daily
+	go func() {
+		for range ticker.C {
+			if err := idempotencyKeyRepository.DeleteExpired(db, time.Now().Add(-middleware.IdempotencyKeyTTL)); err != nil {
+				fmt.Println("Error cleaning up expired idempotency keys:", err)
+			}
+		}
+	}()
+}
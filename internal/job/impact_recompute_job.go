@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+func StartImpactRecomputeJob(impactFactorUsecase *usecase.ImpactFactorUsecase) {
+	ticker := time.NewTicker(time.Hour) // Run Hourly
+	go func() {
+		for range ticker.C {
+			updated, err := impactFactorUsecase.RecomputeCustomerImpact(context.Background(), false)
+			if err != nil {
+				fmt.Println("Error recomputing customer impact:", err)
+				continue
+			}
+			if updated > 0 {
+				fmt.Printf("Recomputed environmental impact for %d customer profiles\n", updated)
+			}
+		}
+	}()
+}
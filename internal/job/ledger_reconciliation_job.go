@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+func StartLedgerReconciliationJob(ledgerUsecase *usecase.LedgerUsecase) {
+	ticker := time.NewTicker(time.Hour * 24) // Run Daily
+	go func() {
+		for range ticker.C {
+			drifts, err := ledgerUsecase.Reconcile(context.Background())
+			if err != nil {
+				fmt.Println("Error reconciling ledger:", err)
+				continue
+			}
+			if drifts > 0 {
+				fmt.Printf("Ledger reconciliation found %d drifted balances\n", drifts)
+			}
+		}
+	}()
+}
@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+func StartOrphanFileCleanupJob(fileUsecase *usecase.FileUsecase) {
+	ticker := time.NewTicker(time.Hour) // Run Hourly
+	go func() {
+		for range ticker.C {
+			removed, err := fileUsecase.CleanupOrphans(context.Background())
+			if err != nil {
+				fmt.Println("Error cleaning up orphaned files:", err)
+				continue
+			}
+			if removed > 0 {
+				fmt.Printf("Removed %d orphaned files\n", removed)
+			}
+		}
+	}()
+}
@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+func StartPickupSubscriptionJob(pickupSubscriptionUsecase *usecase.PickupSubscriptionUsecase) {
+	ticker := time.NewTicker(1 * time.Hour) // Run every hour, requests are generated a week ahead
+	go func() {
+		for range ticker.C {
+			generated, err := pickupSubscriptionUsecase.Generate(context.Background())
+			if err != nil {
+				fmt.Println("Error generating subscription pickups:", err)
+				continue
+			}
+			if generated > 0 {
+				fmt.Printf("Generated %d waste drop requests from pickup subscriptions\n", generated)
+			}
+		}
+	}()
+}
@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+func StartPriceVersionSyncJob(wasteBankPricedTypeUsecase *usecase.WasteBankPricedTypeUsecase) {
+	ticker := time.NewTicker(time.Hour) // Run Hourly
+	go func() {
+		for range ticker.C {
+			updated, err := wasteBankPricedTypeUsecase.SyncCurrentPrices(context.Background())
+			if err != nil {
+				fmt.Println("Error syncing scheduled prices:", err)
+				continue
+			}
+			if updated > 0 {
+				fmt.Printf("Applied %d scheduled waste type prices\n", updated)
+			}
+		}
+	}()
+}
@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wastetrack/wastetrack-backend/internal/usecase"
+)
+
+func StartRequestExpiryJob(requestExpiryUsecase *usecase.RequestExpiryUsecase) {
+	ticker := time.NewTicker(15 * time.Minute) // Run every 15 minutes
+	go func() {
+		for range ticker.C {
+			cancelled, err := requestExpiryUsecase.Run(context.Background())
+			if err != nil {
+				fmt.Println("Error expiring stale requests:", err)
+				continue
+			}
+			if cancelled > 0 {
+				fmt.Printf("Expired or cancelled %d stale requests\n", cancelled)
+			}
+		}
+	}()
+}
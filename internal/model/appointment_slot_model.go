@@ -0,0 +1,64 @@
+package model
+
+type SearchAppointmentSlotRequest struct {
+	WasteBankID   string `json:"waste_bank_id" validate:"required,max=100"`
+	StartDate     string `json:"start_date" validate:"required"` // Format: "2024-01-31"
+	EndDate       string `json:"end_date"`                       // Defaults to start_date, at most 31 days after it
+	DeliveryType  string `json:"delivery_type" validate:"omitempty,oneof=pickup dropoff"`
+	OnlyAvailable bool   `json:"only_available"`
+}
+
+type AppointmentDayResponse struct {
+	Date      string                    `json:"date"`
+	IsClosed  bool                      `json:"is_closed"`
+	Reason    string                    `json:"reason,omitempty"`
+	OpenTime  string                    `json:"open_time,omitempty"`
+	CloseTime string                    `json:"close_time,omitempty"`
+	Slots     []AppointmentSlotResponse `json:"slots"`
+}
+
+type AppointmentSlotResponse struct {
+	StartTime        string `json:"start_time"`
+	EndTime          string `json:"end_time"`
+	DropoffCapacity  int    `json:"dropoff_capacity"`
+	DropoffBooked    int    `json:"dropoff_booked"`
+	DropoffAvailable int    `json:"dropoff_available"`
+	PickupCapacity   int    `json:"pickup_capacity"`
+	PickupBooked     int    `json:"pickup_booked"`
+	PickupAvailable  int    `json:"pickup_available"`
+}
+
+type ScheduleExceptionResponse struct {
+	ID          string `json:"id"`
+	WasteBankID string `json:"waste_bank_id"`
+	Date        string `json:"date"`
+	IsClosed    bool   `json:"is_closed"`
+	OpenTime    string `json:"open_time,omitempty"`
+	CloseTime   string `json:"close_time,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+type ScheduleExceptionRequest struct {
+	WasteBankID string `json:"waste_bank_id" validate:"omitempty,max=100"` // Required for admins, waste banks manage their own
+	Date        string `json:"date" validate:"required"`                   // Format: "2024-01-31"
+	IsClosed    *bool  `json:"is_closed"`                                  // Defaults to true
+	OpenTime    string `json:"open_time"`                                  // Format: "08:00", required when not closed
+	CloseTime   string `json:"close_time"`
+	Reason      string `json:"reason" validate:"max=255"`
+	ActorID     string `json:"-"`
+	ActorRole   string `json:"-"`
+}
+
+type SearchScheduleExceptionRequest struct {
+	WasteBankID string `json:"waste_bank_id" validate:"required,max=100"`
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+	Page        int    `json:"page,omitempty" validate:"min=1"`
+	Size        int    `json:"size,omitempty" validate:"min=1,max=100"`
+}
+
+type DeleteScheduleExceptionRequest struct {
+	ID        string `json:"id" validate:"required,max=100"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
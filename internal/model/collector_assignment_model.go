@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+type CollectorAssignmentResponse struct {
+	ID             string        `json:"id"`
+	RequestID      string        `json:"request_id"`
+	WasteBankID    string        `json:"waste_bank_id"`
+	CollectorID    string        `json:"collector_id"`
+	Collector      *UserResponse `json:"collector,omitempty"`
+	Trigger        string        `json:"trigger"`
+	Score          float64       `json:"score"`
+	DistanceMeters *float64      `json:"distance_meters"`
+	LocationSource string        `json:"location_source,omitempty"`
+	WindowLoad     int           `json:"window_load"`
+	DayLoad        int           `json:"day_load"`
+	DailyCapacity  int           `json:"daily_capacity"`
+	CandidateCount int           `json:"candidate_count"`
+	Reason         string        `json:"reason"`
+	CreatedAt      *time.Time    `json:"created_at"`
+}
+
+type AutoAssignCollectorRequest struct {
+	ID        string `json:"-" validate:"required,max=100"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
+
+type SearchCollectorAssignmentRequest struct {
+	RequestID   string `json:"request_id"`
+	WasteBankID string `json:"waste_bank_id"`
+	CollectorID string `json:"collector_id"`
+	Page        int    `json:"page,omitempty" validate:"min=1"`
+	Size        int    `json:"size,omitempty" validate:"min=1,max=100"`
+	ActorID     string `json:"-"`
+	ActorRole   string `json:"-"`
+}
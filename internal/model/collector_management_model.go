@@ -1,10 +1,11 @@
 package model
 
 type CollectorManagementSimpleResponse struct {
-	ID          string `json:"id"`
-	WasteBankID string `json:"waste_bank_id"`
-	CollectorID string `json:"collector_id"`
-	Status      string `json:"status"`
+	ID            string `json:"id"`
+	WasteBankID   string `json:"waste_bank_id"`
+	CollectorID   string `json:"collector_id"`
+	Status        string `json:"status"`
+	DailyCapacity int    `json:"daily_capacity"`
 }
 
 type CollectorManagementResponse struct {
@@ -14,12 +15,15 @@ type CollectorManagementResponse struct {
 	CollectorID string        `json:"collector_id"`
 	Collector   *UserResponse `json:"collector"`
 	Status      string        `json:"status"`
+	// Requests the collector may take per appointment day
+	DailyCapacity int `json:"daily_capacity"`
 }
 
 type CollectorManagementRequest struct {
-	WasteBankID string `json:"waste_bank_id" validate:"required,max=100"`
-	CollectorID string `json:"collector_id" validate:"required,max=100"`
-	Status      string `json:"status"`
+	WasteBankID   string `json:"waste_bank_id" validate:"required,max=100"`
+	CollectorID   string `json:"collector_id" validate:"required,max=100"`
+	Status        string `json:"status"`
+	DailyCapacity int    `json:"daily_capacity" validate:"omitempty,min=1"`
 }
 
 type SearchCollectorManagementRequest struct {
@@ -33,10 +37,11 @@ type GetCollectorManagementRequest struct {
 	ID string `json:"id" validate:"required,max=100"`
 }
 type UpdateCollectorManagementRequest struct {
-	ID          string `json:"id" validate:"required,max=100"`
-	WasteBankID string `json:"waste_bank_id"`
-	CollectorID string `json:"collector_id"`
-	Status      string `json:"status"`
+	ID            string `json:"id" validate:"required,max=100"`
+	WasteBankID   string `json:"waste_bank_id"`
+	CollectorID   string `json:"collector_id"`
+	Status        string `json:"status"`
+	DailyCapacity int    `json:"daily_capacity" validate:"omitempty,min=1"`
 }
 
 type DeleteCollectorManagementRequest struct {
@@ -0,0 +1,38 @@
+package model
+
+type GetCollectorRouteRequest struct {
+	CollectorID string           `json:"collector_id" validate:"required,max=100"`
+	Date        string           `json:"date" validate:"required"` // Format: "2024-01-31"
+	StartTime   string           `json:"start_time"`               // Format: "08:00", defaults to the configured departure time
+	Start       *LocationRequest `json:"start,omitempty"`          // Defaults to the collector's profile location
+	ActorID     string           `json:"-"`
+	ActorRole   string           `json:"-"`
+}
+
+type CollectorRouteResponse struct {
+	CollectorID          string                       `json:"collector_id"`
+	Date                 string                       `json:"date"`
+	StartLocation        *LocationResponse            `json:"start_location,omitempty"`
+	DepartureTime        string                       `json:"departure_time"`
+	FinishTime           string                       `json:"finish_time"` // Format: "HH:MM", with " (+1)" when past midnight
+	TotalDistanceMeters  float64                      `json:"total_distance_meters"`
+	TotalDurationMinutes int                          `json:"total_duration_minutes"`
+	LateStops            int                          `json:"late_stops"`
+	Stops                []CollectorRouteStopResponse `json:"stops"`
+	Unrouted             []CollectorRouteStopResponse `json:"unrouted"` // Requests without an appointment location
+}
+
+type CollectorRouteStopResponse struct {
+	Sequence                   int               `json:"sequence"`
+	RequestID                  string            `json:"request_id"`
+	RequestType                string            `json:"request_type"`
+	Status                     string            `json:"status"`
+	Location                   *LocationResponse `json:"location,omitempty"`
+	AppointmentStartTime       string            `json:"appointment_start_time,omitempty"`
+	AppointmentEndTime         string            `json:"appointment_end_time,omitempty"`
+	EstimatedArrival           string            `json:"estimated_arrival,omitempty"`
+	EstimatedDeparture         string            `json:"estimated_departure,omitempty"`
+	WaitMinutes                int               `json:"wait_minutes"`
+	LateMinutes                int               `json:"late_minutes"`
+	DistanceFromPreviousMeters float64           `json:"distance_from_previous_meters"`
+}
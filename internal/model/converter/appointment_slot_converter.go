@@ -0,0 +1,18 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func ScheduleExceptionToResponse(exception *entity.WasteBankScheduleException) *model.ScheduleExceptionResponse {
+	return &model.ScheduleExceptionResponse{
+		ID:          exception.ID.String(),
+		WasteBankID: exception.WasteBankID.String(),
+		Date:        exception.Date.Format("2006-01-02"),
+		IsClosed:    exception.IsClosed,
+		OpenTime:    exception.OpenTime.Format("15:04"),
+		CloseTime:   exception.CloseTime.Format("15:04"),
+		Reason:      exception.Reason,
+	}
+}
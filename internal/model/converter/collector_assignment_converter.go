@@ -0,0 +1,29 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func CollectorAssignmentToResponse(assignment *entity.CollectorAssignment) *model.CollectorAssignmentResponse {
+	response := &model.CollectorAssignmentResponse{
+		ID:             assignment.ID.String(),
+		RequestID:      assignment.RequestID.String(),
+		WasteBankID:    assignment.WasteBankID.String(),
+		CollectorID:    assignment.CollectorID.String(),
+		Trigger:        assignment.Trigger,
+		Score:          assignment.Score,
+		DistanceMeters: assignment.DistanceMeters,
+		LocationSource: assignment.LocationSource,
+		WindowLoad:     assignment.WindowLoad,
+		DayLoad:        assignment.DayLoad,
+		DailyCapacity:  assignment.DailyCapacity,
+		CandidateCount: assignment.CandidateCount,
+		Reason:         assignment.Reason,
+		CreatedAt:      &assignment.CreatedAt,
+	}
+	if assignment.Collector != nil {
+		response.Collector = UserToResponse(assignment.Collector)
+	}
+	return response
+}
@@ -8,10 +8,11 @@ import (
 
 func CollectorManagementToSimpleResponse(collectorManagement *entity.CollectorManagement) *model.CollectorManagementSimpleResponse {
 	return &model.CollectorManagementSimpleResponse{
-		ID:          collectorManagement.ID.String(),
-		WasteBankID: collectorManagement.WasteBankID.String(),
-		CollectorID: collectorManagement.CollectorID.String(),
-		Status:      collectorManagement.Status,
+		ID:            collectorManagement.ID.String(),
+		WasteBankID:   collectorManagement.WasteBankID.String(),
+		CollectorID:   collectorManagement.CollectorID.String(),
+		Status:        collectorManagement.Status,
+		DailyCapacity: collectorManagement.DailyCapacity,
 	}
 }
 
@@ -25,11 +26,12 @@ func CollectorManagementToResponse(collectorManagement *entity.CollectorManageme
 		collector = UserToResponse(&collectorManagement.Collector)
 	}
 	return &model.CollectorManagementResponse{
-		ID:          collectorManagement.ID.String(),
-		WasteBankID: collectorManagement.WasteBankID.String(),
-		CollectorID: collectorManagement.CollectorID.String(),
-		Status:      collectorManagement.Status,
-		WasteBank:   wasteBank,
-		Collector:   collector,
+		ID:            collectorManagement.ID.String(),
+		WasteBankID:   collectorManagement.WasteBankID.String(),
+		CollectorID:   collectorManagement.CollectorID.String(),
+		Status:        collectorManagement.Status,
+		WasteBank:     wasteBank,
+		Collector:     collector,
+		DailyCapacity: collectorManagement.DailyCapacity,
 	}
 }
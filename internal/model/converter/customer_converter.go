@@ -16,6 +16,7 @@ func CustomerToResponse(customer *entity.CustomerProfile) *model.CustomerRespons
 		UserID:        customer.UserID.String(),
 		CarbonDeficit: customer.CarbonDeficit,
 		WaterSaved:    customer.WaterSaved,
+		EnergySaved:   customer.EnergySaved,
 		BagsStored:    customer.BagsStored,
 		Trees:         customer.Trees,
 		User:          userResponse,
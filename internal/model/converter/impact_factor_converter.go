@@ -0,0 +1,44 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func ImpactFactorToResponse(factor *entity.ImpactFactor) *model.ImpactFactorResponse {
+	response := &model.ImpactFactorResponse{
+		ID:               factor.ID.String(),
+		Co2ePerKg:        factor.Co2ePerKg,
+		WaterLitersPerKg: factor.WaterLitersPerKg,
+		EnergyKwhPerKg:   factor.EnergyKwhPerKg,
+		TreesPerKg:       factor.TreesPerKg,
+		Source:           factor.Source,
+		IsApplied:        factor.AppliedAt != nil,
+		AppliedAt:        factor.AppliedAt,
+		IsDeleted:        factor.IsDeleted,
+		CreatedAt:        &factor.CreatedAt,
+		UpdatedAt:        &factor.UpdatedAt,
+	}
+	if factor.WasteTypeID != nil {
+		response.WasteTypeID = factor.WasteTypeID.String()
+	}
+	if factor.WasteCategoryID != nil {
+		response.WasteCategoryID = factor.WasteCategoryID.String()
+	}
+	if factor.WasteType != nil {
+		response.WasteType = WasteTypeToResponse(factor.WasteType)
+	}
+	if factor.WasteCategory != nil {
+		response.WasteCategory = WasteCategoryToResponse(factor.WasteCategory)
+	}
+	return response
+}
+
+func EnvironmentalImpactToResponse(impact *entity.EnvironmentalImpact) *model.EnvironmentalImpact {
+	return &model.EnvironmentalImpact{
+		Co2eKg:      impact.Co2e,
+		WaterLiters: impact.WaterLiters,
+		EnergyKwh:   impact.EnergyKwh,
+		Trees:       impact.Trees,
+	}
+}
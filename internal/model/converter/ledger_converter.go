@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func LedgerLineToStatementLineResponse(line *entity.LedgerLine) *model.LedgerStatementLineResponse {
+	response := &model.LedgerStatementLineResponse{
+		ID:           line.ID.String(),
+		EntryID:      line.EntryID.String(),
+		Direction:    line.Direction,
+		Amount:       line.Amount,
+		BalanceAfter: line.BalanceAfter,
+		CreatedAt:    &line.CreatedAt,
+	}
+
+	if line.Entry != nil {
+		response.EntryType = line.Entry.EntryType
+		response.ReferenceType = line.Entry.ReferenceType
+		response.Description = line.Entry.Description
+		if line.Entry.ReferenceID != nil {
+			response.ReferenceID = line.Entry.ReferenceID.String()
+		}
+	}
+
+	return response
+}
+
+func LedgerDriftToResponse(drift *entity.LedgerDrift) *model.LedgerDriftResponse {
+	return &model.LedgerDriftResponse{
+		ID:            drift.ID.String(),
+		UserID:        drift.UserID.String(),
+		Asset:         drift.Asset,
+		CachedBalance: drift.CachedBalance,
+		LedgerBalance: drift.LedgerBalance,
+		Difference:    drift.CachedBalance - drift.LedgerBalance,
+		IsResolved:    drift.IsResolved,
+		DetectedAt:    &drift.DetectedAt,
+		ResolvedAt:    drift.ResolvedAt,
+	}
+}
@@ -0,0 +1,64 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func PickupSubscriptionToResponse(subscription *entity.PickupSubscription) *model.PickupSubscriptionResponse {
+	var endDate string
+	if subscription.EndDate != nil {
+		endDate = subscription.EndDate.Format("2006-01-02")
+	}
+
+	var location *model.LocationResponse
+	if subscription.AppointmentLocation != nil {
+		location = &model.LocationResponse{
+			Latitude:  subscription.AppointmentLocation.Lat,
+			Longitude: subscription.AppointmentLocation.Lng,
+		}
+	}
+
+	items := make([]model.PickupSubscriptionItemResponse, len(subscription.Items))
+	for i, item := range subscription.Items {
+		items[i] = model.PickupSubscriptionItemResponse{
+			WasteTypeID: item.WasteTypeID.String(),
+			Quantity:    item.Quantity,
+		}
+		if item.WasteType.ID == item.WasteTypeID {
+			items[i].WasteType = WasteTypeToResponse(&item.WasteType)
+		}
+	}
+
+	return &model.PickupSubscriptionResponse{
+		ID:                  subscription.ID.String(),
+		CustomerID:          subscription.CustomerID.String(),
+		WasteBankID:         subscription.WasteBankID.String(),
+		Frequency:           subscription.Frequency,
+		DayOfWeek:           subscription.DayOfWeek,
+		DayOfMonth:          subscription.DayOfMonth,
+		StartTime:           subscription.StartTime.Format("15:04:05Z07:00"),
+		EndTime:             subscription.EndTime.Format("15:04:05Z07:00"),
+		StartDate:           subscription.StartDate.Format("2006-01-02"),
+		EndDate:             endDate,
+		AppointmentLocation: location,
+		UserPhoneNumber:     subscription.UserPhoneNumber,
+		Notes:               subscription.Notes,
+		Status:              subscription.Status,
+		Items:               items,
+		CreatedAt:           &subscription.CreatedAt,
+		UpdatedAt:           &subscription.UpdatedAt,
+	}
+}
+
+func PickupSubscriptionOccurrenceToResponse(occurrence *entity.PickupSubscriptionOccurrence) *model.PickupSubscriptionOccurrenceResponse {
+	response := &model.PickupSubscriptionOccurrenceResponse{
+		Date:   occurrence.Date.Format("2006-01-02"),
+		Status: occurrence.Status,
+		Reason: occurrence.Reason,
+	}
+	if occurrence.RequestID != nil {
+		response.RequestID = occurrence.RequestID.String()
+	}
+	return response
+}
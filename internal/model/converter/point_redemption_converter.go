@@ -0,0 +1,65 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func PointConversionRateToResponse(rate *entity.PointConversionRate) *model.PointConversionRateResponse {
+	response := &model.PointConversionRateResponse{
+		ID:                rate.ID.String(),
+		RupiahPerPoint:    rate.RupiahPerPoint,
+		MinPoints:         rate.MinPoints,
+		MaxPoints:         rate.MaxPoints,
+		PeriodLimitPoints: rate.PeriodLimitPoints,
+		LimitPeriod:       rate.LimitPeriod,
+		EffectiveFrom:     &rate.EffectiveFrom,
+		IsActive:          rate.IsActive,
+		CreatedAt:         &rate.CreatedAt,
+		UpdatedAt:         &rate.UpdatedAt,
+	}
+	if rate.WasteBankID != nil {
+		response.WasteBankID = rate.WasteBankID.String()
+	}
+	return response
+}
+
+func PointRedemptionToSimpleResponse(redemption *entity.PointRedemption) *model.PointRedemptionSimpleResponse {
+	response := &model.PointRedemptionSimpleResponse{
+		ID:             redemption.ID.String(),
+		UserID:         redemption.UserID.String(),
+		Points:         redemption.Points,
+		RupiahPerPoint: redemption.RupiahPerPoint,
+		RupiahAmount:   redemption.RupiahAmount,
+		Status:         redemption.Status,
+		Reason:         redemption.Reason,
+		Notes:          redemption.Notes,
+		IsOnHold:       redemption.Status == "pending" && redemption.HoldEntryID != nil,
+		DecidedAt:      redemption.DecidedAt,
+		LegacySource:   redemption.LegacySource,
+		IsDeleted:      redemption.IsDeleted,
+		CreatedAt:      &redemption.CreatedAt,
+		UpdatedAt:      &redemption.UpdatedAt,
+	}
+	if redemption.WasteBankID != nil {
+		response.WasteBankID = redemption.WasteBankID.String()
+	}
+	if redemption.RateID != nil {
+		response.RateID = redemption.RateID.String()
+	}
+	if redemption.DecidedByID != nil {
+		response.DecidedByID = redemption.DecidedByID.String()
+	}
+	return response
+}
+
+func PointRedemptionToResponse(redemption *entity.PointRedemption) *model.PointRedemptionResponse {
+	response := &model.PointRedemptionResponse{
+		PointRedemptionSimpleResponse: *PointRedemptionToSimpleResponse(redemption),
+		User:                          UserToResponse(&redemption.User),
+	}
+	if redemption.WasteBank != nil {
+		response.WasteBank = UserToResponse(redemption.WasteBank)
+	}
+	return response
+}
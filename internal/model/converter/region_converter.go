@@ -0,0 +1,18 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func RegionToResponse(region *entity.Region) *model.RegionResponse {
+	response := &model.RegionResponse{
+		Code:  region.Code,
+		Level: region.Level,
+		Name:  region.Name,
+	}
+	if region.ParentCode != nil {
+		response.ParentCode = *region.ParentCode
+	}
+	return response
+}
@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"encoding/json"
+
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func ServiceAreaToResponse(serviceArea *entity.ServiceArea) *model.ServiceAreaResponse {
+	area, _ := json.Marshal(serviceArea.Area)
+	return &model.ServiceAreaResponse{
+		ID:        serviceArea.ID.String(),
+		UserID:    serviceArea.UserID.String(),
+		Name:      serviceArea.Name,
+		Area:      area,
+		IsActive:  serviceArea.IsActive,
+		CreatedAt: &serviceArea.CreatedAt,
+		UpdatedAt: &serviceArea.UpdatedAt,
+	}
+}
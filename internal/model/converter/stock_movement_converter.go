@@ -0,0 +1,27 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func StockMovementToResponse(movement *entity.StockMovement) *model.StockMovementResponse {
+	response := &model.StockMovementResponse{
+		ID:            movement.ID.String(),
+		StorageID:     movement.StorageID.String(),
+		WasteTypeID:   movement.WasteTypeID.String(),
+		MovementType:  movement.MovementType,
+		WeightKgs:     movement.WeightKgs,
+		BalanceKgs:    movement.BalanceKgs,
+		ReferenceType: movement.ReferenceType,
+		Notes:         movement.Notes,
+		CreatedAt:     movement.CreatedAt,
+	}
+	if movement.ReferenceID != nil {
+		response.ReferenceID = movement.ReferenceID.String()
+	}
+	if movement.ActorID != nil {
+		response.ActorID = movement.ActorID.String()
+	}
+	return response
+}
@@ -0,0 +1,71 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func StockTakeToSimpleResponse(stockTake *entity.StockTake) *model.StockTakeSimpleResponse {
+	response := &model.StockTakeSimpleResponse{
+		ID:          stockTake.ID.String(),
+		StorageID:   stockTake.StorageID.String(),
+		Status:      stockTake.Status,
+		Notes:       stockTake.Notes,
+		OpenedAt:    stockTake.OpenedAt,
+		PostedAt:    stockTake.PostedAt,
+		CancelledAt: stockTake.CancelledAt,
+		CreatedAt:   stockTake.CreatedAt,
+		UpdatedAt:   stockTake.UpdatedAt,
+	}
+	if stockTake.OpenedByID != nil {
+		response.OpenedByID = stockTake.OpenedByID.String()
+	}
+	if stockTake.PostedByID != nil {
+		response.PostedByID = stockTake.PostedByID.String()
+	}
+	return response
+}
+
+func StockTakeLineToResponse(line *entity.StockTakeLine) *model.StockTakeLineResponse {
+	response := &model.StockTakeLineResponse{
+		ID:               line.ID.String(),
+		WasteTypeID:      line.WasteTypeID.String(),
+		WasteTypeName:    line.WasteType.Name,
+		SystemWeightKgs:  line.SystemWeightKgs,
+		CountedWeightKgs: line.CountedWeightKgs,
+		Notes:            line.Notes,
+		CountedAt:        line.CountedAt,
+	}
+	if line.ShrinkageReason != nil {
+		response.ShrinkageReason = *line.ShrinkageReason
+	}
+	if line.CountedWeightKgs != nil {
+		variance := *line.CountedWeightKgs - line.SystemWeightKgs
+		response.VarianceKgs = &variance
+		if line.SystemWeightKgs > 0 {
+			percent := variance / line.SystemWeightKgs * 100
+			response.VariancePercent = &percent
+		}
+	}
+	return response
+}
+
+func StockTakeToResponse(stockTake *entity.StockTake) *model.StockTakeResponse {
+	response := &model.StockTakeResponse{
+		StockTakeSimpleResponse: *StockTakeToSimpleResponse(stockTake),
+		Lines:                   make([]model.StockTakeLineResponse, len(stockTake.Lines)),
+	}
+	for i, line := range stockTake.Lines {
+		response.Lines[i] = *StockTakeLineToResponse(&line)
+
+		response.Summary.Lines++
+		response.Summary.SystemWeightKgs += line.SystemWeightKgs
+		if line.CountedWeightKgs == nil {
+			response.Summary.UncountedLines++
+			continue
+		}
+		response.Summary.CountedWeightKgs += *line.CountedWeightKgs
+		response.Summary.VarianceKgs += *line.CountedWeightKgs - line.SystemWeightKgs
+	}
+	return response
+}
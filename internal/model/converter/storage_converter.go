@@ -14,6 +14,8 @@ func StorageToSimpleResponse(storage *entity.Storage) *model.StorageSimpleRespon
 		Width:                 storage.Width,
 		Height:                storage.Height,
 		IsForRecycledMaterial: storage.IsForRecycledMaterial,
+		CapacityAlertPercent:  storage.CapacityAlertPercent,
+		RejectOverflow:        storage.RejectOverflow,
 	}
 }
 
@@ -30,5 +32,7 @@ func StorageToResponse(storage *entity.Storage) *model.StorageResponse {
 		Height:                storage.Height,
 		User:                  user,
 		IsForRecycledMaterial: storage.IsForRecycledMaterial,
+		CapacityAlertPercent:  storage.CapacityAlertPercent,
+		RejectOverflow:        storage.RejectOverflow,
 	}
 }
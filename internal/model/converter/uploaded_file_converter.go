@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func UploadedFileToResponse(file *entity.UploadedFile) *model.UploadedFileResponse {
+	return &model.UploadedFileResponse{
+		ID:           file.ID.String(),
+		OwnerType:    file.OwnerType,
+		OwnerID:      file.OwnerID.String(),
+		URL:          file.URL,
+		ThumbnailURL: file.ThumbnailURL,
+		ContentType:  file.ContentType,
+		SizeBytes:    file.SizeBytes,
+		Width:        file.Width,
+		Height:       file.Height,
+		CreatedAt:    &file.CreatedAt,
+	}
+}
@@ -22,11 +22,13 @@ func UserToResponse(user *entity.User) *model.UserResponse {
 		Email:               user.Email,
 		Role:                user.Role,
 		AvatarURL:           user.AvatarURL,
+		AvatarThumbnailURL:  user.AvatarThumbnailURL,
 		PhoneNumber:         user.PhoneNumber,
 		Institution:         user.Institution,
 		Address:             user.Address,
 		City:                user.City,
 		Province:            user.Province,
+		RegionCode:          user.RegionCode,
 		Points:              user.Points,
 		Balance:             user.Balance,
 		IsEmailVerified:     user.IsEmailVerified,
@@ -54,11 +56,13 @@ func UserToListResponse(user *entity.User) *model.UserListResponse {
 		Email:               user.Email,
 		Role:                user.Role,
 		AvatarURL:           user.AvatarURL,
+		AvatarThumbnailURL:  user.AvatarThumbnailURL,
 		PhoneNumber:         user.PhoneNumber,
 		Institution:         user.Institution,
 		Address:             user.Address,
 		City:                user.City,
 		Province:            user.Province,
+		RegionCode:          user.RegionCode,
 		Points:              user.Points,
 		Balance:             user.Balance,
 		IsEmailVerified:     user.IsEmailVerified,
@@ -23,12 +23,23 @@ func WasteBankToResponse(wasteBank *entity.WasteBankProfile) *model.WasteBankRes
 	}
 
 	return &model.WasteBankResponse{
-		ID:               wasteBank.ID.String(),
-		UserID:           wasteBank.UserID.String(),
-		TotalWasteWeight: wasteBank.TotalWasteWeight,
-		TotalWorkers:     wasteBank.TotalWorkers,
-		OpenTime:         openTime,
-		CloseTime:        closeTime,
-		User:             userResponse,
+		ID:                       wasteBank.ID.String(),
+		UserID:                   wasteBank.UserID.String(),
+		TotalWasteWeight:         wasteBank.TotalWasteWeight,
+		TotalWorkers:             wasteBank.TotalWorkers,
+		OpenTime:                 openTime,
+		CloseTime:                closeTime,
+		User:                     userResponse,
+		AutoAssignCollectors:     wasteBank.AutoAssignCollectors,
+		AutoAssignMaxDistanceKm:  wasteBank.AutoAssignMaxDistanceKm,
+		SlotDurationMinutes:      wasteBank.SlotDurationMinutes,
+		DropoffSlotCapacity:      wasteBank.DropoffSlotCapacity,
+		PickupSlotCapacity:       wasteBank.PickupSlotCapacity,
+		SlotOverbookingPolicy:    wasteBank.SlotOverbookingPolicy,
+		PendingExpiryHours:       wasteBank.PendingExpiryHours,
+		PickupBaseFee:            wasteBank.PickupBaseFee,
+		PickupFeePerKm:           wasteBank.PickupFeePerKm,
+		PickupFreeRadiusKm:       wasteBank.PickupFreeRadiusKm,
+		RejectOutsideServiceArea: wasteBank.RejectOutsideServiceArea,
 	}
 }
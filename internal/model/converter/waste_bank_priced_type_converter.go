@@ -1,6 +1,8 @@
 package converter
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/wastetrack/wastetrack-backend/internal/entity"
 	"github.com/wastetrack/wastetrack-backend/internal/model"
@@ -43,3 +45,27 @@ func WasteBankPricedTypeToResponse(wasteBankPricedType *entity.WasteBankPricedTy
 		WasteType:         wasteType,
 	}
 }
+
+func WasteBankPriceVersionToResponse(version *entity.WasteBankPriceVersion) *model.WasteBankPriceVersionResponse {
+	now := time.Now()
+	response := &model.WasteBankPriceVersionResponse{
+		ID:            version.ID.String(),
+		WasteBankID:   version.WasteBankID.String(),
+		WasteTypeID:   version.WasteTypeID.String(),
+		PricePerKgs:   version.PricePerKgs,
+		EffectiveFrom: &version.EffectiveFrom,
+		EffectiveTo:   version.EffectiveTo,
+		IsCurrent:     !version.EffectiveFrom.After(now) && (version.EffectiveTo == nil || version.EffectiveTo.After(now)),
+		CreatedAt:     &version.CreatedAt,
+	}
+	if version.PricedTypeID != nil {
+		response.PricedTypeID = version.PricedTypeID.String()
+	}
+	if version.CreatedByID != nil {
+		response.CreatedByID = version.CreatedByID.String()
+	}
+	if version.WasteType.ID != uuid.Nil {
+		response.WasteType = WasteTypeToResponse(&version.WasteType)
+	}
+	return response
+}
@@ -0,0 +1,27 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func WasteBankPromotionToResponse(promotion *entity.WasteBankPromotion) *model.WasteBankPromotionResponse {
+	response := &model.WasteBankPromotionResponse{
+		ID:           promotion.ID.String(),
+		WasteBankID:  promotion.WasteBankID.String(),
+		Name:         promotion.Name,
+		BonusPercent: promotion.BonusPercent,
+		StartsAt:     promotion.StartsAt,
+		EndsAt:       promotion.EndsAt,
+		IsActive:     promotion.IsActive,
+		CreatedAt:    &promotion.CreatedAt,
+		UpdatedAt:    &promotion.UpdatedAt,
+	}
+	if promotion.WasteTypeID != nil {
+		response.WasteTypeID = promotion.WasteTypeID.String()
+	}
+	if promotion.WasteType != nil {
+		response.WasteType = WasteTypeToResponse(promotion.WasteType)
+	}
+	return response
+}
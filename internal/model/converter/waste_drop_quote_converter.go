@@ -0,0 +1,59 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func WasteDropQuoteToResponse(quote *entity.WasteDropQuote) *model.WasteDropQuoteResponse {
+	var location *model.LocationResponse
+	if quote.AppointmentLocation != nil {
+		location = &model.LocationResponse{
+			Latitude:  quote.AppointmentLocation.Lat,
+			Longitude: quote.AppointmentLocation.Lng,
+		}
+	}
+
+	var requestID string
+	if quote.RequestID != nil {
+		requestID = quote.RequestID.String()
+	}
+
+	items := make([]model.WasteDropQuoteItemResponse, len(quote.Items))
+	for i, item := range quote.Items {
+		items[i] = model.WasteDropQuoteItemResponse{
+			WasteTypeID:       item.WasteTypeID.String(),
+			EstimatedWeight:   item.EstimatedWeight,
+			PricePerKgs:       item.PricePerKgs,
+			BonusPercent:      item.BonusPercent,
+			QuotedPricePerKgs: item.QuotedPricePerKgs,
+			Subtotal:          item.Subtotal,
+		}
+		if item.PriceVersionID != nil {
+			items[i].PriceVersionID = item.PriceVersionID.String()
+		}
+		if item.PromotionID != nil {
+			items[i].PromotionID = item.PromotionID.String()
+		}
+		if item.WasteType.ID == item.WasteTypeID {
+			items[i].WasteType = WasteTypeToResponse(&item.WasteType)
+		}
+	}
+
+	return &model.WasteDropQuoteResponse{
+		ID:                  quote.ID.String(),
+		CustomerID:          quote.CustomerID.String(),
+		WasteBankID:         quote.WasteBankID.String(),
+		DeliveryType:        quote.DeliveryType,
+		AppointmentLocation: location,
+		DistanceKm:          quote.DistanceKm,
+		ItemsTotal:          quote.ItemsTotal,
+		PromotionBonus:      quote.PromotionBonus,
+		PickupFee:           quote.PickupFee,
+		TotalPrice:          quote.TotalPrice,
+		ExpiresAt:           quote.ExpiresAt,
+		RequestID:           requestID,
+		Items:               items,
+		CreatedAt:           &quote.CreatedAt,
+	}
+}
@@ -28,13 +28,22 @@ func WasteDropRequestToSimpleResponse(wasteDropRequest *entity.WasteDropRequest)
 	}
 
 	// Handle potentially nil UUID pointers
-	var wasteBankID, assignedCollectorID string
+	var wasteBankID, assignedCollectorID, subscriptionID, cancellationReason, quoteID string
 	if wasteDropRequest.WasteBankID != nil {
 		wasteBankID = wasteDropRequest.WasteBankID.String()
 	}
 	if wasteDropRequest.AssignedCollectorID != nil {
 		assignedCollectorID = wasteDropRequest.AssignedCollectorID.String()
 	}
+	if wasteDropRequest.SubscriptionID != nil {
+		subscriptionID = wasteDropRequest.SubscriptionID.String()
+	}
+	if wasteDropRequest.CancellationReasonCode != nil {
+		cancellationReason = *wasteDropRequest.CancellationReasonCode
+	}
+	if wasteDropRequest.QuoteID != nil {
+		quoteID = wasteDropRequest.QuoteID.String()
+	}
 
 	response := &model.WasteDropRequestSimpleResponse{
 		ID:                   wasteDropRequest.ID.String(),
@@ -45,12 +54,22 @@ func WasteDropRequestToSimpleResponse(wasteDropRequest *entity.WasteDropRequest)
 		AssignedCollectorID:  assignedCollectorID,
 		TotalPrice:           wasteDropRequest.TotalPrice,
 		ImageURL:             wasteDropRequest.ImageURL,
+		ImageThumbnailURL:    wasteDropRequest.ImageThumbnailURL,
 		Status:               wasteDropRequest.Status,
 		AppointmentLocation:  location,
 		AppointmentDate:      appointmentDate,
 		AppointmentStartTime: startTime,
 		AppointmentEndTime:   endTime,
+		IsWaitlisted:         wasteDropRequest.IsWaitlisted,
+		SubscriptionID:       subscriptionID,
 		Notes:                wasteDropRequest.Notes,
+		CancellationReason:   cancellationReason,
+		CancelledAt:          wasteDropRequest.CancelledAt,
+		EstimatedTotalPrice:  wasteDropRequest.EstimatedTotalPrice,
+		QuoteID:              quoteID,
+		PickupFee:            wasteDropRequest.PickupFee,
+		IsOutsideServiceArea: wasteDropRequest.IsOutsideServiceArea,
+		RegionCode:           wasteDropRequest.RegionCode,
 		CreatedAt:            &wasteDropRequest.CreatedAt,
 		UpdatedAt:            &wasteDropRequest.UpdatedAt,
 		Distance:             wasteDropRequest.Distance, // Now directly accessible
@@ -88,13 +107,22 @@ func WasteDropRequestToResponse(wasteDropRequest *entity.WasteDropRequest) *mode
 	}
 
 	// Handle potentially nil UUID pointers
-	var wasteBankID, assignedCollectorID string
+	var wasteBankID, assignedCollectorID, subscriptionID, cancellationReason, quoteID string
 	if wasteDropRequest.WasteBankID != nil {
 		wasteBankID = wasteDropRequest.WasteBankID.String()
 	}
 	if wasteDropRequest.AssignedCollectorID != nil {
 		assignedCollectorID = wasteDropRequest.AssignedCollectorID.String()
 	}
+	if wasteDropRequest.SubscriptionID != nil {
+		subscriptionID = wasteDropRequest.SubscriptionID.String()
+	}
+	if wasteDropRequest.CancellationReasonCode != nil {
+		cancellationReason = *wasteDropRequest.CancellationReasonCode
+	}
+	if wasteDropRequest.QuoteID != nil {
+		quoteID = wasteDropRequest.QuoteID.String()
+	}
 
 	response := &model.WasteDropRequestResponse{
 		ID:                   wasteDropRequest.ID.String(),
@@ -105,12 +133,22 @@ func WasteDropRequestToResponse(wasteDropRequest *entity.WasteDropRequest) *mode
 		AssignedCollectorID:  assignedCollectorID,
 		TotalPrice:           wasteDropRequest.TotalPrice,
 		ImageURL:             wasteDropRequest.ImageURL,
+		ImageThumbnailURL:    wasteDropRequest.ImageThumbnailURL,
 		Status:               wasteDropRequest.Status,
 		AppointmentLocation:  location,
 		AppointmentDate:      appointmentDate,
 		AppointmentStartTime: startTime,
 		AppointmentEndTime:   endTime,
+		IsWaitlisted:         wasteDropRequest.IsWaitlisted,
+		SubscriptionID:       subscriptionID,
 		Notes:                wasteDropRequest.Notes,
+		CancellationReason:   cancellationReason,
+		CancelledAt:          wasteDropRequest.CancelledAt,
+		EstimatedTotalPrice:  wasteDropRequest.EstimatedTotalPrice,
+		QuoteID:              quoteID,
+		PickupFee:            wasteDropRequest.PickupFee,
+		IsOutsideServiceArea: wasteDropRequest.IsOutsideServiceArea,
+		RegionCode:           wasteDropRequest.RegionCode,
 		CreatedAt:            &wasteDropRequest.CreatedAt,
 		UpdatedAt:            &wasteDropRequest.UpdatedAt,
 		Customer:             UserToResponse(&wasteDropRequest.Customer),
@@ -7,14 +7,25 @@ import (
 )
 
 func WasteDropRequestItemToSimpleResponse(wasteDropRequestItem *entity.WasteDropRequestItem) *model.WasteDropRequestItemSimpleResponse {
+	var priceVersionID string
+	if wasteDropRequestItem.PriceVersionID != nil {
+		priceVersionID = wasteDropRequestItem.PriceVersionID.String()
+	}
 	return &model.WasteDropRequestItemSimpleResponse{
-		ID:                  wasteDropRequestItem.ID.String(),
-		RequestID:           wasteDropRequestItem.RequestID.String(),
-		WasteTypeID:         wasteDropRequestItem.WasteTypeID.String(),
-		Quantity:            wasteDropRequestItem.Quantity,
-		VerifiedWeight:      wasteDropRequestItem.VerifiedWeight,
-		VerifiedPricePerKgs: wasteDropRequestItem.VerifiedPricePerKgs,
-		VerifiedSubtotal:    wasteDropRequestItem.VerifiedSubtotal,
+		ID:                   wasteDropRequestItem.ID.String(),
+		RequestID:            wasteDropRequestItem.RequestID.String(),
+		WasteTypeID:          wasteDropRequestItem.WasteTypeID.String(),
+		Quantity:             wasteDropRequestItem.Quantity,
+		VerifiedWeight:       wasteDropRequestItem.VerifiedWeight,
+		VerifiedPricePerKgs:  wasteDropRequestItem.VerifiedPricePerKgs,
+		PriceVersionID:       priceVersionID,
+		VerifiedSubtotal:     wasteDropRequestItem.VerifiedSubtotal,
+		Grade:                wasteDropRequestItem.Grade,
+		GradeMultiplier:      wasteDropRequestItem.GradeMultiplier,
+		ContaminationPercent: wasteDropRequestItem.ContaminationPercent,
+		MoisturePercent:      wasteDropRequestItem.MoisturePercent,
+		GrossSubtotal:        wasteDropRequestItem.GrossSubtotal,
+		IsAddedAtCompletion:  wasteDropRequestItem.IsAddedAtCompletion,
 	}
 }
 
@@ -27,15 +38,26 @@ func WasteDropRequestItemToResponse(wasteDropRequestItem *entity.WasteDropReques
 	if wasteDropRequestItem.WasteTypeID != uuid.Nil {
 		wasteType = WasteTypeToResponse(&wasteDropRequestItem.WasteType)
 	}
+	var priceVersionID string
+	if wasteDropRequestItem.PriceVersionID != nil {
+		priceVersionID = wasteDropRequestItem.PriceVersionID.String()
+	}
 	return &model.WasteDropRequestItemResponse{
-		ID:                  wasteDropRequestItem.ID.String(),
-		RequestID:           wasteDropRequestItem.RequestID.String(),
-		WasteTypeID:         wasteDropRequestItem.WasteTypeID.String(),
-		Quantity:            wasteDropRequestItem.Quantity,
-		VerifiedWeight:      wasteDropRequestItem.VerifiedWeight,
-		VerifiedPricePerKgs: wasteDropRequestItem.VerifiedPricePerKgs,
-		VerifiedSubtotal:    wasteDropRequestItem.VerifiedSubtotal,
-		Request:             wasteDropRequest,
-		WasteType:           wasteType,
+		ID:                   wasteDropRequestItem.ID.String(),
+		RequestID:            wasteDropRequestItem.RequestID.String(),
+		WasteTypeID:          wasteDropRequestItem.WasteTypeID.String(),
+		Quantity:             wasteDropRequestItem.Quantity,
+		VerifiedWeight:       wasteDropRequestItem.VerifiedWeight,
+		VerifiedPricePerKgs:  wasteDropRequestItem.VerifiedPricePerKgs,
+		PriceVersionID:       priceVersionID,
+		VerifiedSubtotal:     wasteDropRequestItem.VerifiedSubtotal,
+		Grade:                wasteDropRequestItem.Grade,
+		GradeMultiplier:      wasteDropRequestItem.GradeMultiplier,
+		ContaminationPercent: wasteDropRequestItem.ContaminationPercent,
+		MoisturePercent:      wasteDropRequestItem.MoisturePercent,
+		GrossSubtotal:        wasteDropRequestItem.GrossSubtotal,
+		IsAddedAtCompletion:  wasteDropRequestItem.IsAddedAtCompletion,
+		Request:              wasteDropRequest,
+		WasteType:            wasteType,
 	}
 }
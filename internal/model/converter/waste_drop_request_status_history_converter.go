@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+)
+
+func WasteDropRequestStatusHistoryToResponse(history *entity.WasteDropRequestStatusHistory) *model.WasteDropRequestStatusHistoryResponse {
+	var fromStatus, actorID string
+	if history.FromStatus != nil {
+		fromStatus = *history.FromStatus
+	}
+	if history.ActorID != nil {
+		actorID = history.ActorID.String()
+	}
+
+	var location *model.LocationResponse
+	if history.Location != nil {
+		location = &model.LocationResponse{
+			Latitude:  history.Location.Lat,
+			Longitude: history.Location.Lng,
+		}
+	}
+
+	var actor *model.UserResponse
+	if history.Actor != nil {
+		actor = UserToResponse(history.Actor)
+	}
+
+	return &model.WasteDropRequestStatusHistoryResponse{
+		ID:         history.ID.String(),
+		RequestID:  history.RequestID.String(),
+		FromStatus: fromStatus,
+		ToStatus:   history.ToStatus,
+		ActorID:    actorID,
+		ActorRole:  history.ActorRole,
+		Reason:     history.Reason,
+		Location:   location,
+		CreatedAt:  &history.CreatedAt,
+		Actor:      actor,
+	}
+}
@@ -35,6 +35,11 @@ func WasteTransferRequestToSimpleResponse(request *entity.WasteTransferRequest)
 		assignedCollectorID = request.AssignedCollectorID.String()
 	}
 
+	var cancellationReason string
+	if request.CancellationReasonCode != nil {
+		cancellationReason = *request.CancellationReasonCode
+	}
+
 	return &model.WasteTransferRequestSimpleResponse{
 		ID:                     request.ID.String(),
 		SourceUserID:           request.SourceUserID.String(),
@@ -45,13 +50,17 @@ func WasteTransferRequestToSimpleResponse(request *entity.WasteTransferRequest)
 		TotalPrice:             request.TotalPrice,
 		Status:                 request.Status,
 		ImageURL:               request.ImageURL,
+		ImageThumbnailURL:      request.ImageThumbnailURL,
 		Notes:                  request.Notes,
+		CancellationReason:     cancellationReason,
+		CancelledAt:            request.CancelledAt,
 		SourcePhoneNumber:      request.SourcePhoneNumber,
 		DestinationPhoneNumber: request.DestinationPhoneNumber,
 		AppointmentDate:        appointmentDate,
 		AppointmentStartTime:   startTime,
 		AppointmentEndTime:     endTime,
 		AppointmentLocation:    location,
+		RegionCode:             request.RegionCode,
 		CreatedAt:              &request.CreatedAt,
 		UpdatedAt:              &request.UpdatedAt,
 		Distance:               request.Distance,
@@ -104,6 +113,11 @@ func WasteTransferRequestToResponse(request *entity.WasteTransferRequest) *model
 		assignedCollectorID = request.AssignedCollectorID.String()
 	}
 
+	var cancellationReason string
+	if request.CancellationReasonCode != nil {
+		cancellationReason = *request.CancellationReasonCode
+	}
+
 	// Convert items with loss weight calculation
 	var items []model.WasteTransferItemOfferingResponse
 	for _, item := range request.Items {
@@ -120,13 +134,17 @@ func WasteTransferRequestToResponse(request *entity.WasteTransferRequest) *model
 		TotalPrice:             request.TotalPrice,
 		Status:                 request.Status,
 		ImageURL:               request.ImageURL,
+		ImageThumbnailURL:      request.ImageThumbnailURL,
 		Notes:                  request.Notes,
+		CancellationReason:     cancellationReason,
+		CancelledAt:            request.CancelledAt,
 		SourcePhoneNumber:      request.SourcePhoneNumber,
 		DestinationPhoneNumber: request.DestinationPhoneNumber,
 		AppointmentDate:        appointmentDate,
 		AppointmentStartTime:   startTime,
 		AppointmentEndTime:     endTime,
 		AppointmentLocation:    location,
+		RegionCode:             request.RegionCode,
 		CreatedAt:              &request.CreatedAt,
 		UpdatedAt:              &request.UpdatedAt,
 		SourceUser:             sourceUser,
@@ -33,6 +33,8 @@ func WasteTransferItemOfferingToSimpleResponse(item *entity.WasteTransferItemOff
 		AcceptedWeight:      item.AcceptedWeight,
 		AcceptedPricePerKgs: item.AcceptedPricePerKgs,
 		VerifiedWeight:      item.VerifiedWeight,
+		RecycledWeight:      item.RecycledWeight,
+		RecycledAt:          item.RecycledAt,
 		LossWeight:          lossWeight,
 		WasteType:           wasteType,
 	}
@@ -66,6 +68,8 @@ func WasteTransferItemOfferingToResponse(item *entity.WasteTransferItemOffering)
 		AcceptedWeight:      item.AcceptedWeight,
 		AcceptedPricePerKgs: item.AcceptedPricePerKgs,
 		VerifiedWeight:      item.VerifiedWeight,
+		RecycledWeight:      item.RecycledWeight,
+		RecycledAt:          item.RecycledAt,
 		LossWeight:          lossWeight,
 		TransferForm:        transferForm,
 		WasteType:           wasteType,
@@ -13,10 +13,11 @@ func WasteTypeToResponse(wasteType *entity.WasteType) *model.WasteTypeResponse {
 	}
 
 	return &model.WasteTypeResponse{
-		ID:            wasteType.ID.String(),
-		CategoryID:    wasteType.CategoryID.String(),
-		Name:          wasteType.Name,
-		Description:   wasteType.Description,
-		WasteCategory: categoryResponse,
+		ID:                  wasteType.ID.String(),
+		CategoryID:          wasteType.CategoryID.String(),
+		Name:                wasteType.Name,
+		Description:         wasteType.Description,
+		BulkDensityKgsPerM3: wasteType.BulkDensityKgsPerM3,
+		WasteCategory:       categoryResponse,
 	}
 }
@@ -5,6 +5,7 @@ type CustomerResponse struct {
 	UserID        string        `json:"user_id"`
 	CarbonDeficit int64         `json:"carbon_deficit"`
 	WaterSaved    int64         `json:"water_saved"`
+	EnergySaved   int64         `json:"energy_saved"`
 	BagsStored    int64         `json:"bags_stored"`
 	Trees         int64         `json:"trees"`
 	User          *UserResponse `json:"user,omitempty"`
@@ -0,0 +1,79 @@
+package model
+
+// CustomerStatementRequest is filtered by month range like GovernmentDashboardRequest, months being in WIB
+type CustomerStatementRequest struct {
+	CustomerID string `json:"customer_id" validate:"omitempty,uuid"` // Required for admins, customers always see their own
+	StartMonth string `json:"start_month"`                           // Format: "2024-01"
+	EndMonth   string `json:"end_month"`                             // Format: "2024-12"
+	Format     string `json:"format" validate:"omitempty,oneof=json csv pdf"`
+	ActorID    string `json:"-"`
+	ActorRole  string `json:"-"`
+}
+
+// CustomerStatementResponse is the passbook of a customer, derived from the drop items and the points ledger
+type CustomerStatementResponse struct {
+	CustomerID     string                   `json:"customer_id"`
+	CustomerName   string                   `json:"customer_name"`
+	StartMonth     string                   `json:"start_month,omitempty"`
+	EndMonth       string                   `json:"end_month,omitempty"`
+	OpeningBalance int64                    `json:"opening_balance"` // Points before the first month
+	Months         []CustomerStatementMonth `json:"months"`
+	Totals         CustomerStatementTotals  `json:"totals"`
+}
+
+type CustomerStatementMonth struct {
+	Month          string                       `json:"month"`
+	Drops          int64                        `json:"drops"`
+	VerifiedWeight float64                      `json:"verified_weight"`
+	PointsEarned   int64                        `json:"points_earned"`
+	PointsRedeemed int64                        `json:"points_redeemed"` // Points put on hold for redemptions, less the ones returned
+	ClosingBalance int64                        `json:"closing_balance"`
+	Impact         CustomerStatementImpact      `json:"impact"`
+	WasteTypes     []CustomerStatementWasteType `json:"waste_types"`
+}
+
+type CustomerStatementWasteType struct {
+	WasteTypeID   string  `json:"waste_type_id"`
+	WasteTypeName string  `json:"waste_type_name"`
+	Weight        float64 `json:"weight"`
+	Points        int64   `json:"points"`
+}
+
+type CustomerStatementImpact struct {
+	Co2e        float64 `json:"co2e"`
+	WaterLiters float64 `json:"water_liters"`
+	EnergyKwh   float64 `json:"energy_kwh"`
+	Trees       float64 `json:"trees"`
+}
+
+type CustomerStatementTotals struct {
+	Drops          int64                   `json:"drops"`
+	VerifiedWeight float64                 `json:"verified_weight"`
+	PointsEarned   int64                   `json:"points_earned"`
+	PointsRedeemed int64                   `json:"points_redeemed"`
+	ClosingBalance int64                   `json:"closing_balance"`
+	Impact         CustomerStatementImpact `json:"impact"`
+}
+
+// CustomerStatementDropRow is the drop items of a month and waste type, as scanned from the database.
+// Drops counts the requests of the whole month, a request usually holding several waste types.
+type CustomerStatementDropRow struct {
+	Month         string
+	WasteTypeID   string
+	WasteTypeName string
+	Drops         int64
+	Weight        float64
+	Points        int64
+	Co2e          float64
+	WaterLiters   float64
+	EnergyKwh     float64
+	Trees         float64
+}
+
+// CustomerStatementPointsRow is the points movement of a month, as scanned from the database
+type CustomerStatementPointsRow struct {
+	Month    string
+	Earned   int64
+	Redeemed int64
+	Net      int64
+}
@@ -0,0 +1,9 @@
+package model
+
+type RebuildDailyWasteRollupRequest struct {
+	Since string `json:"since" validate:"omitempty,datetime=2006-01-02"` // Only rebuild from this day on
+}
+
+type RebuildDailyWasteRollupResponse struct {
+	Rows int64 `json:"rows"`
+}
@@ -1,5 +1,7 @@
 package model
 
+import "encoding/json"
+
 type GovernmentResponse struct {
 	ID     string        `json:"id"`
 	UserID string        `json:"user_id"`
@@ -29,6 +31,8 @@ type GovernmentDashboardRequest struct {
 	EndMonth   string `json:"end_month"`    // Format: "2024-12"
 	Province   string `json:"province,omitempty"`
 	City       string `json:"city,omitempty"`
+	// Region code of any level, matching the region and every region inside it
+	RegionCode string `json:"region_code,omitempty"`
 }
 
 // GovernmentDashboardResponse represents the complete dashboard response
@@ -39,6 +43,7 @@ type GovernmentDashboardResponse struct {
 	CollectionTrends []CollectionTrendByRole `json:"collectionTrends"`
 	TopOfftakers     []TopOfftaker           `json:"topOfftakers"`
 	LargestBanks     []LargestBank           `json:"largestBanks"`
+	Impact           *EnvironmentalImpact    `json:"environmentalImpact"`
 }
 
 // CollectionTrend represents collection data over time
@@ -81,3 +86,83 @@ type WasteBankCountRequest struct {
 	Province   string `json:"province,omitempty"`
 	City       string `json:"city,omitempty"`
 }
+
+// EnvironmentalImpact is computed from verified item weights and the impact factor of each waste type
+type EnvironmentalImpact struct {
+	Co2eKg      float64 `json:"co2e_kg"`
+	WaterLiters float64 `json:"water_liters"`
+	EnergyKwh   float64 `json:"energy_kwh"`
+	Trees       float64 `json:"trees"`
+}
+
+// RegionBreakdownRequest drills the dashboard down one level below ParentCode, or into provinces without it
+type RegionBreakdownRequest struct {
+	StartMonth string `json:"start_month"`
+	EndMonth   string `json:"end_month"`
+	ParentCode string `json:"parent_code,omitempty" validate:"omitempty,max=13"`
+	Format     string `json:"format,omitempty" validate:"omitempty,oneof=json geojson"`
+}
+
+// RegionBreakdownResponse holds the dashboard totals of every region at one level
+type RegionBreakdownResponse struct {
+	Level      string            `json:"level"`
+	ParentCode string            `json:"parent_code,omitempty"`
+	Regions    []RegionDashboard `json:"regions"`
+}
+
+// RegionDashboard is the dashboard of a single region. Records whose region is only known at a coarser
+// level than the breakdown are left out.
+type RegionDashboard struct {
+	Code            string  `json:"code"`
+	Name            string  `json:"name"`
+	Level           string  `json:"level"`
+	CollectedWeight float64 `json:"collected_weight"` // Verified weight of drop requests
+	OfftakenWeight  float64 `json:"offtaken_weight"`  // Weight of completed transfers to industry
+	RecyclingRate   float64 `json:"recycling_rate"`   // Offtaken weight over collected weight, 0 to 1
+	TotalBankSampah int64   `json:"total_bank_sampah"`
+	TotalOfftaker   int64   `json:"total_offtaker"`
+	// Boundary as GeoJSON, only loaded for the GeoJSON output
+	Geometry *string `json:"-"`
+}
+
+// DropHeatmapRequest aggregates drop request locations into grid cells or geohashes
+type DropHeatmapRequest struct {
+	StartMonth string `json:"start_month"`
+	EndMonth   string `json:"end_month"`
+	RegionCode string `json:"region_code,omitempty" validate:"omitempty,max=13"`
+	Mode       string `json:"mode" validate:"omitempty,oneof=grid geohash"`
+	CellSize   int    `json:"cell_size" validate:"omitempty,min=100,max=50000"` // Grid cell size in meters
+	Precision  int    `json:"precision" validate:"omitempty,min=1,max=9"`       // Geohash length
+	Format     string `json:"format,omitempty" validate:"omitempty,oneof=json geojson"`
+}
+
+// DropHeatmapCell is one cell of the heatmap, located at its center
+type DropHeatmapCell struct {
+	Key         string  `json:"key"` // Geohash, or the grid cell center in Web Mercator meters
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Requests    int64   `json:"requests"`
+	TotalWeight float64 `json:"total_weight"`
+	// Cell outline as GeoJSON, only loaded for the GeoJSON output
+	Geometry *string `json:"-"`
+}
+
+type DropHeatmapResponse struct {
+	Mode      string            `json:"mode"`
+	CellSize  int               `json:"cell_size,omitempty"`
+	Precision int               `json:"precision,omitempty"`
+	Cells     []DropHeatmapCell `json:"cells"`
+}
+
+// GeoJSONFeatureCollection is the map output of the dashboard, served as is without a WebResponse wrapper
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+type GeoJSONFeature struct {
+	Type       string          `json:"type"`
+	ID         string          `json:"id,omitempty"`
+	Geometry   json.RawMessage `json:"geometry"`
+	Properties any             `json:"properties"`
+}
@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+type ImpactFactorResponse struct {
+	ID               string                 `json:"id"`
+	WasteTypeID      string                 `json:"waste_type_id,omitempty"`
+	WasteCategoryID  string                 `json:"waste_category_id,omitempty"`
+	Co2ePerKg        float64                `json:"co2e_per_kg"`
+	WaterLitersPerKg float64                `json:"water_liters_per_kg"`
+	EnergyKwhPerKg   float64                `json:"energy_kwh_per_kg"`
+	TreesPerKg       float64                `json:"trees_per_kg"`
+	Source           string                 `json:"source,omitempty"`
+	IsApplied        bool                   `json:"is_applied"`
+	AppliedAt        *time.Time             `json:"applied_at"`
+	IsDeleted        bool                   `json:"is_deleted"`
+	CreatedAt        *time.Time             `json:"created_at"`
+	UpdatedAt        *time.Time             `json:"updated_at"`
+	WasteType        *WasteTypeResponse     `json:"waste_type,omitempty"`
+	WasteCategory    *WasteCategoryResponse `json:"waste_category,omitempty"`
+}
+
+type ImpactFactorRequest struct {
+	// Leave both empty for the default factor
+	WasteTypeID      string  `json:"waste_type_id" validate:"omitempty,uuid"`
+	WasteCategoryID  string  `json:"waste_category_id" validate:"omitempty,uuid"`
+	Co2ePerKg        float64 `json:"co2e_per_kg" validate:"min=0"`
+	WaterLitersPerKg float64 `json:"water_liters_per_kg" validate:"min=0"`
+	EnergyKwhPerKg   float64 `json:"energy_kwh_per_kg" validate:"min=0"`
+	TreesPerKg       float64 `json:"trees_per_kg" validate:"min=0"`
+	Source           string  `json:"source"`
+}
+
+type UpdateImpactFactorRequest struct {
+	ID               string   `json:"-" validate:"required,max=100"`
+	Co2ePerKg        *float64 `json:"co2e_per_kg" validate:"omitempty,min=0"`
+	WaterLitersPerKg *float64 `json:"water_liters_per_kg" validate:"omitempty,min=0"`
+	EnergyKwhPerKg   *float64 `json:"energy_kwh_per_kg" validate:"omitempty,min=0"`
+	TreesPerKg       *float64 `json:"trees_per_kg" validate:"omitempty,min=0"`
+	Source           *string  `json:"source"`
+}
+
+type SearchImpactFactorRequest struct {
+	WasteTypeID     string `json:"waste_type_id"`
+	WasteCategoryID string `json:"waste_category_id"`
+	IsDeleted       *bool  `json:"is_deleted"`
+	Page            int    `json:"page,omitempty" validate:"min=1"`
+	Size            int    `json:"size,omitempty" validate:"min=1,max=100"`
+}
+
+type GetImpactFactorRequest struct {
+	ID string `json:"id" validate:"required,max=100"`
+}
+
+type DeleteImpactFactorRequest struct {
+	ID string `json:"id" validate:"required,max=100"`
+}
+
+type RecomputeImpactResponse struct {
+	UpdatedProfiles int64 `json:"updated_profiles"`
+}
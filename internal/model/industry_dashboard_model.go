@@ -0,0 +1,65 @@
+package model
+
+// IndustryDashboardRequest is filtered by month range like GovernmentDashboardRequest
+type IndustryDashboardRequest struct {
+	IndustryID string `json:"industry_id" validate:"omitempty,uuid"` // Required for admins, industries always see their own
+	StartMonth string `json:"start_month"`                           // Format: "2024-01"
+	EndMonth   string `json:"end_month"`                             // Format: "2024-12"
+	ActorID    string `json:"-"`
+	ActorRole  string `json:"-"`
+}
+
+type IndustryDashboardResponse struct {
+	IndustryID     string                    `json:"industry_id"`
+	Purchases      []IndustryPurchase        `json:"purchases"`
+	Prices         []IndustryPriceComparison `json:"prices"`
+	Yields         []IndustryYieldByMonth    `json:"yields"`
+	SupplierYields []IndustrySupplierYield   `json:"supplier_yields"`
+}
+
+// IndustryPurchase is the verified weight bought from a supplier bank and the amount paid for a waste type in a month
+type IndustryPurchase struct {
+	Month         string  `json:"month"`
+	WasteTypeID   string  `json:"waste_type_id"`
+	WasteTypeName string  `json:"waste_type_name"`
+	SupplierID    string  `json:"supplier_id"`
+	SupplierName  string  `json:"supplier_name"`
+	Weight        float64 `json:"weight"`
+	Spend         int64   `json:"spend"`
+}
+
+// IndustryPriceComparison compares the weight averaged offering and accepted prices of a waste type.
+// Items accepted without a price of their own are counted at the offering price, as they are paid.
+type IndustryPriceComparison struct {
+	WasteTypeID          string  `json:"waste_type_id"`
+	WasteTypeName        string  `json:"waste_type_name"`
+	Weight               float64 `json:"weight"`
+	AverageOfferingPrice float64 `json:"average_offering_price"`
+	AverageAcceptedPrice float64 `json:"average_accepted_price"`
+	PriceDifference      float64 `json:"price_difference"` // Accepted minus offering, per kg
+}
+
+// IndustryYieldByMonth is the recycled share of the verified weight of a waste type, over the items the
+// industry already reported as recycled
+type IndustryYieldByMonth struct {
+	Month          string  `json:"month"`
+	WasteTypeID    string  `json:"waste_type_id"`
+	WasteTypeName  string  `json:"waste_type_name"`
+	VerifiedWeight float64 `json:"verified_weight"`
+	RecycledWeight float64 `json:"recycled_weight"`
+	Yield          float64 `json:"yield"`
+}
+
+// IndustrySupplierYield is the yield of a waste type bought from one supplier. IsLowYield flags suppliers well
+// below the industry's overall yield for the type, which usually means contaminated loads.
+type IndustrySupplierYield struct {
+	SupplierID     string  `json:"supplier_id"`
+	SupplierName   string  `json:"supplier_name"`
+	WasteTypeID    string  `json:"waste_type_id"`
+	WasteTypeName  string  `json:"waste_type_name"`
+	VerifiedWeight float64 `json:"verified_weight"`
+	RecycledWeight float64 `json:"recycled_weight"`
+	Yield          float64 `json:"yield"`
+	AverageYield   float64 `json:"average_yield"`
+	IsLowYield     bool    `json:"is_low_yield"`
+}
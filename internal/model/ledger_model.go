@@ -0,0 +1,71 @@
+package model
+
+import "time"
+
+type LedgerPostingLine struct {
+	// Either UserID or AccountCode (system account) must be set
+	UserID      string `json:"user_id,omitempty"`
+	AccountCode string `json:"account_code,omitempty"`
+	Asset       string `json:"asset" validate:"required,oneof=points rupiah"`
+	Direction   string `json:"direction" validate:"required,oneof=debit credit"`
+	Amount      int64  `json:"amount" validate:"required,min=1"`
+}
+
+type LedgerPostingRequest struct {
+	EntryType     string              `json:"entry_type" validate:"required,max=100"`
+	ReferenceType string              `json:"reference_type,omitempty"`
+	ReferenceID   string              `json:"reference_id,omitempty"`
+	Description   string              `json:"description,omitempty"`
+	Lines         []LedgerPostingLine `json:"lines" validate:"required,min=2,dive"`
+}
+
+type LedgerStatementLineResponse struct {
+	ID            string     `json:"id"`
+	EntryID       string     `json:"entry_id"`
+	EntryType     string     `json:"entry_type"`
+	ReferenceType string     `json:"reference_type,omitempty"`
+	ReferenceID   string     `json:"reference_id,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	Direction     string     `json:"direction"`
+	Amount        int64      `json:"amount"`
+	BalanceAfter  int64      `json:"balance_after"`
+	CreatedAt     *time.Time `json:"created_at"`
+}
+
+type LedgerStatementResponse struct {
+	UserID         string                        `json:"user_id"`
+	Asset          string                        `json:"asset"`
+	OpeningBalance int64                         `json:"opening_balance"`
+	ClosingBalance int64                         `json:"closing_balance"`
+	CachedBalance  int64                         `json:"cached_balance"`
+	Lines          []LedgerStatementLineResponse `json:"lines"`
+}
+
+type LedgerStatementRequest struct {
+	UserID    string `json:"user_id" validate:"required,max=100"`
+	Asset     string `json:"asset" validate:"required,oneof=points rupiah"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+	Page      int    `json:"page,omitempty" validate:"min=1"`
+	Size      int    `json:"size,omitempty" validate:"min=1,max=100"`
+}
+
+type LedgerDriftResponse struct {
+	ID            string     `json:"id"`
+	UserID        string     `json:"user_id"`
+	Asset         string     `json:"asset"`
+	CachedBalance int64      `json:"cached_balance"`
+	LedgerBalance int64      `json:"ledger_balance"`
+	Difference    int64      `json:"difference"`
+	IsResolved    bool       `json:"is_resolved"`
+	DetectedAt    *time.Time `json:"detected_at"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+}
+
+type SearchLedgerDriftRequest struct {
+	UserID     string `json:"user_id"`
+	Asset      string `json:"asset"`
+	IsResolved *bool  `json:"is_resolved"`
+	Page       int    `json:"page,omitempty" validate:"min=1"`
+	Size       int    `json:"size,omitempty" validate:"min=1,max=100"`
+}
@@ -0,0 +1,101 @@
+package model
+
+import "time"
+
+type PickupSubscriptionResponse struct {
+	ID                  string                                 `json:"id"`
+	CustomerID          string                                 `json:"customer_id"`
+	WasteBankID         string                                 `json:"waste_bank_id"`
+	Frequency           string                                 `json:"frequency"`
+	DayOfWeek           *int                                   `json:"day_of_week,omitempty"`
+	DayOfMonth          *int                                   `json:"day_of_month,omitempty"`
+	StartTime           string                                 `json:"start_time"`
+	EndTime             string                                 `json:"end_time"`
+	StartDate           string                                 `json:"start_date"`
+	EndDate             string                                 `json:"end_date,omitempty"`
+	AppointmentLocation *LocationResponse                      `json:"appointment_location,omitempty"`
+	UserPhoneNumber     string                                 `json:"user_phone_number,omitempty"`
+	Notes               string                                 `json:"notes,omitempty"`
+	Status              string                                 `json:"status"`
+	Items               []PickupSubscriptionItemResponse       `json:"items"`
+	NextDates           []string                               `json:"next_dates,omitempty"`
+	Occurrences         []PickupSubscriptionOccurrenceResponse `json:"occurrences,omitempty"`
+	CreatedAt           *time.Time                             `json:"created_at"`
+	UpdatedAt           *time.Time                             `json:"updated_at"`
+}
+
+type PickupSubscriptionItemResponse struct {
+	WasteTypeID string             `json:"waste_type_id"`
+	WasteType   *WasteTypeResponse `json:"waste_type,omitempty"`
+	Quantity    int64              `json:"quantity"`
+}
+
+type PickupSubscriptionOccurrenceResponse struct {
+	Date      string `json:"date"`
+	Status    string `json:"status"`
+	RequestID string `json:"request_id,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type PickupSubscriptionRequest struct {
+	CustomerID          string                 `json:"customer_id" validate:"omitempty,max=100"` // Only read for admins, customers subscribe for themselves
+	WasteBankID         string                 `json:"waste_bank_id" validate:"required,max=100"`
+	Frequency           string                 `json:"frequency" validate:"required,oneof=weekly biweekly monthly"`
+	DayOfWeek           *int                   `json:"day_of_week" validate:"omitempty,min=0,max=6"`
+	DayOfMonth          *int                   `json:"day_of_month" validate:"omitempty,min=1,max=28"`
+	StartTime           string                 `json:"start_time" validate:"required"` // Format: "08:00:00+07:00"
+	EndTime             string                 `json:"end_time" validate:"required"`
+	StartDate           string                 `json:"start_date" validate:"required"` // Format: "2024-01-31"
+	EndDate             string                 `json:"end_date"`
+	AppointmentLocation *LocationRequest       `json:"appointment_location" validate:"required"`
+	UserPhoneNumber     string                 `json:"user_phone_number" validate:"max=20"`
+	Notes               string                 `json:"notes"`
+	Items               *WasteDropRequestItems `json:"items" validate:"required"`
+	ActorID             string                 `json:"-"`
+	ActorRole           string                 `json:"-"`
+}
+
+// UpdatePickupSubscriptionRequest changes future occurrences only, requests already generated keep their details
+type UpdatePickupSubscriptionRequest struct {
+	ID                  string                 `json:"-" validate:"required,max=100"`
+	StartTime           *string                `json:"start_time,omitempty"`
+	EndTime             *string                `json:"end_time,omitempty"`
+	EndDate             *string                `json:"end_date,omitempty"` // Empty string removes the end date
+	AppointmentLocation *LocationRequest       `json:"appointment_location,omitempty"`
+	UserPhoneNumber     *string                `json:"user_phone_number,omitempty" validate:"omitempty,max=20"`
+	Notes               *string                `json:"notes,omitempty"`
+	Items               *WasteDropRequestItems `json:"items,omitempty"`
+	ActorID             string                 `json:"-"`
+	ActorRole           string                 `json:"-"`
+}
+
+type GetPickupSubscriptionRequest struct {
+	ID        string `json:"id" validate:"required,max=100"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
+
+// PickupSubscriptionActionRequest pauses, resumes or cancels a subscription
+type PickupSubscriptionActionRequest struct {
+	ID        string `json:"id" validate:"required,max=100"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
+
+type SkipPickupSubscriptionRequest struct {
+	ID        string `json:"-" validate:"required,max=100"`
+	Date      string `json:"date" validate:"required"` // Format: "2024-01-31"
+	Reason    string `json:"reason"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
+
+type SearchPickupSubscriptionRequest struct {
+	CustomerID  string `json:"customer_id"`
+	WasteBankID string `json:"waste_bank_id"`
+	Status      string `json:"status" validate:"omitempty,oneof=active paused cancelled"`
+	Page        int    `json:"page,omitempty" validate:"min=1"`
+	Size        int    `json:"size,omitempty" validate:"min=1,max=100"`
+	ActorID     string `json:"-"`
+	ActorRole   string `json:"-"`
+}
@@ -0,0 +1,101 @@
+package model
+
+import "time"
+
+type PointConversionRateResponse struct {
+	ID                string     `json:"id"`
+	WasteBankID       string     `json:"waste_bank_id,omitempty"`
+	RupiahPerPoint    float64    `json:"rupiah_per_point"`
+	MinPoints         int64      `json:"min_points"`
+	MaxPoints         *int64     `json:"max_points"`
+	PeriodLimitPoints *int64     `json:"period_limit_points"`
+	LimitPeriod       string     `json:"limit_period"`
+	EffectiveFrom     *time.Time `json:"effective_from"`
+	IsActive          bool       `json:"is_active"`
+	CreatedAt         *time.Time `json:"created_at"`
+	UpdatedAt         *time.Time `json:"updated_at"`
+}
+
+type PointConversionRateRequest struct {
+	WasteBankID       string  `json:"waste_bank_id"`
+	RupiahPerPoint    float64 `json:"rupiah_per_point" validate:"required,gt=0"`
+	MinPoints         int64   `json:"min_points" validate:"omitempty,min=1"`
+	MaxPoints         *int64  `json:"max_points" validate:"omitempty,min=1"`
+	PeriodLimitPoints *int64  `json:"period_limit_points" validate:"omitempty,min=1"`
+	LimitPeriod       string  `json:"limit_period" validate:"omitempty,oneof=day week month"`
+	// Format: YYYY-MM-DD, defaults to now
+	EffectiveFrom string `json:"effective_from"`
+	ActorID       string `json:"-"`
+	ActorRole     string `json:"-"`
+}
+
+type UpdatePointConversionRateRequest struct {
+	ID        string `json:"-" validate:"required,max=100"`
+	IsActive  *bool  `json:"is_active"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
+
+type SearchPointConversionRateRequest struct {
+	WasteBankID string `json:"waste_bank_id"`
+	IsActive    *bool  `json:"is_active"`
+	Page        int    `json:"page,omitempty" validate:"min=1"`
+	Size        int    `json:"size,omitempty" validate:"min=1,max=100"`
+}
+
+type PointRedemptionSimpleResponse struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"user_id"`
+	WasteBankID    string     `json:"waste_bank_id,omitempty"`
+	RateID         string     `json:"rate_id,omitempty"`
+	Points         int64      `json:"points"`
+	RupiahPerPoint float64    `json:"rupiah_per_point"`
+	RupiahAmount   int64      `json:"rupiah_amount"`
+	Status         string     `json:"status"`
+	Reason         string     `json:"reason,omitempty"`
+	Notes          string     `json:"notes,omitempty"`
+	IsOnHold       bool       `json:"is_on_hold"`
+	DecidedByID    string     `json:"decided_by,omitempty"`
+	DecidedAt      *time.Time `json:"decided_at"`
+	LegacySource   string     `json:"legacy_source,omitempty"`
+	IsDeleted      bool       `json:"is_deleted"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+type PointRedemptionResponse struct {
+	PointRedemptionSimpleResponse
+	User      *UserResponse `json:"user,omitempty"`
+	WasteBank *UserResponse `json:"waste_bank,omitempty"`
+}
+
+type PointRedemptionRequest struct {
+	UserID      string `json:"-"`
+	WasteBankID string `json:"waste_bank_id" validate:"required,max=100"`
+	Points      int64  `json:"points" validate:"required,min=1"`
+	Notes       string `json:"notes"`
+}
+
+type DecidePointRedemptionRequest struct {
+	ID        string `json:"-" validate:"required,max=100"`
+	Reason    string `json:"reason"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
+
+type SearchPointRedemptionRequest struct {
+	UserID      string `json:"user_id"`
+	WasteBankID string `json:"waste_bank_id"`
+	Status      string `json:"status"`
+	IsDeleted   *bool  `json:"is_deleted"`
+	OrderBy     string `json:"order_by"`
+	OrderDir    string `json:"order_dir,omitempty"`
+	Page        int    `json:"page,omitempty" validate:"min=1"`
+	Size        int    `json:"size,omitempty" validate:"min=1,max=100"`
+}
+
+type GetPointRedemptionRequest struct {
+	ID        string `json:"id" validate:"required,max=100"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
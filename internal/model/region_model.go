@@ -0,0 +1,30 @@
+package model
+
+type RegionResponse struct {
+	Code       string `json:"code"`
+	ParentCode string `json:"parent_code,omitempty"`
+	Level      string `json:"level"`
+	Name       string `json:"name"`
+}
+
+type GetRegionRequest struct {
+	Code string `json:"code" validate:"required,max=13"`
+}
+
+type SearchRegionRequest struct {
+	Level      string `json:"level" validate:"omitempty,oneof=province regency district village"`
+	ParentCode string `json:"parent_code" validate:"omitempty,max=13"`
+	Name       string `json:"name" validate:"omitempty,max=255"` // Matches part of the name or a whole alias
+	Page       int    `json:"page,omitempty" validate:"min=1"`
+	Size       int    `json:"size,omitempty" validate:"min=1,max=100"`
+}
+
+type BackfillRegionRequest struct {
+	Overwrite bool `json:"overwrite"` // Recompute regions that are already set
+}
+
+type BackfillRegionResponse struct {
+	Users                 int64 `json:"users"`
+	WasteDropRequests     int64 `json:"waste_drop_requests"`
+	WasteTransferRequests int64 `json:"waste_transfer_requests"`
+}
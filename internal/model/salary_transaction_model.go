@@ -28,8 +28,8 @@ type SalaryTransactionResponse struct {
 type SalaryTransactionRequest struct {
 	SenderID        string `json:"-"`
 	ReceiverID      string `json:"receiver_id"`
-	TransactionType string `json:"transaction_type"`
-	Amount          int64  `json:"amount"`
+	TransactionType string `json:"transaction_type" validate:"required,oneof=salary waste_payment"` // Point conversions go through point redemptions
+	Amount          int64  `json:"amount" validate:"min=1"`
 	Status          string `json:"status"`
 	Notes           string `json:"notes"`
 }
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type ServiceAreaResponse struct {
+	ID        string          `json:"id"`
+	UserID    string          `json:"user_id"`
+	Name      string          `json:"name"`
+	Area      json.RawMessage `json:"area"` // GeoJSON Polygon
+	IsActive  bool            `json:"is_active"`
+	CreatedAt *time.Time      `json:"created_at"`
+	UpdatedAt *time.Time      `json:"updated_at"`
+}
+
+type ServiceAreaRequest struct {
+	UserID string `json:"user_id" validate:"omitempty,max=100"` // Required for admins, waste banks and collectors manage their own
+	Name   string `json:"name" validate:"required,max=255"`
+	// GeoJSON Polygon, MultiPolygon, Feature or FeatureCollection, every polygon becomes an area
+	GeoJSON   json.RawMessage `json:"geojson" validate:"required"`
+	ActorID   string          `json:"-"`
+	ActorRole string          `json:"-"`
+}
+
+type UpdateServiceAreaRequest struct {
+	ID        string          `json:"-" validate:"required,max=100"`
+	Name      string          `json:"name" validate:"omitempty,max=255"`
+	GeoJSON   json.RawMessage `json:"geojson"` // GeoJSON Polygon, empty keeps the current area
+	IsActive  *bool           `json:"is_active"`
+	ActorID   string          `json:"-"`
+	ActorRole string          `json:"-"`
+}
+
+type SearchServiceAreaRequest struct {
+	UserID   string `json:"user_id" validate:"omitempty,max=100"`
+	IsActive *bool  `json:"is_active"`
+	Page     int    `json:"page,omitempty" validate:"min=1"`
+	Size     int    `json:"size,omitempty" validate:"min=1,max=100"`
+}
+
+type DeleteServiceAreaRequest struct {
+	ID        string `json:"id" validate:"required,max=100"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+type StockMovementResponse struct {
+	ID            string    `json:"id"`
+	StorageID     string    `json:"storage_id"`
+	WasteTypeID   string    `json:"waste_type_id"`
+	MovementType  string    `json:"movement_type"`
+	WeightKgs     float64   `json:"weight_kgs"`
+	BalanceKgs    float64   `json:"balance_kgs"`
+	ReferenceType string    `json:"reference_type,omitempty"`
+	ReferenceID   string    `json:"reference_id,omitempty"`
+	ActorID       string    `json:"actor_id,omitempty"`
+	Notes         string    `json:"notes,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// SearchStockMovementRequest lists the movements of the storage and waste type of a storage item
+type SearchStockMovementRequest struct {
+	StorageItemID string `json:"storage_item_id" validate:"required,uuid"`
+	ActorID       string `json:"-"`
+	ActorRole     string `json:"-"`
+	Page          int    `json:"page,omitempty" validate:"min=1"`
+	Size          int    `json:"size,omitempty" validate:"min=1,max=100"`
+}
@@ -0,0 +1,137 @@
+package model
+
+import "time"
+
+type StockTakeSimpleResponse struct {
+	ID          string     `json:"id"`
+	StorageID   string     `json:"storage_id"`
+	Status      string     `json:"status"`
+	Notes       string     `json:"notes,omitempty"`
+	OpenedByID  string     `json:"opened_by,omitempty"`
+	PostedByID  string     `json:"posted_by,omitempty"`
+	OpenedAt    time.Time  `json:"opened_at"`
+	PostedAt    *time.Time `json:"posted_at"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type StockTakeResponse struct {
+	StockTakeSimpleResponse
+	Lines   []StockTakeLineResponse `json:"lines"`
+	Summary StockTakeSummary        `json:"summary"`
+}
+
+// StockTakeLineResponse compares the counted weight of a waste type with the system stock. Variance is counted
+// minus system, so a shortfall is negative.
+type StockTakeLineResponse struct {
+	ID               string     `json:"id"`
+	WasteTypeID      string     `json:"waste_type_id"`
+	WasteTypeName    string     `json:"waste_type_name,omitempty"`
+	SystemWeightKgs  float64    `json:"system_weight_kgs"`
+	CountedWeightKgs *float64   `json:"counted_weight_kgs"`
+	VarianceKgs      *float64   `json:"variance_kgs"`
+	VariancePercent  *float64   `json:"variance_percent"`
+	ShrinkageReason  string     `json:"shrinkage_reason,omitempty"`
+	Notes            string     `json:"notes,omitempty"`
+	CountedAt        *time.Time `json:"counted_at"`
+}
+
+type StockTakeSummary struct {
+	SystemWeightKgs  float64 `json:"system_weight_kgs"`
+	CountedWeightKgs float64 `json:"counted_weight_kgs"`
+	VarianceKgs      float64 `json:"variance_kgs"`
+	Lines            int     `json:"lines"`
+	UncountedLines   int     `json:"uncounted_lines"`
+}
+
+type OpenStockTakeRequest struct {
+	StorageID string `json:"storage_id" validate:"required,uuid"`
+	Notes     string `json:"notes"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
+
+type GetStockTakeRequest struct {
+	ID        string `json:"-" validate:"required,uuid"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
+
+type SearchStockTakeRequest struct {
+	StorageID string `json:"storage_id" validate:"omitempty,uuid"`
+	Status    string `json:"status" validate:"omitempty,oneof=open posted cancelled"`
+	OwnerID   string `json:"-"` // Set for non-admins, limits the search to their own storages
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+	Page      int    `json:"page,omitempty" validate:"min=1"`
+	Size      int    `json:"size,omitempty" validate:"min=1,max=100"`
+}
+
+// RecordStockTakeCountsRequest enters or corrects counted weights, waste types not yet on the stock take are added
+type RecordStockTakeCountsRequest struct {
+	ID        string                  `json:"-" validate:"required,uuid"`
+	Lines     []StockTakeCountRequest `json:"lines" validate:"required,min=1,dive"`
+	ActorID   string                  `json:"-"`
+	ActorRole string                  `json:"-"`
+}
+
+type StockTakeCountRequest struct {
+	WasteTypeID      string   `json:"waste_type_id" validate:"required,uuid"`
+	CountedWeightKgs *float64 `json:"counted_weight_kgs" validate:"required,min=0"`
+	// Required when posting a line that counted less than the system stock
+	ShrinkageReason string `json:"shrinkage_reason" validate:"omitempty,oneof=moisture_loss theft sorting_error other"`
+	Notes           string `json:"notes"`
+}
+
+// DecideStockTakeRequest posts or cancels an open stock take
+type DecideStockTakeRequest struct {
+	ID        string `json:"-" validate:"required,uuid"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
+
+// StockTakeShrinkageRequest is filtered by the month the stock takes were posted
+type StockTakeShrinkageRequest struct {
+	WasteBankID string `json:"waste_bank_id" validate:"omitempty,uuid"` // Admins see every bank when empty, banks always see their own
+	StartMonth  string `json:"start_month"`                             // Format: "2024-01"
+	EndMonth    string `json:"end_month"`                               // Format: "2024-12"
+	ActorID     string `json:"-"`
+	ActorRole   string `json:"-"`
+}
+
+type StockTakeShrinkageResponse struct {
+	StartMonth string                         `json:"start_month,omitempty"`
+	EndMonth   string                         `json:"end_month,omitempty"`
+	Banks      []StockTakeShrinkageBank       `json:"banks"`
+	Materials  []StockTakeShrinkageByMaterial `json:"materials"`
+}
+
+// StockTakeShrinkageBank totals the posted stock takes of a bank. Shrinkage is system minus counted weight, so
+// a gain is negative, and the percentage is taken of the system weight.
+type StockTakeShrinkageBank struct {
+	WasteBankID      string  `json:"waste_bank_id"`
+	WasteBankName    string  `json:"waste_bank_name"`
+	StockTakes       int64   `json:"stock_takes"`
+	SystemWeightKgs  float64 `json:"system_weight_kgs"`
+	CountedWeightKgs float64 `json:"counted_weight_kgs"`
+	ShrinkageKgs     float64 `json:"shrinkage_kgs"`
+	ShrinkagePercent float64 `json:"shrinkage_percent"`
+}
+
+// StockTakeShrinkageByMaterial is the shrinkage of a waste type at a bank, split by the reason given on posting
+type StockTakeShrinkageByMaterial struct {
+	WasteBankID      string  `json:"waste_bank_id"`
+	WasteBankName    string  `json:"waste_bank_name"`
+	WasteTypeID      string  `json:"waste_type_id"`
+	WasteTypeName    string  `json:"waste_type_name"`
+	StockTakes       int64   `json:"stock_takes"`
+	SystemWeightKgs  float64 `json:"system_weight_kgs"`
+	CountedWeightKgs float64 `json:"counted_weight_kgs"`
+	ShrinkageKgs     float64 `json:"shrinkage_kgs"`
+	ShrinkagePercent float64 `json:"shrinkage_percent"`
+	MoistureLossKgs  float64 `json:"moisture_loss_kgs"`
+	TheftKgs         float64 `json:"theft_kgs"`
+	SortingErrorKgs  float64 `json:"sorting_error_kgs"`
+	OtherKgs         float64 `json:"other_kgs"`
+}
@@ -25,6 +25,8 @@ type StorageItemRequest struct {
 	StorageID   string  `json:"storage_id" validate:"required,max=100"`
 	WasteTypeID string  `json:"waste_type_id" validate:"required,max=100"`
 	WeightKgs   float64 `json:"weight_kgs"`
+	Notes       string  `json:"notes" validate:"max=500"`
+	ActorID     string  `json:"-"`
 }
 
 type SearchStorageItemRequest struct {
@@ -42,6 +44,7 @@ type UpdateStorageItemRequest struct {
 	UserID    string  `json:"user_id" validate:"required,max=100"`
 	StorageID string  `json:"storage_id" validate:"required,max=100"`
 	Weight    float64 `json:"weight_kgs"`
+	Notes     string  `json:"notes" validate:"max=500"`
 }
 
 type DeductStorageItemRequest struct {
@@ -49,6 +52,9 @@ type DeductStorageItemRequest struct {
 	UserID    string  `json:"user_id" validate:"required,max=100"`
 	StorageID string  `json:"storage_id" validate:"required,max=100"`
 	Weight    float64 `json:"weight_kgs"`
+	// Why the stock is taken out, defaults to manual_adjustment
+	Reason string `json:"reason" validate:"omitempty,oneof=manual_adjustment recycling_consumption"`
+	Notes  string `json:"notes" validate:"max=500"`
 }
 
 type DeleteStorageItemRequest struct {
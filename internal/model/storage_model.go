@@ -7,6 +7,8 @@ type StorageSimpleResponse struct {
 	Width                 float64 `json:"width"`
 	Height                float64 `json:"height"`
 	IsForRecycledMaterial bool    `json:"is_for_recycled_material"`
+	CapacityAlertPercent  float64 `json:"capacity_alert_percent"`
+	RejectOverflow        bool    `json:"reject_overflow"`
 }
 
 type StorageResponse struct {
@@ -16,7 +18,9 @@ type StorageResponse struct {
 	Width                 float64 `json:"width"`
 	Height                float64 `json:"height"`
 	User                  *UserResponse
-	IsForRecycledMaterial bool `json:"is_for_recycled_material"`
+	IsForRecycledMaterial bool    `json:"is_for_recycled_material"`
+	CapacityAlertPercent  float64 `json:"capacity_alert_percent"`
+	RejectOverflow        bool    `json:"reject_overflow"`
 }
 type StorageRequest struct {
 	UserID                string  `json:"user_id"`
@@ -24,6 +28,8 @@ type StorageRequest struct {
 	Width                 float64 `json:"width"`
 	Height                float64 `json:"height"`
 	IsForRecycledMaterial bool    `json:"is_for_recycled_material"`
+	CapacityAlertPercent  float64 `json:"capacity_alert_percent" validate:"omitempty,gt=0,lte=100"` // Defaults to 80
+	RejectOverflow        bool    `json:"reject_overflow"`
 }
 
 type SearchStorageRequest struct {
@@ -42,14 +48,85 @@ type GetStorageRequest struct {
 	ID string `json:"id" validate:"required,max=100"`
 }
 type UpdateStorageRequest struct {
-	ID                    string  `json:"id" validate:"required,max=100"`
-	UserID                string  `json:"user_id"`
-	Length                float64 `json:"length"`
-	Width                 float64 `json:"width"`
-	Height                float64 `json:"height"`
-	IsForRecycledMaterial *bool   `json:"is_for_recycled_material"`
+	ID                    string   `json:"id" validate:"required,max=100"`
+	UserID                string   `json:"user_id"`
+	Length                float64  `json:"length"`
+	Width                 float64  `json:"width"`
+	Height                float64  `json:"height"`
+	IsForRecycledMaterial *bool    `json:"is_for_recycled_material"`
+	CapacityAlertPercent  *float64 `json:"capacity_alert_percent" validate:"omitempty,gt=0,lte=100"`
+	RejectOverflow        *bool    `json:"reject_overflow"`
 }
 
 type DeleteStorageRequest struct {
 	ID string `json:"id" validate:"required,max=100"`
 }
+
+// StorageUtilizationResponse estimates how full a storage is from the weight of its items and the bulk density of
+// their waste types. Utilization is nil when the storage has no dimensions.
+type StorageUtilizationResponse struct {
+	StorageID            string                     `json:"storage_id"`
+	UserID               string                     `json:"user_id"`
+	CapacityM3           float64                    `json:"capacity_m3"`
+	UsedVolumeM3         float64                    `json:"used_volume_m3"`
+	UtilizationPercent   *float64                   `json:"utilization_percent"`
+	CapacityAlertPercent float64                    `json:"capacity_alert_percent"`
+	IsAboveAlert         bool                       `json:"is_above_alert"`
+	IsOverCapacity       bool                       `json:"is_over_capacity"`
+	Items                []StorageItemVolume        `json:"items,omitempty"`
+	TransferSuggestion   *StorageTransferSuggestion `json:"transfer_suggestion,omitempty"`
+}
+
+type StorageItemVolume struct {
+	WasteTypeID         string  `json:"waste_type_id"`
+	WasteTypeName       string  `json:"waste_type_name"`
+	WeightKgs           float64 `json:"weight_kgs"`
+	BulkDensityKgsPerM3 float64 `json:"bulk_density_kgs_per_m3"`
+	IsDefaultDensity    bool    `json:"is_default_density"`
+	VolumeM3            float64 `json:"volume_m3"`
+}
+
+// StorageTransferSuggestion is the stock to move out to bring an alerted storage back to the target utilization.
+// Items can be sent as is as the items of a new waste transfer request from the storage owner.
+type StorageTransferSuggestion struct {
+	SourceUserID   string                    `json:"source_user_id"`
+	TargetPercent  float64                   `json:"target_percent"`
+	VolumeToFreeM3 float64                   `json:"volume_to_free_m3"`
+	Items          WasteTransferRequestItems `json:"items"`
+}
+
+type GetStorageUtilizationRequest struct {
+	ID        string `json:"-" validate:"required,uuid"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
+
+type SearchStorageUtilizationRequest struct {
+	UserID     string `json:"user_id" validate:"omitempty,uuid"` // Admins see every storage when empty, others always see their own
+	AboveAlert bool   `json:"above_alert"`
+	ActorID    string `json:"-"`
+	ActorRole  string `json:"-"`
+	Page       int    `json:"page,omitempty" validate:"min=1"`
+	Size       int    `json:"size,omitempty" validate:"min=1,max=100"`
+}
+
+// StorageUtilizationRow is scanned from the utilization query
+type StorageUtilizationRow struct {
+	StorageID            string
+	UserID               string
+	CapacityM3           float64
+	UsedVolumeM3         float64
+	CapacityAlertPercent float64
+}
+
+// StorageCapacityWarning is returned when an inbound drop leaves its storage at or above the alert threshold
+type StorageCapacityWarning struct {
+	StorageID            string  `json:"storage_id"`
+	CapacityM3           float64 `json:"capacity_m3"`
+	VolumeBeforeM3       float64 `json:"volume_before_m3"`
+	VolumeAfterM3        float64 `json:"volume_after_m3"`
+	UtilizationPercent   float64 `json:"utilization_percent"`
+	CapacityAlertPercent float64 `json:"capacity_alert_percent"`
+	IsOverCapacity       bool    `json:"is_over_capacity"`
+	Message              string  `json:"message"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"mime/multipart"
+	"time"
+)
+
+type UploadedFileResponse struct {
+	ID           string     `json:"id"`
+	OwnerType    string     `json:"owner_type"`
+	OwnerID      string     `json:"owner_id"`
+	URL          string     `json:"url"`
+	ThumbnailURL string     `json:"thumbnail_url,omitempty"`
+	ContentType  string     `json:"content_type"`
+	SizeBytes    int64      `json:"size_bytes"`
+	Width        int        `json:"width"`
+	Height       int        `json:"height"`
+	CreatedAt    *time.Time `json:"created_at"`
+}
+
+type UploadFileRequest struct {
+	OwnerID   string                `json:"-" validate:"required,uuid"`
+	ActorID   string                `json:"-" validate:"required"`
+	ActorRole string                `json:"-" validate:"required"`
+	File      *multipart.FileHeader `json:"-" validate:"required"`
+}
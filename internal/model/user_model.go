@@ -12,11 +12,13 @@ type UserListResponse struct {
 	Email               string            `json:"email"`
 	Role                string            `json:"role"`
 	AvatarURL           string            `json:"avatar_url,omitempty"`
+	AvatarThumbnailURL  string            `json:"avatar_thumbnail_url,omitempty"`
 	PhoneNumber         string            `json:"phone_number,omitempty"`
 	Institution         string            `json:"institution,omitempty"`
 	Address             string            `json:"address,omitempty"`
 	City                string            `json:"city,omitempty"`
 	Province            string            `json:"province,omitempty"`
+	RegionCode          *string           `json:"region_code,omitempty"`
 	Points              int64             `json:"points"`
 	Balance             int64             `json:"balance"`
 	Location            *LocationResponse `json:"location,omitempty"`
@@ -38,11 +40,13 @@ type UserResponse struct {
 	Email               string            `json:"email"`
 	Role                string            `json:"role"`
 	AvatarURL           string            `json:"avatar_url,omitempty"`
+	AvatarThumbnailURL  string            `json:"avatar_thumbnail_url,omitempty"`
 	PhoneNumber         string            `json:"phone_number,omitempty"`
 	Institution         string            `json:"institution,omitempty"`
 	Address             string            `json:"address,omitempty"`
 	City                string            `json:"city,omitempty"`
 	Province            string            `json:"province,omitempty"`
+	RegionCode          *string           `json:"region_code,omitempty"`
 	Points              int64             `json:"points"`
 	Balance             int64             `json:"balance"`
 	Location            *LocationResponse `json:"location,omitempty"`
@@ -68,6 +72,7 @@ type RegisterUserRequest struct {
 	City                string           `json:"city" validate:"required,max=100"`
 	IsAcceptingCustomer *bool            `json:"is_accepting_customer"`
 	Province            string           `json:"province" validate:"required,max=100"`
+	RegionCode          string           `json:"region_code" validate:"omitempty,max=13"`
 	Location            *LocationRequest `json:"location"` // Optional pointer to allow null
 	InstitutionID       string           `json:"institution_id"`
 	IsAgreedToTerms     bool             `json:"is_agreed_to_terms"`
@@ -112,6 +117,7 @@ type SearchUserRequest struct {
 	Address             string   `json:"address"`
 	City                string   `json:"city"`
 	Province            string   `json:"province"`
+	RegionCode          string   `json:"region_code"` // Matches the region and every region inside it
 	IsAcceptingCustomer *bool    `json:"is_accepting_customer"`
 	RadiusMeters        *int     `json:"radius_meters"`
 	Latitude            *float64 `json:"latitude"`
@@ -133,6 +139,7 @@ type UpdateUserRequest struct {
 	Address             string `json:"address"`
 	City                string `json:"city"`
 	Province            string `json:"province"`
+	RegionCode          string `json:"region_code" validate:"omitempty,max=13"`
 	IsAcceptingCustomer *bool  `json:"is_accepting_customer"` // Use pointer to distinguish between false and nil
 }
 
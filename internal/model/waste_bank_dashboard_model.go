@@ -0,0 +1,82 @@
+package model
+
+import "time"
+
+// WasteBankDashboardRequest is filtered by month range like GovernmentDashboardRequest
+type WasteBankDashboardRequest struct {
+	WasteBankID string `json:"waste_bank_id" validate:"omitempty,uuid"` // Required for admins, banks always see their own
+	StartMonth  string `json:"start_month"`                             // Format: "2024-01"
+	EndMonth    string `json:"end_month"`                               // Format: "2024-12"
+	ActorID     string `json:"-"`
+	ActorRole   string `json:"-"`
+}
+
+type WasteBankDashboardResponse struct {
+	WasteBankID  string                           `json:"waste_bank_id"`
+	Inbound      []WasteBankInboundByWasteType    `json:"inbound"`
+	Stock        WasteBankStock                   `json:"stock"`
+	TopCustomers []WasteBankTopCustomer           `json:"top_customers"`
+	Collectors   []WasteBankCollectorProductivity `json:"collectors"`
+	Backlog      WasteBankBacklog                 `json:"backlog"`
+	Outbound     []WasteBankOutboundByMonth       `json:"outbound"`
+}
+
+// WasteBankInboundByWasteType is the weight received and points paid out for a waste type in a month
+type WasteBankInboundByWasteType struct {
+	Month         string  `json:"month"`
+	WasteTypeID   string  `json:"waste_type_id"`
+	WasteTypeName string  `json:"waste_type_name"`
+	Requests      int64   `json:"requests"`
+	Weight        float64 `json:"weight"`
+	Payout        int64   `json:"payout"`
+}
+
+// WasteBankStock is the current storage content valued at the bank's current prices
+type WasteBankStock struct {
+	TotalWeight float64              `json:"total_weight"`
+	TotalValue  int64                `json:"total_value"`
+	Items       []WasteBankStockItem `json:"items"`
+}
+
+type WasteBankStockItem struct {
+	WasteTypeID   string  `json:"waste_type_id"`
+	WasteTypeName string  `json:"waste_type_name"`
+	WeightKgs     float64 `json:"weight_kgs"`
+	PricePerKgs   *int64  `json:"price_per_kgs"` // Nil when the bank has no price for the type
+	Value         int64   `json:"value"`
+}
+
+type WasteBankTopCustomer struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Requests    int64   `json:"requests"`
+	TotalWeight float64 `json:"total_weight"`
+	TotalPayout int64   `json:"total_payout"`
+}
+
+// WasteBankCollectorProductivity counts the drop requests a collector handled for the bank
+type WasteBankCollectorProductivity struct {
+	ID                string  `json:"id"`
+	Name              string  `json:"name"`
+	CompletedRequests int64   `json:"completed_requests"`
+	CancelledRequests int64   `json:"cancelled_requests"`
+	TotalWeight       float64 `json:"total_weight"`
+}
+
+// WasteBankBacklog counts the open drop requests of the bank, regardless of the month range
+type WasteBankBacklog struct {
+	Pending         int64      `json:"pending"`
+	Assigned        int64      `json:"assigned"`
+	Collecting      int64      `json:"collecting"`
+	Waitlisted      int64      `json:"waitlisted"`
+	Overdue         int64      `json:"overdue"` // Open requests whose appointment date has passed
+	OldestPendingAt *time.Time `json:"oldest_pending_at"`
+}
+
+// WasteBankOutboundByMonth is the weight transferred out and revenue earned per destination type in a month
+type WasteBankOutboundByMonth struct {
+	Month           string  `json:"month"`
+	DestinationType string  `json:"destination_type"` // industry or waste_bank
+	Weight          float64 `json:"weight"`
+	Revenue         int64   `json:"revenue"`
+}
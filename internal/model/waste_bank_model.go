@@ -8,6 +8,22 @@ type WasteBankResponse struct {
 	OpenTime         string        `json:"open_time"`
 	CloseTime        string        `json:"close_time"`
 	User             *UserResponse `json:"user,omitempty"`
+	// Automatic collector assignment for pickups
+	AutoAssignCollectors    bool     `json:"auto_assign_collectors"`
+	AutoAssignMaxDistanceKm *float64 `json:"auto_assign_max_distance_km,omitempty"`
+	// Appointment slots
+	SlotDurationMinutes   int    `json:"slot_duration_minutes"`
+	DropoffSlotCapacity   int    `json:"dropoff_slot_capacity"`
+	PickupSlotCapacity    int    `json:"pickup_slot_capacity"`
+	SlotOverbookingPolicy string `json:"slot_overbooking_policy"`
+	// Hours before unattended pending requests expire, nil uses the server default
+	PendingExpiryHours *int `json:"pending_expiry_hours,omitempty"`
+	// Pickup fee charged against the payout
+	PickupBaseFee      int64   `json:"pickup_base_fee"`
+	PickupFeePerKm     int64   `json:"pickup_fee_per_km"`
+	PickupFreeRadiusKm float64 `json:"pickup_free_radius_km"`
+	// Pickups outside every service area are rejected instead of flagged
+	RejectOutsideServiceArea bool `json:"reject_outside_service_area"`
 }
 
 type WasteBankRequest struct {
@@ -23,11 +39,22 @@ type GetWasteBankRequest struct {
 }
 
 type UpdateWasteBankRequest struct {
-	ID               string   `json:"id" validate:"required,max=100"`
-	TotalWasteWeight *float64 `json:"total_waste_weight,omitempty"`
-	TotalWorkers     *int64   `json:"total_workers,omitempty"`
-	OpenTime         *string  `json:"open_time,omitempty"`
-	CloseTime        *string  `json:"close_time,omitempty"`
+	ID                       string   `json:"id" validate:"required,max=100"`
+	TotalWasteWeight         *float64 `json:"total_waste_weight,omitempty"`
+	TotalWorkers             *int64   `json:"total_workers,omitempty"`
+	OpenTime                 *string  `json:"open_time,omitempty"`
+	CloseTime                *string  `json:"close_time,omitempty"`
+	AutoAssignCollectors     *bool    `json:"auto_assign_collectors,omitempty"`
+	AutoAssignMaxDistanceKm  *float64 `json:"auto_assign_max_distance_km,omitempty" validate:"omitempty,gte=0"` // 0 removes the limit
+	SlotDurationMinutes      *int     `json:"slot_duration_minutes,omitempty" validate:"omitempty,min=15,max=480"`
+	DropoffSlotCapacity      *int     `json:"dropoff_slot_capacity,omitempty" validate:"omitempty,min=0"`
+	PickupSlotCapacity       *int     `json:"pickup_slot_capacity,omitempty" validate:"omitempty,min=0"`
+	SlotOverbookingPolicy    *string  `json:"slot_overbooking_policy,omitempty" validate:"omitempty,oneof=reject waitlist"`
+	PendingExpiryHours       *int     `json:"pending_expiry_hours,omitempty" validate:"omitempty,min=0,max=8760"` // 0 restores the server default
+	PickupBaseFee            *int64   `json:"pickup_base_fee,omitempty" validate:"omitempty,gte=0"`
+	PickupFeePerKm           *int64   `json:"pickup_fee_per_km,omitempty" validate:"omitempty,gte=0"`
+	PickupFreeRadiusKm       *float64 `json:"pickup_free_radius_km,omitempty" validate:"omitempty,gte=0"`
+	RejectOutsideServiceArea *bool    `json:"reject_outside_service_area,omitempty"`
 }
 
 type DeleteWasteBankRequest struct {
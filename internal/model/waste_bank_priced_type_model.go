@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type WasteBankPricedTypeSimpleResponse struct {
 	ID                string             `json:"id"`
 	WasteBankID       string             `json:"waste_bank_id"`
@@ -23,7 +25,9 @@ type WasteBankPricedTypeResponse struct {
 type WasteBankPricedTypeRequest struct {
 	WasteBankID       string `json:"waste_bank_id"`
 	WasteTypeID       string `json:"waste_type_id"`
-	CustomPricePerKgs int64  `json:"custom_price_per_kgs"`
+	CustomPricePerKgs int64  `json:"custom_price_per_kgs" validate:"min=0"`
+	// Format: YYYY-MM-DD or RFC3339, defaults to now, past times are rejected
+	EffectiveFrom string `json:"effective_from"`
 }
 
 type WasteBankPricedTypeBatchRequest struct {
@@ -41,7 +45,33 @@ type GetWasteBankPricedTypeRequest struct {
 }
 type UpdateWasteBankPricedTypeRequest struct {
 	ID                string `json:"id" validate:"required,max=100"`
-	CustomPricePerKgs int64  `json:"custom_price_per_kgs"`
+	CustomPricePerKgs int64  `json:"custom_price_per_kgs" validate:"min=0"`
+	// Format: YYYY-MM-DD or RFC3339, defaults to now. A future date schedules the change
+	EffectiveFrom string `json:"effective_from"`
+	ActorID       string `json:"-"`
+}
+
+type WasteBankPriceVersionResponse struct {
+	ID            string             `json:"id"`
+	PricedTypeID  string             `json:"priced_type_id,omitempty"`
+	WasteBankID   string             `json:"waste_bank_id"`
+	WasteTypeID   string             `json:"waste_type_id"`
+	PricePerKgs   int64              `json:"price_per_kgs"`
+	EffectiveFrom *time.Time         `json:"effective_from"`
+	EffectiveTo   *time.Time         `json:"effective_to"`
+	IsCurrent     bool               `json:"is_current"`
+	CreatedByID   string             `json:"created_by,omitempty"`
+	CreatedAt     *time.Time         `json:"created_at"`
+	WasteType     *WasteTypeResponse `json:"waste_type,omitempty"`
+}
+
+type SearchWasteBankPriceHistoryRequest struct {
+	WasteBankID string `json:"waste_bank_id" validate:"required,max=100"`
+	WasteTypeID string `json:"waste_type_id"`
+	// Format: YYYY-MM-DD, returns the versions in effect on that date
+	At   string `json:"at"`
+	Page int    `json:"page,omitempty" validate:"min=1"`
+	Size int    `json:"size,omitempty" validate:"min=1,max=100"`
 }
 
 type DeleteWasteBankPricedTypeRequest struct {
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+type WasteBankPromotionResponse struct {
+	ID           string             `json:"id"`
+	WasteBankID  string             `json:"waste_bank_id"`
+	WasteTypeID  string             `json:"waste_type_id,omitempty"` // Empty when the promotion covers every waste type
+	WasteType    *WasteTypeResponse `json:"waste_type,omitempty"`
+	Name         string             `json:"name"`
+	BonusPercent float64            `json:"bonus_percent"`
+	StartsAt     time.Time          `json:"starts_at"`
+	EndsAt       *time.Time         `json:"ends_at,omitempty"`
+	IsActive     bool               `json:"is_active"`
+	CreatedAt    *time.Time         `json:"created_at"`
+	UpdatedAt    *time.Time         `json:"updated_at"`
+}
+
+type WasteBankPromotionRequest struct {
+	WasteBankID  string  `json:"waste_bank_id" validate:"omitempty,max=100"` // Required for admins, waste banks manage their own
+	WasteTypeID  string  `json:"waste_type_id" validate:"omitempty,max=100"` // Empty applies to every waste type
+	Name         string  `json:"name" validate:"required,max=255"`
+	BonusPercent float64 `json:"bonus_percent" validate:"required,gt=0,lte=100"`
+	StartsAt     string  `json:"starts_at"` // RFC3339, defaults to now
+	EndsAt       string  `json:"ends_at"`   // RFC3339, empty runs until deactivated
+	ActorID      string  `json:"-"`
+	ActorRole    string  `json:"-"`
+}
+
+type SearchWasteBankPromotionRequest struct {
+	WasteBankID string `json:"waste_bank_id" validate:"omitempty,max=100"`
+	WasteTypeID string `json:"waste_type_id" validate:"omitempty,max=100"`
+	OnlyRunning bool   `json:"only_running"`
+	Page        int    `json:"page,omitempty" validate:"min=1"`
+	Size        int    `json:"size,omitempty" validate:"min=1,max=100"`
+}
+
+type DeactivateWasteBankPromotionRequest struct {
+	ID        string `json:"id" validate:"required,max=100"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
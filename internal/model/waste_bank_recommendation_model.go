@@ -0,0 +1,42 @@
+package model
+
+type SearchWasteBankRecommendationRequest struct {
+	Latitude     *float64  `json:"latitude"` // Defaults to the customer's saved location
+	Longitude    *float64  `json:"longitude"`
+	WasteTypeIDs []string  `json:"waste_type_ids" validate:"required,min=1,dive,max=100"`
+	Weights      []float64 `json:"weights" validate:"omitempty,dive,gt=0"` // Estimated kg per waste type, 1 kg each when empty
+	DeliveryType string    `json:"delivery_type" validate:"omitempty,oneof=pickup dropoff"`
+	Date         string    `json:"date"` // Format: "2024-01-31", defaults to today
+	RadiusMeters int       `json:"radius_meters" validate:"min=0,max=100000"`
+	Size         int       `json:"size,omitempty" validate:"min=1,max=50"`
+	ActorID      string    `json:"-"`
+}
+
+type WasteBankRecommendationResponse struct {
+	Rank      int                            `json:"rank"`
+	Score     float64                        `json:"score"` // Sum of the factor scores, out of 100
+	WasteBank *UserResponse                  `json:"waste_bank"`
+	Factors   WasteBankRecommendationFactors `json:"factors"`
+}
+
+// WasteBankRecommendationFactors lists the inputs of the ranking and the score each contributed
+type WasteBankRecommendationFactors struct {
+	DistanceKm          float64  `json:"distance_km"`
+	IsAcceptingCustomer bool     `json:"is_accepting_customer"`
+	CoveredTypes        int      `json:"covered_types"`
+	RequestedTypes      int      `json:"requested_types"`
+	MissingWasteTypeIDs []string `json:"missing_waste_type_ids"`
+	EstimatedPayout     int64    `json:"estimated_payout"` // After the pickup fee for pickups
+	PickupFee           int64    `json:"pickup_fee"`
+	IsOpen              bool     `json:"is_open"`
+	OpenTime            string   `json:"open_time,omitempty"`
+	CloseTime           string   `json:"close_time,omitempty"`
+	RemainingSlots      *int     `json:"remaining_slots,omitempty"` // Absent for banks without opening hours
+
+	DistanceScore  float64 `json:"distance_score"`
+	AcceptingScore float64 `json:"accepting_score"`
+	CoverageScore  float64 `json:"coverage_score"`
+	PayoutScore    float64 `json:"payout_score"`
+	OpeningScore   float64 `json:"opening_score"`
+	CapacityScore  float64 `json:"capacity_score"`
+}
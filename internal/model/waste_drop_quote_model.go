@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+type WasteDropQuoteResponse struct {
+	ID                  string                       `json:"id"`
+	CustomerID          string                       `json:"customer_id"`
+	WasteBankID         string                       `json:"waste_bank_id"`
+	DeliveryType        string                       `json:"delivery_type"`
+	AppointmentLocation *LocationResponse            `json:"appointment_location,omitempty"`
+	DistanceKm          *float64                     `json:"distance_km,omitempty"`
+	ItemsTotal          int64                        `json:"items_total"`
+	PromotionBonus      int64                        `json:"promotion_bonus"`
+	PickupFee           int64                        `json:"pickup_fee"`
+	TotalPrice          int64                        `json:"total_price"`
+	ExpiresAt           time.Time                    `json:"expires_at"`
+	RequestID           string                       `json:"request_id,omitempty"`
+	Items               []WasteDropQuoteItemResponse `json:"items"`
+	CreatedAt           *time.Time                   `json:"created_at"`
+}
+
+type WasteDropQuoteItemResponse struct {
+	WasteTypeID       string             `json:"waste_type_id"`
+	WasteType         *WasteTypeResponse `json:"waste_type,omitempty"`
+	EstimatedWeight   float64            `json:"estimated_weight"`
+	PricePerKgs       int64              `json:"price_per_kgs"`
+	PriceVersionID    string             `json:"price_version_id,omitempty"`
+	PromotionID       string             `json:"promotion_id,omitempty"`
+	BonusPercent      float64            `json:"bonus_percent"`
+	QuotedPricePerKgs int64              `json:"quoted_price_per_kgs"`
+	Subtotal          int64              `json:"subtotal"`
+}
+
+type WasteDropQuoteItems struct {
+	WasteTypeIDs []string  `json:"waste_type_ids" validate:"required,min=1"`
+	Weights      []float64 `json:"weights" validate:"required,min=1,dive,gt=0"` // Estimated weight in kg
+}
+
+type WasteDropQuoteRequest struct {
+	CustomerID          string               `json:"customer_id" validate:"omitempty,max=100"` // Only read for admins, customers quote for themselves
+	WasteBankID         string               `json:"waste_bank_id" validate:"required,max=100"`
+	DeliveryType        string               `json:"delivery_type" validate:"required,oneof=pickup dropoff"`
+	AppointmentLocation *LocationRequest     `json:"appointment_location"` // Required for pickups, used for the pickup fee
+	Items               *WasteDropQuoteItems `json:"items" validate:"required"`
+	ActorID             string               `json:"-"`
+	ActorRole           string               `json:"-"`
+}
+
+type GetWasteDropQuoteRequest struct {
+	ID        string `json:"id" validate:"required,max=100"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
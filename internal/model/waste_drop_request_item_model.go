@@ -5,36 +5,76 @@ type WasteDropRequestItems struct {
 	Quantities   []int64  `json:"quantities" validate:"required,min=1"`
 }
 
+// Waste types missing from the request's items are added at completion, requested types left out are zeroed.
+// Grades and deductions are optional, when given they follow the order of WasteTypeIDs.
 type CompleteWasteDropRequestItems struct {
-	WasteTypeIDs []string  `json:"waste_type_ids" validate:"required,min=1"`
-	Weights      []float64 `json:"weights" validate:"required,min=1"`
+	WasteTypeIDs          []string  `json:"waste_type_ids" validate:"required,min=1"`
+	Weights               []float64 `json:"weights" validate:"required,min=1,dive,gte=0"`
+	Grades                []string  `json:"grades,omitempty" validate:"omitempty,dive,oneof=A B C"`
+	ContaminationPercents []float64 `json:"contamination_percents,omitempty" validate:"omitempty,dive,gte=0,lte=100"`
+	MoisturePercents      []float64 `json:"moisture_percents,omitempty" validate:"omitempty,dive,gte=0,lte=100"`
 }
 
 type CompleteWasteDropRequest struct {
-	ID    string                         `json:"id" validate:"required,max=100"`
-	Items *CompleteWasteDropRequestItems `json:"items" validate:"required"`
+	ID        string                         `json:"id" validate:"required,max=100"`
+	Items     *CompleteWasteDropRequestItems `json:"items" validate:"required"`
+	Reason    string                         `json:"reason,omitempty"`
+	Location  *LocationRequest               `json:"location,omitempty"`
+	ActorID   string                         `json:"-"`
+	ActorRole string                         `json:"-"`
 }
 type WasteDropRequestItemSimpleResponse struct {
-	ID                  string  `json:"id"`
-	RequestID           string  `json:"request_id"`
-	WasteTypeID         string  `json:"waste_type_id"`
-	Quantity            int64   `json:"quantity"`
-	VerifiedWeight      float64 `json:"verified_weight"`
-	VerifiedPricePerKgs int64   `json:"verified_price_per_kgs"`
-	VerifiedSubtotal    int64   `json:"verified_subtotal"`
-	IsDeleted           bool    `json:"is_deleted"`
+	ID                   string  `json:"id"`
+	RequestID            string  `json:"request_id"`
+	WasteTypeID          string  `json:"waste_type_id"`
+	Quantity             int64   `json:"quantity"`
+	VerifiedWeight       float64 `json:"verified_weight"`
+	VerifiedPricePerKgs  int64   `json:"verified_price_per_kgs"`
+	PriceVersionID       string  `json:"price_version_id,omitempty"`
+	VerifiedSubtotal     int64   `json:"verified_subtotal"`
+	Grade                string  `json:"grade"`
+	GradeMultiplier      float64 `json:"grade_multiplier"`
+	ContaminationPercent float64 `json:"contamination_percent"`
+	MoisturePercent      float64 `json:"moisture_percent"`
+	GrossSubtotal        int64   `json:"gross_subtotal"`
+	IsAddedAtCompletion  bool    `json:"is_added_at_completion"`
+	IsDeleted            bool    `json:"is_deleted"`
 }
 type WasteDropRequestItemResponse struct {
-	ID                  string  `json:"id"`
-	RequestID           string  `json:"request_id"`
-	WasteTypeID         string  `json:"waste_type_id"`
-	Quantity            int64   `json:"quantity"`
-	VerifiedWeight      float64 `json:"verified_weight"`
-	VerifiedPricePerKgs int64   `json:"verified_price_per_kgs"`
-	VerifiedSubtotal    int64   `json:"verified_subtotal"`
-	Request             *WasteDropRequestSimpleResponse
-	WasteType           *WasteTypeResponse
-	IsDeleted           bool `json:"is_deleted"`
+	ID                   string  `json:"id"`
+	RequestID            string  `json:"request_id"`
+	WasteTypeID          string  `json:"waste_type_id"`
+	Quantity             int64   `json:"quantity"`
+	VerifiedWeight       float64 `json:"verified_weight"`
+	VerifiedPricePerKgs  int64   `json:"verified_price_per_kgs"`
+	PriceVersionID       string  `json:"price_version_id,omitempty"`
+	VerifiedSubtotal     int64   `json:"verified_subtotal"`
+	Grade                string  `json:"grade"`
+	GradeMultiplier      float64 `json:"grade_multiplier"`
+	ContaminationPercent float64 `json:"contamination_percent"`
+	MoisturePercent      float64 `json:"moisture_percent"`
+	GrossSubtotal        int64   `json:"gross_subtotal"`
+	IsAddedAtCompletion  bool    `json:"is_added_at_completion"`
+	Request              *WasteDropRequestSimpleResponse
+	WasteType            *WasteTypeResponse
+	IsDeleted            bool `json:"is_deleted"`
+}
+
+// WasteDropRequestBreakdownResponse explains how the verified payout differs from the quoted total
+type WasteDropRequestBreakdownResponse struct {
+	RequestID           string                               `json:"request_id"`
+	Status              string                               `json:"status"`
+	EstimatedTotalPrice int64                                `json:"estimated_total_price"`
+	PickupFee           int64                                `json:"pickup_fee"`
+	TotalPrice          int64                                `json:"total_price"`
+	Difference          int64                                `json:"difference"`
+	Items               []WasteDropRequestItemSimpleResponse `json:"items"`
+}
+
+type GetWasteDropRequestBreakdown struct {
+	ID        string `json:"id" validate:"required,max=100"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
 }
 
 type WasteDropRequestItemRequest struct {
@@ -11,16 +11,28 @@ type WasteDropRequestSimpleResponse struct {
 	AssignedCollectorID  string            `json:"assigned_collector_id,omitempty"`
 	TotalPrice           int64             `json:"total_price"`
 	ImageURL             string            `json:"image_url,omitempty"`
+	ImageThumbnailURL    string            `json:"image_thumbnail_url,omitempty"`
 	Status               string            `json:"status"`
 	AppointmentLocation  *LocationResponse `json:"appointment_location,omitempty"`
 	AppointmentDate      string            `json:"appointment_date,omitempty"`
 	AppointmentStartTime string            `json:"appointment_start_time,omitempty"`
 	AppointmentEndTime   string            `json:"appointment_end_time,omitempty"`
+	IsWaitlisted         bool              `json:"is_waitlisted"`
+	SubscriptionID       string            `json:"subscription_id,omitempty"`
 	Notes                string            `json:"notes,omitempty"`
+	CancellationReason   string            `json:"cancellation_reason_code,omitempty"`
+	CancelledAt          *time.Time        `json:"cancelled_at,omitempty"`
+	EstimatedTotalPrice  *int64            `json:"estimated_total_price,omitempty"`
+	QuoteID              string            `json:"quote_id,omitempty"`
+	PickupFee            int64             `json:"pickup_fee"`
+	IsOutsideServiceArea bool              `json:"is_outside_service_area"`
+	RegionCode           *string           `json:"region_code,omitempty"`
 	Distance             *float64          `json:"distance,omitempty"`
 	CreatedAt            *time.Time        `json:"created_at"`
 	UpdatedAt            *time.Time        `json:"updated_at"`
 	IsDeleted            bool              `json:"is_deleted"`
+	// Only set on completion, when the drop leaves the storage at or above its alert threshold
+	StorageCapacityWarning *StorageCapacityWarning `json:"storage_capacity_warning,omitempty"`
 }
 
 type WasteDropRequestResponse struct {
@@ -32,12 +44,22 @@ type WasteDropRequestResponse struct {
 	AssignedCollectorID  string            `json:"assigned_collector_id,omitempty"`
 	TotalPrice           int64             `json:"total_price"`
 	ImageURL             string            `json:"image_url,omitempty"`
+	ImageThumbnailURL    string            `json:"image_thumbnail_url,omitempty"`
 	Status               string            `json:"status"`
 	AppointmentLocation  *LocationResponse `json:"appointment_location,omitempty"`
 	AppointmentDate      string            `json:"appointment_date,omitempty"`
 	AppointmentStartTime string            `json:"appointment_start_time,omitempty"`
 	AppointmentEndTime   string            `json:"appointment_end_time,omitempty"`
+	IsWaitlisted         bool              `json:"is_waitlisted"`
+	SubscriptionID       string            `json:"subscription_id,omitempty"`
 	Notes                string            `json:"notes,omitempty"`
+	CancellationReason   string            `json:"cancellation_reason_code,omitempty"`
+	CancelledAt          *time.Time        `json:"cancelled_at,omitempty"`
+	EstimatedTotalPrice  *int64            `json:"estimated_total_price,omitempty"`
+	QuoteID              string            `json:"quote_id,omitempty"`
+	PickupFee            int64             `json:"pickup_fee"`
+	IsOutsideServiceArea bool              `json:"is_outside_service_area"`
+	RegionCode           *string           `json:"region_code,omitempty"`
 	Distance             *float64          `json:"distance,omitempty"` // Distance in kilometers
 	CreatedAt            *time.Time        `json:"created_at"`
 	UpdatedAt            *time.Time        `json:"updated_at"`
@@ -61,6 +83,10 @@ type WasteDropRequestRequest struct {
 	AppointmentEndTime   string                 `json:"appointment_end_time,omitempty"`
 	Notes                string                 `json:"notes,omitempty"`
 	Items                *WasteDropRequestItems `json:"items" validate:"required"`
+	// Locks in the prices and pickup fee of a quote that has not expired
+	QuoteID string `json:"quote_id,omitempty" validate:"omitempty,max=100"`
+	// Set by the subscription scheduler, never from the body
+	SubscriptionID string `json:"-"`
 }
 
 type SearchWasteDropRequest struct {
@@ -72,6 +98,7 @@ type SearchWasteDropRequest struct {
 	AppointmentStartTime string `json:"appointment_start_time,omitempty"`
 	AppointmentEndTime   string `json:"appointment_end_time,omitempty"`
 	Status               string `json:"status"`
+	CancellationReason   string `json:"cancellation_reason_code,omitempty" validate:"omitempty,oneof=customer_cancelled no_show expired bank_rejected"`
 	// Location parameters for distance calculation
 	Latitude  *float64 `json:"latitude,omitempty"`
 	Longitude *float64 `json:"longitude,omitempty"`
@@ -88,10 +115,16 @@ type GetWasteDropRequest struct {
 }
 
 type UpdateWasteDropRequest struct {
-	ID                  string `json:"id" validate:"required,max=100"`
-	DeliveryType        string `json:"delivery_type"`
-	AssignedCollectorID string `json:"assigned_collector_id,omitempty"`
-	Status              string `json:"status"`
+	ID                  string           `json:"id" validate:"required,max=100"`
+	DeliveryType        string           `json:"delivery_type"`
+	AssignedCollectorID string           `json:"assigned_collector_id,omitempty"`
+	Status              string           `json:"status"`
+	Reason              string           `json:"reason,omitempty"`
+	ReasonCode          string           `json:"reason_code,omitempty" validate:"omitempty,oneof=customer_cancelled no_show expired bank_rejected"`
+	Location            *LocationRequest `json:"location,omitempty"`
+	// Actor is taken from the authenticated user, never from the body
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
 }
 
 type DeleteWasteDropRequest struct {
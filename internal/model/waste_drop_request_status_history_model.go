@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type WasteDropRequestStatusHistoryResponse struct {
+	ID         string            `json:"id"`
+	RequestID  string            `json:"request_id"`
+	FromStatus string            `json:"from_status,omitempty"`
+	ToStatus   string            `json:"to_status"`
+	ActorID    string            `json:"actor_id,omitempty"`
+	ActorRole  string            `json:"actor_role,omitempty"`
+	Reason     string            `json:"reason,omitempty"`
+	Location   *LocationResponse `json:"location,omitempty"`
+	CreatedAt  *time.Time        `json:"created_at"`
+	Actor      *UserResponse     `json:"actor,omitempty"`
+}
+
+type GetWasteDropRequestHistory struct {
+	ID        string `json:"id" validate:"required,max=100"`
+	ActorID   string `json:"-"`
+	ActorRole string `json:"-"`
+}
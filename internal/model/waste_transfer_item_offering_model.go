@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // Waste Transfer Item Offering models
 type WasteTransferItemOfferingSimpleResponse struct {
 	ID                  string             `json:"id"`
@@ -10,6 +12,8 @@ type WasteTransferItemOfferingSimpleResponse struct {
 	AcceptedWeight      float64            `json:"accepted_weight"`
 	AcceptedPricePerKgs int64              `json:"accepted_price_per_kgs"`
 	VerifiedWeight      float64            `json:"verified_weight"`
+	RecycledWeight      float64            `json:"recycled_weight"`
+	RecycledAt          *time.Time         `json:"recycled_at,omitempty"`
 	LossWeight          float64            `json:"loss_weight,omitempty"`
 	WasteType           *WasteTypeResponse `json:"waste_type,omitempty"`
 }
@@ -23,6 +27,8 @@ type WasteTransferItemOfferingResponse struct {
 	AcceptedWeight      float64                             `json:"accepted_weight"`
 	AcceptedPricePerKgs int64                               `json:"accepted_price_per_kgs"`
 	VerifiedWeight      float64                             `json:"verified_weight"`
+	RecycledWeight      float64                             `json:"recycled_weight"`
+	RecycledAt          *time.Time                          `json:"recycled_at,omitempty"`
 	TransferForm        *WasteTransferRequestSimpleResponse `json:"transfer_form,omitempty"`
 	LossWeight          float64                             `json:"loss_weight,omitempty"`
 	WasteType           *WasteTypeResponse                  `json:"waste_type,omitempty"`
@@ -52,8 +52,10 @@ type RecycleWasteTransferRequestItems struct {
 }
 
 type RecycleWasteTransferRequest struct {
-	ID    string                            `json:"id" validate:"required,max=100"`
-	Items *RecycleWasteTransferRequestItems `json:"items" validate:"required"`
+	ID        string                            `json:"id" validate:"required,max=100"`
+	Items     *RecycleWasteTransferRequestItems `json:"items" validate:"required"`
+	ActorID   string                            `json:"-"`
+	ActorRole string                            `json:"-"`
 }
 
 // Response models
@@ -67,13 +69,17 @@ type WasteTransferRequestSimpleResponse struct {
 	TotalPrice             int64             `json:"total_price"`
 	Status                 string            `json:"status"`
 	ImageURL               string            `json:"image_url,omitempty"`
+	ImageThumbnailURL      string            `json:"image_thumbnail_url,omitempty"`
 	Notes                  string            `json:"notes,omitempty"`
+	CancellationReason     string            `json:"cancellation_reason_code,omitempty"`
+	CancelledAt            *time.Time        `json:"cancelled_at,omitempty"`
 	SourcePhoneNumber      string            `json:"source_phone_number"`
 	DestinationPhoneNumber string            `json:"destination_phone_number"`
 	AppointmentDate        string            `json:"appointment_date,omitempty"`
 	AppointmentStartTime   string            `json:"appointment_start_time,omitempty"`
 	AppointmentEndTime     string            `json:"appointment_end_time,omitempty"`
 	AppointmentLocation    *LocationResponse `json:"appointment_location,omitempty"`
+	RegionCode             *string           `json:"region_code,omitempty"`
 	CreatedAt              *time.Time        `json:"created_at"`
 	UpdatedAt              *time.Time        `json:"updated_at"`
 	Distance               *float64          `json:"distance,omitempty"`
@@ -90,13 +96,17 @@ type WasteTransferRequestResponse struct {
 	TotalPrice             int64                               `json:"total_price"`
 	Status                 string                              `json:"status"`
 	ImageURL               string                              `json:"image_url,omitempty"`
+	ImageThumbnailURL      string                              `json:"image_thumbnail_url,omitempty"`
 	Notes                  string                              `json:"notes,omitempty"`
+	CancellationReason     string                              `json:"cancellation_reason_code,omitempty"`
+	CancelledAt            *time.Time                          `json:"cancelled_at,omitempty"`
 	SourcePhoneNumber      string                              `json:"source_phone_number"`
 	DestinationPhoneNumber string                              `json:"destination_phone_number"`
 	AppointmentDate        string                              `json:"appointment_date,omitempty"`
 	AppointmentStartTime   string                              `json:"appointment_start_time,omitempty"`
 	AppointmentEndTime     string                              `json:"appointment_end_time,omitempty"`
 	AppointmentLocation    *LocationResponse                   `json:"appointment_location,omitempty"`
+	RegionCode             *string                             `json:"region_code,omitempty"`
 	CreatedAt              *time.Time                          `json:"created_at"`
 	UpdatedAt              *time.Time                          `json:"updated_at"`
 	SourceUser             *UserResponse                       `json:"source_user"`
@@ -114,6 +124,7 @@ type SearchWasteTransferRequest struct {
 	AssignedCollectorID  string   `json:"assigned_collector_id"` // NEW
 	FormType             string   `json:"form_type"`
 	Status               string   `json:"status"`
+	CancellationReason   string   `json:"cancellation_reason_code,omitempty" validate:"omitempty,oneof=customer_cancelled no_show expired bank_rejected"`
 	AppointmentDate      string   `json:"appointment_date,omitempty"`
 	AppointmentStartTime string   `json:"appointment_start_time,omitempty"`
 	AppointmentEndTime   string   `json:"appointment_end_time,omitempty"`
@@ -135,9 +146,12 @@ type UpdateWasteTransferRequest struct {
 	ID                   string `json:"id" validate:"required,max=100"`
 	FormType             string `json:"form_type"`
 	Status               string `json:"status"`
+	ReasonCode           string `json:"reason_code,omitempty" validate:"omitempty,oneof=customer_cancelled no_show expired bank_rejected"` // Checked against the actor, see transferCancellationReasonCode
 	AppointmentDate      string `json:"appointment_date,omitempty"`
 	AppointmentStartTime string `json:"appointment_start_time,omitempty"`
 	AppointmentEndTime   string `json:"appointment_end_time,omitempty"`
+	ActorID              string `json:"-"`
+	ActorRole            string `json:"-"`
 }
 
 type DeleteWasteTransferRequest struct {
@@ -1,17 +1,19 @@
 package model
 
 type WasteTypeResponse struct {
-	ID            string                 `json:"id"`
-	CategoryID    string                 `json:"category_id"`
-	Name          string                 `json:"name"`
-	Description   string                 `json:"description"`
-	WasteCategory *WasteCategoryResponse `json:"waste_category,omitempty"`
+	ID                  string                 `json:"id"`
+	CategoryID          string                 `json:"category_id"`
+	Name                string                 `json:"name"`
+	Description         string                 `json:"description"`
+	BulkDensityKgsPerM3 *float64               `json:"bulk_density_kgs_per_m3"`
+	WasteCategory       *WasteCategoryResponse `json:"waste_category,omitempty"`
 }
 
 type WasteTypeRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
-	CategoryID  string `json:"category_id"`
+	Name                string   `json:"name"`
+	Description         string   `json:"description,omitempty"`
+	CategoryID          string   `json:"category_id"`
+	BulkDensityKgsPerM3 *float64 `json:"bulk_density_kgs_per_m3" validate:"omitempty,gt=0"`
 }
 
 type SearchWasteTypeRequest struct {
@@ -21,9 +23,10 @@ type SearchWasteTypeRequest struct {
 	Size       int    `json:"size,omitempty" validate:"min=1,max=100"`
 }
 type UpdateWasteTypeRequest struct {
-	ID          string `json:"id" validate:"required,max=100"`
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
+	ID                  string   `json:"id" validate:"required,max=100"`
+	Name                string   `json:"name,omitempty"`
+	Description         string   `json:"description,omitempty"`
+	BulkDensityKgsPerM3 *float64 `json:"bulk_density_kgs_per_m3" validate:"omitempty,gt=0"`
 }
 
 type DeleteWasteTypeRequest struct {
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type CollectorAssignmentRepository struct {
+	Repository[entity.CollectorAssignment]
+	Log *logrus.Logger
+}
+
+func NewCollectorAssignmentRepository(log *logrus.Logger) *CollectorAssignmentRepository {
+	return &CollectorAssignmentRepository{
+		Log: log,
+	}
+}
+
+// LockCollectors locks the active collectors of a waste bank until the transaction ends, so two requests
+// cannot both take a collector's last free slot. Collectors can work for several banks, so the lock is
+// taken on the collectors themselves, in id order to avoid deadlocks.
+func (r *CollectorAssignmentRepository) LockCollectors(db *gorm.DB, wasteBankID string) error {
+	var ids []string
+	return db.Raw(`SELECT u.id FROM users u
+		WHERE u.id IN (SELECT cm.collector_id FROM collector_managements cm WHERE cm.waste_bank_id = ? AND cm.status = 'active')
+		ORDER BY u.id
+		FOR UPDATE`, wasteBankID).Scan(&ids).Error
+}
+
+// FindCandidates returns the active collectors of the request's waste bank. The location is the last one
+// the collector reported on a status change, falling back to their profile location. Loads count the
+// assigned and collecting requests on the appointment day and in the overlapping appointment window.
+// Collectors with service areas are only candidates for pickups inside one of them.
+func (r *CollectorAssignmentRepository) FindCandidates(db *gorm.DB, requestID string) ([]entity.CollectorCandidate, error) {
+	var candidates []entity.CollectorCandidate
+	err := db.Raw(`SELECT cm.collector_id, cm.daily_capacity,
+			ST_Distance(loc.location, r.appointment_location) AS distance_meters,
+			loc.source AS location_source,
+			(SELECT COUNT(*) FROM waste_drop_requests o
+				WHERE o.assigned_collector_id = cm.collector_id AND o.is_deleted = FALSE
+					AND o.status IN ('assigned', 'collecting')
+					AND o.appointment_date = r.appointment_date) AS day_load,
+			(SELECT COUNT(*) FROM waste_drop_requests o
+				WHERE o.assigned_collector_id = cm.collector_id AND o.is_deleted = FALSE
+					AND o.status IN ('assigned', 'collecting')
+					AND o.appointment_date = r.appointment_date
+					AND (r.appointment_start_time IS NULL OR r.appointment_end_time IS NULL
+						OR o.appointment_start_time IS NULL OR o.appointment_end_time IS NULL
+						OR (o.appointment_start_time < r.appointment_end_time AND o.appointment_end_time > r.appointment_start_time))) AS window_load
+		FROM waste_drop_requests r
+		JOIN collector_managements cm ON cm.waste_bank_id = r.waste_bank_id AND cm.status = 'active'
+		JOIN users u ON u.id = cm.collector_id
+		LEFT JOIN LATERAL (
+			SELECT h.location FROM waste_drop_request_status_history h
+			WHERE h.actor_id = cm.collector_id AND h.location IS NOT NULL
+			ORDER BY h.created_at DESC
+			LIMIT 1
+		) last_seen ON TRUE
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(last_seen.location, u.location) AS location,
+				CASE WHEN last_seen.location IS NOT NULL THEN 'last_status_update'
+					WHEN u.location IS NOT NULL THEN 'profile' END AS source
+		) loc
+		WHERE r.id = ?
+			AND (r.appointment_location IS NULL
+				OR NOT EXISTS (SELECT 1 FROM service_areas sa WHERE sa.user_id = cm.collector_id AND sa.is_active = TRUE)
+				OR EXISTS (SELECT 1 FROM service_areas sa WHERE sa.user_id = cm.collector_id AND sa.is_active = TRUE
+					AND ST_Covers(sa.area, r.appointment_location)))`, requestID).
+		Scan(&candidates).Error
+	return candidates, err
+}
+
+// FindSweepable returns pending pickups without a collector whose waste bank has auto-assignment on.
+// Requests the sweep failed to assign after retryBefore are skipped until their retry is due.
+func (r *CollectorAssignmentRepository) FindSweepable(db *gorm.DB, fromDate time.Time, retryBefore time.Time, limit int) ([]entity.WasteDropRequest, error) {
+	var requests []entity.WasteDropRequest
+	err := db.Model(&entity.WasteDropRequest{}).
+		Joins("JOIN waste_bank_profiles wbp ON wbp.user_id = waste_drop_requests.waste_bank_id").
+		Where("wbp.auto_assign_collectors = ?", true).
+		Where("waste_drop_requests.status = ? AND waste_drop_requests.delivery_type = ?", "pending", "pickup").
+		Where("waste_drop_requests.assigned_collector_id IS NULL AND waste_drop_requests.is_deleted = ?", false).
+		Where("waste_drop_requests.is_waitlisted = ?", false).
+		Where("waste_drop_requests.appointment_date >= ?", fromDate).
+		Where("(waste_drop_requests.auto_assign_attempted_at IS NULL OR waste_drop_requests.auto_assign_attempted_at < ?)", retryBefore).
+		Order("waste_drop_requests.auto_assign_attempted_at NULLS FIRST, waste_drop_requests.appointment_date, waste_drop_requests.created_at").
+		Limit(limit).
+		Find(&requests).Error
+	return requests, err
+}
+
+// MarkAttempted records a failed sweep attempt of a request
+func (r *CollectorAssignmentRepository) MarkAttempted(db *gorm.DB, requestID string, attemptedAt time.Time) error {
+	return db.Model(&entity.WasteDropRequest{}).
+		Where("id = ?", requestID).
+		Update("auto_assign_attempted_at", attemptedAt).Error
+}
+
+func (r *CollectorAssignmentRepository) Search(db *gorm.DB, request *model.SearchCollectorAssignmentRequest) ([]entity.CollectorAssignment, int64, error) {
+	var assignments []entity.CollectorAssignment
+	if err := db.Scopes(r.FilterCollectorAssignment(request)).
+		Preload("Collector").
+		Order("created_at DESC").
+		Offset((request.Page - 1) * request.Size).Limit(request.Size).
+		Find(&assignments).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := db.Model(&entity.CollectorAssignment{}).Scopes(r.FilterCollectorAssignment(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return assignments, total, nil
+}
+
+func (r *CollectorAssignmentRepository) FilterCollectorAssignment(request *model.SearchCollectorAssignmentRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if requestID := request.RequestID; requestID != "" {
+			tx = tx.Where("request_id = ?", requestID)
+		}
+		if wasteBankID := request.WasteBankID; wasteBankID != "" {
+			tx = tx.Where("waste_bank_id = ?", wasteBankID)
+		}
+		if collectorID := request.CollectorID; collectorID != "" {
+			tx = tx.Where("collector_id = ?", collectorID)
+		}
+		return tx
+	}
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CollectorRouteRepository struct {
+	Log *logrus.Logger
+}
+
+func NewCollectorRouteRepository(log *logrus.Logger) *CollectorRouteRepository {
+	return &CollectorRouteRepository{
+		Log: log,
+	}
+}
+
+// FindStops returns the drop and transfer requests the collector still has to visit on the date
+func (r *CollectorRouteRepository) FindStops(db *gorm.DB, collectorID string, date string) ([]entity.CollectorRouteStop, error) {
+	var stops []entity.CollectorRouteStop
+	err := db.Raw(`SELECT id AS request_id, 'waste_drop_request' AS request_type, status,
+			appointment_location AS location,
+			appointment_start_time AS start_time, appointment_end_time AS end_time
+		FROM waste_drop_requests
+		WHERE assigned_collector_id = ? AND appointment_date = ? AND is_deleted = FALSE
+			AND status IN ('assigned', 'collecting')
+		UNION ALL
+		SELECT id, 'waste_transfer_request', status,
+			appointment_location,
+			appointment_start_time, appointment_end_time
+		FROM waste_transfer_requests
+		WHERE assigned_collector_id = ? AND appointment_date = ? AND is_deleted = FALSE
+			AND status IN ('assigned', 'collecting')
+		ORDER BY request_id`, collectorID, date, collectorID, date).
+		Scan(&stops).Error
+	return stops, err
+}
+
+// DistanceMatrix returns the pairwise geodesic distances in meters between the points
+func (r *CollectorRouteRepository) DistanceMatrix(db *gorm.DB, points []types.Point) ([][]float64, error) {
+	matrix := make([][]float64, len(points))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(points))
+	}
+	if len(points) < 2 {
+		return matrix, nil
+	}
+
+	wkt := make([]string, len(points))
+	for i, point := range points {
+		wkt[i] = fmt.Sprintf("POINT(%f %f)", point.Lng, point.Lat)
+	}
+
+	var cells []entity.DistanceMatrixCell
+	err := db.Raw(`WITH points AS (
+			SELECT (ordinality - 1)::int AS idx, ST_GeogFromText(wkt) AS geog
+			FROM unnest(string_to_array(?, '|')) WITH ORDINALITY AS p(wkt, ordinality)
+		)
+		SELECT a.idx AS from_index, b.idx AS to_index, ST_Distance(a.geog, b.geog) AS meters
+		FROM points a
+		JOIN points b ON a.idx < b.idx`, strings.Join(wkt, "|")).
+		Scan(&cells).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Distances are symmetric, so only one half is queried
+	for _, cell := range cells {
+		matrix[cell.FromIndex][cell.ToIndex] = cell.Meters
+		matrix[cell.ToIndex][cell.FromIndex] = cell.Meters
+	}
+	return matrix, nil
+}
+
+// FindPlan returns the unexpired route planned for the collector, date and plan key
+func (r *CollectorRouteRepository) FindPlan(db *gorm.DB, plan *entity.CollectorRoutePlan, collectorID string, date string, planKey string) error {
+	return db.Where("collector_id = ? AND route_date = ? AND plan_key = ? AND expires_at > ?", collectorID, date, planKey, time.Now()).
+		Take(plan).Error
+}
+
+// SavePlan stores a planned route, replacing the previous plan of the same collector, date and plan key.
+// Expired plans are removed on the way.
+func (r *CollectorRouteRepository) SavePlan(db *gorm.DB, plan *entity.CollectorRoutePlan) error {
+	if err := db.Where("expires_at <= ?", time.Now()).Delete(&entity.CollectorRoutePlan{}).Error; err != nil {
+		return err
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "collector_id"}, {Name: "route_date"}, {Name: "plan_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"plan", "expires_at", "updated_at"}),
+	}).Create(plan).Error
+}
@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type DailyWasteRollupRepository struct {
+	Repository[entity.DailyWasteRollup]
+	Log *logrus.Logger
+}
+
+func NewDailyWasteRollupRepository(log *logrus.Logger) *DailyWasteRollupRepository {
+	return &DailyWasteRollupRepository{
+		Log: log,
+	}
+}
+
+// rollupDaySQL buckets a request by its creation date in Western Indonesia Time
+func rollupDaySQL(column string) string {
+	return fmt.Sprintf("(%s AT TIME ZONE 'Asia/Jakarta')::date", column)
+}
+
+// rollupDropSQL aggregates completed drop requests matching the extra condition
+func rollupDropSQL(condition string) string {
+	return `INSERT INTO daily_waste_rollups
+			(day, flow, receiver_id, receiver_role, receiver_region_code, sender_id, region_code, waste_type_id, request_count, weight, amount)
+		SELECT ` + rollupDaySQL("wdr.created_at") + `, 'drop', wdr.waste_bank_id, b.role, b.region_code, NULL, wdr.region_code,
+			i.waste_type_id, COUNT(DISTINCT wdr.id), COALESCE(SUM(i.verified_weight), 0), COALESCE(SUM(i.verified_subtotal), 0)
+		FROM waste_drop_requests wdr
+		JOIN waste_drop_request_items i ON i.request_id = wdr.id AND i.is_deleted = FALSE
+		JOIN users b ON b.id = wdr.waste_bank_id
+		WHERE wdr.status = 'completed' AND wdr.is_deleted = FALSE AND ` + condition + `
+		GROUP BY 1, wdr.waste_bank_id, b.role, b.region_code, wdr.region_code, i.waste_type_id`
+}
+
+// rollupTransferSQL aggregates completed transfer requests matching the extra condition, priced like completion does
+func rollupTransferSQL(condition string) string {
+	return `INSERT INTO daily_waste_rollups
+			(day, flow, receiver_id, receiver_role, receiver_region_code, sender_id, region_code, waste_type_id, request_count, weight, amount)
+		SELECT ` + rollupDaySQL("wtr.created_at") + `,
+			CASE WHEN wtr.form_type = 'industry_request' THEN 'industry_transfer' ELSE 'waste_bank_transfer' END::rollup_flow,
+			wtr.destination_user_id, d.role, d.region_code, wtr.source_user_id, wtr.region_code,
+			i.waste_type_id, COUNT(DISTINCT wtr.id), COALESCE(SUM(i.verified_weight), 0),
+			COALESCE(SUM(FLOOR(COALESCE(NULLIF(i.accepted_price_per_kgs, 0), i.offering_price_per_kgs) * i.verified_weight)), 0)
+		FROM waste_transfer_requests wtr
+		JOIN waste_transfer_items i ON i.transfer_request_id = wtr.id
+		JOIN users d ON d.id = wtr.destination_user_id
+		WHERE wtr.status = 'completed' AND wtr.is_deleted = FALSE AND ` + condition + `
+		GROUP BY 1, 2, wtr.destination_user_id, d.role, d.region_code, wtr.source_user_id, wtr.region_code, i.waste_type_id`
+}
+
+// lockRollup serializes refreshes of one receiver and day until the transaction ends, so concurrent
+// refreshes cannot both insert after the delete. Rebuild holds the global key exclusively.
+func lockRollup(db *gorm.DB, receiverID string, day string) error {
+	if err := db.Exec("SELECT pg_advisory_xact_lock_shared(hashtext('rollup'))").Error; err != nil {
+		return err
+	}
+	return db.Exec("SELECT pg_advisory_xact_lock(hashtext('rollup:' || ? || ?))", receiverID, day).Error
+}
+
+// RefreshForWasteDropRequest recomputes the drop rollups of the waste bank and day of a drop request
+func (r *DailyWasteRollupRepository) RefreshForWasteDropRequest(db *gorm.DB, requestID uuid.UUID) error {
+	var target struct {
+		ReceiverID *uuid.UUID
+		Day        string
+	}
+	if err := db.Raw(`SELECT waste_bank_id AS receiver_id, `+rollupDaySQL("created_at")+`::text AS day
+		FROM waste_drop_requests WHERE id = ?`, requestID).Scan(&target).Error; err != nil {
+		return err
+	}
+	if target.ReceiverID == nil {
+		return nil
+	}
+
+	if err := lockRollup(db, target.ReceiverID.String(), target.Day); err != nil {
+		return err
+	}
+	if err := db.Exec("DELETE FROM daily_waste_rollups WHERE flow = 'drop' AND receiver_id = ? AND day = ?::date", target.ReceiverID, target.Day).Error; err != nil {
+		return err
+	}
+	return db.Exec(rollupDropSQL("wdr.waste_bank_id = ? AND "+rollupDaySQL("wdr.created_at")+" = ?::date"), target.ReceiverID, target.Day).Error
+}
+
+// RefreshForWasteTransferRequest recomputes the transfer rollups of the destination and day of a transfer request
+func (r *DailyWasteRollupRepository) RefreshForWasteTransferRequest(db *gorm.DB, requestID uuid.UUID) error {
+	var target struct {
+		ReceiverID uuid.UUID
+		Day        string
+	}
+	if err := db.Raw(`SELECT destination_user_id AS receiver_id, `+rollupDaySQL("created_at")+`::text AS day
+		FROM waste_transfer_requests WHERE id = ?`, requestID).Scan(&target).Error; err != nil {
+		return err
+	}
+
+	if err := lockRollup(db, target.ReceiverID.String(), target.Day); err != nil {
+		return err
+	}
+	if err := db.Exec("DELETE FROM daily_waste_rollups WHERE flow <> 'drop' AND receiver_id = ? AND day = ?::date", target.ReceiverID, target.Day).Error; err != nil {
+		return err
+	}
+	return db.Exec(rollupTransferSQL("wtr.destination_user_id = ? AND "+rollupDaySQL("wtr.created_at")+" = ?::date"), target.ReceiverID, target.Day).Error
+}
+
+// Rebuild recomputes every rollup from the day given on, or all of them when since is nil
+func (r *DailyWasteRollupRepository) Rebuild(db *gorm.DB, since *time.Time) (int64, error) {
+	day := ""
+	if since != nil {
+		day = since.Format("2006-01-02")
+	}
+
+	if err := db.Exec("SELECT pg_advisory_xact_lock(hashtext('rollup'))").Error; err != nil {
+		return 0, err
+	}
+	if err := db.Exec("DELETE FROM daily_waste_rollups WHERE (? = '' OR day >= ?::date)", day, day).Error; err != nil {
+		return 0, err
+	}
+	drops := db.Exec(rollupDropSQL("wdr.waste_bank_id IS NOT NULL AND (? = '' OR "+rollupDaySQL("wdr.created_at")+" >= ?::date)"), day, day)
+	if drops.Error != nil {
+		return 0, drops.Error
+	}
+	transfers := db.Exec(rollupTransferSQL("(? = '' OR "+rollupDaySQL("wtr.created_at")+" >= ?::date)"), day, day)
+	if transfers.Error != nil {
+		return 0, transfers.Error
+	}
+	return drops.RowsAffected + transfers.RowsAffected, nil
+}
+
+// FilterMonths limits rollups to the days of a month range, either end being optional
+func (r *DailyWasteRollupRepository) FilterMonths(startMonth string, endMonth string) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		startMonth, startDate, endMonth, endDate := dashboardMonthParams(startMonth, endMonth)
+		if startMonth != "" {
+			tx = tx.Where("daily_waste_rollups.day >= ?::date", startDate)
+		}
+		if endMonth != "" {
+			tx = tx.Where("daily_waste_rollups.day <= ?::date", endDate)
+		}
+		return tx
+	}
+}
+
+// FilterDashboard limits rollups to the dashboard months and to a region code matched against regionColumn
+func (r *DailyWasteRollupRepository) FilterDashboard(request *model.GovernmentDashboardRequest, regionColumn string) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Scopes(r.FilterMonths(request.StartMonth, request.EndMonth))
+		if request.RegionCode != "" {
+			tx = tx.Where(regionCodeFilter("daily_waste_rollups."+regionColumn), request.RegionCode, request.RegionCode+".%")
+		}
+		return tx
+	}
+}
+
+// SumWeight totals the weight of some flows, filtering the region on the request region
+func (r *DailyWasteRollupRepository) SumWeight(db *gorm.DB, request *model.GovernmentDashboardRequest, flows []string) (float64, error) {
+	var total float64
+	err := db.Model(&entity.DailyWasteRollup{}).
+		Select("COALESCE(SUM(weight), 0)").
+		Where("flow IN ?", flows).
+		Scopes(r.FilterDashboard(request, "region_code")).
+		Scan(&total).Error
+	return total, err
+}
+
+// GetMonthlyWeightByRole returns map[month]map[receiver role]weight of some flows, filtering the region on the receiver
+func (r *DailyWasteRollupRepository) GetMonthlyWeightByRole(db *gorm.DB, request *model.GovernmentDashboardRequest, flows []string) (map[string]map[string]float64, error) {
+	var rows []struct {
+		Month        time.Time
+		ReceiverRole string
+		Weight       float64
+	}
+	if err := db.Model(&entity.DailyWasteRollup{}).
+		Select("DATE_TRUNC('month', day) AS month, receiver_role, SUM(weight) AS weight").
+		Where("flow IN ?", flows).
+		Scopes(r.FilterDashboard(request, "receiver_region_code")).
+		Group("month, receiver_role").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	trends := make(map[string]map[string]float64)
+	for _, row := range rows {
+		month := row.Month.Format("2006-01")
+		if trends[month] == nil {
+			trends[month] = make(map[string]float64)
+		}
+		trends[month][row.ReceiverRole] += row.Weight
+	}
+	return trends, nil
+}
+
+// GetReceiverTotals returns the weight of some flows per receiver, filtering the region on the receiver
+func (r *DailyWasteRollupRepository) GetReceiverTotals(db *gorm.DB, request *model.GovernmentDashboardRequest, flows []string) (map[string]float64, error) {
+	var rows []struct {
+		ReceiverID string
+		Weight     float64
+	}
+	if err := db.Model(&entity.DailyWasteRollup{}).
+		Select("receiver_id::text AS receiver_id, SUM(weight) AS weight").
+		Where("flow IN ?", flows).
+		Scopes(r.FilterDashboard(request, "receiver_region_code")).
+		Group("receiver_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]float64)
+	for _, row := range rows {
+		totals[row.ReceiverID] = row.Weight
+	}
+	return totals, nil
+}
+
+// GetTopOfftakers returns the three industries that received the most weight
+func (r *DailyWasteRollupRepository) GetTopOfftakers(db *gorm.DB, request *model.GovernmentDashboardRequest) ([]model.TopOfftaker, error) {
+	var topOfftakers []model.TopOfftaker
+	err := db.Model(&entity.DailyWasteRollup{}).
+		Select(`daily_waste_rollups.receiver_id::text AS id,
+			COALESCE(u.username, '') AS name,
+			COALESCE(u.institution, '') AS institution,
+			COALESCE(u.city, '') AS city,
+			COALESCE(u.province, '') AS province,
+			SUM(daily_waste_rollups.weight) AS total_weight,
+			SUM(daily_waste_rollups.amount) AS total_price`).
+		Joins("JOIN users u ON u.id = daily_waste_rollups.receiver_id").
+		Where("daily_waste_rollups.flow = ?", "industry_transfer").
+		Scopes(r.FilterDashboard(request, "receiver_region_code")).
+		Group("daily_waste_rollups.receiver_id, u.username, u.institution, u.city, u.province").
+		Order("total_weight DESC, total_price DESC").
+		Limit(3).
+		Scan(&topOfftakers).Error
+	return topOfftakers, err
+}
+
+// GetEnvironmentalImpact applies the impact factors to the collected weight of each waste type
+func (r *DailyWasteRollupRepository) GetEnvironmentalImpact(db *gorm.DB, request *model.GovernmentDashboardRequest) (*entity.EnvironmentalImpact, error) {
+	// The rollups are shaped like drop items so the impact factor lookup can be shared
+	items := db.Model(&entity.DailyWasteRollup{}).
+		Select("waste_type_id, SUM(weight) AS verified_weight").
+		Where("flow = ?", "drop").
+		Scopes(r.FilterDashboard(request, "region_code")).
+		Group("waste_type_id")
+
+	impact := new(entity.EnvironmentalImpact)
+	err := db.Table("(?) i", items).
+		Select(impactSelect).
+		Joins("JOIN waste_types t ON t.id = i.waste_type_id").
+		Joins(impactFactorLateral).
+		Scan(impact).Error
+	return impact, err
+}
+
+// GetInboundByWasteType returns the monthly weight and payout of the drops a waste bank received, per waste type
+func (r *DailyWasteRollupRepository) GetInboundByWasteType(db *gorm.DB, wasteBankID string, startMonth string, endMonth string) ([]model.WasteBankInboundByWasteType, error) {
+	var inbound []model.WasteBankInboundByWasteType
+	err := db.Model(&entity.DailyWasteRollup{}).
+		Select(`TO_CHAR(DATE_TRUNC('month', daily_waste_rollups.day), 'YYYY-MM') AS month,
+			daily_waste_rollups.waste_type_id::text AS waste_type_id,
+			COALESCE(t.name, '') AS waste_type_name,
+			SUM(daily_waste_rollups.request_count) AS requests,
+			SUM(daily_waste_rollups.weight) AS weight,
+			SUM(daily_waste_rollups.amount) AS payout`).
+		Joins("JOIN waste_types t ON t.id = daily_waste_rollups.waste_type_id").
+		Where("daily_waste_rollups.flow = ? AND daily_waste_rollups.receiver_id = ?", "drop", wasteBankID).
+		Scopes(r.FilterMonths(startMonth, endMonth)).
+		Group("1, daily_waste_rollups.waste_type_id, t.name").
+		Order("month, weight DESC").
+		Scan(&inbound).Error
+	return inbound, err
+}
+
+// GetOutboundByMonth returns the monthly weight a user transferred out and the revenue it earned, per destination type
+func (r *DailyWasteRollupRepository) GetOutboundByMonth(db *gorm.DB, senderID string, startMonth string, endMonth string) ([]model.WasteBankOutboundByMonth, error) {
+	var outbound []model.WasteBankOutboundByMonth
+	err := db.Model(&entity.DailyWasteRollup{}).
+		Select(`TO_CHAR(DATE_TRUNC('month', day), 'YYYY-MM') AS month,
+			CASE WHEN flow = 'industry_transfer' THEN 'industry' ELSE 'waste_bank' END AS destination_type,
+			SUM(weight) AS weight,
+			SUM(amount) AS revenue`).
+		Where("sender_id = ?", senderID).
+		Scopes(r.FilterMonths(startMonth, endMonth)).
+		Group("1, 2").
+		Order("month, destination_type").
+		Scan(&outbound).Error
+	return outbound, err
+}
+
+// GetPurchasesBySupplier returns the monthly weight an industry bought and the amount it paid, per waste type and supplier
+func (r *DailyWasteRollupRepository) GetPurchasesBySupplier(db *gorm.DB, industryID string, startMonth string, endMonth string) ([]model.IndustryPurchase, error) {
+	var purchases []model.IndustryPurchase
+	err := db.Model(&entity.DailyWasteRollup{}).
+		Select(`TO_CHAR(DATE_TRUNC('month', daily_waste_rollups.day), 'YYYY-MM') AS month,
+			daily_waste_rollups.waste_type_id::text AS waste_type_id,
+			COALESCE(t.name, '') AS waste_type_name,
+			daily_waste_rollups.sender_id::text AS supplier_id,
+			COALESCE(NULLIF(s.institution, ''), s.username, '') AS supplier_name,
+			SUM(daily_waste_rollups.weight) AS weight,
+			SUM(daily_waste_rollups.amount) AS spend`).
+		Joins("JOIN waste_types t ON t.id = daily_waste_rollups.waste_type_id").
+		Joins("LEFT JOIN users s ON s.id = daily_waste_rollups.sender_id").
+		Where("daily_waste_rollups.flow = ? AND daily_waste_rollups.receiver_id = ?", "industry_transfer", industryID).
+		Scopes(r.FilterMonths(startMonth, endMonth)).
+		Group("1, daily_waste_rollups.waste_type_id, t.name, daily_waste_rollups.sender_id, s.institution, s.username").
+		Order("month, weight DESC").
+		Scan(&purchases).Error
+	return purchases, err
+}
@@ -2,9 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
 
 	"cloud.google.com/go/storage"
 )
@@ -21,12 +21,12 @@ func NewGCSFileRepository(client *storage.Client, bucketName string) *GCSFileRep
 	}
 }
 
-func (r *GCSFileRepository) UploadFile(file multipart.File, fileName string, contentType string) (string, error) {
-	ctx := context.Background()
-	writer := r.Client.Bucket(r.BucketName).Object(fileName).NewWriter(ctx)
+func (r *GCSFileRepository) Upload(ctx context.Context, objectName string, content io.Reader, contentType string) (string, error) {
+	writer := r.Client.Bucket(r.BucketName).Object(objectName).NewWriter(ctx)
 	writer.ObjectAttrs.ContentType = contentType
 
-	if _, err := io.Copy(writer, file); err != nil {
+	if _, err := io.Copy(writer, content); err != nil {
+		writer.Close()
 		return "", err
 	}
 
@@ -34,12 +34,14 @@ func (r *GCSFileRepository) UploadFile(file multipart.File, fileName string, con
 		return "", err
 	}
 
-	url := fmt.Sprintf("https://storage.googleapis.com/%s/%s", r.BucketName, fileName)
+	url := fmt.Sprintf("https://storage.googleapis.com/%s/%s", r.BucketName, objectName)
 	return url, nil
 }
 
-func (r *GCSFileRepository) DeleteFile(fileURL string) error {
-	ctx := context.Background()
-	object := r.Client.Bucket(r.BucketName).Object(fileURL) // Use just object name in real use
-	return object.Delete(ctx)
+func (r *GCSFileRepository) Delete(ctx context.Context, objectName string) error {
+	err := r.Client.Bucket(r.BucketName).Object(objectName).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil
+	}
+	return err
 }
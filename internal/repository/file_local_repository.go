@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalFileRepository stores files on disk for development and tests.
+// The directory is expected to be served statically under BaseURL.
+type LocalFileRepository struct {
+	BaseDir string
+	BaseURL string
+}
+
+func NewLocalFileRepository(baseDir string, baseURL string) *LocalFileRepository {
+	return &LocalFileRepository{
+		BaseDir: baseDir,
+		BaseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Helper method to resolve an object name inside the base directory
+func (r *LocalFileRepository) path(objectName string) (string, error) {
+	base, err := filepath.Abs(r.BaseDir)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(base, filepath.FromSlash(objectName))
+	if !strings.HasPrefix(path, base+string(filepath.Separator)) {
+		return "", fmt.Errorf("object name %q escapes the storage directory", objectName)
+	}
+	return path, nil
+}
+
+func (r *LocalFileRepository) Upload(ctx context.Context, objectName string, content io.Reader, contentType string) (string, error) {
+	path, err := r.path(objectName)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+
+	return r.BaseURL + "/" + objectName, nil
+}
+
+func (r *LocalFileRepository) Delete(ctx context.Context, objectName string) error {
+	path, err := r.path(objectName)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
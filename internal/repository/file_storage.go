@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"io"
+)
+
+// FileStorage stores uploaded files under server generated object names
+type FileStorage interface {
+	// Upload stores the content under the object name and returns its public URL
+	Upload(ctx context.Context, objectName string, content io.Reader, contentType string) (string, error)
+	// Delete removes the object, deleting a missing object is not an error
+	Delete(ctx context.Context, objectName string) error
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyKeyRepository struct {
+	Repository[entity.IdempotencyKey]
+	Log *logrus.Logger
+}
+
+func NewIdempotencyKeyRepository(log *logrus.Logger) *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{
+		Log: log,
+	}
+}
+
+// Reserve inserts the key and reports whether this call owns it. False means the key already exists.
+func (r *IdempotencyKeyRepository) Reserve(db *gorm.DB, idempotencyKey *entity.IdempotencyKey) (bool, error) {
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "key"}},
+		DoNothing: true,
+	}).Create(idempotencyKey)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *IdempotencyKeyRepository) FindByUserAndKey(db *gorm.DB, idempotencyKey *entity.IdempotencyKey, userID string, key string) error {
+	return db.Where("user_id = ? AND key = ?", userID, key).First(idempotencyKey).Error
+}
+
+func (r *IdempotencyKeyRepository) SaveResponse(db *gorm.DB, idempotencyKey *entity.IdempotencyKey) error {
+	return db.Model(idempotencyKey).Updates(map[string]interface{}{
+		"status_code":   idempotencyKey.StatusCode,
+		"content_type":  idempotencyKey.ContentType,
+		"response_body": idempotencyKey.ResponseBody,
+		"completed_at":  idempotencyKey.CompletedAt,
+	}).Error
+}
+
+func (r *IdempotencyKeyRepository) DeleteExpired(db *gorm.DB, before time.Time) error {
+	return db.Where("created_at < ?", before).Delete(&entity.IdempotencyKey{}).Error
+}
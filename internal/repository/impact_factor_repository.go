@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type ImpactFactorRepository struct {
+	Repository[entity.ImpactFactor]
+	Log *logrus.Logger
+}
+
+func NewImpactFactorRepository(log *logrus.Logger) *ImpactFactorRepository {
+	return &ImpactFactorRepository{
+		Log: log,
+	}
+}
+
+// impactFactorLateral picks the most specific factor for each item: waste type first,
+// then the category of the type, then the default factor.
+const impactFactorLateral = `CROSS JOIN LATERAL (
+	SELECT f.co2e_per_kg, f.water_liters_per_kg, f.energy_kwh_per_kg, f.trees_per_kg
+	FROM impact_factors f
+	WHERE f.is_deleted = FALSE
+		AND (f.waste_type_id = i.waste_type_id
+			OR (f.waste_type_id IS NULL AND f.waste_category_id = t.category_id)
+			OR (f.waste_type_id IS NULL AND f.waste_category_id IS NULL))
+	ORDER BY (f.waste_type_id IS NULL), (f.waste_category_id IS NULL)
+	LIMIT 1
+) f`
+
+const impactSelect = `COALESCE(SUM(i.verified_weight * f.co2e_per_kg), 0) AS co2e,
+	COALESCE(SUM(i.verified_weight * f.water_liters_per_kg), 0) AS water_liters,
+	COALESCE(SUM(i.verified_weight * f.energy_kwh_per_kg), 0) AS energy_kwh,
+	COALESCE(SUM(i.verified_weight * f.trees_per_kg), 0) AS trees,
+	COALESCE(SUM(i.verified_weight), 0) AS weight`
+
+func (r *ImpactFactorRepository) FindByIdWithRelations(db *gorm.DB, factor *entity.ImpactFactor, id string) error {
+	return db.Where("id = ?", id).
+		Preload("WasteType").
+		Preload("WasteCategory").
+		First(factor).Error
+}
+
+// CountByKey counts active factors for the same waste type / category pair, nil meaning unset
+func (r *ImpactFactorRepository) CountByKey(db *gorm.DB, wasteTypeID, wasteCategoryID *string) (int64, error) {
+	query := db.Model(&entity.ImpactFactor{}).Where("is_deleted = ?", false)
+	if wasteTypeID != nil {
+		query = query.Where("waste_type_id = ?", *wasteTypeID)
+	} else {
+		query = query.Where("waste_type_id IS NULL")
+	}
+	if wasteCategoryID != nil {
+		query = query.Where("waste_category_id = ?", *wasteCategoryID)
+	} else {
+		query = query.Where("waste_category_id IS NULL")
+	}
+
+	var total int64
+	err := query.Count(&total).Error
+	return total, err
+}
+
+// SumImpactForRequest calculates the impact of the verified items of a drop request
+func (r *ImpactFactorRepository) SumImpactForRequest(db *gorm.DB, requestID string) (*entity.EnvironmentalImpact, error) {
+	impact := new(entity.EnvironmentalImpact)
+	err := db.Raw(`SELECT `+impactSelect+`
+		FROM waste_drop_request_items i
+		JOIN waste_types t ON t.id = i.waste_type_id
+		`+impactFactorLateral+`
+		WHERE i.request_id = ? AND i.is_deleted = FALSE`, requestID).
+		Scan(impact).Error
+	return impact, err
+}
+
+// RecomputeCustomerProfiles rebuilds the impact totals of every customer profile from their
+// completed drop requests. Each request is truncated on its own, the same way completion does.
+func (r *ImpactFactorRepository) RecomputeCustomerProfiles(db *gorm.DB) (int64, error) {
+	result := db.Exec(`UPDATE customer_profiles cp SET
+			carbon_deficit = COALESCE(totals.co2e, 0),
+			water_saved = COALESCE(totals.water_liters, 0),
+			energy_saved = COALESCE(totals.energy_kwh, 0),
+			trees = COALESCE(totals.trees, 0)
+		FROM customer_profiles target
+		LEFT JOIN (
+			SELECT per_request.customer_id,
+				SUM(FLOOR(per_request.co2e))::BIGINT AS co2e,
+				SUM(FLOOR(per_request.water_liters))::BIGINT AS water_liters,
+				SUM(FLOOR(per_request.energy_kwh))::BIGINT AS energy_kwh,
+				SUM(FLOOR(per_request.trees))::BIGINT AS trees
+			FROM (
+				SELECT r.customer_id, ` + impactSelect + `
+				FROM waste_drop_requests r
+				JOIN waste_drop_request_items i ON i.request_id = r.id AND i.is_deleted = FALSE
+				JOIN waste_types t ON t.id = i.waste_type_id
+				` + impactFactorLateral + `
+				WHERE r.status = 'completed' AND r.is_deleted = FALSE
+				GROUP BY r.id, r.customer_id
+			) per_request
+			GROUP BY per_request.customer_id
+		) totals ON totals.customer_id = target.user_id
+		WHERE cp.id = target.id`)
+	return result.RowsAffected, result.Error
+}
+
+// HasPendingChanges reports whether any factor was changed after the last recompute
+func (r *ImpactFactorRepository) HasPendingChanges(db *gorm.DB) (bool, error) {
+	var total int64
+	err := db.Model(&entity.ImpactFactor{}).Where("applied_at IS NULL").Count(&total).Error
+	return total > 0, err
+}
+
+func (r *ImpactFactorRepository) MarkApplied(db *gorm.DB, at time.Time) error {
+	return db.Model(&entity.ImpactFactor{}).Where("applied_at IS NULL").Update("applied_at", at).Error
+}
+
+// GetEnvironmentalImpact calculates the impact of completed drop requests for the government dashboard
+func (r *ImpactFactorRepository) GetEnvironmentalImpact(db *gorm.DB, request *model.GovernmentDashboardRequest) (*entity.EnvironmentalImpact, error) {
+	query := db.Table("waste_drop_request_items i").
+		Select(impactSelect).
+		Joins("JOIN waste_drop_requests r ON r.id = i.request_id").
+		Joins("JOIN waste_types t ON t.id = i.waste_type_id").
+		Joins(impactFactorLateral).
+		Where("i.is_deleted = ? AND r.is_deleted = ? AND r.status = ?", false, false, "completed")
+
+	if request.StartMonth != "" {
+		startDate, err := time.Parse("2006-01", request.StartMonth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start_month format: %v", err)
+		}
+		query = query.Where("r.created_at >= ?", startDate)
+	}
+
+	if request.EndMonth != "" {
+		endDate, err := time.Parse("2006-01", request.EndMonth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end_month format: %v", err)
+		}
+		endDate = endDate.AddDate(0, 1, -1).Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+		query = query.Where("r.created_at <= ?", endDate)
+	}
+
+	if request.Province != "" || request.City != "" || request.RegionCode != "" {
+		query = query.Joins("JOIN users customers ON customers.id = r.customer_id")
+		if request.Province != "" {
+			query = query.Where("customers.province ILIKE ?", "%"+request.Province+"%")
+		}
+		if request.City != "" {
+			query = query.Where("customers.city ILIKE ?", "%"+request.City+"%")
+		}
+		if request.RegionCode != "" {
+			query = query.Where(regionCodeFilter("customers.region_code"), request.RegionCode, request.RegionCode+".%")
+		}
+	}
+
+	impact := new(entity.EnvironmentalImpact)
+	if err := query.Scan(impact).Error; err != nil {
+		return nil, fmt.Errorf("database error occurred while calculating environmental impact")
+	}
+	return impact, nil
+}
+
+func (r *ImpactFactorRepository) Search(db *gorm.DB, request *model.SearchImpactFactorRequest) ([]entity.ImpactFactor, int64, error) {
+	var factors []entity.ImpactFactor
+	if err := db.Scopes(r.FilterImpactFactor(request)).
+		Preload("WasteType").
+		Preload("WasteCategory").
+		Order("created_at DESC").
+		Offset((request.Page - 1) * request.Size).Limit(request.Size).
+		Find(&factors).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := db.Model(&entity.ImpactFactor{}).Scopes(r.FilterImpactFactor(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return factors, total, nil
+}
+
+func (r *ImpactFactorRepository) FilterImpactFactor(request *model.SearchImpactFactorRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if wasteTypeID := request.WasteTypeID; wasteTypeID != "" {
+			tx = tx.Where("waste_type_id = ?", wasteTypeID)
+		}
+		if wasteCategoryID := request.WasteCategoryID; wasteCategoryID != "" {
+			tx = tx.Where("waste_category_id = ?", wasteCategoryID)
+		}
+		if isDeleted := request.IsDeleted; isDeleted != nil {
+			tx = tx.Where("is_deleted = ?", *isDeleted)
+		} else {
+			tx = tx.Where("is_deleted = ?", false)
+		}
+		return tx
+	}
+}
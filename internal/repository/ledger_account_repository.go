@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+type LedgerAccountRepository struct {
+	Repository[entity.LedgerAccount]
+	Log *logrus.Logger
+}
+
+func NewLedgerAccountRepository(log *logrus.Logger) *LedgerAccountRepository {
+	return &LedgerAccountRepository{
+		Log: log,
+	}
+}
+
+// Signed sum of an account: credits increase the balance, debits decrease it
+const ledgerBalanceExpression = "COALESCE(SUM(CASE WHEN ledger_lines.direction = 'credit' THEN ledger_lines.amount ELSE -ledger_lines.amount END), 0)"
+
+func (r *LedgerAccountRepository) FindOrCreateUserAccount(db *gorm.DB, userID uuid.UUID, asset string) (*entity.LedgerAccount, error) {
+	account := &entity.LedgerAccount{}
+	err := db.Where("user_id = ? AND asset = ?", userID, asset).
+		Attrs(entity.LedgerAccount{UserID: &userID, Asset: asset}).
+		FirstOrCreate(account).Error
+	return account, err
+}
+
+func (r *LedgerAccountRepository) FindOrCreateSystemAccount(db *gorm.DB, code string, asset string) (*entity.LedgerAccount, error) {
+	account := &entity.LedgerAccount{}
+	err := db.Where("code = ? AND asset = ?", code, asset).
+		Attrs(entity.LedgerAccount{Code: &code, Asset: asset}).
+		FirstOrCreate(account).Error
+	return account, err
+}
+
+func (r *LedgerAccountRepository) FindUserAccount(db *gorm.DB, account *entity.LedgerAccount, userID string, asset string) error {
+	return db.Where("user_id = ? AND asset = ?", userID, asset).First(account).Error
+}
+
+func (r *LedgerAccountRepository) GetBalance(db *gorm.DB, accountID uuid.UUID) (int64, error) {
+	var balance int64
+	err := db.Table("ledger_lines").
+		Select(ledgerBalanceExpression).
+		Where("account_id = ?", accountID).
+		Scan(&balance).Error
+	return balance, err
+}
+
+func (r *LedgerAccountRepository) GetBalanceBefore(db *gorm.DB, accountID uuid.UUID, before time.Time) (int64, error) {
+	var balance int64
+	err := db.Table("ledger_lines").
+		Select(ledgerBalanceExpression).
+		Where("account_id = ? AND created_at < ?", accountID, before).
+		Scan(&balance).Error
+	return balance, err
+}
+
+// FindDrifts returns every user/asset whose cached column differs from the ledger sum
+func (r *LedgerAccountRepository) FindDrifts(db *gorm.DB) ([]entity.LedgerDrift, error) {
+	var drifts []entity.LedgerDrift
+	query := `
+		SELECT
+			users.id AS user_id,
+			assets.asset AS asset,
+			CASE assets.asset WHEN 'points' THEN COALESCE(users.points, 0) ELSE COALESCE(users.balance, 0) END AS cached_balance,
+			` + ledgerBalanceExpression + ` AS ledger_balance
+		FROM users
+		CROSS JOIN (VALUES ('points'), ('rupiah')) AS assets(asset)
+		LEFT JOIN ledger_accounts ON ledger_accounts.user_id = users.id AND ledger_accounts.asset::text = assets.asset
+		LEFT JOIN ledger_lines ON ledger_lines.account_id = ledger_accounts.id
+		GROUP BY users.id, assets.asset, users.points, users.balance
+		HAVING CASE assets.asset WHEN 'points' THEN COALESCE(users.points, 0) ELSE COALESCE(users.balance, 0) END <> ` + ledgerBalanceExpression
+
+	if err := db.Raw(query).Scan(&drifts).Error; err != nil {
+		return nil, err
+	}
+	return drifts, nil
+}
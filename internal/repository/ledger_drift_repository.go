@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type LedgerDriftRepository struct {
+	Repository[entity.LedgerDrift]
+	Log *logrus.Logger
+}
+
+func NewLedgerDriftRepository(log *logrus.Logger) *LedgerDriftRepository {
+	return &LedgerDriftRepository{
+		Log: log,
+	}
+}
+
+func (r *LedgerDriftRepository) FindUnresolved(db *gorm.DB) ([]entity.LedgerDrift, error) {
+	var drifts []entity.LedgerDrift
+	if err := db.Where("is_resolved = ?", false).Find(&drifts).Error; err != nil {
+		return nil, err
+	}
+	return drifts, nil
+}
+
+func (r *LedgerDriftRepository) Search(db *gorm.DB, request *model.SearchLedgerDriftRequest) ([]entity.LedgerDrift, int64, error) {
+	var drifts []entity.LedgerDrift
+	if err := db.Scopes(r.FilterLedgerDrift(request)).Order("detected_at DESC").Offset((request.Page - 1) * request.Size).Limit(request.Size).Find(&drifts).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := db.Model(&entity.LedgerDrift{}).Scopes(r.FilterLedgerDrift(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return drifts, total, nil
+}
+
+func (r *LedgerDriftRepository) FilterLedgerDrift(request *model.SearchLedgerDriftRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if userID := request.UserID; userID != "" {
+			tx = tx.Where("user_id = ?", userID)
+		}
+		if asset := request.Asset; asset != "" {
+			tx = tx.Where("asset = ?", asset)
+		}
+		if isResolved := request.IsResolved; isResolved != nil {
+			tx = tx.Where("is_resolved = ?", *isResolved)
+		}
+		return tx
+	}
+}
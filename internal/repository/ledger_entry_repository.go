@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type LedgerEntryRepository struct {
+	Repository[entity.LedgerEntry]
+	Log *logrus.Logger
+}
+
+func NewLedgerEntryRepository(log *logrus.Logger) *LedgerEntryRepository {
+	return &LedgerEntryRepository{
+		Log: log,
+	}
+}
+
+func (r *LedgerEntryRepository) FindByReference(db *gorm.DB, referenceType string, referenceID string) ([]entity.LedgerEntry, error) {
+	var entries []entity.LedgerEntry
+	if err := db.Preload("Lines").Where("reference_type = ? AND reference_id = ?", referenceType, referenceID).Order("created_at ASC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindStatementLines returns the lines of an account in chronological order with their running balance
+func (r *LedgerEntryRepository) FindStatementLines(db *gorm.DB, accountID uuid.UUID, startDate, endDate *time.Time, page, size int) ([]entity.LedgerLine, int64, error) {
+	runningBalance := db.Table("ledger_lines").
+		Select("ledger_lines.*, SUM(CASE WHEN direction = 'credit' THEN amount ELSE -amount END) OVER (ORDER BY created_at, id) AS balance_after").
+		Where("account_id = ?", accountID)
+
+	filter := func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Table("(?) AS ledger_lines", runningBalance)
+		if startDate != nil {
+			tx = tx.Where("created_at >= ?", *startDate)
+		}
+		if endDate != nil {
+			tx = tx.Where("created_at <= ?", *endDate)
+		}
+		return tx
+	}
+
+	var lines []entity.LedgerLine
+	if err := db.Scopes(filter).Preload("Entry").Order("created_at ASC, id ASC").Offset((page - 1) * size).Limit(size).Find(&lines).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := db.Scopes(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return lines, total, nil
+}
+
+// GetMonthlyPointsMovements sums the lines of a points account per month in WIB. Earned points come from drop
+// completions, redeemed points are the ones held for redemptions less the ones released back, plus the ones
+// redeemed straight from the user without a hold, as migrated requests are.
+func (r *LedgerEntryRepository) GetMonthlyPointsMovements(db *gorm.DB, accountID uuid.UUID, startMonth string, endMonth string) ([]model.CustomerStatementPointsRow, error) {
+	var rows []model.CustomerStatementPointsRow
+	startMonth, startDate, endMonth, endDate := dashboardMonthParams(startMonth, endMonth)
+	err := db.Raw(`
+		SELECT
+			TO_CHAR(ledger_lines.created_at AT TIME ZONE 'Asia/Jakarta', 'YYYY-MM') AS month,
+			COALESCE(SUM(ledger_lines.amount) FILTER (WHERE e.entry_type = 'drop_completion' AND ledger_lines.direction = 'credit'), 0) AS earned,
+			COALESCE(SUM(CASE
+				WHEN e.entry_type = 'point_redemption_hold' AND ledger_lines.direction = 'debit' THEN ledger_lines.amount
+				WHEN e.entry_type = 'point_redemption_release' AND ledger_lines.direction = 'credit' THEN -ledger_lines.amount
+				WHEN e.entry_type = 'point_redemption' AND ledger_lines.direction = 'debit' THEN ledger_lines.amount
+				ELSE 0 END), 0) AS redeemed,
+			`+ledgerBalanceExpression+` AS net
+		FROM ledger_lines
+		JOIN ledger_entries e ON e.id = ledger_lines.entry_id
+		WHERE ledger_lines.account_id = ?
+		  AND (? = '' OR ledger_lines.created_at AT TIME ZONE 'Asia/Jakarta' >= ?::timestamp)
+		  AND (? = '' OR ledger_lines.created_at AT TIME ZONE 'Asia/Jakarta' <= ?::timestamp)
+		GROUP BY 1
+		ORDER BY 1
+	`, accountID, startMonth, startDate, endMonth, endDate).Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+type PickupSubscriptionItemRepository struct {
+	Repository[entity.PickupSubscriptionItem]
+	Log *logrus.Logger
+}
+
+func NewPickupSubscriptionItemRepository(log *logrus.Logger) *PickupSubscriptionItemRepository {
+	return &PickupSubscriptionItemRepository{
+		Log: log,
+	}
+}
+
+func (r *PickupSubscriptionItemRepository) DeleteBySubscriptionID(db *gorm.DB, subscriptionID string) error {
+	return db.Where("subscription_id = ?", subscriptionID).Delete(&entity.PickupSubscriptionItem{}).Error
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PickupSubscriptionOccurrenceRepository struct {
+	Repository[entity.PickupSubscriptionOccurrence]
+	Log *logrus.Logger
+}
+
+func NewPickupSubscriptionOccurrenceRepository(log *logrus.Logger) *PickupSubscriptionOccurrenceRepository {
+	return &PickupSubscriptionOccurrenceRepository{
+		Log: log,
+	}
+}
+
+// Reserve inserts the occurrence and reports whether this call owns its date
+func (r *PickupSubscriptionOccurrenceRepository) Reserve(db *gorm.DB, occurrence *entity.PickupSubscriptionOccurrence) (bool, error) {
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "date"}},
+		DoNothing: true,
+	}).Create(occurrence)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *PickupSubscriptionOccurrenceRepository) FindBySubscriptionAndDate(db *gorm.DB, occurrence *entity.PickupSubscriptionOccurrence, subscriptionID string, date string) error {
+	return db.Where("subscription_id = ? AND date = ?", subscriptionID, date).First(occurrence).Error
+}
+
+// FindFrom returns the occurrences of a subscription on or after a date
+func (r *PickupSubscriptionOccurrenceRepository) FindFrom(db *gorm.DB, subscriptionID string, fromDate string) ([]entity.PickupSubscriptionOccurrence, error) {
+	var occurrences []entity.PickupSubscriptionOccurrence
+	err := db.Where("subscription_id = ? AND date >= ?", subscriptionID, fromDate).
+		Order("date").
+		Find(&occurrences).Error
+	return occurrences, err
+}
+
+func (r *PickupSubscriptionOccurrenceRepository) UpdateResult(db *gorm.DB, occurrence *entity.PickupSubscriptionOccurrence) error {
+	return db.Model(occurrence).Updates(map[string]interface{}{
+		"status":     occurrence.Status,
+		"request_id": occurrence.RequestID,
+		"reason":     occurrence.Reason,
+	}).Error
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PickupSubscriptionRepository struct {
+	Repository[entity.PickupSubscription]
+	Log *logrus.Logger
+}
+
+func NewPickupSubscriptionRepository(log *logrus.Logger) *PickupSubscriptionRepository {
+	return &PickupSubscriptionRepository{
+		Log: log,
+	}
+}
+
+func (r *PickupSubscriptionRepository) FindByIdWithItems(db *gorm.DB, subscription *entity.PickupSubscription, id string) error {
+	return db.Where("id = ?", id).
+		Preload("Items").
+		Preload("Items.WasteType").
+		First(subscription).Error
+}
+
+// FindByIdForUpdate locks the subscription while its status or occurrences change
+func (r *PickupSubscriptionRepository) FindByIdForUpdate(db *gorm.DB, subscription *entity.PickupSubscription, id string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(subscription).Error
+}
+
+// FindActive returns the active subscriptions with their items, for the scheduler
+func (r *PickupSubscriptionRepository) FindActive(db *gorm.DB) ([]entity.PickupSubscription, error) {
+	var subscriptions []entity.PickupSubscription
+	err := db.Where("status = ?", "active").
+		Preload("Items").
+		Order("created_at").
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *PickupSubscriptionRepository) Search(db *gorm.DB, request *model.SearchPickupSubscriptionRequest) ([]entity.PickupSubscription, int64, error) {
+	var subscriptions []entity.PickupSubscription
+	if err := db.Scopes(r.FilterPickupSubscription(request)).
+		Preload("Items").
+		Preload("Items.WasteType").
+		Order("created_at DESC").
+		Offset((request.Page - 1) * request.Size).Limit(request.Size).
+		Find(&subscriptions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := db.Model(&entity.PickupSubscription{}).Scopes(r.FilterPickupSubscription(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return subscriptions, total, nil
+}
+
+func (r *PickupSubscriptionRepository) FilterPickupSubscription(request *model.SearchPickupSubscriptionRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if customerID := request.CustomerID; customerID != "" {
+			tx = tx.Where("customer_id = ?", customerID)
+		}
+		if wasteBankID := request.WasteBankID; wasteBankID != "" {
+			tx = tx.Where("waste_bank_id = ?", wasteBankID)
+		}
+		if status := request.Status; status != "" {
+			tx = tx.Where("status = ?", status)
+		}
+		return tx
+	}
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type PointConversionRateRepository struct {
+	Repository[entity.PointConversionRate]
+	Log *logrus.Logger
+}
+
+func NewPointConversionRateRepository(log *logrus.Logger) *PointConversionRateRepository {
+	return &PointConversionRateRepository{
+		Log: log,
+	}
+}
+
+// FindEffective returns the latest active rate of the waste bank at the given time,
+// falling back to the default rate when the bank has none.
+func (r *PointConversionRateRepository) FindEffective(db *gorm.DB, rate *entity.PointConversionRate, wasteBankID string, at time.Time) error {
+	return db.Where("is_active = ? AND effective_from <= ?", true, at).
+		Where("waste_bank_id = ? OR waste_bank_id IS NULL", wasteBankID).
+		Order("waste_bank_id IS NULL, effective_from DESC").
+		First(rate).Error
+}
+
+func (r *PointConversionRateRepository) Search(db *gorm.DB, request *model.SearchPointConversionRateRequest) ([]entity.PointConversionRate, int64, error) {
+	var rates []entity.PointConversionRate
+	if err := db.Scopes(r.FilterPointConversionRate(request)).Order("effective_from DESC").Offset((request.Page - 1) * request.Size).Limit(request.Size).Find(&rates).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := db.Model(&entity.PointConversionRate{}).Scopes(r.FilterPointConversionRate(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return rates, total, nil
+}
+
+func (r *PointConversionRateRepository) FilterPointConversionRate(request *model.SearchPointConversionRateRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if wasteBankID := request.WasteBankID; wasteBankID != "" {
+			if wasteBankID == "default" {
+				tx = tx.Where("waste_bank_id IS NULL")
+			} else {
+				tx = tx.Where("waste_bank_id = ?", wasteBankID)
+			}
+		}
+		if isActive := request.IsActive; isActive != nil {
+			tx = tx.Where("is_active = ?", *isActive)
+		}
+		return tx
+	}
+}
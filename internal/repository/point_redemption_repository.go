@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PointRedemptionRepository struct {
+	Repository[entity.PointRedemption]
+	Log *logrus.Logger
+}
+
+func NewPointRedemptionRepository(log *logrus.Logger) *PointRedemptionRepository {
+	return &PointRedemptionRepository{
+		Log: log,
+	}
+}
+
+// FindByIdForUpdate locks the row so concurrent approve/reject calls are serialized
+func (r *PointRedemptionRepository) FindByIdForUpdate(db *gorm.DB, redemption *entity.PointRedemption, id string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(redemption).Error
+}
+
+func (r *PointRedemptionRepository) FindByIdWithRelations(db *gorm.DB, redemption *entity.PointRedemption, id string) error {
+	return db.Preload("User").Preload("WasteBank").Where("id = ?", id).First(redemption).Error
+}
+
+// SumPointsSince totals the points a user has pending or redeemed since the given time
+func (r *PointRedemptionRepository) SumPointsSince(db *gorm.DB, userID string, since time.Time) (int64, error) {
+	var total int64
+	err := db.Model(&entity.PointRedemption{}).
+		Select("COALESCE(SUM(points), 0)").
+		Where("user_id = ? AND status IN ? AND created_at >= ? AND is_deleted = ?", userID, []string{"pending", "approved"}, since, false).
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *PointRedemptionRepository) Search(db *gorm.DB, request *model.SearchPointRedemptionRequest) ([]entity.PointRedemption, int64, error) {
+	var redemptions []entity.PointRedemption
+	if err := db.Scopes(r.FilterPointRedemption(request), r.OrderPointRedemption(request)).Offset((request.Page - 1) * request.Size).Limit(request.Size).Find(&redemptions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := db.Model(&entity.PointRedemption{}).Scopes(r.FilterPointRedemption(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return redemptions, total, nil
+}
+
+func (r *PointRedemptionRepository) FilterPointRedemption(request *model.SearchPointRedemptionRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if userID := request.UserID; userID != "" {
+			tx = tx.Where("user_id = ?", userID)
+		}
+		if wasteBankID := request.WasteBankID; wasteBankID != "" {
+			tx = tx.Where("waste_bank_id = ?", wasteBankID)
+		}
+		if status := request.Status; status != "" {
+			tx = tx.Where("status = ?", status)
+		}
+		if isDeleted := request.IsDeleted; isDeleted != nil {
+			tx = tx.Where("is_deleted = ?", *isDeleted)
+		}
+		return tx
+	}
+}
+
+func (r *PointRedemptionRepository) OrderPointRedemption(request *model.SearchPointRedemptionRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		orderBy := request.OrderBy
+		orderDir := request.OrderDir
+
+		// Set defaults if not provided
+		if orderBy == "" {
+			orderBy = "created_at"
+		}
+		if orderDir != "asc" && orderDir != "desc" {
+			orderDir = "desc"
+		}
+
+		// Validate order by column (whitelist approach for security)
+		validColumns := map[string]bool{
+			"created_at":    true,
+			"updated_at":    true,
+			"decided_at":    true,
+			"points":        true,
+			"rupiah_amount": true,
+			"status":        true,
+		}
+
+		if !validColumns[orderBy] {
+			orderBy = "created_at"
+		}
+
+		return tx.Order(orderBy + " " + orderDir)
+	}
+}
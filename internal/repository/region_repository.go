@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type RegionRepository struct {
+	Repository[entity.Region]
+	Log *logrus.Logger
+}
+
+func NewRegionRepository(log *logrus.Logger) *RegionRepository {
+	return &RegionRepository{
+		Log: log,
+	}
+}
+
+// regionCodeFilter matches a region code column against a region of any level and every region inside it.
+// It takes the code and the code followed by ".%" as arguments.
+func regionCodeFilter(column string) string {
+	return fmt.Sprintf("(%s = ? OR %s LIKE ?)", column, column)
+}
+
+// dashboardMonthParams returns each month with its first or last moment, as raw dashboard queries take them,
+// or empty strings for a missing or malformed month
+func dashboardMonthParams(start string, end string) (string, string, string, string) {
+	var startDate, endDate string
+	if parsed, err := time.Parse("2006-01", start); err == nil {
+		startDate = parsed.Format("2006-01-02 15:04:05")
+	} else {
+		start = ""
+	}
+	if parsed, err := time.Parse("2006-01", end); err == nil {
+		endDate = parsed.AddDate(0, 1, -1).Add(23*time.Hour + 59*time.Minute + 59*time.Second).Format("2006-01-02 15:04:05")
+	} else {
+		end = ""
+	}
+	return start, startDate, end, endDate
+}
+
+// regionByPointSQL selects the most specific region whose boundary covers a geography expression
+func regionByPointSQL(point string) string {
+	return fmt.Sprintf(`(SELECT rg.code FROM regions rg
+		WHERE rg.boundary IS NOT NULL AND ST_Covers(rg.boundary, %s)
+		ORDER BY rg.level DESC
+		LIMIT 1)`, point)
+}
+
+// regionByNameSQL selects the regency matching a free text city inside the province matching a free text
+// province, or the province alone, by name or alias. "Surabaya" matches "Kota Surabaya".
+func regionByNameSQL(province string, city string) string {
+	return fmt.Sprintf(`(SELECT COALESCE(
+			(SELECT c.code FROM regions c
+				LEFT JOIN region_aliases ca ON ca.region_code = c.code
+				WHERE c.parent_code = p.code
+					AND (LOWER(c.name) IN (LOWER(TRIM(%[2]s)), 'kota ' || LOWER(TRIM(%[2]s)), 'kabupaten ' || LOWER(TRIM(%[2]s)))
+						OR ca.alias = LOWER(TRIM(%[2]s)))
+				ORDER BY c.code
+				LIMIT 1),
+			p.code)
+		FROM (SELECT r.code FROM regions r
+			LEFT JOIN region_aliases a ON a.region_code = r.code
+			WHERE r.level = 'province' AND (LOWER(r.name) = LOWER(TRIM(%[1]s)) OR a.alias = LOWER(TRIM(%[1]s)))
+			ORDER BY r.code
+			LIMIT 1) p)`, province, city)
+}
+
+func (r *RegionRepository) FindByCode(db *gorm.DB, region *entity.Region, code string) error {
+	return db.Omit("boundary").Where("code = ?", code).Take(region).Error
+}
+
+// CountWithBoundary returns how many regions have a boundary, the backfills place nothing by location without them
+func (r *RegionRepository) CountWithBoundary(db *gorm.DB) (int64, error) {
+	var total int64
+	err := db.Model(&entity.Region{}).Where("boundary IS NOT NULL").Count(&total).Error
+	return total, err
+}
+
+// ResolveCode finds the region of a point by boundary, falling back to the free text province and city
+func (r *RegionRepository) ResolveCode(db *gorm.DB, lat *float64, lng *float64, province string, city string) (*string, error) {
+	var code *string
+	if lat != nil && lng != nil {
+		point := fmt.Sprintf("ST_SetSRID(ST_MakePoint(%f, %f), 4326)::geography", *lng, *lat)
+		if err := db.Raw("SELECT " + regionByPointSQL(point)).Scan(&code).Error; err != nil {
+			return nil, err
+		}
+		if code != nil {
+			return code, nil
+		}
+	}
+	if province == "" {
+		return nil, nil
+	}
+	err := db.Raw("SELECT "+regionByNameSQL("?", "?"), city, city, city, city, province, province).Scan(&code).Error
+	return code, err
+}
+
+// BackfillUsers sets the region of users from their location, then from their province and city.
+// Users that already have a region are only updated when overwrite is set.
+func (r *RegionRepository) BackfillUsers(db *gorm.DB, overwrite bool) (int64, error) {
+	byPoint := db.Exec(`UPDATE users SET region_code = `+regionByPointSQL("users.location")+`
+		WHERE users.location IS NOT NULL AND (? OR users.region_code IS NULL)
+			AND `+regionByPointSQL("users.location")+` IS NOT NULL`, overwrite)
+	if byPoint.Error != nil {
+		return 0, byPoint.Error
+	}
+
+	// Users placed by location above are skipped, their region is more precise than the address
+	byName := db.Exec(`UPDATE users SET region_code = `+regionByNameSQL("users.province", "users.city")+`
+		WHERE (users.location IS NULL OR `+regionByPointSQL("users.location")+` IS NULL)
+			AND (? OR users.region_code IS NULL)
+			AND `+regionByNameSQL("users.province", "users.city")+` IS NOT NULL`, overwrite)
+	if byName.Error != nil {
+		return 0, byName.Error
+	}
+	return byPoint.RowsAffected + byName.RowsAffected, nil
+}
+
+// BackfillWasteDropRequests sets the region of drop requests from their appointment location, or the customer's region
+func (r *RegionRepository) BackfillWasteDropRequests(db *gorm.DB, overwrite bool) (int64, error) {
+	result := db.Exec(`UPDATE waste_drop_requests wdr SET region_code = COALESCE(
+			CASE WHEN wdr.appointment_location IS NOT NULL THEN `+regionByPointSQL("wdr.appointment_location")+` END,
+			(SELECT u.region_code FROM users u WHERE u.id = wdr.customer_id))
+		WHERE (? OR wdr.region_code IS NULL)`, overwrite)
+	return result.RowsAffected, result.Error
+}
+
+// BackfillWasteTransferRequests sets the region of transfer requests from their appointment location, or the source user's region
+func (r *RegionRepository) BackfillWasteTransferRequests(db *gorm.DB, overwrite bool) (int64, error) {
+	result := db.Exec(`UPDATE waste_transfer_requests wtr SET region_code = COALESCE(
+			CASE WHEN wtr.appointment_location IS NOT NULL THEN `+regionByPointSQL("wtr.appointment_location")+` END,
+			(SELECT u.region_code FROM users u WHERE u.id = wtr.source_user_id))
+		WHERE (? OR wtr.region_code IS NULL)`, overwrite)
+	return result.RowsAffected, result.Error
+}
+
+func (r *RegionRepository) Search(db *gorm.DB, request *model.SearchRegionRequest) ([]entity.Region, int64, error) {
+	var regions []entity.Region
+	if err := db.Omit("boundary").Scopes(r.FilterRegion(request)).
+		Order("code").
+		Offset((request.Page - 1) * request.Size).Limit(request.Size).
+		Find(&regions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := db.Model(&entity.Region{}).Scopes(r.FilterRegion(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return regions, total, nil
+}
+
+func (r *RegionRepository) FilterRegion(request *model.SearchRegionRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if level := request.Level; level != "" {
+			tx = tx.Where("level = ?", level)
+		}
+		if parentCode := request.ParentCode; parentCode != "" {
+			tx = tx.Where("parent_code = ?", parentCode)
+		}
+		if name := request.Name; name != "" {
+			tx = tx.Where("name ILIKE ? OR code IN (SELECT region_code FROM region_aliases WHERE alias = LOWER(?))", "%"+name+"%", name)
+		}
+		return tx
+	}
+}
+
+// GetDashboardBreakdown aggregates the government dashboard per region of a level, below parentCode when set.
+// Weights come from the daily rollups like the rest of the dashboard. Records are assigned to the region whose
+// code is the first depth segments of their own region code.
+func (r *RegionRepository) GetDashboardBreakdown(db *gorm.DB, request *model.RegionBreakdownRequest, level string, depth int, withGeometry bool) ([]model.RegionDashboard, error) {
+	var results []model.RegionDashboard
+
+	startMonth, startDate, endMonth, endDate := dashboardMonthParams(request.StartMonth, request.EndMonth)
+	prefix := func(column string) string {
+		return fmt.Sprintf("array_to_string((string_to_array(%s, '.'))[1:%d], '.')", column, depth)
+	}
+
+	query := db.Raw(`
+		WITH flows AS (
+			SELECT `+prefix("dwr.region_code")+` AS code,
+				SUM(dwr.weight) FILTER (WHERE dwr.flow = 'drop') AS collected,
+				SUM(dwr.weight) FILTER (WHERE dwr.flow = 'industry_transfer') AS offtaken
+			FROM daily_waste_rollups dwr
+			WHERE dwr.flow IN ('drop', 'industry_transfer')
+			  AND dwr.region_code IS NOT NULL
+			  AND (? = '' OR dwr.day >= ?::date)
+			  AND (? = '' OR dwr.day <= ?::date)
+			GROUP BY 1
+		), members AS (
+			SELECT `+prefix("u.region_code")+` AS code,
+				COUNT(*) FILTER (WHERE u.role IN ('waste_bank_unit', 'waste_bank_central')) AS banks,
+				COUNT(*) FILTER (WHERE u.role = 'industry') AS offtakers
+			FROM users u
+			WHERE u.region_code IS NOT NULL
+			  AND (? = '' OR u.created_at <= ?::timestamp)
+			GROUP BY 1
+		)
+		SELECT
+			rg.code,
+			rg.name,
+			rg.level,
+			COALESCE(f.collected, 0) AS collected_weight,
+			COALESCE(f.offtaken, 0) AS offtaken_weight,
+			COALESCE(m.banks, 0) AS total_bank_sampah,
+			COALESCE(m.offtakers, 0) AS total_offtaker,
+			CASE WHEN ? AND rg.boundary IS NOT NULL THEN ST_AsGeoJSON(rg.boundary, 6) END AS geometry
+		FROM regions rg
+		LEFT JOIN flows f ON f.code = rg.code
+		LEFT JOIN members m ON m.code = rg.code
+		WHERE rg.level = ?
+		  AND (? = '' OR rg.parent_code = ?)
+		ORDER BY rg.code
+	`,
+		startMonth, startDate,
+		endMonth, endDate,
+		endMonth, endDate,
+		withGeometry,
+		level,
+		request.ParentCode, request.ParentCode)
+
+	if err := query.Scan(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to get region breakdown: %v", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+	"gorm.io/gorm"
+)
+
+type ServiceAreaRepository struct {
+	Repository[entity.ServiceArea]
+	Log *logrus.Logger
+}
+
+func NewServiceAreaRepository(log *logrus.Logger) *ServiceAreaRepository {
+	return &ServiceAreaRepository{
+		Log: log,
+	}
+}
+
+// serviceAreaFilter matches users whose active service areas cover a point. Users without any
+// service area are matched by the fallback condition instead, usually a radius around their location.
+func serviceAreaFilter(userIDColumn string, lng float64, lat float64, fallback string) string {
+	return fmt.Sprintf(`(CASE
+			WHEN EXISTS (SELECT 1 FROM service_areas sa WHERE sa.user_id = %s AND sa.is_active = TRUE) THEN
+				EXISTS (SELECT 1 FROM service_areas sa WHERE sa.user_id = %s AND sa.is_active = TRUE
+					AND ST_Covers(sa.area, ST_SetSRID(ST_MakePoint(%f, %f), 4326)::geography))
+			ELSE %s
+		END)`, userIDColumn, userIDColumn, lng, lat, fallback)
+}
+
+// Covers reports whether a user has active service areas and whether any of them covers the point
+func (r *ServiceAreaRepository) Covers(db *gorm.DB, userID string, lat float64, lng float64) (bool, bool, error) {
+	var result struct {
+		HasAreas bool
+		Covered  bool
+	}
+	err := db.Raw(`SELECT COUNT(*) > 0 AS has_areas,
+			COALESCE(BOOL_OR(ST_Covers(area, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography)), FALSE) AS covered
+		FROM service_areas
+		WHERE user_id = ? AND is_active = TRUE`, lng, lat, userID).
+		Scan(&result).Error
+	return result.HasAreas, result.Covered, err
+}
+
+func (r *ServiceAreaRepository) Search(db *gorm.DB, request *model.SearchServiceAreaRequest) ([]entity.ServiceArea, int64, error) {
+	var serviceAreas []entity.ServiceArea
+	if err := db.Scopes(r.FilterServiceArea(request)).
+		Order("created_at DESC").
+		Offset((request.Page - 1) * request.Size).Limit(request.Size).
+		Find(&serviceAreas).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := db.Model(&entity.ServiceArea{}).Scopes(r.FilterServiceArea(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return serviceAreas, total, nil
+}
+
+func (r *ServiceAreaRepository) FilterServiceArea(request *model.SearchServiceAreaRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if userID := request.UserID; userID != "" {
+			tx = tx.Where("user_id = ?", userID)
+		}
+		if request.IsActive != nil {
+			tx = tx.Where("is_active = ?", *request.IsActive)
+		}
+		return tx
+	}
+}
+
+// IsValid reports whether a polygon is a valid simple area, without self intersections
+func (r *ServiceAreaRepository) IsValid(db *gorm.DB, area types.Polygon) (bool, error) {
+	var valid bool
+	err := db.Raw("SELECT ST_IsValid(?::geometry)", area).Scan(&valid).Error
+	return valid, err
+}
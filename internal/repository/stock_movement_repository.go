@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+type StockMovementRepository struct {
+	Repository[entity.StockMovement]
+	Log *logrus.Logger
+}
+
+func NewStockMovementRepository(log *logrus.Logger) *StockMovementRepository {
+	return &StockMovementRepository{
+		Log: log,
+	}
+}
+
+// Record appends a movement for a storage item whose weight was just changed by weightKgs
+func (r *StockMovementRepository) Record(db *gorm.DB, item *entity.StorageItem, movementType string, weightKgs float64, referenceType string, referenceID *uuid.UUID, actorID *uuid.UUID, notes string) error {
+	return db.Create(&entity.StockMovement{
+		StorageID:     item.StorageID,
+		WasteTypeID:   item.WasteTypeID,
+		MovementType:  movementType,
+		WeightKgs:     weightKgs,
+		BalanceKgs:    item.WeightKgs,
+		ReferenceType: referenceType,
+		ReferenceID:   referenceID,
+		ActorID:       actorID,
+		Notes:         notes,
+	}).Error
+}
+
+// FindByStorageAndWasteType returns the movements of a waste type in a storage, newest first
+func (r *StockMovementRepository) FindByStorageAndWasteType(db *gorm.DB, storageID uuid.UUID, wasteTypeID uuid.UUID, page int, size int) ([]entity.StockMovement, int64, error) {
+	query := db.Model(&entity.StockMovement{}).Where("storage_id = ? AND waste_type_id = ?", storageID, wasteTypeID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var movements []entity.StockMovement
+	if err := query.Order("created_at DESC, id DESC").Offset((page - 1) * size).Limit(size).Find(&movements).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return movements, total, nil
+}
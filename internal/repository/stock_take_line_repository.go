@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+type StockTakeLineRepository struct {
+	Repository[entity.StockTakeLine]
+	Log *logrus.Logger
+}
+
+func NewStockTakeLineRepository(log *logrus.Logger) *StockTakeLineRepository {
+	return &StockTakeLineRepository{
+		Log: log,
+	}
+}
+
+func (r *StockTakeLineRepository) FindByStockTake(db *gorm.DB, stockTakeID string) ([]entity.StockTakeLine, error) {
+	var lines []entity.StockTakeLine
+	err := db.Where("stock_take_id = ?", stockTakeID).Find(&lines).Error
+	return lines, err
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockTakeRepository struct {
+	Repository[entity.StockTake]
+	Log *logrus.Logger
+}
+
+func NewStockTakeRepository(log *logrus.Logger) *StockTakeRepository {
+	return &StockTakeRepository{
+		Log: log,
+	}
+}
+
+// FindByIdForUpdate locks the stock take so counting and posting are serialized
+func (r *StockTakeRepository) FindByIdForUpdate(db *gorm.DB, stockTake *entity.StockTake, id string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Preload("Storage").Take(stockTake).Error
+}
+
+func (r *StockTakeRepository) FindByIdWithLines(db *gorm.DB, stockTake *entity.StockTake, id string) error {
+	return db.Where("id = ?", id).
+		Preload("Storage").
+		Preload("Lines", func(tx *gorm.DB) *gorm.DB {
+			return tx.Select("stock_take_lines.*").Joins("JOIN waste_types ON waste_types.id = stock_take_lines.waste_type_id").Order("waste_types.name")
+		}).
+		Preload("Lines.WasteType").
+		First(stockTake).Error
+}
+
+// HasOpen reports whether the storage already has a count in progress
+func (r *StockTakeRepository) HasOpen(db *gorm.DB, storageID string) (bool, error) {
+	var total int64
+	err := db.Model(&entity.StockTake{}).Where("storage_id = ? AND status = ?", storageID, "open").Count(&total).Error
+	return total > 0, err
+}
+
+func (r *StockTakeRepository) Search(db *gorm.DB, request *model.SearchStockTakeRequest) ([]entity.StockTake, int64, error) {
+	var stockTakes []entity.StockTake
+	if err := db.Scopes(r.FilterStockTake(request)).Order("opened_at DESC").Offset((request.Page - 1) * request.Size).Limit(request.Size).Find(&stockTakes).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := db.Model(&entity.StockTake{}).Scopes(r.FilterStockTake(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return stockTakes, total, nil
+}
+
+func (r *StockTakeRepository) FilterStockTake(request *model.SearchStockTakeRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if storageID := request.StorageID; storageID != "" {
+			tx = tx.Where("storage_id = ?", storageID)
+		}
+		if status := request.Status; status != "" {
+			tx = tx.Where("status = ?", status)
+		}
+		if ownerID := request.OwnerID; ownerID != "" {
+			tx = tx.Where("storage_id IN (SELECT id FROM storage WHERE user_id = ?)", ownerID)
+		}
+		return tx
+	}
+}
+
+// postedLinesSQL selects the counted lines of posted stock takes with their shrinkage, system minus counted weight.
+// Lines posted without a reason were gains and are reported as other.
+const postedLinesSQL = `
+	SELECT
+		s.user_id AS waste_bank_id,
+		l.stock_take_id,
+		l.waste_type_id,
+		l.system_weight_kgs,
+		l.counted_weight_kgs,
+		l.system_weight_kgs - l.counted_weight_kgs AS shrinkage_kgs,
+		COALESCE(l.shrinkage_reason::text, 'other') AS reason
+	FROM stock_take_lines l
+	JOIN stock_takes st ON st.id = l.stock_take_id
+	JOIN storage s ON s.id = st.storage_id
+	WHERE st.status = 'posted'
+	  AND l.counted_weight_kgs IS NOT NULL
+	  AND (? = '' OR s.user_id::text = ?)
+	  AND (? = '' OR st.posted_at >= ?::timestamp)
+	  AND (? = '' OR st.posted_at <= ?::timestamp)`
+
+// GetShrinkageByBank totals the posted stock takes per waste bank, an empty waste bank ID reports every bank
+func (r *StockTakeRepository) GetShrinkageByBank(db *gorm.DB, wasteBankID string, startMonth string, endMonth string) ([]model.StockTakeShrinkageBank, error) {
+	var banks []model.StockTakeShrinkageBank
+	startMonth, startDate, endMonth, endDate := dashboardMonthParams(startMonth, endMonth)
+	err := db.Raw(`
+		SELECT
+			l.waste_bank_id::text AS waste_bank_id,
+			COALESCE(NULLIF(u.institution, ''), u.username, '') AS waste_bank_name,
+			COUNT(DISTINCT l.stock_take_id) AS stock_takes,
+			SUM(l.system_weight_kgs) AS system_weight_kgs,
+			SUM(l.counted_weight_kgs) AS counted_weight_kgs,
+			SUM(l.shrinkage_kgs) AS shrinkage_kgs,
+			COALESCE(SUM(l.shrinkage_kgs) / NULLIF(SUM(l.system_weight_kgs), 0) * 100, 0) AS shrinkage_percent
+		FROM (`+postedLinesSQL+`) l
+		LEFT JOIN users u ON u.id = l.waste_bank_id
+		GROUP BY l.waste_bank_id, u.institution, u.username
+		ORDER BY shrinkage_percent DESC
+	`, wasteBankID, wasteBankID, startMonth, startDate, endMonth, endDate).Scan(&banks).Error
+	return banks, err
+}
+
+// GetShrinkageByMaterial splits the shrinkage of each bank by waste type and reason
+func (r *StockTakeRepository) GetShrinkageByMaterial(db *gorm.DB, wasteBankID string, startMonth string, endMonth string) ([]model.StockTakeShrinkageByMaterial, error) {
+	var materials []model.StockTakeShrinkageByMaterial
+	startMonth, startDate, endMonth, endDate := dashboardMonthParams(startMonth, endMonth)
+	err := db.Raw(`
+		SELECT
+			l.waste_bank_id::text AS waste_bank_id,
+			COALESCE(NULLIF(u.institution, ''), u.username, '') AS waste_bank_name,
+			l.waste_type_id::text AS waste_type_id,
+			COALESCE(t.name, '') AS waste_type_name,
+			COUNT(DISTINCT l.stock_take_id) AS stock_takes,
+			SUM(l.system_weight_kgs) AS system_weight_kgs,
+			SUM(l.counted_weight_kgs) AS counted_weight_kgs,
+			SUM(l.shrinkage_kgs) AS shrinkage_kgs,
+			COALESCE(SUM(l.shrinkage_kgs) / NULLIF(SUM(l.system_weight_kgs), 0) * 100, 0) AS shrinkage_percent,
+			COALESCE(SUM(l.shrinkage_kgs) FILTER (WHERE l.reason = 'moisture_loss'), 0) AS moisture_loss_kgs,
+			COALESCE(SUM(l.shrinkage_kgs) FILTER (WHERE l.reason = 'theft'), 0) AS theft_kgs,
+			COALESCE(SUM(l.shrinkage_kgs) FILTER (WHERE l.reason = 'sorting_error'), 0) AS sorting_error_kgs,
+			COALESCE(SUM(l.shrinkage_kgs) FILTER (WHERE l.reason = 'other'), 0) AS other_kgs
+		FROM (`+postedLinesSQL+`) l
+		JOIN waste_types t ON t.id = l.waste_type_id
+		LEFT JOIN users u ON u.id = l.waste_bank_id
+		GROUP BY l.waste_bank_id, u.institution, u.username, l.waste_type_id, t.name
+		ORDER BY waste_bank_name, shrinkage_percent DESC
+	`, wasteBankID, wasteBankID, startMonth, startDate, endMonth, endDate).Scan(&materials).Error
+	return materials, err
+}
@@ -1,10 +1,12 @@
 package repository
 
 import (
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/wastetrack/wastetrack-backend/internal/entity"
 	"github.com/wastetrack/wastetrack-backend/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type StorageItemRepository struct {
@@ -26,6 +28,44 @@ func (r *StorageItemRepository) FindById(db *gorm.DB, item *entity.StorageItem,
 		First(item).Error
 }
 
+// FindByIdForUpdate is FindById with the item locked until the transaction ends
+func (r *StorageItemRepository) FindByIdForUpdate(db *gorm.DB, item *entity.StorageItem, id string) error {
+	return r.FindById(db.Clauses(clause.Locking{Strength: "UPDATE"}), item, id)
+}
+
+// FindByStorageAndWasteTypeForUpdate locks the item holding a waste type in a storage until the transaction ends
+func (r *StorageItemRepository) FindByStorageAndWasteTypeForUpdate(db *gorm.DB, item *entity.StorageItem, storageID, wasteTypeID uuid.UUID) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("storage_id = ? AND waste_type_id = ?", storageID, wasteTypeID).
+		First(item).Error
+}
+
+// FindByStorage returns the items of a storage keyed by waste type
+func (r *StorageItemRepository) FindByStorage(db *gorm.DB, storageID uuid.UUID) (map[uuid.UUID]*entity.StorageItem, error) {
+	var items []entity.StorageItem
+	if err := db.Where("storage_id = ?", storageID).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	byWasteType := make(map[uuid.UUID]*entity.StorageItem, len(items))
+	for i := range items {
+		byWasteType[items[i].WasteTypeID] = &items[i]
+	}
+	return byWasteType, nil
+}
+
+// FindByStorageForUpdate is FindByStorage with the items locked until the transaction ends
+func (r *StorageItemRepository) FindByStorageForUpdate(db *gorm.DB, storageID uuid.UUID) (map[uuid.UUID]*entity.StorageItem, error) {
+	return r.FindByStorage(db.Clauses(clause.Locking{Strength: "UPDATE"}), storageID)
+}
+
+// FindInStockWithWasteType returns the items of a storage holding stock, with their waste types
+func (r *StorageItemRepository) FindInStockWithWasteType(db *gorm.DB, storageID uuid.UUID) ([]entity.StorageItem, error) {
+	var items []entity.StorageItem
+	err := db.Where("storage_id = ? AND weight_kgs > 0", storageID).Preload("WasteType").Find(&items).Error
+	return items, err
+}
+
 func (r *StorageItemRepository) Search(db *gorm.DB, request *model.SearchStorageItemRequest) ([]entity.StorageItem, int64, error) {
 	var items []entity.StorageItem
 
@@ -66,3 +106,27 @@ func (r *StorageItemRepository) FilterStorageItem(request *model.SearchStorageIt
 		return tx
 	}
 }
+
+// GetStockValue sums the stock of a user's storages per waste type, valued at the user's current price for the type
+func (r *StorageItemRepository) GetStockValue(db *gorm.DB, userID string) ([]model.WasteBankStockItem, error) {
+	var items []model.WasteBankStockItem
+	err := db.Raw(`
+		SELECT
+			si.waste_type_id::text AS waste_type_id,
+			COALESCE(t.name, '') AS waste_type_name,
+			SUM(si.weight_kgs) AS weight_kgs,
+			p.custom_price_per_kgs AS price_per_kgs,
+			COALESCE(FLOOR(SUM(si.weight_kgs) * p.custom_price_per_kgs), 0) AS value
+		FROM storage_items si
+		JOIN storage s ON s.id = si.storage_id
+		JOIN waste_types t ON t.id = si.waste_type_id
+		LEFT JOIN waste_bank_priced_types p ON p.waste_bank_id = s.user_id AND p.waste_type_id = si.waste_type_id
+		WHERE s.user_id = ?
+		  AND s.is_deleted = FALSE
+		  AND si.is_deleted = FALSE
+		GROUP BY si.waste_type_id, t.name, p.custom_price_per_kgs
+		HAVING SUM(si.weight_kgs) > 0
+		ORDER BY value DESC, weight_kgs DESC
+	`, userID).Scan(&items).Error
+	return items, err
+}
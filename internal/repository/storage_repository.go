@@ -3,6 +3,7 @@ package repository
 import (
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/wastetrack/wastetrack-backend/internal/entity"
 	"github.com/wastetrack/wastetrack-backend/internal/model"
@@ -87,11 +88,13 @@ func (r *StorageRepository) GetWasteBankStorageVolumes(db *gorm.DB, request *mod
 		  AND u.role IN ('waste_bank_unit', 'waste_bank_central')
 		  AND ($1 = '' OR u.province ILIKE $2)
 		  AND ($3 = '' OR u.city ILIKE $4)
+		  AND ($5 = '' OR u.region_code = $5 OR u.region_code LIKE $6)
 		GROUP BY s.user_id
 		HAVING SUM(s.length * s.width * s.height) > 0
 	`,
 		request.Province, "%"+request.Province+"%",
-		request.City, "%"+request.City+"%")
+		request.City, "%"+request.City+"%",
+		request.RegionCode, request.RegionCode+".%")
 
 	if err := query.Scan(&results).Error; err != nil {
 		return nil, fmt.Errorf("failed to get storage volumes: %v", err)
@@ -105,3 +108,51 @@ func (r *StorageRepository) GetWasteBankStorageVolumes(db *gorm.DB, request *mod
 
 	return volumes, nil
 }
+
+// storageUtilizationSQL estimates the volume used in each storage, types without a bulk density use the default
+const storageUtilizationSQL = `
+	SELECT
+		s.id::text AS storage_id,
+		s.user_id::text AS user_id,
+		COALESCE(s.length * s.width * s.height, 0) AS capacity_m3,
+		COALESCE(SUM(si.weight_kgs / COALESCE(t.bulk_density_kgs_per_m3, ?)), 0) AS used_volume_m3,
+		s.capacity_alert_percent
+	FROM storage s
+	LEFT JOIN storage_items si ON si.storage_id = s.id AND si.is_deleted = FALSE AND si.weight_kgs > 0
+	LEFT JOIN waste_types t ON t.id = si.waste_type_id
+	WHERE s.is_deleted = FALSE
+	  AND (? = '' OR s.user_id::text = ?)
+	GROUP BY s.id`
+
+// SearchUtilization lists storages fullest first, optionally only the ones at or above their alert threshold
+func (r *StorageRepository) SearchUtilization(db *gorm.DB, request *model.SearchStorageUtilizationRequest, defaultDensity float64) ([]model.StorageUtilizationRow, int64, error) {
+	filter := `
+		FROM (` + storageUtilizationSQL + `) u
+		WHERE (? = FALSE OR (u.capacity_m3 > 0 AND u.used_volume_m3 / u.capacity_m3 * 100 >= u.capacity_alert_percent))`
+	params := []interface{}{defaultDensity, request.UserID, request.UserID, request.AboveAlert}
+
+	var total int64
+	if err := db.Raw(`SELECT COUNT(*) `+filter, params...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []model.StorageUtilizationRow
+	err := db.Raw(`SELECT u.* `+filter+`
+		ORDER BY CASE WHEN u.capacity_m3 > 0 THEN u.used_volume_m3 / u.capacity_m3 END DESC NULLS LAST, u.storage_id
+		LIMIT ? OFFSET ?`, append(params, request.Size, (request.Page-1)*request.Size)...).Scan(&rows).Error
+	return rows, total, err
+}
+
+// GetUsedVolume estimates the volume currently used in a storage
+func (r *StorageRepository) GetUsedVolume(db *gorm.DB, storageID uuid.UUID, defaultDensity float64) (float64, error) {
+	var used float64
+	err := db.Raw(`
+		SELECT COALESCE(SUM(si.weight_kgs / COALESCE(t.bulk_density_kgs_per_m3, ?)), 0)
+		FROM storage_items si
+		JOIN waste_types t ON t.id = si.waste_type_id
+		WHERE si.storage_id = ?
+		  AND si.is_deleted = FALSE
+		  AND si.weight_kgs > 0
+	`, defaultDensity, storageID).Scan(&used).Error
+	return used, err
+}
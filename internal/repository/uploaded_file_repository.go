@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+type UploadedFileRepository struct {
+	Repository[entity.UploadedFile]
+	Log *logrus.Logger
+}
+
+func NewUploadedFileRepository(log *logrus.Logger) *UploadedFileRepository {
+	return &UploadedFileRepository{
+		Log: log,
+	}
+}
+
+// FindByOwnerURL finds the file an owner points at through its URL
+func (r *UploadedFileRepository) FindByOwnerURL(db *gorm.DB, file *entity.UploadedFile, ownerType string, ownerID string, url string) error {
+	return db.Where("owner_type = ? AND owner_id = ? AND url = ?", ownerType, ownerID, url).First(file).Error
+}
+
+// FindOrphans returns files whose owner was deleted or no longer points at them.
+// Files newer than the grace period are skipped since their owner may still be updating.
+func (r *UploadedFileRepository) FindOrphans(db *gorm.DB, createdBefore time.Time, limit int) ([]entity.UploadedFile, error) {
+	var files []entity.UploadedFile
+	err := db.Where("created_at < ?", createdBefore).
+		Where(`(owner_type = 'waste_drop_request' AND NOT EXISTS (
+				SELECT 1 FROM waste_drop_requests r WHERE r.id = uploaded_files.owner_id AND r.is_deleted = FALSE AND r.image_url = uploaded_files.url))
+			OR (owner_type = 'waste_transfer_request' AND NOT EXISTS (
+				SELECT 1 FROM waste_transfer_requests r WHERE r.id = uploaded_files.owner_id AND r.is_deleted = FALSE AND r.image_url = uploaded_files.url))
+			OR (owner_type = 'user_avatar' AND NOT EXISTS (
+				SELECT 1 FROM users u WHERE u.id = uploaded_files.owner_id AND u.avatar_url = uploaded_files.url))`).
+		Order("created_at").
+		Limit(limit).
+		Find(&files).Error
+	return files, err
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -71,11 +72,11 @@ func (r *UserRepository) Search(db *gorm.DB, request *model.SearchUserRequest) (
 			radiusMeters = *request.RadiusMeters
 		}
 
-		// Add distance filter for specified radius
-		distanceFilter := fmt.Sprintf(`(location IS NULL OR ST_Distance(
+		// Users with service areas must cover the point, the rest are filtered by the radius
+		distanceFilter := serviceAreaFilter("users.id", *request.Longitude, *request.Latitude, fmt.Sprintf(`(location IS NULL OR ST_Distance(
 			location, 
 			ST_SetSRID(ST_MakePoint(%f, %f), 4326)
-		) <= %d)`, *request.Longitude, *request.Latitude, radiusMeters)
+		) <= %d)`, *request.Longitude, *request.Latitude, radiusMeters))
 
 		query = query.Select(distanceSelect).
 			Where(distanceFilter).
@@ -100,10 +101,10 @@ func (r *UserRepository) Search(db *gorm.DB, request *model.SearchUserRequest) (
 			radiusMeters = *request.RadiusMeters
 		}
 
-		distanceFilter := fmt.Sprintf(`(location IS NULL OR ST_Distance(
+		distanceFilter := serviceAreaFilter("users.id", *request.Longitude, *request.Latitude, fmt.Sprintf(`(location IS NULL OR ST_Distance(
 			location, 
 			ST_SetSRID(ST_MakePoint(%f, %f), 4326)
-		) <= %d)`, *request.Longitude, *request.Latitude, radiusMeters)
+		) <= %d)`, *request.Longitude, *request.Latitude, radiusMeters))
 		countQuery = countQuery.Where(distanceFilter)
 	}
 
@@ -195,6 +196,9 @@ func (r *UserRepository) FilterUser(request *model.SearchUserRequest) func(tx *g
 		if province := request.Province; province != "" {
 			tx = tx.Where("province ILIKE ?", "%"+province+"%")
 		}
+		if regionCode := request.RegionCode; regionCode != "" {
+			tx = tx.Where(regionCodeFilter("region_code"), regionCode, regionCode+".%")
+		}
 		// Fixed: Now properly handle the boolean filter
 		if request.IsAcceptingCustomer != nil {
 			tx = tx.Where("is_accepting_customer = ?", *request.IsAcceptingCustomer)
@@ -219,11 +223,11 @@ func (r *UserRepository) FindByIDWithDistance(db *gorm.DB, user *entity.User, id
 			END as distance`,
 			*lng, *lat)
 
-		// Also apply 10km radius filter for single user lookup
-		distanceFilter := fmt.Sprintf(`(location IS NULL OR ST_Distance(
+		// Also apply the service areas or 10km radius filter for single user lookup
+		distanceFilter := serviceAreaFilter("users.id", *lng, *lat, fmt.Sprintf(`(location IS NULL OR ST_Distance(
 			location, 
 			ST_SetSRID(ST_MakePoint(%f, %f), 4326)
-		) <= 10000)`, *lng, *lat)
+		) <= 10000)`, *lng, *lat))
 
 		query = query.Select(distanceSelect).Where(distanceFilter)
 	}
@@ -231,6 +235,22 @@ func (r *UserRepository) FindByIDWithDistance(db *gorm.DB, user *entity.User, id
 	return query.First(user).Error
 }
 
+// DistanceTo returns the distance in meters from a user's location to a point, nil when the user has no location
+func (r *UserRepository) DistanceTo(db *gorm.DB, id string, lat float64, lng float64) (*float64, error) {
+	var distance sql.NullFloat64
+	row := db.Model(&entity.User{}).
+		Select("ST_Distance(location, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography)", lng, lat).
+		Where("id = ?", id).
+		Row()
+	if err := row.Scan(&distance); err != nil {
+		return nil, err
+	}
+	if !distance.Valid {
+		return nil, nil
+	}
+	return &distance.Float64, nil
+}
+
 func (r *UserRepository) FindByEmailChangeToken(db *gorm.DB, user *entity.User, token string) error {
 	return db.Where("email_change_token = ? AND email_change_expiry > NOW()", token).First(user).Error
 }
@@ -266,6 +286,10 @@ func (r *UserRepository) CountWasteBanks(db *gorm.DB, request *model.GovernmentD
 		query = query.Where("city ILIKE ?", "%"+request.City+"%")
 	}
 
+	if request.RegionCode != "" {
+		query = query.Where(regionCodeFilter("region_code"), request.RegionCode, request.RegionCode+".%")
+	}
+
 	if err := query.Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("failed to count waste banks: %v", err)
 	}
@@ -298,6 +322,10 @@ func (r *UserRepository) CountOfftakers(db *gorm.DB, request *model.GovernmentDa
 		query = query.Where("city ILIKE ?", "%"+request.City+"%")
 	}
 
+	if request.RegionCode != "" {
+		query = query.Where(regionCodeFilter("region_code"), request.RegionCode, request.RegionCode+".%")
+	}
+
 	if err := query.Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("failed to count offtakers: %v", err)
 	}
@@ -305,29 +333,33 @@ func (r *UserRepository) CountOfftakers(db *gorm.DB, request *model.GovernmentDa
 	return count, nil
 }
 
-func (r *UserRepository) GetWasteBankUsers(db *gorm.DB, request *model.GovernmentDashboardRequest) (map[string]*entity.User, error) {
+// FindWasteBanksByIDs loads the waste bank users among ids, keyed by ID
+func (r *UserRepository) FindWasteBanksByIDs(db *gorm.DB, ids []string) (map[string]*entity.User, error) {
 	var users []entity.User
-
-	query := db.Model(&entity.User{}).
-		Where("role IN ? ", []string{"waste_bank_unit", "waste_bank_central"})
-
-	// Apply location filters
-	if request.Province != "" {
-		query = query.Where("province ILIKE ?", "%"+request.Province+"%")
-	}
-	if request.City != "" {
-		query = query.Where("city ILIKE ?", "%"+request.City+"%")
-	}
-
-	if err := query.Find(&users).Error; err != nil {
-		return nil, fmt.Errorf("failed to get waste bank users: %v", err)
+	if len(ids) > 0 {
+		if err := db.Where("id IN ? AND role IN ?", ids, []string{"waste_bank_unit", "waste_bank_central"}).Find(&users).Error; err != nil {
+			return nil, err
+		}
 	}
 
-	// Convert to map for easy lookup
 	userMap := make(map[string]*entity.User)
 	for i := range users {
 		userMap[users[i].ID.String()] = &users[i]
 	}
-
 	return userMap, nil
 }
+
+// UpdateCachedBalances overwrites the cached points/balance columns, which are derived from the ledger
+func (r *UserRepository) UpdateCachedBalances(db *gorm.DB, userID uuid.UUID, columns map[string]interface{}) error {
+	return db.Model(&entity.User{}).Where("id = ?", userID).UpdateColumns(columns).Error
+}
+
+// UpdateAvatar points the user at an uploaded avatar
+func (r *UserRepository) UpdateAvatar(db *gorm.DB, id string, avatarURL string, thumbnailURL string) error {
+	return db.Model(&entity.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"avatar_url":           avatarURL,
+			"avatar_thumbnail_url": thumbnailURL,
+		}).Error
+}
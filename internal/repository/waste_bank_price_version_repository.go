@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type WasteBankPriceVersionRepository struct {
+	Repository[entity.WasteBankPriceVersion]
+	Log *logrus.Logger
+}
+
+func NewWasteBankPriceVersionRepository(log *logrus.Logger) *WasteBankPriceVersionRepository {
+	return &WasteBankPriceVersionRepository{
+		Log: log,
+	}
+}
+
+// FindEffective returns the version of a bank's price for a waste type that is in effect at the given time
+func (r *WasteBankPriceVersionRepository) FindEffective(db *gorm.DB, version *entity.WasteBankPriceVersion, wasteBankID string, wasteTypeID string, at time.Time) error {
+	return db.Where("waste_bank_id = ? AND waste_type_id = ?", wasteBankID, wasteTypeID).
+		Where("effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", at, at).
+		Order("effective_from DESC").
+		First(version).Error
+}
+
+// FindEffectiveByBanksAndTypes returns the versions in effect at the given time for a set of waste banks and
+// waste types, oldest first so the latest of any overlapping versions comes last like in FindEffective
+func (r *WasteBankPriceVersionRepository) FindEffectiveByBanksAndTypes(db *gorm.DB, wasteBankIDs []string, wasteTypeIDs []string, at time.Time) ([]entity.WasteBankPriceVersion, error) {
+	var versions []entity.WasteBankPriceVersion
+	err := db.Where("waste_bank_id IN ? AND waste_type_id IN ?", wasteBankIDs, wasteTypeIDs).
+		Where("effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", at, at).
+		Order("effective_from ASC").
+		Find(&versions).Error
+	return versions, err
+}
+
+// FindNextAfter returns the first version scheduled to start after the given time
+func (r *WasteBankPriceVersionRepository) FindNextAfter(db *gorm.DB, version *entity.WasteBankPriceVersion, wasteBankID string, wasteTypeID string, after time.Time) error {
+	return db.Where("waste_bank_id = ? AND waste_type_id = ? AND effective_from > ?", wasteBankID, wasteTypeID, after).
+		Order("effective_from ASC").
+		First(version).Error
+}
+
+// CloseOpenVersions ends the running versions of a priced type and removes the scheduled ones
+func (r *WasteBankPriceVersionRepository) CloseOpenVersions(db *gorm.DB, pricedTypeID string, at time.Time) error {
+	if err := db.Where("priced_type_id = ? AND effective_from > ?", pricedTypeID, at).
+		Delete(&entity.WasteBankPriceVersion{}).Error; err != nil {
+		return err
+	}
+	return db.Model(&entity.WasteBankPriceVersion{}).
+		Where("priced_type_id = ? AND (effective_to IS NULL OR effective_to > ?)", pricedTypeID, at).
+		Update("effective_to", at).Error
+}
+
+// SyncCurrentPrices copies the version in effect now into waste_bank_priced_types.custom_price_per_kgs,
+// so scheduled changes show up once they start. It returns the number of prices changed.
+func (r *WasteBankPriceVersionRepository) SyncCurrentPrices(db *gorm.DB) (int64, error) {
+	result := db.Exec(`
+		UPDATE waste_bank_priced_types p
+		SET custom_price_per_kgs = v.price_per_kgs, updated_at = NOW()
+		FROM waste_bank_price_versions v
+		WHERE v.priced_type_id = p.id
+		  AND v.effective_from <= NOW()
+		  AND (v.effective_to IS NULL OR v.effective_to > NOW())
+		  AND p.custom_price_per_kgs IS DISTINCT FROM v.price_per_kgs`)
+	return result.RowsAffected, result.Error
+}
+
+func (r *WasteBankPriceVersionRepository) Search(db *gorm.DB, request *model.SearchWasteBankPriceHistoryRequest, at *time.Time) ([]entity.WasteBankPriceVersion, int64, error) {
+	var versions []entity.WasteBankPriceVersion
+	if err := db.Scopes(r.FilterWasteBankPriceVersion(request, at)).Preload("WasteType").
+		Order("effective_from DESC").
+		Offset((request.Page - 1) * request.Size).Limit(request.Size).
+		Find(&versions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := db.Model(&entity.WasteBankPriceVersion{}).Scopes(r.FilterWasteBankPriceVersion(request, at)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return versions, total, nil
+}
+
+func (r *WasteBankPriceVersionRepository) FilterWasteBankPriceVersion(request *model.SearchWasteBankPriceHistoryRequest, at *time.Time) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where("waste_bank_id = ?", request.WasteBankID)
+		if wasteTypeID := request.WasteTypeID; wasteTypeID != "" {
+			tx = tx.Where("waste_type_id = ?", wasteTypeID)
+		}
+		if at != nil {
+			tx = tx.Where("effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", *at, *at)
+		}
+		return tx
+	}
+}
@@ -29,6 +29,21 @@ func (r *WasteBankPricedTypeRepository) ExistsByBankAndType(db *gorm.DB, wasteBa
 	return count > 0, nil
 }
 
+// FindByWasteBankAndType finds the current price a waste bank pays for a waste type
+func (r *WasteBankPricedTypeRepository) FindByWasteBankAndType(db *gorm.DB, wpt *entity.WasteBankPricedType, wasteBankID string, wasteTypeID string) error {
+	return db.Where("waste_bank_id = ? AND waste_type_id = ?", wasteBankID, wasteTypeID).
+		Take(wpt).
+		Error
+}
+
+// FindByWasteBanksAndTypes returns the prices a set of waste banks pays for a set of waste types
+func (r *WasteBankPricedTypeRepository) FindByWasteBanksAndTypes(db *gorm.DB, wasteBankIDs []string, wasteTypeIDs []string) ([]entity.WasteBankPricedType, error) {
+	var result []entity.WasteBankPricedType
+	err := db.Where("waste_bank_id IN ? AND waste_type_id IN ?", wasteBankIDs, wasteTypeIDs).
+		Find(&result).Error
+	return result, err
+}
+
 func (r *WasteBankPricedTypeRepository) FindById(db *gorm.DB, wpt *entity.WasteBankPricedType, id string) error {
 	return db.
 		Preload("WasteBank").
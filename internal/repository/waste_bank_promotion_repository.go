@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type WasteBankPromotionRepository struct {
+	Repository[entity.WasteBankPromotion]
+	Log *logrus.Logger
+}
+
+func NewWasteBankPromotionRepository(log *logrus.Logger) *WasteBankPromotionRepository {
+	return &WasteBankPromotionRepository{
+		Log: log,
+	}
+}
+
+// FindBestActive finds the running promotion with the highest bonus for a waste type, including bank-wide ones
+func (r *WasteBankPromotionRepository) FindBestActive(db *gorm.DB, promotion *entity.WasteBankPromotion, wasteBankID string, wasteTypeID string, at time.Time) error {
+	return db.Where("waste_bank_id = ? AND is_active = ?", wasteBankID, true).
+		Where("waste_type_id = ? OR waste_type_id IS NULL", wasteTypeID).
+		Where("starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", at, at).
+		Order("bonus_percent DESC, created_at").
+		First(promotion).Error
+}
+
+func (r *WasteBankPromotionRepository) Search(db *gorm.DB, request *model.SearchWasteBankPromotionRequest) ([]entity.WasteBankPromotion, int64, error) {
+	var promotions []entity.WasteBankPromotion
+	if err := db.Scopes(r.FilterWasteBankPromotion(request)).
+		Preload("WasteType").
+		Order("starts_at DESC").
+		Offset((request.Page - 1) * request.Size).Limit(request.Size).
+		Find(&promotions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := db.Model(&entity.WasteBankPromotion{}).Scopes(r.FilterWasteBankPromotion(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return promotions, total, nil
+}
+
+func (r *WasteBankPromotionRepository) FilterWasteBankPromotion(request *model.SearchWasteBankPromotionRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if wasteBankID := request.WasteBankID; wasteBankID != "" {
+			tx = tx.Where("waste_bank_id = ?", wasteBankID)
+		}
+		if wasteTypeID := request.WasteTypeID; wasteTypeID != "" {
+			tx = tx.Where("waste_type_id = ? OR waste_type_id IS NULL", wasteTypeID)
+		}
+		if request.OnlyRunning {
+			now := time.Now()
+			tx = tx.Where("is_active = ? AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", true, now, now)
+		}
+		return tx
+	}
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"fmt"
+
 	"github.com/sirupsen/logrus"
 	"github.com/wastetrack/wastetrack-backend/internal/entity"
 	"gorm.io/gorm"
@@ -23,3 +25,21 @@ func (r *WasteBankRepository) FindByUserID(db *gorm.DB, profile *entity.WasteBan
 func (r *WasteBankRepository) FindByUserIDNoPreload(db *gorm.DB, profile *entity.WasteBankProfile, userID string) error {
 	return db.Where("user_id = ?", userID).First(profile).Error
 }
+
+// FindNearby returns the waste banks whose service areas cover a point, or without service areas
+// the ones with a location within radiusMeters of it, nearest first
+func (r *WasteBankRepository) FindNearby(db *gorm.DB, lat float64, lng float64, radiusMeters int, limit int) ([]entity.WasteBankProfile, error) {
+	var profiles []entity.WasteBankProfile
+	point := "ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography"
+	withinRadius := fmt.Sprintf("(users.location IS NOT NULL AND ST_DWithin(users.location, ST_SetSRID(ST_MakePoint(%f, %f), 4326)::geography, %d))", lng, lat, radiusMeters)
+	err := db.Model(&entity.WasteBankProfile{}).
+		Select("waste_bank_profiles.*, ST_Distance(users.location, "+point+") AS distance", lng, lat).
+		Joins("JOIN users ON users.id = waste_bank_profiles.user_id").
+		Where("users.role IN ?", []string{"waste_bank_unit", "waste_bank_central"}).
+		Where(serviceAreaFilter("users.id", lng, lat, withinRadius)).
+		Preload("User").
+		Order("distance").
+		Limit(limit).
+		Find(&profiles).Error
+	return profiles, err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type WasteBankScheduleExceptionRepository struct {
+	Repository[entity.WasteBankScheduleException]
+	Log *logrus.Logger
+}
+
+func NewWasteBankScheduleExceptionRepository(log *logrus.Logger) *WasteBankScheduleExceptionRepository {
+	return &WasteBankScheduleExceptionRepository{
+		Log: log,
+	}
+}
+
+func (r *WasteBankScheduleExceptionRepository) CountByBankAndDate(db *gorm.DB, wasteBankID string, date string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.WasteBankScheduleException{}).
+		Where("waste_bank_id = ? AND date = ?", wasteBankID, date).
+		Count(&total).Error
+	return total, err
+}
+
+// FindByBankAndRange returns the exceptions of a waste bank between two dates, inclusive
+func (r *WasteBankScheduleExceptionRepository) FindByBankAndRange(db *gorm.DB, wasteBankID string, startDate string, endDate string) ([]entity.WasteBankScheduleException, error) {
+	var exceptions []entity.WasteBankScheduleException
+	err := db.Where("waste_bank_id = ? AND date BETWEEN ? AND ?", wasteBankID, startDate, endDate).
+		Order("date").
+		Find(&exceptions).Error
+	return exceptions, err
+}
+
+// FindByBanksAndDate returns the exceptions of several waste banks on one date
+func (r *WasteBankScheduleExceptionRepository) FindByBanksAndDate(db *gorm.DB, wasteBankIDs []string, date string) ([]entity.WasteBankScheduleException, error) {
+	var exceptions []entity.WasteBankScheduleException
+	err := db.Where("waste_bank_id IN ? AND date = ?", wasteBankIDs, date).
+		Find(&exceptions).Error
+	return exceptions, err
+}
+
+func (r *WasteBankScheduleExceptionRepository) Search(db *gorm.DB, request *model.SearchScheduleExceptionRequest) ([]entity.WasteBankScheduleException, int64, error) {
+	var exceptions []entity.WasteBankScheduleException
+	if err := db.Scopes(r.FilterScheduleException(request)).
+		Order("date").
+		Offset((request.Page - 1) * request.Size).Limit(request.Size).
+		Find(&exceptions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := db.Model(&entity.WasteBankScheduleException{}).Scopes(r.FilterScheduleException(request)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return exceptions, total, nil
+}
+
+func (r *WasteBankScheduleExceptionRepository) FilterScheduleException(request *model.SearchScheduleExceptionRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if wasteBankID := request.WasteBankID; wasteBankID != "" {
+			tx = tx.Where("waste_bank_id = ?", wasteBankID)
+		}
+		if startDate := request.StartDate; startDate != "" {
+			tx = tx.Where("date >= ?", startDate)
+		}
+		if endDate := request.EndDate; endDate != "" {
+			tx = tx.Where("date <= ?", endDate)
+		}
+		return tx
+	}
+}
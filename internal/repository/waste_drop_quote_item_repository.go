@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+)
+
+type WasteDropQuoteItemRepository struct {
+	Repository[entity.WasteDropQuoteItem]
+	Log *logrus.Logger
+}
+
+func NewWasteDropQuoteItemRepository(log *logrus.Logger) *WasteDropQuoteItemRepository {
+	return &WasteDropQuoteItemRepository{
+		Log: log,
+	}
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WasteDropQuoteRepository struct {
+	Repository[entity.WasteDropQuote]
+	Log *logrus.Logger
+}
+
+func NewWasteDropQuoteRepository(log *logrus.Logger) *WasteDropQuoteRepository {
+	return &WasteDropQuoteRepository{
+		Log: log,
+	}
+}
+
+func (r *WasteDropQuoteRepository) FindByIdWithItems(db *gorm.DB, quote *entity.WasteDropQuote, id string) error {
+	return db.Where("id = ?", id).
+		Preload("Items").
+		Preload("Items.WasteType").
+		First(quote).Error
+}
+
+// FindByIdForUpdate locks the quote so it is not booked twice concurrently
+func (r *WasteDropQuoteRepository) FindByIdForUpdate(db *gorm.DB, quote *entity.WasteDropQuote, id string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Preload("Items").First(quote).Error
+}
+
+// MarkUsed links the quote to the drop request booked from it
+func (r *WasteDropQuoteRepository) MarkUsed(db *gorm.DB, id string, requestID string) error {
+	return db.Model(&entity.WasteDropQuote{}).
+		Where("id = ?", id).
+		Update("request_id", requestID).Error
+}
+
+// DistanceTo returns the distance in meters from the quoted appointment location to a point, nil when the quote has no location
+func (r *WasteDropQuoteRepository) DistanceTo(db *gorm.DB, id string, lat float64, lng float64) (*float64, error) {
+	var distance sql.NullFloat64
+	row := db.Model(&entity.WasteDropQuote{}).
+		Select("ST_Distance(appointment_location, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography)", lng, lat).
+		Where("id = ?", id).
+		Row()
+	if err := row.Scan(&distance); err != nil {
+		return nil, err
+	}
+	if !distance.Valid {
+		return nil, nil
+	}
+	return &distance.Float64, nil
+}
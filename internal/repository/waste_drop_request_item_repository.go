@@ -105,7 +105,7 @@ func (r *WasteDropRequestItemRepository) GetTotalCollectedWaste(db *gorm.DB, req
 	}
 
 	// Apply location filters if provided
-	if request.Province != "" || request.City != "" {
+	if request.Province != "" || request.City != "" || request.RegionCode != "" {
 		// Join with users through waste_drop_requests to filter by location
 		query = query.Joins("JOIN users ON waste_drop_requests.customer_id = users.id")
 
@@ -116,6 +116,10 @@ func (r *WasteDropRequestItemRepository) GetTotalCollectedWaste(db *gorm.DB, req
 		if request.City != "" {
 			query = query.Where("users.city ILIKE ?", "%"+request.City+"%")
 		}
+
+		if request.RegionCode != "" {
+			query = query.Where(regionCodeFilter("users.region_code"), request.RegionCode, request.RegionCode+".%")
+		}
 	}
 
 	// Execute the query
@@ -159,13 +163,16 @@ func (r *WasteDropRequestItemRepository) GetCollectionTrendsByRole(db *gorm.DB,
 	}
 
 	// Apply location filters for waste drop if provided
-	if request.Province != "" || request.City != "" {
+	if request.Province != "" || request.City != "" || request.RegionCode != "" {
 		if request.Province != "" {
 			wasteDropQuery = wasteDropQuery.Where("waste_banks.province ILIKE ?", "%"+request.Province+"%")
 		}
 		if request.City != "" {
 			wasteDropQuery = wasteDropQuery.Where("waste_banks.city ILIKE ?", "%"+request.City+"%")
 		}
+		if request.RegionCode != "" {
+			wasteDropQuery = wasteDropQuery.Where(regionCodeFilter("waste_banks.region_code"), request.RegionCode, request.RegionCode+".%")
+		}
 	}
 
 	// Execute waste drop query
@@ -225,7 +232,7 @@ func (r *WasteDropRequestItemRepository) GetWasteDropVerifiedWeightByMonth(db *g
 	}
 
 	// Apply location filters if provided (filter by waste bank location)
-	if request.Province != "" || request.City != "" {
+	if request.Province != "" || request.City != "" || request.RegionCode != "" {
 		query = query.Joins("JOIN users AS waste_banks ON waste_drop_requests.waste_bank_id = waste_banks.id")
 
 		if request.Province != "" {
@@ -234,6 +241,9 @@ func (r *WasteDropRequestItemRepository) GetWasteDropVerifiedWeightByMonth(db *g
 		if request.City != "" {
 			query = query.Where("waste_banks.city ILIKE ?", "%"+request.City+"%")
 		}
+		if request.RegionCode != "" {
+			query = query.Where(regionCodeFilter("waste_banks.region_code"), request.RegionCode, request.RegionCode+".%")
+		}
 	}
 
 	// Execute query
@@ -282,12 +292,14 @@ func (r *WasteDropRequestItemRepository) GetWasteBankWasteDropTotals(db *gorm.DB
 		  AND (? = '' OR wdr.created_at <= ?::timestamp)
 		  AND (? = '' OR u.province ILIKE ?)
 		  AND (? = '' OR u.city ILIKE ?)
+		  AND (? = '' OR u.region_code = ? OR u.region_code LIKE ?)
 		GROUP BY wdr.waste_bank_id
 	`,
 		startMonth, startDate,
 		endMonth, endDate,
 		request.Province, "%"+request.Province+"%",
-		request.City, "%"+request.City+"%")
+		request.City, "%"+request.City+"%",
+		request.RegionCode, request.RegionCode, request.RegionCode+".%")
 
 	if err := query.Scan(&results).Error; err != nil {
 		return nil, fmt.Errorf("failed to get waste drop totals: %v", err)
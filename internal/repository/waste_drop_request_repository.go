@@ -7,6 +7,7 @@ import (
 	"github.com/wastetrack/wastetrack-backend/internal/entity"
 	"github.com/wastetrack/wastetrack-backend/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type WasteDropRequestRepository struct {
@@ -92,6 +93,9 @@ func (r *WasteDropRequestRepository) FilterWasteDropRequest(request *model.Searc
 		if status := request.Status; status != "" {
 			tx = tx.Where("status = ?", status)
 		}
+		if reasonCode := request.CancellationReason; reasonCode != "" {
+			tx = tx.Where("cancellation_reason_code = ?", reasonCode)
+		}
 		if appointmentDate := request.AppointmentDate; appointmentDate != "" {
 			tx = tx.Where("appointment_date = ?", appointmentDate)
 		}
@@ -140,3 +144,312 @@ func (r *WasteDropRequestRepository) AssignCollector(db *gorm.DB, id string, col
 		Where("id = ?", id).
 		Update("assigned_collector_id", collectorID).Error
 }
+
+// UpdateImage points the request at an uploaded photo
+func (r *WasteDropRequestRepository) UpdateImage(db *gorm.DB, id string, imageURL string, thumbnailURL string) error {
+	return db.Model(&entity.WasteDropRequest{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"image_url":           imageURL,
+			"image_thumbnail_url": thumbnailURL,
+		}).Error
+}
+
+// FindByIdForUpdate locks the request so it is not assigned twice concurrently
+func (r *WasteDropRequestRepository) FindByIdForUpdate(db *gorm.DB, wasteDropRequest *entity.WasteDropRequest, id string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(wasteDropRequest).Error
+}
+
+// slotBookingScope matches the requests holding a place in a waste bank's slots.
+// Appointment times are compared as local clock times, like the bank's opening hours.
+func slotBookingScope(wasteBankID string) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("waste_bank_id = ? AND is_deleted = ? AND status <> ?", wasteBankID, false, "cancelled").
+			Where("appointment_start_time IS NOT NULL")
+	}
+}
+
+// CountSlotBookings counts booked, not waitlisted, requests of a delivery type starting within [startTime, endTime)
+func (r *WasteDropRequestRepository) CountSlotBookings(db *gorm.DB, wasteBankID string, date string, deliveryType string, startTime string, endTime string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.WasteDropRequest{}).
+		Scopes(slotBookingScope(wasteBankID)).
+		Where("appointment_date = ? AND delivery_type = ? AND is_waitlisted = ?", date, deliveryType, false).
+		Where("appointment_start_time::time >= ?::time AND appointment_start_time::time < ?::time", startTime, endTime).
+		Count(&total).Error
+	return total, err
+}
+
+// FindSlotBookings counts booked requests per start time, used to fill in slot availability
+func (r *WasteDropRequestRepository) FindSlotBookings(db *gorm.DB, wasteBankID string, startDate string, endDate string) ([]entity.SlotBookingCount, error) {
+	var counts []entity.SlotBookingCount
+	err := db.Model(&entity.WasteDropRequest{}).
+		Select("appointment_date, appointment_start_time::time AS start_time, delivery_type, COUNT(*) AS total").
+		Scopes(slotBookingScope(wasteBankID)).
+		Where("appointment_date BETWEEN ? AND ? AND is_waitlisted = ?", startDate, endDate, false).
+		Group("appointment_date, appointment_start_time::time, delivery_type").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// CountOpenBookingsOutside counts open requests, waitlisted ones included, booked on a date outside [openTime, closeTime).
+// Empty times count every open request of the date, as when the waste bank closes for the day.
+func (r *WasteDropRequestRepository) CountOpenBookingsOutside(db *gorm.DB, wasteBankID string, date string, openTime string, closeTime string) (int64, error) {
+	var total int64
+	query := db.Model(&entity.WasteDropRequest{}).
+		Scopes(slotBookingScope(wasteBankID)).
+		Where("appointment_date = ? AND status IN ?", date, []string{"pending", "assigned", "collecting"})
+	if openTime != "" && closeTime != "" {
+		query = query.Where("(appointment_start_time::time < ?::time OR appointment_start_time::time >= ?::time)", openTime, closeTime)
+	}
+	err := query.Count(&total).Error
+	return total, err
+}
+
+// FindSlotBookingsByBanks counts booked requests per waste bank and start time on one date
+func (r *WasteDropRequestRepository) FindSlotBookingsByBanks(db *gorm.DB, wasteBankIDs []string, date string) ([]entity.SlotBookingCount, error) {
+	var counts []entity.SlotBookingCount
+	err := db.Model(&entity.WasteDropRequest{}).
+		Select("waste_bank_id, appointment_date, appointment_start_time::time AS start_time, delivery_type, COUNT(*) AS total").
+		Where("waste_bank_id IN ? AND is_deleted = ? AND status <> ?", wasteBankIDs, false, "cancelled").
+		Where("appointment_start_time IS NOT NULL").
+		Where("appointment_date = ? AND is_waitlisted = ?", date, false).
+		Group("waste_bank_id, appointment_date, appointment_start_time::time, delivery_type").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// FindFirstWaitlisted returns the oldest waitlisted request of a delivery type starting within [startTime, endTime)
+func (r *WasteDropRequestRepository) FindFirstWaitlisted(db *gorm.DB, wasteDropRequest *entity.WasteDropRequest, wasteBankID string, date string, deliveryType string, startTime string, endTime string) error {
+	return db.Scopes(slotBookingScope(wasteBankID)).
+		Where("appointment_date = ? AND delivery_type = ? AND is_waitlisted = ?", date, deliveryType, true).
+		Where("appointment_start_time::time >= ?::time AND appointment_start_time::time < ?::time", startTime, endTime).
+		Order("created_at").
+		First(wasteDropRequest).Error
+}
+
+// FindBySubscriptionAndDate finds the request a subscription generated for a date, if any
+func (r *WasteDropRequestRepository) FindBySubscriptionAndDate(db *gorm.DB, wasteDropRequest *entity.WasteDropRequest, subscriptionID string, date string) error {
+	return db.Where("subscription_id = ? AND appointment_date = ? AND is_deleted = ?", subscriptionID, date, false).
+		Order("created_at").
+		First(wasteDropRequest).Error
+}
+
+// FindExpiredPending returns pending requests left unattended past their waste bank's expiry window. The
+// window starts at creation or on the appointment date, whichever is later, so early bookings are not expired.
+func (r *WasteDropRequestRepository) FindExpiredPending(db *gorm.DB, defaultHours int, limit int) ([]string, error) {
+	var ids []string
+	err := db.Model(&entity.WasteDropRequest{}).
+		Joins("LEFT JOIN waste_bank_profiles wbp ON wbp.user_id = waste_drop_requests.waste_bank_id").
+		Where("waste_drop_requests.status = ? AND waste_drop_requests.is_deleted = ?", "pending", false).
+		Where(`GREATEST(waste_drop_requests.created_at, waste_drop_requests.appointment_date::timestamp AT TIME ZONE 'Asia/Jakarta')
+			+ make_interval(hours => COALESCE(wbp.pending_expiry_hours, ?::int)) < NOW()`, defaultHours).
+		Order("waste_drop_requests.created_at").
+		Limit(limit).
+		Pluck("waste_drop_requests.id", &ids).Error
+	return ids, err
+}
+
+// FindOverdueAssigned returns assigned requests whose appointment date passed before collection started
+func (r *WasteDropRequestRepository) FindOverdueAssigned(db *gorm.DB, today string, limit int) ([]string, error) {
+	var ids []string
+	err := db.Model(&entity.WasteDropRequest{}).
+		Where("status = ? AND is_deleted = ? AND appointment_date < ?", "assigned", false, today).
+		Order("appointment_date").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// GetHeatmap counts drop requests, except cancelled ones, per grid cell or geohash of their appointment location,
+// or of the customer's location for drop-offs. Grid cells are measured in Web Mercator meters, which stay close
+// to true meters at Indonesian latitudes.
+func (r *WasteDropRequestRepository) GetHeatmap(db *gorm.DB, request *model.DropHeatmapRequest, withGeometry bool) ([]model.DropHeatmapCell, error) {
+	var cells []model.DropHeatmapCell
+
+	startMonth, startDate, endMonth, endDate := dashboardMonthParams(request.StartMonth, request.EndMonth)
+
+	// cell is the grid point or geohash of each location, key, center and outline are derived from it
+	var cell, key, center, outline string
+	var cellArg interface{}
+	if request.Mode == "geohash" {
+		cell = "ST_GeoHash(p.location, ?)"
+		cellArg = request.Precision
+		key = "cell"
+		center = "ST_PointFromGeoHash(cell)"
+		outline = "ST_SetSRID(ST_Box2dFromGeoHash(cell)::geometry, 4326)"
+	} else {
+		cell = "ST_SnapToGrid(ST_Transform(p.location, 3857), ?)"
+		cellArg = request.CellSize
+		key = "ST_X(cell)::bigint || ':' || ST_Y(cell)::bigint"
+		center = "ST_Transform(cell, 4326)"
+		outline = fmt.Sprintf("ST_Transform(ST_Expand(cell, %f), 4326)", float64(request.CellSize)/2)
+	}
+
+	query := db.Raw(`
+		SELECT
+			`+key+` AS key,
+			ST_Y(`+center+`) AS latitude,
+			ST_X(`+center+`) AS longitude,
+			requests,
+			total_weight,
+			CASE WHEN ? THEN ST_AsGeoJSON(`+outline+`, 6) END AS geometry
+		FROM (
+			SELECT `+cell+` AS cell, COUNT(*) AS requests, COALESCE(SUM(p.weight), 0) AS total_weight
+			FROM (
+				SELECT
+					COALESCE(wdr.appointment_location, u.location)::geometry AS location,
+					(SELECT SUM(wdri.verified_weight) FROM waste_drop_request_items wdri
+						WHERE wdri.request_id = wdr.id AND wdri.is_deleted = false) AS weight
+				FROM waste_drop_requests wdr
+				JOIN users u ON u.id = wdr.customer_id
+				WHERE wdr.is_deleted = false
+				  AND wdr.status <> 'cancelled'
+				  AND COALESCE(wdr.appointment_location, u.location) IS NOT NULL
+				  AND (? = '' OR wdr.created_at >= ?::timestamp)
+				  AND (? = '' OR wdr.created_at <= ?::timestamp)
+				  AND (? = '' OR wdr.region_code = ? OR wdr.region_code LIKE ?)
+			) p
+			GROUP BY 1
+		) c
+		ORDER BY requests DESC, key
+	`,
+		withGeometry,
+		cellArg,
+		startMonth, startDate,
+		endMonth, endDate,
+		request.RegionCode, request.RegionCode, request.RegionCode+".%")
+
+	if err := query.Scan(&cells).Error; err != nil {
+		return nil, fmt.Errorf("failed to get drop request heatmap: %v", err)
+	}
+	return cells, nil
+}
+
+// GetTopCustomers returns the customers that brought a waste bank the most verified weight in completed requests
+func (r *WasteDropRequestRepository) GetTopCustomers(db *gorm.DB, wasteBankID string, startMonth string, endMonth string, limit int) ([]model.WasteBankTopCustomer, error) {
+	var customers []model.WasteBankTopCustomer
+	startMonth, startDate, endMonth, endDate := dashboardMonthParams(startMonth, endMonth)
+	err := db.Raw(`
+		SELECT
+			u.id::text AS id,
+			COALESCE(u.username, '') AS name,
+			COUNT(*) AS requests,
+			COALESCE(SUM(w.weight), 0) AS total_weight,
+			COALESCE(SUM(wdr.total_price), 0) AS total_payout
+		FROM waste_drop_requests wdr
+		JOIN users u ON u.id = wdr.customer_id
+		LEFT JOIN LATERAL (
+			SELECT SUM(i.verified_weight) AS weight FROM waste_drop_request_items i
+			WHERE i.request_id = wdr.id AND i.is_deleted = FALSE
+		) w ON TRUE
+		WHERE wdr.waste_bank_id = ?
+		  AND wdr.status = 'completed'
+		  AND wdr.is_deleted = FALSE
+		  AND (? = '' OR wdr.created_at >= ?::timestamp)
+		  AND (? = '' OR wdr.created_at <= ?::timestamp)
+		GROUP BY u.id, u.username
+		ORDER BY total_weight DESC, total_payout DESC
+		LIMIT ?
+	`, wasteBankID, startMonth, startDate, endMonth, endDate, limit).Scan(&customers).Error
+	return customers, err
+}
+
+// GetCollectorProductivity counts the completed and cancelled drop requests of each collector assigned by a waste bank
+func (r *WasteDropRequestRepository) GetCollectorProductivity(db *gorm.DB, wasteBankID string, startMonth string, endMonth string) ([]model.WasteBankCollectorProductivity, error) {
+	var collectors []model.WasteBankCollectorProductivity
+	startMonth, startDate, endMonth, endDate := dashboardMonthParams(startMonth, endMonth)
+	err := db.Raw(`
+		SELECT
+			u.id::text AS id,
+			COALESCE(u.username, '') AS name,
+			COUNT(*) FILTER (WHERE wdr.status = 'completed') AS completed_requests,
+			COUNT(*) FILTER (WHERE wdr.status = 'cancelled') AS cancelled_requests,
+			COALESCE(SUM(w.weight) FILTER (WHERE wdr.status = 'completed'), 0) AS total_weight
+		FROM waste_drop_requests wdr
+		JOIN users u ON u.id = wdr.assigned_collector_id
+		LEFT JOIN LATERAL (
+			SELECT SUM(i.verified_weight) AS weight FROM waste_drop_request_items i
+			WHERE i.request_id = wdr.id AND i.is_deleted = FALSE
+		) w ON TRUE
+		WHERE wdr.waste_bank_id = ?
+		  AND wdr.status IN ('completed', 'cancelled')
+		  AND wdr.is_deleted = FALSE
+		  AND (? = '' OR wdr.created_at >= ?::timestamp)
+		  AND (? = '' OR wdr.created_at <= ?::timestamp)
+		GROUP BY u.id, u.username
+		ORDER BY completed_requests DESC, total_weight DESC
+	`, wasteBankID, startMonth, startDate, endMonth, endDate).Scan(&collectors).Error
+	return collectors, err
+}
+
+// GetBacklog counts the open drop requests of a waste bank, overdue ones having an appointment before today
+func (r *WasteDropRequestRepository) GetBacklog(db *gorm.DB, wasteBankID string, today string) (*model.WasteBankBacklog, error) {
+	backlog := new(model.WasteBankBacklog)
+	err := db.Raw(`
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+			COUNT(*) FILTER (WHERE status = 'assigned') AS assigned,
+			COUNT(*) FILTER (WHERE status = 'collecting') AS collecting,
+			COUNT(*) FILTER (WHERE is_waitlisted) AS waitlisted,
+			COUNT(*) FILTER (WHERE appointment_date < ?::date) AS overdue,
+			MIN(created_at) FILTER (WHERE status = 'pending') AS oldest_pending_at
+		FROM waste_drop_requests
+		WHERE waste_bank_id = ?
+		  AND status IN ('pending', 'assigned', 'collecting')
+		  AND is_deleted = FALSE
+	`, today, wasteBankID).Scan(backlog).Error
+	return backlog, err
+}
+
+// GetCustomerStatement returns the verified weight, points and impact of the completed drops of a customer per
+// month and waste type, bucketed by the WIB month the drop was completed in.
+func (r *WasteDropRequestRepository) GetCustomerStatement(db *gorm.DB, customerID string, startMonth string, endMonth string) ([]model.CustomerStatementDropRow, error) {
+	var rows []model.CustomerStatementDropRow
+	startMonth, startDate, endMonth, endDate := dashboardMonthParams(startMonth, endMonth)
+	err := db.Raw(`
+		WITH completed AS (
+			-- Drops are credited on completion, bucketing them there keeps them level with the points ledger.
+			-- Drops completed before the status history existed fall back to their last update.
+			SELECT
+				wdr.id,
+				COALESCE(h.completed_at, wdr.updated_at) AT TIME ZONE 'Asia/Jakarta' AS completed_at
+			FROM waste_drop_requests wdr
+			LEFT JOIN LATERAL (
+				SELECT MAX(sh.created_at) AS completed_at
+				FROM waste_drop_request_status_history sh
+				WHERE sh.request_id = wdr.id AND sh.to_status = 'completed'
+			) h ON TRUE
+			WHERE wdr.customer_id = ?
+			  AND wdr.status = 'completed'
+			  AND wdr.is_deleted = FALSE
+		), items AS (
+			SELECT
+				TO_CHAR(c.completed_at, 'YYYY-MM') AS month,
+				c.id AS request_id,
+				i.waste_type_id,
+				i.verified_weight,
+				i.verified_subtotal
+			FROM completed c
+			JOIN waste_drop_request_items i ON i.request_id = c.id AND i.is_deleted = FALSE
+			WHERE (? = '' OR c.completed_at >= ?::timestamp)
+			  AND (? = '' OR c.completed_at <= ?::timestamp)
+		), months AS (
+			SELECT month, COUNT(DISTINCT request_id) AS drops FROM items GROUP BY month
+		)
+		SELECT
+			i.month,
+			i.waste_type_id::text AS waste_type_id,
+			COALESCE(t.name, '') AS waste_type_name,
+			m.drops,
+			COALESCE(SUM(i.verified_subtotal), 0) AS points,
+			`+impactSelect+`
+		FROM items i
+		JOIN months m ON m.month = i.month
+		JOIN waste_types t ON t.id = i.waste_type_id
+		`+impactFactorLateral+`
+		GROUP BY i.month, i.waste_type_id, t.name, m.drops
+		ORDER BY i.month, weight DESC
+	`, customerID, startMonth, startDate, endMonth, endDate).Scan(&rows).Error
+	return rows, err
+}
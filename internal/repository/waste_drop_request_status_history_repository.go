@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+type WasteDropRequestStatusHistoryRepository struct {
+	Repository[entity.WasteDropRequestStatusHistory]
+	Log *logrus.Logger
+}
+
+func NewWasteDropRequestStatusHistoryRepository(log *logrus.Logger) *WasteDropRequestStatusHistoryRepository {
+	return &WasteDropRequestStatusHistoryRepository{
+		Log: log,
+	}
+}
+
+func (r *WasteDropRequestStatusHistoryRepository) FindByRequestID(db *gorm.DB, requestID string) ([]entity.WasteDropRequestStatusHistory, error) {
+	var histories []entity.WasteDropRequestStatusHistory
+	if err := db.Preload("Actor").Where("request_id = ?", requestID).Order("created_at ASC").Find(&histories).Error; err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
@@ -115,7 +115,7 @@ func (r *WasteTransferItemOfferingRepository) GetTransferVerifiedWeightByMonth(d
 	}
 
 	// Apply location filters if provided (filter by destination user location)
-	if request.Province != "" || request.City != "" {
+	if request.Province != "" || request.City != "" || request.RegionCode != "" {
 		query = query.Joins("JOIN users AS destination_users ON waste_transfer_requests.destination_user_id = destination_users.id")
 
 		if request.Province != "" {
@@ -124,6 +124,9 @@ func (r *WasteTransferItemOfferingRepository) GetTransferVerifiedWeightByMonth(d
 		if request.City != "" {
 			query = query.Where("destination_users.city ILIKE ?", "%"+request.City+"%")
 		}
+		if request.RegionCode != "" {
+			query = query.Where(regionCodeFilter("destination_users.region_code"), request.RegionCode, request.RegionCode+".%")
+		}
 	}
 
 	// Execute query
@@ -181,13 +184,16 @@ func (r *WasteTransferItemOfferingRepository) GetWasteTransferTrendsByRole(db *g
 	}
 
 	// Apply location filters for waste transfer if provided
-	if request.Province != "" || request.City != "" {
+	if request.Province != "" || request.City != "" || request.RegionCode != "" {
 		if request.Province != "" {
 			wasteTransferQuery = wasteTransferQuery.Where("destination_users.province ILIKE ?", "%"+request.Province+"%")
 		}
 		if request.City != "" {
 			wasteTransferQuery = wasteTransferQuery.Where("destination_users.city ILIKE ?", "%"+request.City+"%")
 		}
+		if request.RegionCode != "" {
+			wasteTransferQuery = wasteTransferQuery.Where(regionCodeFilter("destination_users.region_code"), request.RegionCode, request.RegionCode+".%")
+		}
 	}
 
 	// Execute waste transfer query
@@ -216,3 +222,90 @@ func (r *WasteTransferItemOfferingRepository) GetWasteTransferTrendsByRole(db *g
 
 	return trends, nil
 }
+
+// industryItemsSQL selects the items of the completed transfers an industry bought in a month range.
+// It takes the industry ID followed by the four dashboardMonthParams values. Months are WIB calendar
+// months, like the purchase rollups.
+const industryItemsSQL = `
+	SELECT
+		wti.waste_type_id,
+		wtr.source_user_id,
+		DATE_TRUNC('month', wtr.created_at AT TIME ZONE 'Asia/Jakarta') AS month,
+		wti.offering_price_per_kgs,
+		COALESCE(NULLIF(wti.accepted_price_per_kgs, 0), wti.offering_price_per_kgs) AS accepted_price_per_kgs,
+		wti.verified_weight,
+		wti.recycled_weight,
+		wti.recycled_at
+	FROM waste_transfer_items wti
+	JOIN waste_transfer_requests wtr ON wtr.id = wti.transfer_request_id
+	WHERE wtr.destination_user_id = ?
+	  AND wtr.form_type = 'industry_request'
+	  AND wtr.status = 'completed'
+	  AND wtr.is_deleted = FALSE
+	  AND wti.verified_weight > 0
+	  AND (? = '' OR wtr.created_at AT TIME ZONE 'Asia/Jakarta' >= ?::timestamp)
+	  AND (? = '' OR wtr.created_at AT TIME ZONE 'Asia/Jakarta' <= ?::timestamp)`
+
+// GetIndustryPriceComparison returns the weight averaged offering and accepted prices an industry paid per waste type
+func (r *WasteTransferItemOfferingRepository) GetIndustryPriceComparison(db *gorm.DB, industryID string, startMonth string, endMonth string) ([]model.IndustryPriceComparison, error) {
+	var prices []model.IndustryPriceComparison
+	startMonth, startDate, endMonth, endDate := dashboardMonthParams(startMonth, endMonth)
+	err := db.Raw(`
+		SELECT
+			i.waste_type_id::text AS waste_type_id,
+			COALESCE(t.name, '') AS waste_type_name,
+			SUM(i.verified_weight) AS weight,
+			SUM(i.offering_price_per_kgs * i.verified_weight) / SUM(i.verified_weight) AS average_offering_price,
+			SUM(i.accepted_price_per_kgs * i.verified_weight) / SUM(i.verified_weight) AS average_accepted_price,
+			SUM((i.accepted_price_per_kgs - i.offering_price_per_kgs) * i.verified_weight) / SUM(i.verified_weight) AS price_difference
+		FROM (`+industryItemsSQL+`) i
+		JOIN waste_types t ON t.id = i.waste_type_id
+		GROUP BY i.waste_type_id, t.name
+		ORDER BY weight DESC
+	`, industryID, startMonth, startDate, endMonth, endDate).Scan(&prices).Error
+	return prices, err
+}
+
+// GetIndustryYieldByMonth returns the monthly recycling yield of an industry per waste type, over recycled items only
+func (r *WasteTransferItemOfferingRepository) GetIndustryYieldByMonth(db *gorm.DB, industryID string, startMonth string, endMonth string) ([]model.IndustryYieldByMonth, error) {
+	var yields []model.IndustryYieldByMonth
+	startMonth, startDate, endMonth, endDate := dashboardMonthParams(startMonth, endMonth)
+	err := db.Raw(`
+		SELECT
+			TO_CHAR(i.month, 'YYYY-MM') AS month,
+			i.waste_type_id::text AS waste_type_id,
+			COALESCE(t.name, '') AS waste_type_name,
+			SUM(i.verified_weight) AS verified_weight,
+			SUM(i.recycled_weight) AS recycled_weight,
+			SUM(i.recycled_weight) / SUM(i.verified_weight) AS yield
+		FROM (`+industryItemsSQL+`) i
+		JOIN waste_types t ON t.id = i.waste_type_id
+		WHERE i.recycled_at IS NOT NULL
+		GROUP BY i.month, i.waste_type_id, t.name
+		ORDER BY i.month, verified_weight DESC
+	`, industryID, startMonth, startDate, endMonth, endDate).Scan(&yields).Error
+	return yields, err
+}
+
+// GetIndustrySupplierYields returns the recycling yield of an industry per supplier and waste type, over recycled items only
+func (r *WasteTransferItemOfferingRepository) GetIndustrySupplierYields(db *gorm.DB, industryID string, startMonth string, endMonth string) ([]model.IndustrySupplierYield, error) {
+	var yields []model.IndustrySupplierYield
+	startMonth, startDate, endMonth, endDate := dashboardMonthParams(startMonth, endMonth)
+	err := db.Raw(`
+		SELECT
+			i.source_user_id::text AS supplier_id,
+			COALESCE(NULLIF(s.institution, ''), s.username, '') AS supplier_name,
+			i.waste_type_id::text AS waste_type_id,
+			COALESCE(t.name, '') AS waste_type_name,
+			SUM(i.verified_weight) AS verified_weight,
+			SUM(i.recycled_weight) AS recycled_weight,
+			SUM(i.recycled_weight) / SUM(i.verified_weight) AS yield
+		FROM (`+industryItemsSQL+`) i
+		JOIN waste_types t ON t.id = i.waste_type_id
+		LEFT JOIN users s ON s.id = i.source_user_id
+		WHERE i.recycled_at IS NOT NULL
+		GROUP BY i.source_user_id, s.institution, s.username, i.waste_type_id, t.name
+		ORDER BY t.name, yield
+	`, industryID, startMonth, startDate, endMonth, endDate).Scan(&yields).Error
+	return yields, err
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/wastetrack/wastetrack-backend/internal/entity"
 	"github.com/wastetrack/wastetrack-backend/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type WasteTransferRequestRepository struct {
@@ -124,6 +125,9 @@ func (r *WasteTransferRequestRepository) FilterWasteTransferRequest(request *mod
 		if status := request.Status; status != "" {
 			tx = tx.Where("status = ?", status)
 		}
+		if reasonCode := request.CancellationReason; reasonCode != "" {
+			tx = tx.Where("cancellation_reason_code = ?", reasonCode)
+		}
 		if appointmentDate := request.AppointmentDate; appointmentDate != "" {
 			tx = tx.Where("appointment_date = ?", appointmentDate)
 		}
@@ -150,6 +154,10 @@ func (r *WasteTransferItemOfferingRepository) UpdateVerifiedWeight(tx *gorm.DB,
 	return tx.Model(item).Select("verified_weight").Updates(item).Error
 }
 
+func (r *WasteTransferItemOfferingRepository) UpdateRecycledWeight(tx *gorm.DB, item *entity.WasteTransferItemOffering) error {
+	return tx.Model(item).Select("recycled_weight", "recycled_at").Updates(item).Error
+}
+
 // NEW: AssignCollector assigns a collector to a waste transfer request and updates status to "assigned"
 func (r *WasteTransferRequestRepository) AssignCollector(db *gorm.DB, id string, collectorID uuid.UUID) error {
 	return db.Model(&entity.WasteTransferRequest{}).
@@ -226,6 +234,9 @@ func (r *WasteTransferRequestRepository) GetTopOfftakers(db *gorm.DB, request *m
 	if request.City != "" {
 		query = query.Where("destination_users.city ILIKE ?", "%"+request.City+"%")
 	}
+	if request.RegionCode != "" {
+		query = query.Where(regionCodeFilter("destination_users.region_code"), request.RegionCode, request.RegionCode+".%")
+	}
 
 	// Execute the query directly into the result struct
 	rows, err := query.Rows()
@@ -282,12 +293,14 @@ func (r *WasteTransferRequestRepository) GetWasteBankTransferTotals(db *gorm.DB,
 		  AND (? = '' OR wtr.created_at <= ?::timestamp)
 		  AND (? = '' OR u.province ILIKE ?)
 		  AND (? = '' OR u.city ILIKE ?)
+		  AND (? = '' OR u.region_code = ? OR u.region_code LIKE ?)
 		GROUP BY wtr.destination_user_id
 	`,
 		startMonth, startDate,
 		endMonth, endDate,
 		request.Province, "%"+request.Province+"%",
-		request.City, "%"+request.City+"%")
+		request.City, "%"+request.City+"%",
+		request.RegionCode, request.RegionCode, request.RegionCode+".%")
 
 	if err := query.Scan(&results).Error; err != nil {
 		return nil, fmt.Errorf("failed to get waste transfer totals: %v", err)
@@ -326,3 +339,45 @@ func (r *WasteTransferRequestRepository) prepareEndDateParams(monthStr string) (
 
 	return "", ""
 }
+
+// UpdateImage points the request at an uploaded photo
+func (r *WasteTransferRequestRepository) UpdateImage(db *gorm.DB, id string, imageURL string, thumbnailURL string) error {
+	return db.Model(&entity.WasteTransferRequest{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"image_url":           imageURL,
+			"image_thumbnail_url": thumbnailURL,
+		}).Error
+}
+
+// FindByIdForUpdate locks the request while its status changes
+func (r *WasteTransferRequestRepository) FindByIdForUpdate(db *gorm.DB, wasteTransferRequest *entity.WasteTransferRequest, id string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(wasteTransferRequest).Error
+}
+
+// FindExpiredPending returns pending transfers left unattended past the expiry window of the waste bank
+// involved, preferring the destination's. The window starts at creation or on the appointment date.
+func (r *WasteTransferRequestRepository) FindExpiredPending(db *gorm.DB, defaultHours int, limit int) ([]string, error) {
+	var ids []string
+	err := db.Model(&entity.WasteTransferRequest{}).
+		Joins("LEFT JOIN waste_bank_profiles dst ON dst.user_id = waste_transfer_requests.destination_user_id").
+		Joins("LEFT JOIN waste_bank_profiles src ON src.user_id = waste_transfer_requests.source_user_id").
+		Where("waste_transfer_requests.status = ? AND waste_transfer_requests.is_deleted = ?", "pending", false).
+		Where(`GREATEST(waste_transfer_requests.created_at, waste_transfer_requests.appointment_date::timestamp AT TIME ZONE 'Asia/Jakarta')
+			+ make_interval(hours => COALESCE(dst.pending_expiry_hours, src.pending_expiry_hours, ?::int)) < NOW()`, defaultHours).
+		Order("waste_transfer_requests.created_at").
+		Limit(limit).
+		Pluck("waste_transfer_requests.id", &ids).Error
+	return ids, err
+}
+
+// FindOverdueAssigned returns assigned transfers whose appointment date passed before collection started
+func (r *WasteTransferRequestRepository) FindOverdueAssigned(db *gorm.DB, today string, limit int) ([]string, error) {
+	var ids []string
+	err := db.Model(&entity.WasteTransferRequest{}).
+		Where("status = ? AND is_deleted = ? AND appointment_date < ?", "assigned", false, today).
+		Order("appointment_date").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
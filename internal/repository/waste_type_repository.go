@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/wastetrack/wastetrack-backend/internal/entity"
 	"github.com/wastetrack/wastetrack-backend/internal/model"
@@ -26,6 +27,12 @@ func (r *WasteTypeRepository) FindById(db *gorm.DB, entity *entity.WasteType, id
 		Error
 }
 
+func (r *WasteTypeRepository) FindByIds(db *gorm.DB, ids []uuid.UUID) ([]entity.WasteType, error) {
+	var wasteTypes []entity.WasteType
+	err := db.Where("id IN ?", ids).Find(&wasteTypes).Error
+	return wasteTypes, err
+}
+
 func (r *WasteTypeRepository) Search(db *gorm.DB, request *model.SearchWasteTypeRequest) ([]entity.WasteType, int64, error) {
 	var wasteTypes []entity.WasteType
 	if err := db.
@@ -0,0 +1,224 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Polygon represents a geographic polygon, the first ring is the outer boundary and the rest are holes
+type Polygon struct {
+	Rings [][]Point
+}
+
+const (
+	ewkbPolygonType = 3
+	ewkbSRIDFlag    = 0x20000000
+)
+
+// Scan implements the sql.Scanner interface for reading from database
+func (p *Polygon) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Polygon", value)
+	}
+
+	rings, err := parsePolygonEWKB(strings.TrimPrefix(str, "\\x"))
+	if err != nil {
+		return err
+	}
+	p.Rings = rings
+	return nil
+}
+
+// Value implements the driver.Valuer interface for writing to database
+func (p Polygon) Value() (driver.Value, error) {
+	rings := make([]string, len(p.Rings))
+	for i, ring := range p.Rings {
+		points := make([]string, len(ring))
+		for j, point := range ring {
+			points[j] = fmt.Sprintf("%f %f", point.Lng, point.Lat)
+		}
+		rings[i] = "(" + strings.Join(points, ", ") + ")"
+	}
+	return fmt.Sprintf("SRID=4326;POLYGON(%s)", strings.Join(rings, ", ")), nil
+}
+
+// MarshalJSON writes the polygon as a GeoJSON geometry
+func (p Polygon) MarshalJSON() ([]byte, error) {
+	coordinates := make([][][2]float64, len(p.Rings))
+	for i, ring := range p.Rings {
+		coordinates[i] = make([][2]float64, len(ring))
+		for j, point := range ring {
+			coordinates[i][j] = [2]float64{point.Lng, point.Lat}
+		}
+	}
+	return json.Marshal(map[string]interface{}{
+		"type":        "Polygon",
+		"coordinates": coordinates,
+	})
+}
+
+// Validate checks that every ring has at least three distinct corners and valid coordinates,
+// closing rings that do not end on their first point
+func (p *Polygon) Validate() error {
+	if len(p.Rings) == 0 {
+		return errors.New("polygon has no rings")
+	}
+	for i, ring := range p.Rings {
+		for _, point := range ring {
+			if point.Lat < -90 || point.Lat > 90 || point.Lng < -180 || point.Lng > 180 {
+				return fmt.Errorf("coordinate [%f, %f] is out of range", point.Lng, point.Lat)
+			}
+		}
+		if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+			ring = append(ring, ring[0])
+			p.Rings[i] = ring
+		}
+		if len(ring) < 4 {
+			return errors.New("polygon ring needs at least 3 corners")
+		}
+	}
+	return nil
+}
+
+// ParseGeoJSONPolygons reads every polygon of a GeoJSON Polygon, MultiPolygon, Feature or FeatureCollection
+func ParseGeoJSONPolygons(data []byte) ([]Polygon, error) {
+	var object struct {
+		Type        string            `json:"type"`
+		Coordinates json.RawMessage   `json:"coordinates"`
+		Geometry    json.RawMessage   `json:"geometry"`
+		Features    []json.RawMessage `json:"features"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+
+	switch object.Type {
+	case "Polygon":
+		var coordinates [][][]float64
+		if err := json.Unmarshal(object.Coordinates, &coordinates); err != nil {
+			return nil, fmt.Errorf("invalid Polygon coordinates: %w", err)
+		}
+		polygon, err := polygonFromCoordinates(coordinates)
+		if err != nil {
+			return nil, err
+		}
+		return []Polygon{polygon}, nil
+	case "MultiPolygon":
+		var coordinates [][][][]float64
+		if err := json.Unmarshal(object.Coordinates, &coordinates); err != nil {
+			return nil, fmt.Errorf("invalid MultiPolygon coordinates: %w", err)
+		}
+		polygons := make([]Polygon, 0, len(coordinates))
+		for _, polygonCoordinates := range coordinates {
+			polygon, err := polygonFromCoordinates(polygonCoordinates)
+			if err != nil {
+				return nil, err
+			}
+			polygons = append(polygons, polygon)
+		}
+		return polygons, nil
+	case "Feature":
+		if len(object.Geometry) == 0 || string(object.Geometry) == "null" {
+			return nil, errors.New("feature has no geometry")
+		}
+		return ParseGeoJSONPolygons(object.Geometry)
+	case "FeatureCollection":
+		var polygons []Polygon
+		for _, feature := range object.Features {
+			featurePolygons, err := ParseGeoJSONPolygons(feature)
+			if err != nil {
+				return nil, err
+			}
+			polygons = append(polygons, featurePolygons...)
+		}
+		return polygons, nil
+	default:
+		return nil, fmt.Errorf("unsupported GeoJSON type %q", object.Type)
+	}
+}
+
+func polygonFromCoordinates(coordinates [][][]float64) (Polygon, error) {
+	polygon := Polygon{Rings: make([][]Point, len(coordinates))}
+	for i, ring := range coordinates {
+		polygon.Rings[i] = make([]Point, len(ring))
+		for j, position := range ring {
+			if len(position) < 2 {
+				return Polygon{}, errors.New("position needs longitude and latitude")
+			}
+			polygon.Rings[i][j] = Point{Lat: position[1], Lng: position[0]}
+		}
+	}
+	return polygon, polygon.Validate()
+}
+
+// parsePolygonEWKB parses a polygon in Extended Well-Known Binary format from PostGIS
+func parsePolygonEWKB(ewkb string) ([][]Point, error) {
+	data, err := hex.DecodeString(ewkb)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse polygon: %w", err)
+	}
+	if len(data) < 9 {
+		return nil, errors.New("cannot parse polygon: too short")
+	}
+
+	var order binary.ByteOrder = binary.BigEndian
+	if data[0] == 1 {
+		order = binary.LittleEndian
+	}
+	geometryType := order.Uint32(data[1:5])
+	offset := 5
+	if geometryType&ewkbSRIDFlag != 0 {
+		offset += 4
+	}
+	if geometryType&0xff != ewkbPolygonType {
+		return nil, fmt.Errorf("cannot parse polygon: geometry type %d", geometryType&0xff)
+	}
+
+	readUint32 := func() (uint32, error) {
+		if offset+4 > len(data) {
+			return 0, errors.New("cannot parse polygon: truncated")
+		}
+		value := order.Uint32(data[offset : offset+4])
+		offset += 4
+		return value, nil
+	}
+
+	ringCount, err := readUint32()
+	if err != nil {
+		return nil, err
+	}
+	rings := make([][]Point, 0, ringCount)
+	for i := uint32(0); i < ringCount; i++ {
+		pointCount, err := readUint32()
+		if err != nil {
+			return nil, err
+		}
+		if offset+int(pointCount)*16 > len(data) {
+			return nil, errors.New("cannot parse polygon: truncated")
+		}
+		ring := make([]Point, pointCount)
+		for j := range ring {
+			ring[j].Lng = math.Float64frombits(order.Uint64(data[offset : offset+8]))
+			ring[j].Lat = math.Float64frombits(order.Uint64(data[offset+8 : offset+16]))
+			offset += 16
+		}
+		rings = append(rings, ring)
+	}
+	return rings, nil
+}
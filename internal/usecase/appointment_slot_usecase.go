@@ -0,0 +1,639 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+	"github.com/wastetrack/wastetrack-backend/pkg/timezone"
+	"gorm.io/gorm"
+)
+
+const (
+	SlotOverbookingReject   = "reject"
+	SlotOverbookingWaitlist = "waitlist"
+
+	maxSlotSearchDays = 31
+)
+
+// appointmentSlot is a bookable window in minutes since midnight, end exclusive
+type appointmentSlot struct {
+	Start int
+	End   int
+}
+
+// openingHours are the hours of a waste bank on one date after applying exceptions
+type openingHours struct {
+	Open     int
+	Close    int
+	IsClosed bool
+	Reason   string
+}
+
+type AppointmentSlotUsecase struct {
+	DB                                   *gorm.DB
+	Log                                  *logrus.Logger
+	Validate                             *validator.Validate
+	WasteBankRepository                  *repository.WasteBankRepository
+	WasteBankScheduleExceptionRepository *repository.WasteBankScheduleExceptionRepository
+	WasteDropRequestRepository           *repository.WasteDropRequestRepository
+}
+
+func NewAppointmentSlotUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	wasteBankRepository *repository.WasteBankRepository,
+	wasteBankScheduleExceptionRepository *repository.WasteBankScheduleExceptionRepository,
+	wasteDropRequestRepository *repository.WasteDropRequestRepository,
+) *AppointmentSlotUsecase {
+	return &AppointmentSlotUsecase{
+		DB:                                   db,
+		Log:                                  log,
+		Validate:                             validate,
+		WasteBankRepository:                  wasteBankRepository,
+		WasteBankScheduleExceptionRepository: wasteBankScheduleExceptionRepository,
+		WasteDropRequestRepository:           wasteDropRequestRepository,
+	}
+}
+
+func clockMinute(t types.TimeOnly) int {
+	return t.Time.Hour()*60 + t.Time.Minute()
+}
+
+func formatClockMinute(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// Helper method to split opening hours into slots, the last slot is cut short at closing time
+func generateSlots(hours openingHours, duration int) []appointmentSlot {
+	if hours.IsClosed || duration <= 0 {
+		return nil
+	}
+	var slots []appointmentSlot
+	for start := hours.Open; start < hours.Close; start += duration {
+		slots = append(slots, appointmentSlot{Start: start, End: min(start+duration, hours.Close)})
+	}
+	return slots
+}
+
+// Helper method to find the slot an appointment starting at minute falls into
+func findSlot(hours openingHours, duration int, minute int) (appointmentSlot, bool) {
+	if hours.IsClosed || minute < hours.Open || minute >= hours.Close {
+		return appointmentSlot{}, false
+	}
+	start := hours.Open + (minute-hours.Open)/duration*duration
+	return appointmentSlot{Start: start, End: min(start+duration, hours.Close)}, true
+}
+
+func slotCapacity(wasteBank *entity.WasteBankProfile, deliveryType string) int {
+	if deliveryType == "pickup" {
+		return wasteBank.PickupSlotCapacity
+	}
+	return wasteBank.DropoffSlotCapacity
+}
+
+// Helper method to tell whether the waste bank takes bookings through slots at all
+func usesSlots(wasteBank *entity.WasteBankProfile) bool {
+	return wasteBank.OpenTime.Valid && wasteBank.CloseTime.Valid && clockMinute(wasteBank.OpenTime) < clockMinute(wasteBank.CloseTime)
+}
+
+// Helper method to apply the exception of a date, if any, to the regular opening hours
+func resolveOpeningHours(wasteBank *entity.WasteBankProfile, exception *entity.WasteBankScheduleException) openingHours {
+	hours := openingHours{
+		Open:  clockMinute(wasteBank.OpenTime),
+		Close: clockMinute(wasteBank.CloseTime),
+	}
+	if exception == nil {
+		return hours
+	}
+	hours.Reason = exception.Reason
+	if exception.IsClosed {
+		hours.IsClosed = true
+		return hours
+	}
+	hours.Open = clockMinute(exception.OpenTime)
+	hours.Close = clockMinute(exception.CloseTime)
+	return hours
+}
+
+// Helper method to find the opening hours of a waste bank on a date
+func (c *AppointmentSlotUsecase) openingHoursOn(tx *gorm.DB, wasteBank *entity.WasteBankProfile, date string) (openingHours, error) {
+	exceptions, err := c.WasteBankScheduleExceptionRepository.FindByBankAndRange(tx, wasteBank.UserID.String(), date, date)
+	if err != nil {
+		return openingHours{}, err
+	}
+	if len(exceptions) > 0 {
+		return resolveOpeningHours(wasteBank, &exceptions[0]), nil
+	}
+	return resolveOpeningHours(wasteBank, nil), nil
+}
+
+// IsClosedOn tells whether a waste bank with opening hours is closed on a date, with the reason when given
+func (c *AppointmentSlotUsecase) IsClosedOn(tx *gorm.DB, wasteBankID string, date string) (bool, string, error) {
+	wasteBank := new(entity.WasteBankProfile)
+	if err := c.WasteBankRepository.FindByUserIDNoPreload(tx, wasteBank, wasteBankID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, "", nil
+		}
+		return false, "", err
+	}
+	if !usesSlots(wasteBank) {
+		return false, "", nil
+	}
+
+	hours, err := c.openingHoursOn(tx, wasteBank, date)
+	if err != nil {
+		return false, "", err
+	}
+	return hours.IsClosed, hours.Reason, nil
+}
+
+// Helper method to count the places left in the slots of a waste bank on a date that have not started yet
+func remainingSlotCapacity(wasteBank *entity.WasteBankProfile, hours openingHours, bookings []entity.SlotBookingCount, date string, deliveryType string) int {
+	remaining := 0
+	if hours.IsClosed {
+		return remaining
+	}
+
+	booked := make(map[int]int)
+	for _, booking := range bookings {
+		if deliveryType != "" && booking.DeliveryType != deliveryType {
+			continue
+		}
+		if slot, ok := findSlot(hours, wasteBank.SlotDurationMinutes, clockMinute(booking.StartTime)); ok {
+			booked[slot.Start] += booking.Total
+		}
+	}
+
+	capacity := wasteBank.DropoffSlotCapacity + wasteBank.PickupSlotCapacity
+	if deliveryType != "" {
+		capacity = slotCapacity(wasteBank, deliveryType)
+	}
+
+	now := time.Now().In(timezone.WIB)
+	nowMinute := now.Hour()*60 + now.Minute()
+	for _, slot := range generateSlots(hours, wasteBank.SlotDurationMinutes) {
+		if date < now.Format("2006-01-02") || (date == now.Format("2006-01-02") && slot.Start < nowMinute) {
+			continue
+		}
+		remaining += max(capacity-booked[slot.Start], 0)
+	}
+	return remaining
+}
+
+// Helper method to find the opening hours of several waste banks on a date and the places left in their slots,
+// keyed by waste bank user ID. Banks without opening hours get no entry. Exceptions and bookings are loaded
+// in one query each, whatever the number of banks.
+func (c *AppointmentSlotUsecase) remainingCapacities(tx *gorm.DB, wasteBanks []entity.WasteBankProfile, date string, deliveryType string) (map[string]openingHours, map[string]int, error) {
+	hours := make(map[string]openingHours)
+	remaining := make(map[string]int)
+
+	var wasteBankIDs []string
+	for i := range wasteBanks {
+		if usesSlots(&wasteBanks[i]) {
+			wasteBankIDs = append(wasteBankIDs, wasteBanks[i].UserID.String())
+		}
+	}
+	if len(wasteBankIDs) == 0 {
+		return hours, remaining, nil
+	}
+
+	exceptions, err := c.WasteBankScheduleExceptionRepository.FindByBanksAndDate(tx, wasteBankIDs, date)
+	if err != nil {
+		return nil, nil, err
+	}
+	exceptionByBank := make(map[string]*entity.WasteBankScheduleException, len(exceptions))
+	for i := range exceptions {
+		exceptionByBank[exceptions[i].WasteBankID.String()] = &exceptions[i]
+	}
+
+	bookings, err := c.WasteDropRequestRepository.FindSlotBookingsByBanks(tx, wasteBankIDs, date)
+	if err != nil {
+		return nil, nil, err
+	}
+	bookingsByBank := make(map[string][]entity.SlotBookingCount)
+	for _, booking := range bookings {
+		bookingsByBank[booking.WasteBankID.String()] = append(bookingsByBank[booking.WasteBankID.String()], booking)
+	}
+
+	for i := range wasteBanks {
+		wasteBank := &wasteBanks[i]
+		if !usesSlots(wasteBank) {
+			continue
+		}
+		bankID := wasteBank.UserID.String()
+		hours[bankID] = resolveOpeningHours(wasteBank, exceptionByBank[bankID])
+		remaining[bankID] = remainingSlotCapacity(wasteBank, hours[bankID], bookingsByBank[bankID], date, deliveryType)
+	}
+	return hours, remaining, nil
+}
+
+// Helper method to serialize bookings of one waste bank date until the transaction ends
+func (c *AppointmentSlotUsecase) lockBankDate(tx *gorm.DB, wasteBankID string, date string) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(hashtext('appointment_slot:' || ? || ':' || ?))", wasteBankID, date).Error
+}
+
+// Book checks a new request against the waste bank's hours and slot capacity. A full slot is rejected or
+// the request is waitlisted, depending on the bank's policy. Banks without opening hours take any time.
+func (c *AppointmentSlotUsecase) Book(tx *gorm.DB, wasteDropRequest *entity.WasteDropRequest) error {
+	if wasteDropRequest.WasteBankID == nil {
+		return nil
+	}
+
+	wasteBank := new(entity.WasteBankProfile)
+	if err := c.WasteBankRepository.FindByUserIDNoPreload(tx, wasteBank, wasteDropRequest.WasteBankID.String()); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		c.Log.Warnf("Failed to find waste bank profile: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if !usesSlots(wasteBank) {
+		return nil
+	}
+
+	date := wasteDropRequest.AppointmentDate.Format("2006-01-02")
+	hours, err := c.openingHoursOn(tx, wasteBank, date)
+	if err != nil {
+		c.Log.Warnf("Failed to find opening hours: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if hours.IsClosed {
+		message := fmt.Sprintf("Waste bank is closed on %s", date)
+		if hours.Reason != "" {
+			message += ": " + hours.Reason
+		}
+		return fiber.NewError(fiber.StatusBadRequest, message)
+	}
+
+	if !wasteDropRequest.AppointmentStartTime.Valid {
+		return fiber.NewError(fiber.StatusBadRequest, "Appointment start time is required to book a slot")
+	}
+	start := clockMinute(wasteDropRequest.AppointmentStartTime)
+	slot, ok := findSlot(hours, wasteBank.SlotDurationMinutes, start)
+	if !ok || (wasteDropRequest.AppointmentEndTime.Valid && clockMinute(wasteDropRequest.AppointmentEndTime) > hours.Close) {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Appointment must be within opening hours %s-%s",
+			formatClockMinute(hours.Open), formatClockMinute(hours.Close)))
+	}
+
+	if err := c.lockBankDate(tx, wasteBank.UserID.String(), date); err != nil {
+		c.Log.Warnf("Failed to lock appointment slots: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	booked, err := c.WasteDropRequestRepository.CountSlotBookings(tx, wasteBank.UserID.String(), date, wasteDropRequest.DeliveryType,
+		formatClockMinute(slot.Start), formatClockMinute(slot.End))
+	if err != nil {
+		c.Log.Warnf("Failed to count slot bookings: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if booked < int64(slotCapacity(wasteBank, wasteDropRequest.DeliveryType)) {
+		return nil
+	}
+
+	if wasteBank.SlotOverbookingPolicy == SlotOverbookingWaitlist {
+		c.Log.Infof("Slot %s %s of waste bank %s is full, waitlisting request", date, formatClockMinute(slot.Start), wasteBank.UserID)
+		wasteDropRequest.IsWaitlisted = true
+		return nil
+	}
+	return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("The %s slot %s-%s on %s is fully booked",
+		wasteDropRequest.DeliveryType, formatClockMinute(slot.Start), formatClockMinute(slot.End), date))
+}
+
+// ReleaseSlot hands the place of a cancelled or deleted request to the oldest waitlisted request in its slot
+func (c *AppointmentSlotUsecase) ReleaseSlot(tx *gorm.DB, wasteDropRequest *entity.WasteDropRequest) error {
+	if wasteDropRequest.WasteBankID == nil || wasteDropRequest.IsWaitlisted || !wasteDropRequest.AppointmentStartTime.Valid {
+		return nil
+	}
+
+	wasteBank := new(entity.WasteBankProfile)
+	if err := c.WasteBankRepository.FindByUserIDNoPreload(tx, wasteBank, wasteDropRequest.WasteBankID.String()); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+	if !usesSlots(wasteBank) {
+		return nil
+	}
+
+	date := wasteDropRequest.AppointmentDate.Format("2006-01-02")
+	hours, err := c.openingHoursOn(tx, wasteBank, date)
+	if err != nil {
+		return err
+	}
+	slot, ok := findSlot(hours, wasteBank.SlotDurationMinutes, clockMinute(wasteDropRequest.AppointmentStartTime))
+	if !ok {
+		return nil
+	}
+
+	if err := c.lockBankDate(tx, wasteBank.UserID.String(), date); err != nil {
+		return err
+	}
+
+	from, to := formatClockMinute(slot.Start), formatClockMinute(slot.End)
+	booked, err := c.WasteDropRequestRepository.CountSlotBookings(tx, wasteBank.UserID.String(), date, wasteDropRequest.DeliveryType, from, to)
+	if err != nil {
+		return err
+	}
+	if booked >= int64(slotCapacity(wasteBank, wasteDropRequest.DeliveryType)) {
+		return nil
+	}
+
+	waitlisted := new(entity.WasteDropRequest)
+	if err := c.WasteDropRequestRepository.FindFirstWaitlisted(tx, waitlisted, wasteBank.UserID.String(), date, wasteDropRequest.DeliveryType, from, to); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+
+	c.Log.Infof("Promoting waitlisted waste drop request %s into slot %s %s", waitlisted.ID, date, from)
+	return tx.Model(waitlisted).Update("is_waitlisted", false).Error
+}
+
+// ListSlots returns the slots of a waste bank with their remaining capacity for each day in the range
+func (c *AppointmentSlotUsecase) ListSlots(ctx context.Context, request *model.SearchAppointmentSlotRequest) ([]model.AppointmentDayResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	startDate, err := time.ParseInLocation("2006-01-02", request.StartDate, timezone.WIB)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "start_date must be in YYYY-MM-DD format")
+	}
+	endDate := startDate
+	if request.EndDate != "" {
+		if endDate, err = time.ParseInLocation("2006-01-02", request.EndDate, timezone.WIB); err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "end_date must be in YYYY-MM-DD format")
+		}
+	}
+	if endDate.Before(startDate) || endDate.Sub(startDate) > maxSlotSearchDays*24*time.Hour {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("end_date must be within %d days after start_date", maxSlotSearchDays))
+	}
+
+	wasteBank := new(entity.WasteBankProfile)
+	if err := c.WasteBankRepository.FindByUserIDNoPreload(tx, wasteBank, request.WasteBankID); err != nil {
+		c.Log.Warnf("Failed to find waste bank profile: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if !usesSlots(wasteBank) {
+		return nil, fiber.NewError(fiber.StatusUnprocessableEntity, "Waste bank has no opening hours set")
+	}
+
+	exceptions, err := c.WasteBankScheduleExceptionRepository.FindByBankAndRange(tx, request.WasteBankID, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if err != nil {
+		c.Log.Warnf("Failed to find schedule exceptions: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	exceptionsByDate := make(map[string]*entity.WasteBankScheduleException, len(exceptions))
+	for i := range exceptions {
+		exceptionsByDate[exceptions[i].Date.Format("2006-01-02")] = &exceptions[i]
+	}
+
+	bookings, err := c.WasteDropRequestRepository.FindSlotBookings(tx, request.WasteBankID, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if err != nil {
+		c.Log.Warnf("Failed to find slot bookings: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	now := time.Now().In(timezone.WIB)
+	today := now.Format("2006-01-02")
+	nowMinute := now.Hour()*60 + now.Minute()
+
+	days := make([]model.AppointmentDayResponse, 0, int(endDate.Sub(startDate).Hours()/24)+1)
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		hours := resolveOpeningHours(wasteBank, exceptionsByDate[date])
+		response := model.AppointmentDayResponse{
+			Date:     date,
+			IsClosed: hours.IsClosed,
+			Reason:   hours.Reason,
+			Slots:    []model.AppointmentSlotResponse{},
+		}
+		if !hours.IsClosed {
+			response.OpenTime = formatClockMinute(hours.Open)
+			response.CloseTime = formatClockMinute(hours.Close)
+		}
+
+		slots := generateSlots(hours, wasteBank.SlotDurationMinutes)
+		slotIndex := make(map[int]int, len(slots))
+		for i, slot := range slots {
+			slotIndex[slot.Start] = i
+			response.Slots = append(response.Slots, model.AppointmentSlotResponse{
+				StartTime:       formatClockMinute(slot.Start),
+				EndTime:         formatClockMinute(slot.End),
+				DropoffCapacity: wasteBank.DropoffSlotCapacity,
+				PickupCapacity:  wasteBank.PickupSlotCapacity,
+			})
+		}
+
+		for _, booking := range bookings {
+			if booking.AppointmentDate.Format("2006-01-02") != date {
+				continue
+			}
+			slot, ok := findSlot(hours, wasteBank.SlotDurationMinutes, clockMinute(booking.StartTime))
+			if !ok {
+				continue
+			}
+			if booking.DeliveryType == "pickup" {
+				response.Slots[slotIndex[slot.Start]].PickupBooked += booking.Total
+			} else {
+				response.Slots[slotIndex[slot.Start]].DropoffBooked += booking.Total
+			}
+		}
+
+		available := response.Slots[:0]
+		for i, slot := range response.Slots {
+			slot.DropoffAvailable = max(slot.DropoffCapacity-slot.DropoffBooked, 0)
+			slot.PickupAvailable = max(slot.PickupCapacity-slot.PickupBooked, 0)
+
+			// Slots that already started today cannot be booked
+			if date < today || (date == today && slots[i].Start < nowMinute) {
+				continue
+			}
+			if request.OnlyAvailable {
+				if request.DeliveryType == "pickup" && slot.PickupAvailable == 0 ||
+					request.DeliveryType == "dropoff" && slot.DropoffAvailable == 0 ||
+					request.DeliveryType == "" && slot.PickupAvailable == 0 && slot.DropoffAvailable == 0 {
+					continue
+				}
+			}
+			available = append(available, slot)
+		}
+		response.Slots = available
+
+		days = append(days, response)
+	}
+
+	return days, nil
+}
+
+// Helper method to resolve which waste bank an exception request is for
+func scheduleExceptionBankID(actorID string, actorRole string, requested string) (string, error) {
+	switch {
+	case actorRole == "admin":
+		if requested == "" {
+			return "", fiber.NewError(fiber.StatusBadRequest, "waste_bank_id is required")
+		}
+		return requested, nil
+	case isWasteBankRole(actorRole):
+		if requested != "" && requested != actorID {
+			return "", fiber.NewError(fiber.StatusForbidden, "You can only manage your own schedule")
+		}
+		return actorID, nil
+	}
+	return "", fiber.NewError(fiber.StatusForbidden, "You can only manage your own schedule")
+}
+
+func (c *AppointmentSlotUsecase) CreateException(ctx context.Context, request *model.ScheduleExceptionRequest) (*model.ScheduleExceptionResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	wasteBankID, err := scheduleExceptionBankID(request.ActorID, request.ActorRole, request.WasteBankID)
+	if err != nil {
+		return nil, err
+	}
+	bankUUID, err := uuid.Parse(wasteBankID)
+	if err != nil {
+		c.Log.Warnf("Invalid waste bank ID: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "date must be in YYYY-MM-DD format")
+	}
+
+	exception := &entity.WasteBankScheduleException{
+		WasteBankID: bankUUID,
+		Date:        date,
+		IsClosed:    request.IsClosed == nil || *request.IsClosed,
+		Reason:      request.Reason,
+	}
+	if !exception.IsClosed {
+		openTime, openErr := time.Parse("15:04", request.OpenTime)
+		closeTime, closeErr := time.Parse("15:04", request.CloseTime)
+		if openErr != nil || closeErr != nil || !openTime.Before(closeTime) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "open_time and close_time in HH:MM format are required, with open_time before close_time")
+		}
+		exception.OpenTime = types.NewTimeOnly(openTime)
+		exception.CloseTime = types.NewTimeOnly(closeTime)
+	}
+
+	wasteBank := new(entity.WasteBankProfile)
+	if err := c.WasteBankRepository.FindByUserIDNoPreload(tx, wasteBank, wasteBankID); err != nil {
+		c.Log.Warnf("Failed to find waste bank profile: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	total, err := c.WasteBankScheduleExceptionRepository.CountByBankAndDate(tx, wasteBankID, request.Date)
+	if err != nil {
+		c.Log.Warnf("Failed to count schedule exceptions: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if total > 0 {
+		return nil, fiber.NewError(fiber.StatusConflict, "An exception already exists for this date")
+	}
+
+	// Bookings already made for the date must be moved or cancelled before the bank closes over them
+	if err := c.lockBankDate(tx, wasteBankID, request.Date); err != nil {
+		c.Log.Warnf("Failed to lock appointment slots: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	openTime, closeTime := "", ""
+	if !exception.IsClosed {
+		openTime, closeTime = request.OpenTime, request.CloseTime
+	}
+	booked, err := c.WasteDropRequestRepository.CountOpenBookingsOutside(tx, wasteBankID, request.Date, openTime, closeTime)
+	if err != nil {
+		c.Log.Warnf("Failed to count bookings: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if booked > 0 {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("%d open requests are booked outside these hours, reschedule or cancel them first", booked))
+	}
+
+	if err := c.WasteBankScheduleExceptionRepository.Create(tx, exception); err != nil {
+		c.Log.Warnf("Failed to create schedule exception: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ScheduleExceptionToResponse(exception), nil
+}
+
+func (c *AppointmentSlotUsecase) SearchExceptions(ctx context.Context, request *model.SearchScheduleExceptionRequest) ([]model.ScheduleExceptionResponse, int64, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	exceptions, total, err := c.WasteBankScheduleExceptionRepository.Search(tx, request)
+	if err != nil {
+		c.Log.Warnf("Failed to search schedule exceptions: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.ScheduleExceptionResponse, len(exceptions))
+	for i, exception := range exceptions {
+		responses[i] = *converter.ScheduleExceptionToResponse(&exception)
+	}
+
+	return responses, total, nil
+}
+
+func (c *AppointmentSlotUsecase) DeleteException(ctx context.Context, request *model.DeleteScheduleExceptionRequest) (*model.ScheduleExceptionResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	exception := new(entity.WasteBankScheduleException)
+	if err := c.WasteBankScheduleExceptionRepository.FindById(tx, exception, request.ID); err != nil {
+		c.Log.Warnf("Failed to find schedule exception by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if request.ActorRole != "admin" && exception.WasteBankID.String() != request.ActorID {
+		return nil, fiber.NewError(fiber.StatusForbidden, "You can only manage your own schedule")
+	}
+
+	if err := c.WasteBankScheduleExceptionRepository.Delete(tx, exception); err != nil {
+		c.Log.Warnf("Failed to delete schedule exception: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ScheduleExceptionToResponse(exception), nil
+}
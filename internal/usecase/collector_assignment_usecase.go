@@ -0,0 +1,327 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/pkg/timezone"
+	"gorm.io/gorm"
+)
+
+const (
+	AssignmentTriggerCreate = "create"
+	AssignmentTriggerSweep  = "sweep"
+	AssignmentTriggerManual = "manual"
+
+	// Score weights, lower scores win. One request in the same window costs as much as 5 km of travel.
+	assignmentWindowLoadWeight = 5.0
+	assignmentDayLoadWeight    = 10.0
+	// Collectors without any known location are still eligible, but ranked as if this far away
+	assignmentUnknownDistanceKm = 25.0
+	assignmentSweepBatchSize    = 100
+	// Requests the sweep could not assign are retried after this long, so they do not fill every batch
+	assignmentSweepRetryInterval = 30 * time.Minute
+)
+
+var errNoCollectorCandidate = fiber.NewError(fiber.StatusUnprocessableEntity, "No active collector with free capacity is available for this request")
+
+type CollectorAssignmentUsecase struct {
+	DB                                      *gorm.DB
+	Log                                     *logrus.Logger
+	Validate                                *validator.Validate
+	CollectorAssignmentRepository           *repository.CollectorAssignmentRepository
+	WasteDropRequestRepository              *repository.WasteDropRequestRepository
+	WasteDropRequestStatusHistoryRepository *repository.WasteDropRequestStatusHistoryRepository
+	WasteBankRepository                     *repository.WasteBankRepository
+}
+
+func NewCollectorAssignmentUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	collectorAssignmentRepository *repository.CollectorAssignmentRepository,
+	wasteDropRequestRepository *repository.WasteDropRequestRepository,
+	wasteDropRequestStatusHistoryRepository *repository.WasteDropRequestStatusHistoryRepository,
+	wasteBankRepository *repository.WasteBankRepository,
+) *CollectorAssignmentUsecase {
+	return &CollectorAssignmentUsecase{
+		DB:                                      db,
+		Log:                                     log,
+		Validate:                                validate,
+		CollectorAssignmentRepository:           collectorAssignmentRepository,
+		WasteDropRequestRepository:              wasteDropRequestRepository,
+		WasteDropRequestStatusHistoryRepository: wasteDropRequestStatusHistoryRepository,
+		WasteBankRepository:                     wasteBankRepository,
+	}
+}
+
+// Helper method to score a candidate, returns false when the collector cannot take the request
+func scoreCollectorCandidate(candidate *entity.CollectorCandidate, maxDistanceKm *float64) (float64, bool) {
+	if candidate.DailyCapacity <= 0 || candidate.DayLoad >= candidate.DailyCapacity {
+		return 0, false
+	}
+
+	distanceKm := assignmentUnknownDistanceKm
+	if candidate.DistanceMeters != nil {
+		distanceKm = *candidate.DistanceMeters / 1000
+		if maxDistanceKm != nil && distanceKm > *maxDistanceKm {
+			return 0, false
+		}
+	}
+
+	score := distanceKm +
+		float64(candidate.WindowLoad)*assignmentWindowLoadWeight +
+		float64(candidate.DayLoad)/float64(candidate.DailyCapacity)*assignmentDayLoadWeight
+	return math.Round(score*1000) / 1000, true
+}
+
+// Helper method to explain in words why a candidate was chosen
+func describeCollectorChoice(candidate *entity.CollectorCandidate, score float64, candidateCount int) string {
+	distance := "no known location"
+	if candidate.DistanceMeters != nil {
+		source := "last status update"
+		if candidate.LocationSource == "profile" {
+			source = "profile location"
+		}
+		distance = fmt.Sprintf("%.2f km away by %s", *candidate.DistanceMeters/1000, source)
+	}
+	return fmt.Sprintf("Lowest score %.3f of %d candidates: %s, %d requests in the same window, %d/%d daily capacity used",
+		score, candidateCount, distance, candidate.WindowLoad, candidate.DayLoad, candidate.DailyCapacity)
+}
+
+// AutoAssign picks a collector for a pending pickup and assigns it within the caller's transaction.
+// It returns nil without error when the request is not eligible or the waste bank has auto-assignment off.
+func (c *CollectorAssignmentUsecase) AutoAssign(tx *gorm.DB, wasteDropRequest *entity.WasteDropRequest, trigger string) (*entity.CollectorAssignment, error) {
+	if wasteDropRequest.Status != "pending" || wasteDropRequest.DeliveryType != "pickup" || wasteDropRequest.IsDeleted ||
+		wasteDropRequest.IsWaitlisted || wasteDropRequest.WasteBankID == nil || wasteDropRequest.AssignedCollectorID != nil {
+		return nil, nil
+	}
+
+	wasteBank := new(entity.WasteBankProfile)
+	if err := c.WasteBankRepository.FindByUserIDNoPreload(tx, wasteBank, wasteDropRequest.WasteBankID.String()); err != nil {
+		return nil, err
+	}
+	if !wasteBank.AutoAssignCollectors && trigger != AssignmentTriggerManual {
+		return nil, nil
+	}
+
+	if err := c.CollectorAssignmentRepository.LockCollectors(tx, wasteDropRequest.WasteBankID.String()); err != nil {
+		return nil, err
+	}
+
+	candidates, err := c.CollectorAssignmentRepository.FindCandidates(tx, wasteDropRequest.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var best *entity.CollectorCandidate
+	bestScore := 0.0
+	for i := range candidates {
+		candidate := &candidates[i]
+		score, ok := scoreCollectorCandidate(candidate, wasteBank.AutoAssignMaxDistanceKm)
+		if !ok {
+			continue
+		}
+		if best == nil || score < bestScore || (score == bestScore && candidate.CollectorID.String() < best.CollectorID.String()) {
+			best = candidate
+			bestScore = score
+		}
+	}
+	if best == nil {
+		return nil, errNoCollectorCandidate
+	}
+
+	reason := describeCollectorChoice(best, bestScore, len(candidates))
+
+	if err := c.WasteDropRequestRepository.AssignCollector(tx, wasteDropRequest.ID.String(), best.CollectorID.String()); err != nil {
+		return nil, err
+	}
+	if err := c.WasteDropRequestRepository.UpdateStatus(tx, wasteDropRequest.ID.String(), "assigned"); err != nil {
+		return nil, err
+	}
+
+	fromStatus := wasteDropRequest.Status
+	if err := c.WasteDropRequestStatusHistoryRepository.Create(tx, &entity.WasteDropRequestStatusHistory{
+		RequestID:  wasteDropRequest.ID,
+		FromStatus: &fromStatus,
+		ToStatus:   "assigned",
+		ActorRole:  "system",
+		Reason:     "Auto-assigned: " + reason,
+	}); err != nil {
+		return nil, err
+	}
+
+	assignment := &entity.CollectorAssignment{
+		RequestID:      wasteDropRequest.ID,
+		WasteBankID:    *wasteDropRequest.WasteBankID,
+		CollectorID:    best.CollectorID,
+		Trigger:        trigger,
+		Score:          bestScore,
+		DistanceMeters: best.DistanceMeters,
+		LocationSource: best.LocationSource,
+		WindowLoad:     best.WindowLoad,
+		DayLoad:        best.DayLoad,
+		DailyCapacity:  best.DailyCapacity,
+		CandidateCount: len(candidates),
+		Reason:         reason,
+	}
+	if err := c.CollectorAssignmentRepository.Create(tx, assignment); err != nil {
+		return nil, err
+	}
+
+	collectorID := best.CollectorID
+	wasteDropRequest.AssignedCollectorID = &collectorID
+	wasteDropRequest.Status = "assigned"
+
+	c.Log.Infof("Assigned collector %s to waste drop request %s (%s): %s", collectorID, wasteDropRequest.ID, trigger, reason)
+	return assignment, nil
+}
+
+// AssignRequest runs the assignment on demand, even when the waste bank has not turned it on
+func (c *CollectorAssignmentUsecase) AssignRequest(ctx context.Context, request *model.AutoAssignCollectorRequest) (*model.CollectorAssignmentResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	wasteDropRequest := new(entity.WasteDropRequest)
+	if err := c.WasteDropRequestRepository.FindByIdForUpdate(tx, wasteDropRequest, request.ID); err != nil {
+		c.Log.Warnf("Failed to find waste drop request by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if wasteDropRequest.IsDeleted {
+		return nil, fiber.ErrNotFound
+	}
+
+	if request.ActorRole != "admin" {
+		if !isWasteBankRole(request.ActorRole) || wasteDropRequest.WasteBankID == nil || wasteDropRequest.WasteBankID.String() != request.ActorID {
+			return nil, fiber.NewError(fiber.StatusForbidden, "You are not allowed to access this request")
+		}
+	}
+
+	if wasteDropRequest.DeliveryType != "pickup" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Only pickup requests can be assigned a collector")
+	}
+	if wasteDropRequest.Status != "pending" || wasteDropRequest.AssignedCollectorID != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, "Only pending requests without a collector can be auto-assigned")
+	}
+	if wasteDropRequest.IsWaitlisted {
+		return nil, fiber.NewError(fiber.StatusConflict, "Waitlisted requests are assigned once they get a slot")
+	}
+
+	assignment, err := c.AutoAssign(tx, wasteDropRequest, AssignmentTriggerManual)
+	if err != nil {
+		if err == errNoCollectorCandidate {
+			return nil, err
+		}
+		c.Log.Warnf("Failed to auto-assign collector: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.CollectorAssignmentToResponse(assignment), nil
+}
+
+// Sweep assigns pending pickups that were left without a collector, for example because every collector
+// was busy when the request was created. Each request is assigned in its own transaction.
+func (c *CollectorAssignmentUsecase) Sweep(ctx context.Context) (int, error) {
+	now := time.Now().In(timezone.WIB)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, timezone.WIB)
+
+	requests, err := c.CollectorAssignmentRepository.FindSweepable(c.DB.WithContext(ctx), today, now.Add(-assignmentSweepRetryInterval), assignmentSweepBatchSize)
+	if err != nil {
+		c.Log.Warnf("Failed to find requests to assign: %+v", err)
+		return 0, err
+	}
+
+	assigned := 0
+	for _, pending := range requests {
+		ok, err := c.sweepRequest(ctx, pending.ID.String())
+		if err != nil {
+			c.Log.Warnf("Failed to auto-assign waste drop request %s: %+v", pending.ID, err)
+		}
+		if ok {
+			assigned++
+			continue
+		}
+		if err := c.CollectorAssignmentRepository.MarkAttempted(c.DB.WithContext(ctx), pending.ID.String(), time.Now()); err != nil {
+			c.Log.Warnf("Failed to record assignment attempt of waste drop request %s: %+v", pending.ID, err)
+		}
+	}
+
+	return assigned, nil
+}
+
+func (c *CollectorAssignmentUsecase) sweepRequest(ctx context.Context, id string) (bool, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// The request may have been assigned by hand since it was listed
+	wasteDropRequest := new(entity.WasteDropRequest)
+	if err := c.WasteDropRequestRepository.FindByIdForUpdate(tx, wasteDropRequest, id); err != nil {
+		return false, err
+	}
+
+	assignment, err := c.AutoAssign(tx, wasteDropRequest, AssignmentTriggerSweep)
+	if err == errNoCollectorCandidate {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if assignment == nil {
+		return false, nil
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *CollectorAssignmentUsecase) Search(ctx context.Context, request *model.SearchCollectorAssignmentRequest) ([]model.CollectorAssignmentResponse, int64, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	// Waste banks only see the assignments of their own requests
+	switch {
+	case request.ActorRole == "admin":
+	case isWasteBankRole(request.ActorRole):
+		request.WasteBankID = request.ActorID
+	default:
+		return nil, 0, fiber.NewError(fiber.StatusForbidden, "You are not allowed to view collector assignments")
+	}
+
+	assignments, total, err := c.CollectorAssignmentRepository.Search(tx, request)
+	if err != nil {
+		c.Log.Warnf("Failed to search collector assignments: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.CollectorAssignmentResponse, len(assignments))
+	for i, assignment := range assignments {
+		responses[i] = *converter.CollectorAssignmentToResponse(&assignment)
+	}
+
+	return responses, total, nil
+}
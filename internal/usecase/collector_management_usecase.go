@@ -83,6 +83,9 @@ func (u *CollectorManagementUsecase) Create(ctx context.Context, request *model.
 		CollectorID: collectorID,
 		Status:      request.Status,
 	}
+	if request.DailyCapacity > 0 {
+		collectorManagement.DailyCapacity = request.DailyCapacity
+	}
 
 	if err := u.CollectorManagementRepository.Create(tx, collectorManagement); err != nil {
 		u.Log.Warnf("Failed to create collector management: %+v", err)
@@ -182,6 +185,10 @@ func (u *CollectorManagementUsecase) Update(ctx context.Context, request *model.
 		collectorManagement.Status = request.Status
 	}
 
+	if request.DailyCapacity > 0 {
+		collectorManagement.DailyCapacity = request.DailyCapacity
+	}
+
 	if err := u.CollectorManagementRepository.Update(tx, collectorManagement); err != nil {
 		u.Log.Warnf("Update failed: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -0,0 +1,369 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/helper"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+	"github.com/wastetrack/wastetrack-backend/pkg/timezone"
+	"gorm.io/gorm"
+)
+
+// Planned routes are kept this long so a cancellation only reschedules the remaining stops. Plans are
+// stored in collector_route_plans so every prefork child follows the same order, the in-memory map only
+// saves reading them back.
+const collectorRouteCacheTTL = 12 * time.Hour
+
+// cachedCollectorRoute holds a planned route with its distance matrix. Stop keys include the location
+// and time window, so any change to a stop other than removing it triggers a full replan.
+type cachedCollectorRoute struct {
+	Keys      []string           `json:"keys"`
+	Stops     []helper.RouteStop `json:"stops"`
+	Matrix    [][]float64        `json:"matrix"`
+	Order     []int              `json:"order"`
+	expiresAt time.Time
+}
+
+type CollectorRouteUsecase struct {
+	DB                            *gorm.DB
+	Log                           *logrus.Logger
+	Validate                      *validator.Validate
+	CollectorRouteRepository      *repository.CollectorRouteRepository
+	CollectorManagementRepository *repository.CollectorManagementRepository
+	UserRepository                *repository.UserRepository
+	RouteOptions                  helper.RouteOptions
+
+	cacheMutex sync.Mutex
+	cache      map[string]*cachedCollectorRoute
+}
+
+func NewCollectorRouteUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	collectorRouteRepository *repository.CollectorRouteRepository,
+	collectorManagementRepository *repository.CollectorManagementRepository,
+	userRepository *repository.UserRepository,
+	routeOptions helper.RouteOptions,
+) *CollectorRouteUsecase {
+	return &CollectorRouteUsecase{
+		DB:                            db,
+		Log:                           log,
+		Validate:                      validate,
+		CollectorRouteRepository:      collectorRouteRepository,
+		CollectorManagementRepository: collectorManagementRepository,
+		UserRepository:                userRepository,
+		RouteOptions:                  routeOptions,
+		cache:                         make(map[string]*cachedCollectorRoute),
+	}
+}
+
+// Helper method to convert minutes since midnight to a clock time. Times past midnight wrap around
+// and get a day marker, e.g. 25:00 is "01:00 (+1)".
+func formatRouteMinute(minute int) string {
+	clock := fmt.Sprintf("%02d:%02d", minute%helper.MinutesPerDay/60, minute%60)
+	if days := minute / helper.MinutesPerDay; days > 0 {
+		clock += fmt.Sprintf(" (+%d)", days)
+	}
+	return clock
+}
+
+// Helper method to convert an appointment time to minutes since midnight, using fallback when unset
+func routeMinute(t types.TimeOnly, fallback int) int {
+	if !t.Valid {
+		return fallback
+	}
+	return t.Time.Hour()*60 + t.Time.Minute()
+}
+
+func routeStopKey(stop *entity.CollectorRouteStop) string {
+	return fmt.Sprintf("%s:%s:%.6f,%.6f:%s-%s", stop.RequestType, stop.RequestID,
+		stop.Location.Lat, stop.Location.Lng, stop.StartTime.Format("15:04"), stop.EndTime.Format("15:04"))
+}
+
+func routeStopToResponse(stop *entity.CollectorRouteStop) model.CollectorRouteStopResponse {
+	response := model.CollectorRouteStopResponse{
+		RequestID:            stop.RequestID.String(),
+		RequestType:          stop.RequestType,
+		Status:               stop.Status,
+		AppointmentStartTime: stop.StartTime.Format("15:04"),
+		AppointmentEndTime:   stop.EndTime.Format("15:04"),
+	}
+	if stop.Location != nil {
+		response.Location = &model.LocationResponse{
+			Latitude:  stop.Location.Lat,
+			Longitude: stop.Location.Lng,
+		}
+	}
+	return response
+}
+
+// Helper method to check that the actor may see the collector's route
+func (c *CollectorRouteUsecase) checkRouteAccess(tx *gorm.DB, request *model.GetCollectorRouteRequest) error {
+	switch request.ActorRole {
+	case "admin":
+		return nil
+	case "waste_collector_unit", "waste_collector_central":
+		if request.CollectorID == request.ActorID {
+			return nil
+		}
+	case "waste_bank_unit", "waste_bank_central":
+		collectorManagement := new(entity.CollectorManagement)
+		if err := c.CollectorManagementRepository.FindByWasteBankAndCollector(tx, collectorManagement, request.ActorID, request.CollectorID); err == nil {
+			return nil
+		}
+	}
+	return fiber.NewError(fiber.StatusForbidden, "You are not allowed to view this collector's route")
+}
+
+// GetRoute orders the collector's assigned stops for the day and estimates when each is reached
+func (c *CollectorRouteUsecase) GetRoute(ctx context.Context, request *model.GetCollectorRouteRequest) (*model.CollectorRouteResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", request.Date, timezone.WIB)
+	if err != nil {
+		c.Log.Warnf("Invalid route date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Date must be in YYYY-MM-DD format")
+	}
+
+	options := c.RouteOptions
+	if request.StartTime != "" {
+		departure, err := time.Parse("15:04", request.StartTime)
+		if err != nil {
+			c.Log.Warnf("Invalid route start time: %+v", err)
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Start time must be in HH:MM format")
+		}
+		options.DepartureMinute = departure.Hour()*60 + departure.Minute()
+	}
+
+	if err := c.checkRouteAccess(tx, request); err != nil {
+		c.Log.Warnf("Actor %s cannot view route of collector %s", request.ActorID, request.CollectorID)
+		return nil, err
+	}
+
+	var start *types.Point
+	if request.Start != nil {
+		start = &types.Point{Lat: request.Start.Latitude, Lng: request.Start.Longitude}
+	} else {
+		collector := new(entity.User)
+		if err := c.UserRepository.FindById(tx, collector, request.CollectorID); err != nil {
+			c.Log.Warnf("Failed to find collector by ID: %+v", err)
+			return nil, fiber.ErrNotFound
+		}
+		start = collector.Location
+	}
+
+	stops, err := c.CollectorRouteRepository.FindStops(tx, request.CollectorID, date.Format("2006-01-02"))
+	if err != nil {
+		c.Log.Warnf("Failed to find route stops: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.CollectorRouteResponse{
+		CollectorID:   request.CollectorID,
+		Date:          date.Format("2006-01-02"),
+		DepartureTime: formatRouteMinute(options.DepartureMinute),
+		Stops:         []model.CollectorRouteStopResponse{},
+		Unrouted:      []model.CollectorRouteStopResponse{},
+	}
+	if start != nil {
+		response.StartLocation = &model.LocationResponse{Latitude: start.Lat, Longitude: start.Lng}
+	}
+
+	routable := make(map[string]*entity.CollectorRouteStop, len(stops))
+	for i := range stops {
+		if stops[i].Location == nil {
+			response.Unrouted = append(response.Unrouted, routeStopToResponse(&stops[i]))
+			continue
+		}
+		routable[routeStopKey(&stops[i])] = &stops[i]
+	}
+
+	planKey := fmt.Sprintf("%d", options.DepartureMinute)
+	if start != nil {
+		planKey += fmt.Sprintf(":%.6f,%.6f", start.Lat, start.Lng)
+	}
+	cacheKey := fmt.Sprintf("%s:%s:%s", request.CollectorID, response.Date, planKey)
+
+	route := c.cachedRoute(cacheKey, routable)
+	if route == nil {
+		route = c.loadRoute(tx, request.CollectorID, response.Date, planKey, routable)
+		if route == nil {
+			if route, err = c.planRoute(tx, start, stops, routable, options); err != nil {
+				c.Log.Warnf("Failed to plan collector route: %+v", err)
+				return nil, fiber.ErrInternalServerError
+			}
+			route.expiresAt = time.Now().Add(collectorRouteCacheTTL)
+			c.saveRoute(tx, request.CollectorID, date, planKey, route)
+		}
+		c.storeRoute(cacheKey, route)
+	}
+
+	// Stops dropped since the route was planned are skipped, the rest keep their order
+	order := make([]int, 0, len(routable))
+	for _, stop := range route.Order {
+		if _, ok := routable[route.Keys[stop]]; ok {
+			order = append(order, stop)
+		}
+	}
+	plan := helper.ScheduleRoute(order, route.Stops, route.Matrix, start != nil, options)
+
+	for i, visit := range plan.Visits {
+		stop := routeStopToResponse(routable[route.Keys[visit.Stop]])
+		stop.Sequence = i + 1
+		stop.EstimatedArrival = formatRouteMinute(visit.ArrivalMinute)
+		stop.EstimatedDeparture = formatRouteMinute(visit.DepartMinute)
+		stop.WaitMinutes = visit.WaitMinutes
+		stop.LateMinutes = visit.LateMinutes
+		stop.DistanceFromPreviousMeters = visit.DistanceMeters
+		response.Stops = append(response.Stops, stop)
+	}
+	response.TotalDistanceMeters = plan.TotalDistanceMeters
+	response.FinishTime = formatRouteMinute(plan.FinishMinute)
+	response.TotalDurationMinutes = plan.FinishMinute - options.DepartureMinute
+	response.LateStops = plan.LateStops
+
+	return response, nil
+}
+
+// Helper method to plan a route from scratch, including the distance matrix
+func (c *CollectorRouteUsecase) planRoute(tx *gorm.DB, start *types.Point, stops []entity.CollectorRouteStop, routable map[string]*entity.CollectorRouteStop, options helper.RouteOptions) (*cachedCollectorRoute, error) {
+	route := &cachedCollectorRoute{
+		Keys:  make([]string, 0, len(routable)),
+		Stops: make([]helper.RouteStop, 0, len(routable)),
+	}
+
+	// Matrix row 0 is the start, which is unused when the collector has no known location
+	points := []types.Point{{}}
+	if start != nil {
+		points[0] = *start
+	}
+	for i := range stops {
+		if stops[i].Location == nil {
+			continue
+		}
+		route.Keys = append(route.Keys, routeStopKey(&stops[i]))
+		route.Stops = append(route.Stops, helper.RouteStop{
+			WindowStart: routeMinute(stops[i].StartTime, 0),
+			WindowEnd:   routeMinute(stops[i].EndTime, helper.MinutesPerDay),
+		})
+		points = append(points, *stops[i].Location)
+	}
+
+	matrix, err := c.CollectorRouteRepository.DistanceMatrix(tx, points)
+	if err != nil {
+		return nil, err
+	}
+	route.Matrix = matrix
+
+	plan := helper.PlanRoute(route.Stops, route.Matrix, start != nil, options)
+	route.Order = make([]int, len(plan.Visits))
+	for i, visit := range plan.Visits {
+		route.Order[i] = visit.Stop
+	}
+
+	return route, nil
+}
+
+// Helper method to check that a planned route covers every current stop
+func routeCovers(route *cachedCollectorRoute, routable map[string]*entity.CollectorRouteStop) bool {
+	known := make(map[string]bool, len(route.Keys))
+	for _, key := range route.Keys {
+		known[key] = true
+	}
+	for key := range routable {
+		if !known[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper method to find a cached route covering every current stop
+func (c *CollectorRouteUsecase) cachedRoute(cacheKey string, routable map[string]*entity.CollectorRouteStop) *cachedCollectorRoute {
+	c.cacheMutex.Lock()
+	defer c.cacheMutex.Unlock()
+
+	route, ok := c.cache[cacheKey]
+	if !ok || time.Now().After(route.expiresAt) || !routeCovers(route, routable) {
+		return nil
+	}
+	return route
+}
+
+// Helper method to read back a route planned by any process, nil when there is none covering every current stop
+func (c *CollectorRouteUsecase) loadRoute(tx *gorm.DB, collectorID string, date string, planKey string, routable map[string]*entity.CollectorRouteStop) *cachedCollectorRoute {
+	stored := new(entity.CollectorRoutePlan)
+	if err := c.CollectorRouteRepository.FindPlan(tx, stored, collectorID, date, planKey); err != nil {
+		if err != gorm.ErrRecordNotFound {
+			c.Log.Warnf("Failed to find collector route plan: %+v", err)
+		}
+		return nil
+	}
+
+	route := new(cachedCollectorRoute)
+	if err := json.Unmarshal([]byte(stored.Plan), route); err != nil {
+		c.Log.Warnf("Failed to decode collector route plan: %+v", err)
+		return nil
+	}
+	if !routeCovers(route, routable) {
+		return nil
+	}
+	route.expiresAt = stored.ExpiresAt
+	return route
+}
+
+// Helper method to share a newly planned route with the other processes. Failing to store it only costs a
+// replan elsewhere, so the route is still returned.
+func (c *CollectorRouteUsecase) saveRoute(tx *gorm.DB, collectorID string, date time.Time, planKey string, route *cachedCollectorRoute) {
+	collectorUUID, err := uuid.Parse(collectorID)
+	if err != nil {
+		c.Log.Warnf("Invalid collector ID: %+v", err)
+		return
+	}
+	plan, err := json.Marshal(route)
+	if err != nil {
+		c.Log.Warnf("Failed to encode collector route plan: %+v", err)
+		return
+	}
+
+	if err := c.CollectorRouteRepository.SavePlan(tx, &entity.CollectorRoutePlan{
+		CollectorID: collectorUUID,
+		RouteDate:   date,
+		PlanKey:     planKey,
+		Plan:        string(plan),
+		ExpiresAt:   route.expiresAt,
+	}); err != nil {
+		c.Log.Warnf("Failed to save collector route plan: %+v", err)
+	}
+}
+
+func (c *CollectorRouteUsecase) storeRoute(cacheKey string, route *cachedCollectorRoute) {
+	c.cacheMutex.Lock()
+	defer c.cacheMutex.Unlock()
+
+	now := time.Now()
+	for key, cached := range c.cache {
+		if now.After(cached.expiresAt) {
+			delete(c.cache, key)
+		}
+	}
+
+	c.cache[cacheKey] = route
+}
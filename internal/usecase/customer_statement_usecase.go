@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/pkg/pdf"
+	"github.com/wastetrack/wastetrack-backend/pkg/timezone"
+	"gorm.io/gorm"
+)
+
+type CustomerStatementUsecase struct {
+	DB                         *gorm.DB
+	Log                        *logrus.Logger
+	Validate                   *validator.Validate
+	UserRepository             *repository.UserRepository
+	WasteDropRequestRepository *repository.WasteDropRequestRepository
+	LedgerAccountRepository    *repository.LedgerAccountRepository
+	LedgerEntryRepository      *repository.LedgerEntryRepository
+}
+
+func NewCustomerStatementUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	userRepository *repository.UserRepository,
+	wasteDropRequestRepository *repository.WasteDropRequestRepository,
+	ledgerAccountRepository *repository.LedgerAccountRepository,
+	ledgerEntryRepository *repository.LedgerEntryRepository,
+) *CustomerStatementUsecase {
+	return &CustomerStatementUsecase{
+		DB:                         db,
+		Log:                        log,
+		Validate:                   validate,
+		UserRepository:             userRepository,
+		WasteDropRequestRepository: wasteDropRequestRepository,
+		LedgerAccountRepository:    ledgerAccountRepository,
+		LedgerEntryRepository:      ledgerEntryRepository,
+	}
+}
+
+// Get builds the month by month statement of a customer. Weights and impact come from the completed drop items,
+// points from the ledger, so the lifetime counters on the customer profile are not used.
+func (c *CustomerStatementUsecase) Get(ctx context.Context, request *model.CustomerStatementRequest) (*model.CustomerStatementResponse, error) {
+	db := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+	if err := validateMonthRange(request.StartMonth, request.EndMonth); err != nil {
+		return nil, err
+	}
+
+	// Customers only see their own statement, admins pick one
+	if request.ActorRole != "admin" {
+		request.CustomerID = request.ActorID
+	}
+	if request.CustomerID == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "customer_id is required")
+	}
+
+	customer := new(entity.User)
+	if err := c.UserRepository.FindById(db, customer, request.CustomerID); err != nil {
+		c.Log.Warnf("Failed to find customer: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if customer.Role != "customer" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "User is not a customer")
+	}
+
+	dropRows, err := c.WasteDropRequestRepository.GetCustomerStatement(db, request.CustomerID, request.StartMonth, request.EndMonth)
+	if err != nil {
+		c.Log.Warnf("Failed to get customer drops: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	var pointsRows []model.CustomerStatementPointsRow
+	var openingBalance int64
+	account := new(entity.LedgerAccount)
+	if err := c.LedgerAccountRepository.FindUserAccount(db, account, request.CustomerID, "points"); err != nil {
+		if err != gorm.ErrRecordNotFound {
+			c.Log.Warnf("Failed to find ledger account: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		// No postings yet, every month has no points movement
+	} else {
+		if request.StartMonth != "" {
+			start, _ := time.ParseInLocation("2006-01", request.StartMonth, timezone.WIB)
+			if openingBalance, err = c.LedgerAccountRepository.GetBalanceBefore(db, account.ID, start); err != nil {
+				c.Log.Warnf("Failed to get opening balance: %+v", err)
+				return nil, fiber.ErrInternalServerError
+			}
+		}
+		if pointsRows, err = c.LedgerEntryRepository.GetMonthlyPointsMovements(db, account.ID, request.StartMonth, request.EndMonth); err != nil {
+			c.Log.Warnf("Failed to get points movements: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	response := &model.CustomerStatementResponse{
+		CustomerID:     request.CustomerID,
+		CustomerName:   customer.Username,
+		StartMonth:     request.StartMonth,
+		EndMonth:       request.EndMonth,
+		OpeningBalance: openingBalance,
+		Months:         []model.CustomerStatementMonth{},
+	}
+
+	months := make(map[string]*model.CustomerStatementMonth)
+	month := func(key string) *model.CustomerStatementMonth {
+		if months[key] == nil {
+			months[key] = &model.CustomerStatementMonth{Month: key, WasteTypes: []model.CustomerStatementWasteType{}}
+		}
+		return months[key]
+	}
+
+	for _, row := range dropRows {
+		m := month(row.Month)
+		m.Drops = row.Drops
+		m.VerifiedWeight += row.Weight
+		m.Impact.Co2e += row.Co2e
+		m.Impact.WaterLiters += row.WaterLiters
+		m.Impact.EnergyKwh += row.EnergyKwh
+		m.Impact.Trees += row.Trees
+		m.WasteTypes = append(m.WasteTypes, model.CustomerStatementWasteType{
+			WasteTypeID:   row.WasteTypeID,
+			WasteTypeName: row.WasteTypeName,
+			Weight:        row.Weight,
+			Points:        row.Points,
+		})
+	}
+
+	netByMonth := make(map[string]int64)
+	for _, row := range pointsRows {
+		m := month(row.Month)
+		m.PointsEarned = row.Earned
+		m.PointsRedeemed = row.Redeemed
+		netByMonth[row.Month] = row.Net
+	}
+
+	keys := make([]string, 0, len(months))
+	for key := range months {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	balance := openingBalance
+	totals := &response.Totals
+	for _, key := range keys {
+		m := months[key]
+		balance += netByMonth[key]
+		m.ClosingBalance = balance
+		response.Months = append(response.Months, *m)
+
+		totals.Drops += m.Drops
+		totals.VerifiedWeight += m.VerifiedWeight
+		totals.PointsEarned += m.PointsEarned
+		totals.PointsRedeemed += m.PointsRedeemed
+		totals.Impact.Co2e += m.Impact.Co2e
+		totals.Impact.WaterLiters += m.Impact.WaterLiters
+		totals.Impact.EnergyKwh += m.Impact.EnergyKwh
+		totals.Impact.Trees += m.Impact.Trees
+	}
+	totals.ClosingBalance = balance
+
+	return response, nil
+}
+
+// StatementToCSV writes one row per month and waste type, months without drops get a single row
+func (c *CustomerStatementUsecase) StatementToCSV(statement *model.CustomerStatementResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	rows := [][]string{{
+		"month", "drops", "waste_type", "weight_kgs", "points", "month_weight_kgs", "points_earned", "points_redeemed",
+		"closing_balance", "co2e_kg", "water_liters", "energy_kwh", "trees",
+	}}
+	for _, m := range statement.Months {
+		monthColumns := []string{
+			formatDecimal(m.VerifiedWeight),
+			strconv.FormatInt(m.PointsEarned, 10),
+			strconv.FormatInt(m.PointsRedeemed, 10),
+			strconv.FormatInt(m.ClosingBalance, 10),
+			formatDecimal(m.Impact.Co2e),
+			formatDecimal(m.Impact.WaterLiters),
+			formatDecimal(m.Impact.EnergyKwh),
+			formatDecimal(m.Impact.Trees),
+		}
+		if len(m.WasteTypes) == 0 {
+			rows = append(rows, append([]string{m.Month, strconv.FormatInt(m.Drops, 10), "", "", ""}, monthColumns...))
+			continue
+		}
+		for _, wasteType := range m.WasteTypes {
+			rows = append(rows, append([]string{
+				m.Month,
+				strconv.FormatInt(m.Drops, 10),
+				wasteType.WasteTypeName,
+				formatDecimal(wasteType.Weight),
+				strconv.FormatInt(wasteType.Points, 10),
+			}, monthColumns...))
+		}
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		c.Log.Warnf("Failed to write statement csv: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return buf.Bytes(), nil
+}
+
+// StatementToPDF renders the statement as a passbook (buku tabungan sampah)
+func (c *CustomerStatementUsecase) StatementToPDF(statement *model.CustomerStatementResponse) []byte {
+	doc := pdf.NewDocument()
+
+	period := "Semua periode"
+	if statement.StartMonth != "" || statement.EndMonth != "" {
+		period = fmt.Sprintf("%s s/d %s", valueOr(statement.StartMonth, "awal"), valueOr(statement.EndMonth, "sekarang"))
+	}
+
+	doc.Line("BUKU TABUNGAN SAMPAH - WASTETRACK", true)
+	doc.Line(fmt.Sprintf("Nasabah : %s", statement.CustomerName), false)
+	doc.Line(fmt.Sprintf("ID      : %s", statement.CustomerID), false)
+	doc.Line(fmt.Sprintf("Periode : %s", period), false)
+	doc.Line(fmt.Sprintf("Dicetak : %s", time.Now().In(timezone.WIB).Format("2006-01-02 15:04 WIB")), false)
+	doc.Blank()
+
+	doc.Line(fmt.Sprintf("%-8s %6s %12s %10s %10s %12s", "Bulan", "Setor", "Berat (kg)", "Poin +", "Poin -", "Saldo"), true)
+	doc.Line(fmt.Sprintf("%-8s %6s %12s %10s %10s %12d", "Awal", "", "", "", "", statement.OpeningBalance), false)
+	for _, m := range statement.Months {
+		doc.Line(fmt.Sprintf("%-8s %6d %12s %10d %10d %12d",
+			m.Month, m.Drops, formatDecimal(m.VerifiedWeight), m.PointsEarned, m.PointsRedeemed, m.ClosingBalance), false)
+		for _, wasteType := range m.WasteTypes {
+			doc.Line(fmt.Sprintf("  - %-30.30s %12s kg %10d poin", wasteType.WasteTypeName, formatDecimal(wasteType.Weight), wasteType.Points), false)
+		}
+	}
+	totals := statement.Totals
+	doc.Line(fmt.Sprintf("%-8s %6d %12s %10d %10d %12d",
+		"Total", totals.Drops, formatDecimal(totals.VerifiedWeight), totals.PointsEarned, totals.PointsRedeemed, totals.ClosingBalance), true)
+	doc.Blank()
+
+	doc.Line("Dampak lingkungan", true)
+	doc.Line(fmt.Sprintf("Emisi CO2e dicegah : %s kg", formatDecimal(totals.Impact.Co2e)), false)
+	doc.Line(fmt.Sprintf("Air dihemat        : %s liter", formatDecimal(totals.Impact.WaterLiters)), false)
+	doc.Line(fmt.Sprintf("Energi dihemat     : %s kWh", formatDecimal(totals.Impact.EnergyKwh)), false)
+	doc.Line(fmt.Sprintf("Setara pohon       : %s", formatDecimal(totals.Impact.Trees)), false)
+
+	return doc.Bytes()
+}
+
+func formatDecimal(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+func valueOr(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+type DailyWasteRollupUsecase struct {
+	DB                         *gorm.DB
+	Log                        *logrus.Logger
+	Validate                   *validator.Validate
+	DailyWasteRollupRepository *repository.DailyWasteRollupRepository
+}
+
+func NewDailyWasteRollupUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	dailyWasteRollupRepository *repository.DailyWasteRollupRepository,
+) *DailyWasteRollupUsecase {
+	return &DailyWasteRollupUsecase{
+		DB:                         db,
+		Log:                        log,
+		Validate:                   validate,
+		DailyWasteRollupRepository: dailyWasteRollupRepository,
+	}
+}
+
+// Rebuild recomputes the rollups from the item tables, for instance after fixing historical requests
+func (c *DailyWasteRollupUsecase) Rebuild(ctx context.Context, request *model.RebuildDailyWasteRollupRequest) (*model.RebuildDailyWasteRollupResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	var since *time.Time
+	if request.Since != "" {
+		day, err := time.Parse("2006-01-02", request.Since)
+		if err != nil {
+			return nil, fiber.ErrBadRequest
+		}
+		since = &day
+	}
+
+	rows, err := c.DailyWasteRollupRepository.Rebuild(tx, since)
+	if err != nil {
+		c.Log.Warnf("Failed to rebuild daily waste rollups: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.RebuildDailyWasteRollupResponse{Rows: rows}, nil
+}
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/helper"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	FileOwnerWasteDropRequest     = "waste_drop_request"
+	FileOwnerWasteTransferRequest = "waste_transfer_request"
+	FileOwnerUserAvatar           = "user_avatar"
+
+	// Uploads stay attached for this long before the cleanup job may treat them as orphaned
+	orphanFileGracePeriod = time.Hour
+	orphanFileBatchSize   = 100
+)
+
+type FileUsecase struct {
+	DB                             *gorm.DB
+	Log                            *logrus.Logger
+	Validate                       *validator.Validate
+	FileStorage                    repository.FileStorage
+	UploadedFileRepository         *repository.UploadedFileRepository
+	UserRepository                 *repository.UserRepository
+	WasteDropRequestRepository     *repository.WasteDropRequestRepository
+	WasteTransferRequestRepository *repository.WasteTransferRequestRepository
+	MaxUploadSize                  int64
+	ThumbnailSize                  int
+}
+
+func NewFileUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	fileStorage repository.FileStorage,
+	uploadedFileRepository *repository.UploadedFileRepository,
+	userRepository *repository.UserRepository,
+	wasteDropRequestRepository *repository.WasteDropRequestRepository,
+	wasteTransferRequestRepository *repository.WasteTransferRequestRepository,
+	maxUploadSize int64,
+	thumbnailSize int,
+) *FileUsecase {
+	return &FileUsecase{
+		DB:                             db,
+		Log:                            log,
+		Validate:                       validate,
+		FileStorage:                    fileStorage,
+		UploadedFileRepository:         uploadedFileRepository,
+		UserRepository:                 userRepository,
+		WasteDropRequestRepository:     wasteDropRequestRepository,
+		WasteTransferRequestRepository: wasteTransferRequestRepository,
+		MaxUploadSize:                  maxUploadSize,
+		ThumbnailSize:                  thumbnailSize,
+	}
+}
+
+func (c *FileUsecase) UploadWasteDropRequestImage(ctx context.Context, request *model.UploadFileRequest) (*model.UploadedFileResponse, error) {
+	return c.upload(ctx, FileOwnerWasteDropRequest, "waste-drop-requests", request)
+}
+
+func (c *FileUsecase) UploadWasteTransferRequestImage(ctx context.Context, request *model.UploadFileRequest) (*model.UploadedFileResponse, error) {
+	return c.upload(ctx, FileOwnerWasteTransferRequest, "waste-transfer-requests", request)
+}
+
+func (c *FileUsecase) UploadAvatar(ctx context.Context, request *model.UploadFileRequest) (*model.UploadedFileResponse, error) {
+	return c.upload(ctx, FileOwnerUserAvatar, "avatars", request)
+}
+
+// Helper method to check the actor may attach a file to the owner, locking the owner row when db is a transaction.
+// Returns the URL the owner points at now, which the new file replaces.
+func (c *FileUsecase) checkOwnerAccess(db *gorm.DB, ownerType string, request *model.UploadFileRequest) (string, error) {
+	isActor := func(id *uuid.UUID) bool {
+		return id != nil && id.String() == request.ActorID
+	}
+
+	switch ownerType {
+	case FileOwnerWasteDropRequest:
+		wasteDropRequest := new(entity.WasteDropRequest)
+		if err := c.WasteDropRequestRepository.FindById(db, wasteDropRequest, request.OwnerID); err != nil || wasteDropRequest.IsDeleted {
+			return "", fiber.NewError(fiber.StatusNotFound, "Waste drop request not found")
+		}
+		if request.ActorRole == "admin" || isActor(&wasteDropRequest.CustomerID) || isActor(wasteDropRequest.WasteBankID) || isActor(wasteDropRequest.AssignedCollectorID) {
+			return wasteDropRequest.ImageURL, nil
+		}
+	case FileOwnerWasteTransferRequest:
+		wasteTransferRequest := new(entity.WasteTransferRequest)
+		if err := c.WasteTransferRequestRepository.FindById(db, wasteTransferRequest, request.OwnerID); err != nil || wasteTransferRequest.IsDeleted {
+			return "", fiber.NewError(fiber.StatusNotFound, "Waste transfer request not found")
+		}
+		if request.ActorRole == "admin" || isActor(&wasteTransferRequest.SourceUserID) || isActor(&wasteTransferRequest.DestinationUserID) || isActor(wasteTransferRequest.AssignedCollectorID) {
+			return wasteTransferRequest.ImageURL, nil
+		}
+	case FileOwnerUserAvatar:
+		user := new(entity.User)
+		if err := c.UserRepository.FindById(db, user, request.OwnerID); err != nil {
+			return "", fiber.NewError(fiber.StatusNotFound, "User not found")
+		}
+		if isActor(&user.ID) {
+			return user.AvatarURL, nil
+		}
+	}
+	return "", fiber.NewError(fiber.StatusForbidden, "You are not allowed to upload a file here")
+}
+
+// Helper method to point the owner at the uploaded file
+func (c *FileUsecase) attachToOwner(tx *gorm.DB, ownerType string, ownerID string, url string, thumbnailURL string) error {
+	switch ownerType {
+	case FileOwnerWasteDropRequest:
+		return c.WasteDropRequestRepository.UpdateImage(tx, ownerID, url, thumbnailURL)
+	case FileOwnerWasteTransferRequest:
+		return c.WasteTransferRequestRepository.UpdateImage(tx, ownerID, url, thumbnailURL)
+	default:
+		return c.UserRepository.UpdateAvatar(tx, ownerID, url, thumbnailURL)
+	}
+}
+
+// Helper method to remove a file's objects from storage
+func (c *FileUsecase) deleteObjects(ctx context.Context, file *entity.UploadedFile) error {
+	if err := c.FileStorage.Delete(ctx, file.ObjectName); err != nil {
+		return err
+	}
+	if file.ThumbnailObjectName != "" {
+		return c.FileStorage.Delete(ctx, file.ThumbnailObjectName)
+	}
+	return nil
+}
+
+func (c *FileUsecase) upload(ctx context.Context, ownerType string, prefix string, request *model.UploadFileRequest) (*model.UploadedFileResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+	if request.File.Size > c.MaxUploadSize {
+		return nil, fiber.NewError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("File must be at most %d bytes", c.MaxUploadSize))
+	}
+
+	// Checked before processing so unauthorized uploads never reach storage
+	if _, err := c.checkOwnerAccess(c.DB.WithContext(ctx), ownerType, request); err != nil {
+		return nil, err
+	}
+
+	source, err := request.File.Open()
+	if err != nil {
+		c.Log.Warnf("Failed to open uploaded file: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+	defer source.Close()
+
+	// The header size is client supplied, so the read is capped as well
+	data, err := io.ReadAll(io.LimitReader(source, c.MaxUploadSize+1))
+	if err != nil {
+		c.Log.Warnf("Failed to read uploaded file: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+	if int64(len(data)) > c.MaxUploadSize {
+		return nil, fiber.NewError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("File must be at most %d bytes", c.MaxUploadSize))
+	}
+
+	processed, err := helper.ProcessImage(data, c.ThumbnailSize)
+	if err != nil {
+		if errors.Is(err, helper.ErrUnsupportedImage) || errors.Is(err, helper.ErrImageTooLarge) {
+			return nil, fiber.NewError(fiber.StatusUnsupportedMediaType, err.Error())
+		}
+		c.Log.Warnf("Failed to process image: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	fileID := uuid.New()
+	file := &entity.UploadedFile{
+		ID:                  fileID,
+		OwnerType:           ownerType,
+		OwnerID:             uuid.MustParse(request.OwnerID),
+		ObjectName:          fmt.Sprintf("%s/%s/%s%s", prefix, request.OwnerID, fileID, processed.Extension),
+		ThumbnailObjectName: fmt.Sprintf("%s/%s/%s_thumb%s", prefix, request.OwnerID, fileID, processed.Extension),
+		ContentType:         processed.ContentType,
+		SizeBytes:           int64(len(processed.Data)),
+		Width:               processed.Width,
+		Height:              processed.Height,
+	}
+	if actorID, err := uuid.Parse(request.ActorID); err == nil {
+		file.UploadedByID = &actorID
+	}
+
+	if file.URL, err = c.FileStorage.Upload(ctx, file.ObjectName, bytes.NewReader(processed.Data), processed.ContentType); err != nil {
+		c.Log.Warnf("Failed to store file: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if file.ThumbnailURL, err = c.FileStorage.Upload(ctx, file.ThumbnailObjectName, bytes.NewReader(processed.Thumbnail), processed.ContentType); err != nil {
+		c.Log.Warnf("Failed to store thumbnail: %+v", err)
+		file.ThumbnailObjectName = ""
+		if err := c.deleteObjects(ctx, file); err != nil {
+			c.Log.Warnf("Failed to remove stored file: %+v", err)
+		}
+		return nil, fiber.ErrInternalServerError
+	}
+
+	replaced, err := c.saveUpload(ctx, ownerType, request, file)
+	if err != nil {
+		// Nothing points at the objects, so they are removed right away instead of waiting for the job
+		if deleteErr := c.deleteObjects(ctx, file); deleteErr != nil {
+			c.Log.Warnf("Failed to remove stored file: %+v", deleteErr)
+		}
+		return nil, err
+	}
+
+	// The replaced file is an orphan now, the cleanup job retries it if this fails
+	if replaced != nil {
+		if err := c.deleteObjects(ctx, replaced); err != nil {
+			c.Log.Warnf("Failed to remove replaced file: %+v", err)
+		} else if err := c.UploadedFileRepository.Delete(c.DB.WithContext(ctx), replaced); err != nil {
+			c.Log.Warnf("Failed to delete replaced file record: %+v", err)
+		}
+	}
+
+	return converter.UploadedFileToResponse(file), nil
+}
+
+// Helper method to record the file and attach it to its owner in one transaction.
+// Returns the file the owner pointed at before, if it was uploaded here.
+func (c *FileUsecase) saveUpload(ctx context.Context, ownerType string, request *model.UploadFileRequest, file *entity.UploadedFile) (*entity.UploadedFile, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// The owner may have been deleted while the image was processed. The lock also keeps
+	// overlapping uploads from replacing the same previous file.
+	previousURL, err := c.checkOwnerAccess(tx.Clauses(clause.Locking{Strength: "UPDATE"}), ownerType, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var replaced *entity.UploadedFile
+	if previousURL != "" {
+		previous := new(entity.UploadedFile)
+		err := c.UploadedFileRepository.FindByOwnerURL(tx, previous, ownerType, request.OwnerID, previousURL)
+		if err == nil {
+			replaced = previous
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Failed to find replaced file: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := c.UploadedFileRepository.Create(tx, file); err != nil {
+		c.Log.Warnf("Failed to create uploaded file: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.attachToOwner(tx, ownerType, request.OwnerID, file.URL, file.ThumbnailURL); err != nil {
+		c.Log.Warnf("Failed to attach uploaded file: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Commit error: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return replaced, nil
+}
+
+// CleanupOrphans removes stored files whose owner was deleted or replaced them
+func (c *FileUsecase) CleanupOrphans(ctx context.Context) (int, error) {
+	db := c.DB.WithContext(ctx)
+
+	files, err := c.UploadedFileRepository.FindOrphans(db, time.Now().Add(-orphanFileGracePeriod), orphanFileBatchSize)
+	if err != nil {
+		c.Log.Warnf("Failed to find orphaned files: %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	removed := 0
+	for i := range files {
+		if err := c.deleteObjects(ctx, &files[i]); err != nil {
+			c.Log.Warnf("Failed to remove orphaned file %s: %+v", files[i].ObjectName, err)
+			continue
+		}
+		if err := c.UploadedFileRepository.Delete(db, &files[i]); err != nil {
+			c.Log.Warnf("Failed to delete orphaned file record: %+v", err)
+			continue
+		}
+		removed++
+	}
+
+	return removed, nil
+}
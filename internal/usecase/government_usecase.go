@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/wastetrack/wastetrack-backend/internal/entity"
 	"github.com/wastetrack/wastetrack-backend/internal/helper"
 	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
 	"github.com/wastetrack/wastetrack-backend/internal/repository"
 	"gorm.io/gorm"
 )
@@ -24,6 +26,10 @@ type GovernmentUseCase struct {
 	WasteTransferItemRepository    *repository.WasteTransferItemOfferingRepository
 	WasteTransferRequestRepository *repository.WasteTransferRequestRepository
 	StorageRepository              *repository.StorageRepository
+	ImpactFactorRepository         *repository.ImpactFactorRepository
+	RegionRepository               *repository.RegionRepository
+	WasteDropRequestRepository     *repository.WasteDropRequestRepository
+	DailyWasteRollupRepository     *repository.DailyWasteRollupRepository
 }
 
 func NewGovernmentUseCase(
@@ -35,6 +41,10 @@ func NewGovernmentUseCase(
 	wasteTransferItemRepository *repository.WasteTransferItemOfferingRepository,
 	wasteTransferRequestRepository *repository.WasteTransferRequestRepository,
 	storageRepository *repository.StorageRepository,
+	impactFactorRepository *repository.ImpactFactorRepository,
+	regionRepository *repository.RegionRepository,
+	wasteDropRequestRepository *repository.WasteDropRequestRepository,
+	dailyWasteRollupRepository *repository.DailyWasteRollupRepository,
 ) *GovernmentUseCase {
 	return &GovernmentUseCase{
 		DB:                             db,
@@ -45,6 +55,10 @@ func NewGovernmentUseCase(
 		WasteTransferItemRepository:    wasteTransferItemRepository,
 		WasteTransferRequestRepository: wasteTransferRequestRepository,
 		StorageRepository:              storageRepository,
+		ImpactFactorRepository:         impactFactorRepository,
+		RegionRepository:               regionRepository,
+		WasteDropRequestRepository:     wasteDropRequestRepository,
+		DailyWasteRollupRepository:     dailyWasteRollupRepository,
 	}
 }
 
@@ -72,6 +86,10 @@ func (uc *GovernmentUseCase) GetDashboard(request *model.GovernmentDashboardRequ
 	// Log the dashboard request
 	uc.Log.Infof("Getting government dashboard data for period %s to %s", request.StartMonth, request.EndMonth)
 
+	// Weights come from the daily rollups, except for the free text province and city filters which
+	// match user addresses the rollups do not keep
+	useRollups := request.Province == "" && request.City == ""
+
 	// Get basic statistics (total bank sampah and total offtakers)
 	totalBankSampah, err := uc.UserRepository.CountWasteBanks(uc.DB, request)
 	if err != nil {
@@ -100,7 +118,12 @@ func (uc *GovernmentUseCase) GetDashboard(request *model.GovernmentDashboardRequ
 	}
 
 	// Get total collected waste
-	totalCollected, err := uc.WasteDropRequestItemRepository.GetTotalCollectedWaste(uc.DB, request)
+	var totalCollected float64
+	if useRollups {
+		totalCollected, err = uc.DailyWasteRollupRepository.SumWeight(uc.DB, request, []string{"drop"})
+	} else {
+		totalCollected, err = uc.WasteDropRequestItemRepository.GetTotalCollectedWaste(uc.DB, request)
+	}
 	if err != nil {
 		uc.Log.Errorf("Failed to get total collected waste: %v", err)
 
@@ -113,9 +136,39 @@ func (uc *GovernmentUseCase) GetDashboard(request *model.GovernmentDashboardRequ
 		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve collected waste data")
 	}
 
+	// Get environmental impact of the collected waste
+	var impact *entity.EnvironmentalImpact
+	if useRollups {
+		impact, err = uc.DailyWasteRollupRepository.GetEnvironmentalImpact(uc.DB, request)
+	} else {
+		impact, err = uc.ImpactFactorRepository.GetEnvironmentalImpact(uc.DB, request)
+	}
+	if err != nil {
+		uc.Log.Errorf("Failed to get environmental impact: %v", err)
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "format") {
+			return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve environmental impact data")
+	}
+
 	// Get collection trends by role (combining waste drop and waste transfer)
 	var collectionTrends []model.CollectionTrendByRole
-	if request.StartMonth != "" && request.EndMonth != "" {
+	if request.StartMonth != "" && request.EndMonth != "" && useRollups {
+		wasteDropTrends, err := uc.DailyWasteRollupRepository.GetMonthlyWeightByRole(uc.DB, request, []string{"drop"})
+		if err != nil {
+			uc.Log.Errorf("Failed to get waste drop collection trends: %v", err)
+			return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve waste drop trends data")
+		}
+
+		wasteTransferTrends, err := uc.DailyWasteRollupRepository.GetMonthlyWeightByRole(uc.DB, request, []string{"waste_bank_transfer", "industry_transfer"})
+		if err != nil {
+			uc.Log.Errorf("Failed to get waste transfer collection trends: %v", err)
+			return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve waste transfer trends data")
+		}
+
+		combinedTrends := uc.combineTrends(wasteDropTrends, wasteTransferTrends)
+		collectionTrends = uc.formatTrendsResponse(combinedTrends, sumRoles(wasteDropTrends), sumRoles(wasteTransferTrends), request.StartMonth, request.EndMonth)
+	} else if request.StartMonth != "" && request.EndMonth != "" {
 		// Get waste drop trends
 		wasteDropTrends, err := uc.WasteDropRequestItemRepository.GetCollectionTrendsByRole(uc.DB, request)
 		if err != nil {
@@ -162,7 +215,12 @@ func (uc *GovernmentUseCase) GetDashboard(request *model.GovernmentDashboardRequ
 	}
 
 	// Get top offtakers
-	topOfftakers, err := uc.WasteTransferRequestRepository.GetTopOfftakers(uc.DB, request)
+	var topOfftakers []model.TopOfftaker
+	if useRollups {
+		topOfftakers, err = uc.DailyWasteRollupRepository.GetTopOfftakers(uc.DB, request)
+	} else {
+		topOfftakers, err = uc.WasteTransferRequestRepository.GetTopOfftakers(uc.DB, request)
+	}
 	if err != nil {
 		uc.Log.Errorf("Failed to get top offtakers: %v", err)
 		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "format") {
@@ -170,7 +228,7 @@ func (uc *GovernmentUseCase) GetDashboard(request *model.GovernmentDashboardRequ
 		}
 		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve top offtakers data")
 	}
-	largestBanks, err := uc.GetLargestBanks(request)
+	largestBanks, err := uc.GetLargestBanks(request, useRollups)
 	if err != nil {
 		uc.Log.Errorf("Failed to get largest waste banks: %v", err)
 		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "format") {
@@ -187,6 +245,7 @@ func (uc *GovernmentUseCase) GetDashboard(request *model.GovernmentDashboardRequ
 		CollectionTrends: collectionTrends,
 		TopOfftakers:     topOfftakers,
 		LargestBanks:     largestBanks, // NOW PROPERLY ASSIGNED
+		Impact:           converter.EnvironmentalImpactToResponse(impact),
 	}
 
 	uc.Log.Infof("Successfully retrieved complete dashboard data: %d bank sampah, %d offtakers, %.2f kg collected, %d trend months, %d top offtakers, %d largest banks",
@@ -195,63 +254,200 @@ func (uc *GovernmentUseCase) GetDashboard(request *model.GovernmentDashboardRequ
 	return response, nil
 }
 
+// regionLevels lists the region levels from the top, a level's index is the number of dots in its codes
+var regionLevels = []string{"province", "regency", "district", "village"}
+
+// GetRegionBreakdown returns the dashboard of every region one level below the parent region
+func (uc *GovernmentUseCase) GetRegionBreakdown(request *model.RegionBreakdownRequest) (*model.RegionBreakdownResponse, error) {
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.Warnf("Failed to validate region breakdown request: %v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("validation failed: %v", err))
+	}
+	if err := validateMonthRange(request.StartMonth, request.EndMonth); err != nil {
+		return nil, err
+	}
+
+	depth := 1
+	if request.ParentCode != "" {
+		parent := new(entity.Region)
+		if err := uc.RegionRepository.FindByCode(uc.DB, parent, request.ParentCode); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, fiber.NewError(fiber.StatusNotFound, "Region not found")
+			}
+			uc.Log.Errorf("Failed to find region: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		depth = strings.Count(parent.Code, ".") + 2
+		if depth > len(regionLevels) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Villages have no regions below them")
+		}
+	}
+	level := regionLevels[depth-1]
+
+	regions, err := uc.RegionRepository.GetDashboardBreakdown(uc.DB, request, level, depth, request.Format == "geojson")
+	if err != nil {
+		uc.Log.Errorf("Failed to get region breakdown: %v", err)
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve region breakdown data")
+	}
+	for i := range regions {
+		if regions[i].CollectedWeight > 0 {
+			regions[i].RecyclingRate = min(regions[i].OfftakenWeight/regions[i].CollectedWeight, 1)
+		}
+	}
+
+	return &model.RegionBreakdownResponse{
+		Level:      level,
+		ParentCode: request.ParentCode,
+		Regions:    regions,
+	}, nil
+}
+
+// GetDropHeatmap returns the drop request heatmap, in 1 km grid cells by default
+func (uc *GovernmentUseCase) GetDropHeatmap(request *model.DropHeatmapRequest) (*model.DropHeatmapResponse, error) {
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.Warnf("Failed to validate heatmap request: %v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("validation failed: %v", err))
+	}
+	if err := validateMonthRange(request.StartMonth, request.EndMonth); err != nil {
+		return nil, err
+	}
+
+	response := &model.DropHeatmapResponse{Mode: request.Mode}
+	if request.Mode == "geohash" {
+		if request.Precision == 0 {
+			request.Precision = 6
+		}
+		response.Precision = request.Precision
+	} else {
+		request.Mode = "grid"
+		if request.CellSize == 0 {
+			request.CellSize = 1000
+		}
+		response.Mode = request.Mode
+		response.CellSize = request.CellSize
+	}
+
+	cells, err := uc.WasteDropRequestRepository.GetHeatmap(uc.DB, request, request.Format == "geojson")
+	if err != nil {
+		uc.Log.Errorf("Failed to get drop request heatmap: %v", err)
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve heatmap data")
+	}
+	response.Cells = cells
+
+	return response, nil
+}
+
+// RegionBreakdownToGeoJSON turns a breakdown into a FeatureCollection for choropleth maps. Regions without
+// an imported boundary are kept with a null geometry so their totals are not lost.
+func (uc *GovernmentUseCase) RegionBreakdownToGeoJSON(response *model.RegionBreakdownResponse) *model.GeoJSONFeatureCollection {
+	collection := &model.GeoJSONFeatureCollection{Type: "FeatureCollection", Features: make([]model.GeoJSONFeature, 0, len(response.Regions))}
+	for _, region := range response.Regions {
+		collection.Features = append(collection.Features, model.GeoJSONFeature{
+			Type:       "Feature",
+			ID:         region.Code,
+			Geometry:   geoJSONGeometry(region.Geometry),
+			Properties: region,
+		})
+	}
+	return collection
+}
+
+// DropHeatmapToGeoJSON turns the heatmap into a FeatureCollection of cell outlines
+func (uc *GovernmentUseCase) DropHeatmapToGeoJSON(response *model.DropHeatmapResponse) *model.GeoJSONFeatureCollection {
+	collection := &model.GeoJSONFeatureCollection{Type: "FeatureCollection", Features: make([]model.GeoJSONFeature, 0, len(response.Cells))}
+	for _, cell := range response.Cells {
+		collection.Features = append(collection.Features, model.GeoJSONFeature{
+			Type:       "Feature",
+			ID:         cell.Key,
+			Geometry:   geoJSONGeometry(cell.Geometry),
+			Properties: cell,
+		})
+	}
+	return collection
+}
+
+func geoJSONGeometry(geometry *string) json.RawMessage {
+	if geometry == nil {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(*geometry)
+}
+
+// validateMonthRange checks the optional YYYY-MM bounds of a dashboard
+func validateMonthRange(startMonth string, endMonth string) error {
+	if err := helper.ValidateMonthFormat(startMonth); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err := helper.ValidateMonthFormat(endMonth); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err := helper.ValidateDateRange(startMonth, endMonth); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	return nil
+}
+
 // BankScore holds temporary calculation data
 type BankScore struct {
-	User        *entity.User
+	UserID      string
 	Volume      float64
 	TotalWeight float64
 	Score       float64
 }
 
-func (uc *GovernmentUseCase) GetLargestBanks(request *model.GovernmentDashboardRequest) ([]model.LargestBank, error) {
-	// 1. Get all waste bank users (single source of truth for user data)
-	users, err := uc.UserRepository.GetWasteBankUsers(uc.DB, request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get waste bank users: %v", err)
-	}
-
-	// 2. Get storage volumes (storage repository responsibility)
+func (uc *GovernmentUseCase) GetLargestBanks(request *model.GovernmentDashboardRequest, useRollups bool) ([]model.LargestBank, error) {
+	// 1. Get storage volumes (storage repository responsibility)
 	storageVolumes, err := uc.StorageRepository.GetWasteBankStorageVolumes(uc.DB, request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get storage volumes: %v", err)
 	}
 
-	// 3. Get waste drop totals (waste drop repository responsibility)
-	wasteDropTotals, err := uc.WasteDropRequestItemRepository.GetWasteBankWasteDropTotals(uc.DB, request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get waste drop totals: %v", err)
+	// 2. Get waste drop and waste transfer totals, from the rollups when the filters allow it
+	var wasteDropTotals, wasteTransferTotals map[string]float64
+	if useRollups {
+		if wasteDropTotals, err = uc.DailyWasteRollupRepository.GetReceiverTotals(uc.DB, request, []string{"drop"}); err != nil {
+			return nil, fmt.Errorf("failed to get waste drop totals: %v", err)
+		}
+		if wasteTransferTotals, err = uc.DailyWasteRollupRepository.GetReceiverTotals(uc.DB, request, []string{"waste_bank_transfer"}); err != nil {
+			return nil, fmt.Errorf("failed to get waste transfer totals: %v", err)
+		}
+	} else {
+		if wasteDropTotals, err = uc.WasteDropRequestItemRepository.GetWasteBankWasteDropTotals(uc.DB, request); err != nil {
+			return nil, fmt.Errorf("failed to get waste drop totals: %v", err)
+		}
+		if wasteTransferTotals, err = uc.WasteTransferRequestRepository.GetWasteBankTransferTotals(uc.DB, request); err != nil {
+			return nil, fmt.Errorf("failed to get waste transfer totals: %v", err)
+		}
 	}
 
-	// 4. Get waste transfer totals (waste transfer repository responsibility)
-	wasteTransferTotals, err := uc.WasteTransferRequestRepository.GetWasteBankTransferTotals(uc.DB, request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get waste transfer totals: %v", err)
+	// 3. BUSINESS LOGIC: Combine data and calculate scores (UseCase responsibility)
+	// Every source is already limited to waste banks in the requested area, so only the banks that
+	// have some activity or storage are scored
+	userIDs := make(map[string]bool)
+	for _, totals := range []map[string]float64{storageVolumes, wasteDropTotals, wasteTransferTotals} {
+		for userID := range totals {
+			userIDs[userID] = true
+		}
 	}
 
-	// 5. BUSINESS LOGIC: Combine data and calculate scores (UseCase responsibility)
-	bankScores := make([]BankScore, 0)
-
-	for userID, user := range users {
+	bankScores := make([]BankScore, 0, len(userIDs))
+	for userID := range userIDs {
 		volume := storageVolumes[userID]
-		dropWeight := wasteDropTotals[userID]
-		transferWeight := wasteTransferTotals[userID]
-		totalWeight := dropWeight + transferWeight
-
-		// Only include banks with some activity or storage
-		if volume > 0 || totalWeight > 0 {
-			// BUSINESS RULE: 70% processing capacity + 30% storage volume
-			score := totalWeight*0.3 + volume*0.7
-
-			bankScores = append(bankScores, BankScore{
-				User:        user,
-				Volume:      volume,
-				TotalWeight: totalWeight,
-				Score:       score,
-			})
+		totalWeight := wasteDropTotals[userID] + wasteTransferTotals[userID]
+		if volume <= 0 && totalWeight <= 0 {
+			continue
 		}
+
+		// BUSINESS RULE: 70% processing capacity + 30% storage volume
+		bankScores = append(bankScores, BankScore{
+			UserID:      userID,
+			Volume:      volume,
+			TotalWeight: totalWeight,
+			Score:       totalWeight*0.3 + volume*0.7,
+		})
 	}
 
-	// 6. BUSINESS LOGIC: Sort by composite score (UseCase responsibility)
+	// 4. BUSINESS LOGIC: Sort by composite score (UseCase responsibility)
 	sort.Slice(bankScores, func(i, j int) bool {
 		if bankScores[i].Score != bankScores[j].Score {
 			return bankScores[i].Score > bankScores[j].Score // Higher score first
@@ -262,30 +458,51 @@ func (uc *GovernmentUseCase) GetLargestBanks(request *model.GovernmentDashboardR
 		return bankScores[i].TotalWeight > bankScores[j].TotalWeight // Higher weight first
 	})
 
-	// 7. BUSINESS RULE: Return top 3 (UseCase responsibility)
-	limit := 3
-	if len(bankScores) < limit {
-		limit = len(bankScores)
-	}
-
-	// 8. Convert to response model (UseCase responsibility)
-	largestBanks := make([]model.LargestBank, limit)
-	for i := 0; i < limit; i++ {
-		bank := bankScores[i]
-		largestBanks[i] = model.LargestBank{
-			ID:          bank.User.ID.String(),
-			Name:        uc.getUserName(bank.User), // Use helper method
-			Institution: bank.User.Institution,
-			City:        bank.User.City,
-			Province:    bank.User.Province,
+	// 5. BUSINESS RULE: Return top 3 (UseCase responsibility)
+	bankScores = bankScores[:min(3, len(bankScores))]
+
+	// 6. Load only the users being returned
+	ids := make([]string, len(bankScores))
+	for i, bank := range bankScores {
+		ids[i] = bank.UserID
+	}
+	users, err := uc.UserRepository.FindWasteBanksByIDs(uc.DB, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waste bank users: %v", err)
+	}
+
+	// 7. Convert to response model (UseCase responsibility)
+	largestBanks := make([]model.LargestBank, 0, len(bankScores))
+	for _, bank := range bankScores {
+		user, ok := users[bank.UserID]
+		if !ok {
+			continue
+		}
+		largestBanks = append(largestBanks, model.LargestBank{
+			ID:          bank.UserID,
+			Name:        uc.getUserName(user), // Use helper method
+			Institution: user.Institution,
+			City:        user.City,
+			Province:    user.Province,
 			TotalWeight: bank.TotalWeight, // Total waste processed
 			Volume:      bank.Volume,      // Storage volume
-		}
+		})
 	}
 
 	return largestBanks, nil
 }
 
+// sumRoles collapses monthly weights by role into monthly totals
+func sumRoles(trends map[string]map[string]float64) map[string]float64 {
+	totals := make(map[string]float64)
+	for month, roles := range trends {
+		for _, weight := range roles {
+			totals[month] += weight
+		}
+	}
+	return totals
+}
+
 // Helper method to safely get user name
 func (uc *GovernmentUseCase) getUserName(user *entity.User) string {
 	if user.Username != "" {
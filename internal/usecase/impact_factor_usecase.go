@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+type ImpactFactorUsecase struct {
+	DB                      *gorm.DB
+	Log                     *logrus.Logger
+	Validate                *validator.Validate
+	ImpactFactorRepository  *repository.ImpactFactorRepository
+	WasteTypeRepository     *repository.WasteTypeRepository
+	WasteCategoryRepository *repository.WasteCategoryRepository
+}
+
+func NewImpactFactorUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	impactFactorRepository *repository.ImpactFactorRepository,
+	wasteTypeRepository *repository.WasteTypeRepository,
+	wasteCategoryRepository *repository.WasteCategoryRepository,
+) *ImpactFactorUsecase {
+	return &ImpactFactorUsecase{
+		DB:                      db,
+		Log:                     log,
+		Validate:                validate,
+		ImpactFactorRepository:  impactFactorRepository,
+		WasteTypeRepository:     wasteTypeRepository,
+		WasteCategoryRepository: wasteCategoryRepository,
+	}
+}
+
+func (c *ImpactFactorUsecase) Create(ctx context.Context, request *model.ImpactFactorRequest) (*model.ImpactFactorResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if request.WasteTypeID != "" && request.WasteCategoryID != "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Set either waste_type_id or waste_category_id, not both")
+	}
+
+	factor := &entity.ImpactFactor{
+		Co2ePerKg:        request.Co2ePerKg,
+		WaterLitersPerKg: request.WaterLitersPerKg,
+		EnergyKwhPerKg:   request.EnergyKwhPerKg,
+		TreesPerKg:       request.TreesPerKg,
+		Source:           request.Source,
+	}
+
+	var wasteTypeID, wasteCategoryID *string
+	if request.WasteTypeID != "" {
+		wasteType := new(entity.WasteType)
+		if err := c.WasteTypeRepository.FindById(tx, wasteType, request.WasteTypeID); err != nil {
+			c.Log.Warnf("Waste type not found: %v", err)
+			return nil, fiber.NewError(fiber.StatusNotFound, "Waste type not found")
+		}
+		factor.WasteTypeID = &wasteType.ID
+		wasteTypeID = &request.WasteTypeID
+	}
+	if request.WasteCategoryID != "" {
+		wasteCategory := new(entity.WasteCategory)
+		if err := c.WasteCategoryRepository.FindById(tx, wasteCategory, request.WasteCategoryID); err != nil {
+			c.Log.Warnf("Waste category not found: %v", err)
+			return nil, fiber.NewError(fiber.StatusNotFound, "Waste category not found")
+		}
+		factor.WasteCategoryID = &wasteCategory.ID
+		wasteCategoryID = &request.WasteCategoryID
+	}
+
+	total, err := c.ImpactFactorRepository.CountByKey(tx, wasteTypeID, wasteCategoryID)
+	if err != nil {
+		c.Log.Warnf("Failed to count impact factors: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if total > 0 {
+		return nil, fiber.NewError(fiber.StatusConflict, "An impact factor already exists for this waste type or category")
+	}
+
+	if err := c.ImpactFactorRepository.Create(tx, factor); err != nil {
+		c.Log.Warnf("Failed to create impact factor: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.ImpactFactorRepository.FindByIdWithRelations(tx, factor, factor.ID.String()); err != nil {
+		c.Log.Warnf("Failed to reload impact factor: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Commit error: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ImpactFactorToResponse(factor), nil
+}
+
+func (c *ImpactFactorUsecase) Get(ctx context.Context, request *model.GetImpactFactorRequest) (*model.ImpactFactorResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	factor := new(entity.ImpactFactor)
+	if err := c.ImpactFactorRepository.FindByIdWithRelations(tx, factor, request.ID); err != nil {
+		c.Log.Warnf("Impact factor not found: %v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Commit error: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ImpactFactorToResponse(factor), nil
+}
+
+func (c *ImpactFactorUsecase) Update(ctx context.Context, request *model.UpdateImpactFactorRequest) (*model.ImpactFactorResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	factor := new(entity.ImpactFactor)
+	if err := c.ImpactFactorRepository.FindByIdWithRelations(tx, factor, request.ID); err != nil {
+		c.Log.Warnf("Impact factor not found: %v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if factor.IsDeleted {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Impact factor has been deleted")
+	}
+
+	if request.Co2ePerKg != nil {
+		factor.Co2ePerKg = *request.Co2ePerKg
+	}
+	if request.WaterLitersPerKg != nil {
+		factor.WaterLitersPerKg = *request.WaterLitersPerKg
+	}
+	if request.EnergyKwhPerKg != nil {
+		factor.EnergyKwhPerKg = *request.EnergyKwhPerKg
+	}
+	if request.TreesPerKg != nil {
+		factor.TreesPerKg = *request.TreesPerKg
+	}
+	if request.Source != nil {
+		factor.Source = *request.Source
+	}
+	// Customer totals are recomputed by the job once the factor is marked unapplied
+	factor.AppliedAt = nil
+
+	if err := c.ImpactFactorRepository.Update(tx, factor); err != nil {
+		c.Log.Warnf("Update failed: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Commit error: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ImpactFactorToResponse(factor), nil
+}
+
+func (c *ImpactFactorUsecase) Delete(ctx context.Context, request *model.DeleteImpactFactorRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	factor := new(entity.ImpactFactor)
+	if err := c.ImpactFactorRepository.FindById(tx, factor, request.ID); err != nil {
+		c.Log.Warnf("Impact factor not found: %v", err)
+		return fiber.ErrNotFound
+	}
+
+	// The default factor is the fallback for every waste type
+	if factor.WasteTypeID == nil && factor.WasteCategoryID == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "The default impact factor cannot be deleted")
+	}
+
+	factor.IsDeleted = true
+	factor.AppliedAt = nil
+	if err := c.ImpactFactorRepository.Update(tx, factor); err != nil {
+		c.Log.Warnf("Delete failed: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Commit error: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *ImpactFactorUsecase) Search(ctx context.Context, request *model.SearchImpactFactorRequest) ([]model.ImpactFactorResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Warn("Invalid request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	factors, total, err := c.ImpactFactorRepository.Search(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Error("Failed to search impact factors")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("Failed to commit transaction")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.ImpactFactorResponse, len(factors))
+	for i, factor := range factors {
+		responses[i] = *converter.ImpactFactorToResponse(&factor)
+	}
+
+	return responses, total, nil
+}
+
+// RecomputeCustomerImpact rebuilds customer impact totals when a factor changed since the last run.
+// Set force to recompute regardless.
+func (c *ImpactFactorUsecase) RecomputeCustomerImpact(ctx context.Context, force bool) (int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if !force {
+		pending, err := c.ImpactFactorRepository.HasPendingChanges(tx)
+		if err != nil {
+			c.Log.Warnf("Failed to check impact factor changes: %+v", err)
+			return 0, fiber.ErrInternalServerError
+		}
+		if !pending {
+			return 0, nil
+		}
+	}
+
+	updated, err := c.ImpactFactorRepository.RecomputeCustomerProfiles(tx)
+	if err != nil {
+		c.Log.Warnf("Failed to recompute customer profiles: %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	if err := c.ImpactFactorRepository.MarkApplied(tx, time.Now()); err != nil {
+		c.Log.Warnf("Failed to mark impact factors applied: %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Commit error: %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// A supplier is flagged when its yield for a waste type is below this share of the industry's yield for the type
+	lowYieldRatio = 0.8
+	// Suppliers with less verified weight than this are not flagged, a single bad load says little
+	lowYieldMinWeight = 50.0
+)
+
+type IndustryDashboardUsecase struct {
+	DB                                  *gorm.DB
+	Log                                 *logrus.Logger
+	Validate                            *validator.Validate
+	UserRepository                      *repository.UserRepository
+	DailyWasteRollupRepository          *repository.DailyWasteRollupRepository
+	WasteTransferItemOfferingRepository *repository.WasteTransferItemOfferingRepository
+}
+
+func NewIndustryDashboardUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	userRepository *repository.UserRepository,
+	dailyWasteRollupRepository *repository.DailyWasteRollupRepository,
+	wasteTransferItemOfferingRepository *repository.WasteTransferItemOfferingRepository,
+) *IndustryDashboardUsecase {
+	return &IndustryDashboardUsecase{
+		DB:                                  db,
+		Log:                                 log,
+		Validate:                            validate,
+		UserRepository:                      userRepository,
+		DailyWasteRollupRepository:          dailyWasteRollupRepository,
+		WasteTransferItemOfferingRepository: wasteTransferItemOfferingRepository,
+	}
+}
+
+// Get builds the procurement dashboard of an industry. Purchases come from the daily rollups, prices and
+// yields from the transfer items.
+func (c *IndustryDashboardUsecase) Get(ctx context.Context, request *model.IndustryDashboardRequest) (*model.IndustryDashboardResponse, error) {
+	db := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+	if err := validateMonthRange(request.StartMonth, request.EndMonth); err != nil {
+		return nil, err
+	}
+
+	// Industries only see their own dashboard, admins pick one
+	if request.ActorRole != "admin" {
+		request.IndustryID = request.ActorID
+	}
+	if request.IndustryID == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "industry_id is required")
+	}
+
+	industry := new(entity.User)
+	if err := c.UserRepository.FindById(db, industry, request.IndustryID); err != nil {
+		c.Log.Warnf("Failed to find industry: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if industry.Role != "industry" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "User is not an industry")
+	}
+
+	response := &model.IndustryDashboardResponse{IndustryID: request.IndustryID}
+	var err error
+
+	if response.Purchases, err = c.DailyWasteRollupRepository.GetPurchasesBySupplier(db, request.IndustryID, request.StartMonth, request.EndMonth); err != nil {
+		c.Log.Warnf("Failed to get purchases: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if response.Prices, err = c.WasteTransferItemOfferingRepository.GetIndustryPriceComparison(db, request.IndustryID, request.StartMonth, request.EndMonth); err != nil {
+		c.Log.Warnf("Failed to get price comparison: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if response.Yields, err = c.WasteTransferItemOfferingRepository.GetIndustryYieldByMonth(db, request.IndustryID, request.StartMonth, request.EndMonth); err != nil {
+		c.Log.Warnf("Failed to get recycling yield: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if response.SupplierYields, err = c.WasteTransferItemOfferingRepository.GetIndustrySupplierYields(db, request.IndustryID, request.StartMonth, request.EndMonth); err != nil {
+		c.Log.Warnf("Failed to get supplier yields: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	flagLowYieldSuppliers(response.SupplierYields)
+
+	return response, nil
+}
+
+// flagLowYieldSuppliers compares every supplier with the yield of the waste type across all suppliers
+func flagLowYieldSuppliers(yields []model.IndustrySupplierYield) {
+	verified := make(map[string]float64)
+	recycled := make(map[string]float64)
+	for _, yield := range yields {
+		verified[yield.WasteTypeID] += yield.VerifiedWeight
+		recycled[yield.WasteTypeID] += yield.RecycledWeight
+	}
+
+	for i := range yields {
+		if verified[yields[i].WasteTypeID] == 0 {
+			continue
+		}
+		yields[i].AverageYield = recycled[yields[i].WasteTypeID] / verified[yields[i].WasteTypeID]
+		yields[i].IsLowYield = yields[i].VerifiedWeight >= lowYieldMinWeight &&
+			yields[i].Yield < yields[i].AverageYield*lowYieldRatio
+	}
+}
@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// System accounts on the other side of user postings
+const (
+	LedgerAccountPointsIssuance        = "points_issuance"
+	LedgerAccountPointConversionPayout = "point_conversion_payout"
+	LedgerAccountPointRedemptionHolds  = "point_redemption_holds"
+)
+
+type LedgerUsecase struct {
+	DB                      *gorm.DB
+	Log                     *logrus.Logger
+	Validate                *validator.Validate
+	LedgerAccountRepository *repository.LedgerAccountRepository
+	LedgerEntryRepository   *repository.LedgerEntryRepository
+	LedgerDriftRepository   *repository.LedgerDriftRepository
+	UserRepository          *repository.UserRepository
+}
+
+func NewLedgerUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	ledgerAccountRepository *repository.LedgerAccountRepository,
+	ledgerEntryRepository *repository.LedgerEntryRepository,
+	ledgerDriftRepository *repository.LedgerDriftRepository,
+	userRepository *repository.UserRepository,
+) *LedgerUsecase {
+	return &LedgerUsecase{
+		DB:                      db,
+		Log:                     log,
+		Validate:                validate,
+		LedgerAccountRepository: ledgerAccountRepository,
+		LedgerEntryRepository:   ledgerEntryRepository,
+		LedgerDriftRepository:   ledgerDriftRepository,
+		UserRepository:          userRepository,
+	}
+}
+
+// Post writes a balanced journal entry inside the caller's transaction and refreshes
+// the cached users.points / users.balance columns of every user it touches.
+func (c *LedgerUsecase) Post(tx *gorm.DB, request *model.LedgerPostingRequest) (*entity.LedgerEntry, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		return nil, fmt.Errorf("invalid ledger posting: %w", err)
+	}
+
+	// Debits and credits must match per asset
+	totals := make(map[string]int64)
+	for _, line := range request.Lines {
+		if (line.UserID == "") == (line.AccountCode == "") {
+			return nil, fmt.Errorf("ledger line must have either a user or an account code")
+		}
+		if line.Direction == "debit" {
+			totals[line.Asset] += line.Amount
+		} else {
+			totals[line.Asset] -= line.Amount
+		}
+	}
+	for asset, total := range totals {
+		if total != 0 {
+			return nil, fmt.Errorf("ledger entry %s is unbalanced for %s by %d", request.EntryType, asset, total)
+		}
+	}
+
+	entry := &entity.LedgerEntry{
+		EntryType:     request.EntryType,
+		ReferenceType: request.ReferenceType,
+		Description:   request.Description,
+	}
+	if request.ReferenceID != "" {
+		referenceID, err := uuid.Parse(request.ReferenceID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ledger reference ID: %w", err)
+		}
+		entry.ReferenceID = &referenceID
+	}
+
+	type userAsset struct {
+		UserID    uuid.UUID
+		Asset     string
+		AccountID uuid.UUID
+	}
+	touched := make(map[string]userAsset)
+
+	for _, line := range request.Lines {
+		var account *entity.LedgerAccount
+		var err error
+		if line.UserID != "" {
+			userID, parseErr := uuid.Parse(line.UserID)
+			if parseErr != nil {
+				return nil, fmt.Errorf("invalid ledger user ID: %w", parseErr)
+			}
+			account, err = c.LedgerAccountRepository.FindOrCreateUserAccount(tx, userID, line.Asset)
+			if err == nil {
+				touched[line.UserID+line.Asset] = userAsset{UserID: userID, Asset: line.Asset, AccountID: account.ID}
+			}
+		} else {
+			account, err = c.LedgerAccountRepository.FindOrCreateSystemAccount(tx, line.AccountCode, line.Asset)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve ledger account: %w", err)
+		}
+
+		entry.Lines = append(entry.Lines, entity.LedgerLine{
+			AccountID: account.ID,
+			Direction: line.Direction,
+			Amount:    line.Amount,
+		})
+	}
+
+	if err := c.LedgerEntryRepository.Create(tx, entry); err != nil {
+		return nil, fmt.Errorf("failed to create ledger entry: %w", err)
+	}
+
+	for _, ua := range touched {
+		balance, err := c.LedgerAccountRepository.GetBalance(tx, ua.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute ledger balance: %w", err)
+		}
+		column := "balance"
+		if ua.Asset == "points" {
+			column = "points"
+		}
+		if err := c.UserRepository.UpdateCachedBalances(tx, ua.UserID, map[string]interface{}{column: balance}); err != nil {
+			return nil, fmt.Errorf("failed to update cached %s: %w", column, err)
+		}
+	}
+
+	return entry, nil
+}
+
+func (c *LedgerUsecase) GetStatement(ctx context.Context, request *model.LedgerStatementRequest) (*model.LedgerStatementResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	var startDate, endDate *time.Time
+	if request.StartDate != "" {
+		parsed, err := time.Parse("2006-01-02", request.StartDate)
+		if err != nil {
+			c.Log.Warnf("Invalid start date: %+v", err)
+			return nil, 0, fiber.NewError(fiber.StatusBadRequest, "Invalid start_date format, expected YYYY-MM-DD")
+		}
+		startDate = &parsed
+	}
+	if request.EndDate != "" {
+		parsed, err := time.Parse("2006-01-02", request.EndDate)
+		if err != nil {
+			c.Log.Warnf("Invalid end date: %+v", err)
+			return nil, 0, fiber.NewError(fiber.StatusBadRequest, "Invalid end_date format, expected YYYY-MM-DD")
+		}
+		endOfDay := parsed.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+		endDate = &endOfDay
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserID); err != nil {
+		c.Log.Warnf("Failed to find user by ID: %+v", err)
+		return nil, 0, fiber.ErrNotFound
+	}
+
+	response := &model.LedgerStatementResponse{
+		UserID:        request.UserID,
+		Asset:         request.Asset,
+		CachedBalance: user.Balance,
+		Lines:         []model.LedgerStatementLineResponse{},
+	}
+	if request.Asset == "points" {
+		response.CachedBalance = user.Points
+	}
+
+	account := new(entity.LedgerAccount)
+	if err := c.LedgerAccountRepository.FindUserAccount(tx, account, request.UserID, request.Asset); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			// No postings yet, the statement is empty
+			return response, 0, nil
+		}
+		c.Log.Warnf("Failed to find ledger account: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if startDate != nil {
+		opening, err := c.LedgerAccountRepository.GetBalanceBefore(tx, account.ID, *startDate)
+		if err != nil {
+			c.Log.Warnf("Failed to compute opening balance: %+v", err)
+			return nil, 0, fiber.ErrInternalServerError
+		}
+		response.OpeningBalance = opening
+	}
+
+	closingBefore := time.Now()
+	if endDate != nil {
+		closingBefore = endDate.Add(time.Second)
+	}
+	closing, err := c.LedgerAccountRepository.GetBalanceBefore(tx, account.ID, closingBefore)
+	if err != nil {
+		c.Log.Warnf("Failed to compute closing balance: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+	response.ClosingBalance = closing
+
+	lines, total, err := c.LedgerEntryRepository.FindStatementLines(tx, account.ID, startDate, endDate, request.Page, request.Size)
+	if err != nil {
+		c.Log.Warnf("Failed to find statement lines: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	for i := range lines {
+		response.Lines = append(response.Lines, *converter.LedgerLineToStatementLineResponse(&lines[i]))
+	}
+
+	return response, total, nil
+}
+
+// Reconcile flags every user whose cached points/balance drifted from the ledger,
+// and resolves earlier flags that no longer drift. It returns the number of open drifts.
+func (c *LedgerUsecase) Reconcile(ctx context.Context) (int, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	drifts, err := c.LedgerAccountRepository.FindDrifts(tx)
+	if err != nil {
+		c.Log.Warnf("Failed to find ledger drifts: %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	unresolved, err := c.LedgerDriftRepository.FindUnresolved(tx)
+	if err != nil {
+		c.Log.Warnf("Failed to find unresolved ledger drifts: %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	open := make(map[string]*entity.LedgerDrift, len(unresolved))
+	for i := range unresolved {
+		open[unresolved[i].UserID.String()+unresolved[i].Asset] = &unresolved[i]
+	}
+
+	for i := range drifts {
+		key := drifts[i].UserID.String() + drifts[i].Asset
+		if existing, ok := open[key]; ok {
+			delete(open, key)
+			existing.CachedBalance = drifts[i].CachedBalance
+			existing.LedgerBalance = drifts[i].LedgerBalance
+			if err := c.LedgerDriftRepository.Update(tx, existing); err != nil {
+				c.Log.Warnf("Failed to update ledger drift: %+v", err)
+				return 0, fiber.ErrInternalServerError
+			}
+			continue
+		}
+
+		c.Log.Warnf("Ledger drift for user %s (%s): cached=%d ledger=%d",
+			drifts[i].UserID.String(), drifts[i].Asset, drifts[i].CachedBalance, drifts[i].LedgerBalance)
+		if err := c.LedgerDriftRepository.Create(tx, &drifts[i]); err != nil {
+			c.Log.Warnf("Failed to create ledger drift: %+v", err)
+			return 0, fiber.ErrInternalServerError
+		}
+	}
+
+	// Anything left has been brought back in line since it was flagged
+	now := time.Now()
+	for _, drift := range open {
+		drift.IsResolved = true
+		drift.ResolvedAt = &now
+		if err := c.LedgerDriftRepository.Update(tx, drift); err != nil {
+			c.Log.Warnf("Failed to resolve ledger drift: %+v", err)
+			return 0, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	return len(drifts), nil
+}
+
+func (c *LedgerUsecase) SearchDrifts(ctx context.Context, request *model.SearchLedgerDriftRequest) ([]model.LedgerDriftResponse, int64, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Warn("Invalid request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	drifts, total, err := c.LedgerDriftRepository.Search(tx, request)
+	if err != nil {
+		c.Log.WithError(err).Warn("Failed to search ledger drifts")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("Failed to commit transaction")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.LedgerDriftResponse, len(drifts))
+	for i, drift := range drifts {
+		responses[i] = *converter.LedgerDriftToResponse(&drift)
+	}
+
+	return responses, total, nil
+}
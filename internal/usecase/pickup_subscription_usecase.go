@@ -0,0 +1,820 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+	"github.com/wastetrack/wastetrack-backend/pkg/timezone"
+	"gorm.io/gorm"
+)
+
+const (
+	// Drop requests are generated this many days ahead so banks can plan their collectors
+	pickupSubscriptionHorizonDays = 7
+	// An occurrence left generating this long belongs to a run that died and may be retried
+	pickupSubscriptionStaleAfter  = time.Hour
+	pickupSubscriptionNextDates   = 5
+	pickupSubscriptionHistoryDays = 30
+)
+
+type PickupSubscriptionUsecase struct {
+	DB                                     *gorm.DB
+	Log                                    *logrus.Logger
+	Validate                               *validator.Validate
+	PickupSubscriptionRepository           *repository.PickupSubscriptionRepository
+	PickupSubscriptionItemRepository       *repository.PickupSubscriptionItemRepository
+	PickupSubscriptionOccurrenceRepository *repository.PickupSubscriptionOccurrenceRepository
+	WasteDropRequestRepository             *repository.WasteDropRequestRepository
+	UserRepository                         *repository.UserRepository
+	WasteTypeRepository                    *repository.WasteTypeRepository
+	WasteDropRequestUsecase                *WasteDropRequestUsecase
+	AppointmentSlotUsecase                 *AppointmentSlotUsecase
+}
+
+func NewPickupSubscriptionUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	pickupSubscriptionRepository *repository.PickupSubscriptionRepository,
+	pickupSubscriptionItemRepository *repository.PickupSubscriptionItemRepository,
+	pickupSubscriptionOccurrenceRepository *repository.PickupSubscriptionOccurrenceRepository,
+	wasteDropRequestRepository *repository.WasteDropRequestRepository,
+	userRepository *repository.UserRepository,
+	wasteTypeRepository *repository.WasteTypeRepository,
+	wasteDropRequestUsecase *WasteDropRequestUsecase,
+	appointmentSlotUsecase *AppointmentSlotUsecase,
+) *PickupSubscriptionUsecase {
+	return &PickupSubscriptionUsecase{
+		DB:                                     db,
+		Log:                                    log,
+		Validate:                               validate,
+		PickupSubscriptionRepository:           pickupSubscriptionRepository,
+		PickupSubscriptionItemRepository:       pickupSubscriptionItemRepository,
+		PickupSubscriptionOccurrenceRepository: pickupSubscriptionOccurrenceRepository,
+		WasteDropRequestRepository:             wasteDropRequestRepository,
+		UserRepository:                         userRepository,
+		WasteTypeRepository:                    wasteTypeRepository,
+		WasteDropRequestUsecase:                wasteDropRequestUsecase,
+		AppointmentSlotUsecase:                 appointmentSlotUsecase,
+	}
+}
+
+// Helper method to drop the time and zone of a date so dates compare by calendar day
+func subscriptionDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func subscriptionToday() time.Time {
+	return subscriptionDate(time.Now().In(timezone.WIB))
+}
+
+// isSubscriptionDue reports whether the subscription's recurrence rule falls on the date
+func isSubscriptionDue(subscription *entity.PickupSubscription, date time.Time) bool {
+	startDate := subscriptionDate(subscription.StartDate)
+	if date.Before(startDate) {
+		return false
+	}
+	if subscription.EndDate != nil && date.After(subscriptionDate(*subscription.EndDate)) {
+		return false
+	}
+
+	switch subscription.Frequency {
+	case "weekly":
+		return subscription.DayOfWeek != nil && int(date.Weekday()) == *subscription.DayOfWeek
+	case "biweekly":
+		if subscription.DayOfWeek == nil || int(date.Weekday()) != *subscription.DayOfWeek {
+			return false
+		}
+		// Every other week counted from the first matching day on or after the start date
+		anchor := startDate.AddDate(0, 0, (*subscription.DayOfWeek-int(startDate.Weekday())+7)%7)
+		return int(date.Sub(anchor).Hours()/24)%14 == 0
+	case "monthly":
+		return subscription.DayOfMonth != nil && date.Day() == *subscription.DayOfMonth
+	}
+	return false
+}
+
+// Helper method to list the next due dates on or after from, looking at most a few months ahead
+func nextSubscriptionDates(subscription *entity.PickupSubscription, from time.Time, count int) []time.Time {
+	var dates []time.Time
+	for date := from; len(dates) < count && date.Before(from.AddDate(0, 4, 0)); date = date.AddDate(0, 0, 1) {
+		if isSubscriptionDue(subscription, date) {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+// Helper method to check that the actor may see the subscription, or change it when manage is set
+func checkSubscriptionAccess(subscription *entity.PickupSubscription, actorID string, actorRole string, manage bool) error {
+	switch actorRole {
+	case "admin":
+		return nil
+	case "customer":
+		if subscription.CustomerID.String() == actorID {
+			return nil
+		}
+	case "waste_bank_unit", "waste_bank_central":
+		if !manage && subscription.WasteBankID.String() == actorID {
+			return nil
+		}
+	}
+	return fiber.NewError(fiber.StatusForbidden, "You are not allowed to access this subscription")
+}
+
+// Helper method to parse the appointment window of a subscription
+func parseSubscriptionWindow(startTimeStr string, endTimeStr string) (types.TimeOnly, types.TimeOnly, error) {
+	startTime, _, err := timezone.ParseTimeWithTimezone(startTimeStr)
+	if err != nil {
+		return types.TimeOnly{}, types.TimeOnly{}, fiber.NewError(fiber.StatusBadRequest, "start_time must be in HH:MM:SS+07:00 format")
+	}
+	endTime, _, err := timezone.ParseTimeWithTimezone(endTimeStr)
+	if err != nil {
+		return types.TimeOnly{}, types.TimeOnly{}, fiber.NewError(fiber.StatusBadRequest, "end_time must be in HH:MM:SS+07:00 format")
+	}
+	if !endTime.After(startTime) {
+		return types.TimeOnly{}, types.TimeOnly{}, fiber.NewError(fiber.StatusBadRequest, "end_time must be after start_time")
+	}
+	return types.NewTimeOnly(startTime), types.NewTimeOnly(endTime), nil
+}
+
+// Helper method to turn the requested items into subscription items, checking every waste type exists
+func (c *PickupSubscriptionUsecase) buildItems(tx *gorm.DB, subscriptionID uuid.UUID, request *model.WasteDropRequestItems) ([]*entity.PickupSubscriptionItem, error) {
+	if len(request.WasteTypeIDs) == 0 || len(request.WasteTypeIDs) != len(request.Quantities) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "At least one item is required, with a quantity for every waste type")
+	}
+
+	items := make([]*entity.PickupSubscriptionItem, len(request.WasteTypeIDs))
+	for i, wasteTypeIDStr := range request.WasteTypeIDs {
+		wasteTypeID, err := uuid.Parse(wasteTypeIDStr)
+		if err != nil {
+			c.Log.Warnf("Invalid waste type ID: %+v", err)
+			return nil, fiber.ErrBadRequest
+		}
+		if request.Quantities[i] <= 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Quantities must be greater than zero")
+		}
+
+		wasteType := new(entity.WasteType)
+		if err := c.WasteTypeRepository.FindById(tx, wasteType, wasteTypeIDStr); err != nil {
+			c.Log.Warnf("Failed to find waste type by ID %s: %+v", wasteTypeIDStr, err)
+			return nil, fiber.ErrNotFound
+		}
+
+		items[i] = &entity.PickupSubscriptionItem{
+			SubscriptionID: subscriptionID,
+			WasteTypeID:    wasteTypeID,
+			Quantity:       request.Quantities[i],
+		}
+	}
+	return items, nil
+}
+
+func (c *PickupSubscriptionUsecase) Create(ctx context.Context, request *model.PickupSubscriptionRequest) (*model.PickupSubscriptionResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	// Customers subscribe for themselves, only admins may subscribe on someone's behalf
+	if request.ActorRole == "customer" {
+		request.CustomerID = request.ActorID
+	}
+	customerID, err := uuid.Parse(request.CustomerID)
+	if err != nil {
+		c.Log.Warnf("Invalid customer ID: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+	wasteBankID, err := uuid.Parse(request.WasteBankID)
+	if err != nil {
+		c.Log.Warnf("Invalid waste bank ID: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	startDate, err := time.Parse("2006-01-02", request.StartDate)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "start_date must be in YYYY-MM-DD format")
+	}
+	if startDate.Before(subscriptionToday()) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "start_date cannot be in the past")
+	}
+
+	var endDate *time.Time
+	if request.EndDate != "" {
+		date, err := time.Parse("2006-01-02", request.EndDate)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "end_date must be in YYYY-MM-DD format")
+		}
+		if date.Before(startDate) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "end_date cannot be before start_date")
+		}
+		endDate = &date
+	}
+
+	startTime, endTime, err := parseSubscriptionWindow(request.StartTime, request.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// The recurrence day defaults to the start date's
+	dayOfWeek, dayOfMonth := request.DayOfWeek, request.DayOfMonth
+	switch request.Frequency {
+	case "weekly", "biweekly":
+		if dayOfWeek == nil {
+			weekday := int(startDate.Weekday())
+			dayOfWeek = &weekday
+		}
+		dayOfMonth = nil
+	case "monthly":
+		if dayOfMonth == nil {
+			if startDate.Day() > 28 {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "day_of_month is required when starting after the 28th")
+			}
+			day := startDate.Day()
+			dayOfMonth = &day
+		}
+		dayOfWeek = nil
+	}
+
+	customer := new(entity.User)
+	if err := c.UserRepository.FindById(tx, customer, request.CustomerID); err != nil {
+		c.Log.Warnf("Failed to find customer by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if customer.Role != "customer" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Subscriptions can only be made for customers")
+	}
+
+	wasteBank := new(entity.User)
+	if err := c.UserRepository.FindById(tx, wasteBank, request.WasteBankID); err != nil {
+		c.Log.Warnf("Failed to find waste bank by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if !isWasteBankRole(wasteBank.Role) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "waste_bank_id must belong to a waste bank")
+	}
+
+	subscription := &entity.PickupSubscription{
+		ID:          uuid.New(),
+		CustomerID:  customerID,
+		WasteBankID: wasteBankID,
+		Frequency:   request.Frequency,
+		DayOfWeek:   dayOfWeek,
+		DayOfMonth:  dayOfMonth,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		AppointmentLocation: &types.Point{
+			Lat: request.AppointmentLocation.Latitude,
+			Lng: request.AppointmentLocation.Longitude,
+		},
+		UserPhoneNumber: request.UserPhoneNumber,
+		Notes:           request.Notes,
+		Status:          "active",
+	}
+
+	items, err := c.buildItems(tx, subscription.ID, request.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.PickupSubscriptionRepository.Create(tx, subscription); err != nil {
+		c.Log.Warnf("Failed to create pickup subscription: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if err := c.PickupSubscriptionItemRepository.CreateBatch(tx, items); err != nil {
+		c.Log.Warnf("Failed to create pickup subscription items: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return c.Get(ctx, &model.GetPickupSubscriptionRequest{ID: subscription.ID.String(), ActorID: request.ActorID, ActorRole: request.ActorRole})
+}
+
+func (c *PickupSubscriptionUsecase) Get(ctx context.Context, request *model.GetPickupSubscriptionRequest) (*model.PickupSubscriptionResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	subscription := new(entity.PickupSubscription)
+	if err := c.PickupSubscriptionRepository.FindByIdWithItems(tx, subscription, request.ID); err != nil {
+		c.Log.Warnf("Failed to find pickup subscription by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if err := checkSubscriptionAccess(subscription, request.ActorID, request.ActorRole, false); err != nil {
+		return nil, err
+	}
+
+	today := subscriptionToday()
+	occurrences, err := c.PickupSubscriptionOccurrenceRepository.FindFrom(tx, request.ID, today.AddDate(0, 0, -pickupSubscriptionHistoryDays).Format("2006-01-02"))
+	if err != nil {
+		c.Log.Warnf("Failed to find pickup subscription occurrences: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.PickupSubscriptionToResponse(subscription)
+	response.Occurrences = make([]model.PickupSubscriptionOccurrenceResponse, len(occurrences))
+	for i, occurrence := range occurrences {
+		response.Occurrences[i] = *converter.PickupSubscriptionOccurrenceToResponse(&occurrence)
+	}
+	if subscription.Status == "active" {
+		for _, date := range nextSubscriptionDates(subscription, today, pickupSubscriptionNextDates) {
+			response.NextDates = append(response.NextDates, date.Format("2006-01-02"))
+		}
+	}
+
+	return response, nil
+}
+
+func (c *PickupSubscriptionUsecase) Search(ctx context.Context, request *model.SearchPickupSubscriptionRequest) ([]model.PickupSubscriptionResponse, int64, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	// Customers see their own subscriptions and waste banks the ones they serve
+	switch {
+	case request.ActorRole == "customer":
+		request.CustomerID = request.ActorID
+	case isWasteBankRole(request.ActorRole):
+		request.WasteBankID = request.ActorID
+	case request.ActorRole != "admin":
+		return nil, 0, fiber.NewError(fiber.StatusForbidden, "You are not allowed to view subscriptions")
+	}
+
+	subscriptions, total, err := c.PickupSubscriptionRepository.Search(tx, request)
+	if err != nil {
+		c.Log.Warnf("Failed to search pickup subscriptions: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.PickupSubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = *converter.PickupSubscriptionToResponse(&subscription)
+	}
+
+	return responses, total, nil
+}
+
+// Update changes the details used for future requests, requests already generated are left alone
+func (c *PickupSubscriptionUsecase) Update(ctx context.Context, request *model.UpdatePickupSubscriptionRequest) (*model.PickupSubscriptionResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	subscription := new(entity.PickupSubscription)
+	if err := c.PickupSubscriptionRepository.FindByIdForUpdate(tx, subscription, request.ID); err != nil {
+		c.Log.Warnf("Failed to find pickup subscription by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if err := checkSubscriptionAccess(subscription, request.ActorID, request.ActorRole, true); err != nil {
+		return nil, err
+	}
+	if subscription.Status == "cancelled" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Cancelled subscriptions cannot be changed")
+	}
+
+	if request.StartTime != nil || request.EndTime != nil {
+		startTimeStr := subscription.StartTime.Format("15:04:05Z07:00")
+		endTimeStr := subscription.EndTime.Format("15:04:05Z07:00")
+		if request.StartTime != nil {
+			startTimeStr = *request.StartTime
+		}
+		if request.EndTime != nil {
+			endTimeStr = *request.EndTime
+		}
+		startTime, endTime, err := parseSubscriptionWindow(startTimeStr, endTimeStr)
+		if err != nil {
+			return nil, err
+		}
+		subscription.StartTime = startTime
+		subscription.EndTime = endTime
+	}
+	if request.EndDate != nil {
+		if *request.EndDate == "" {
+			subscription.EndDate = nil
+		} else {
+			endDate, err := time.Parse("2006-01-02", *request.EndDate)
+			if err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "end_date must be in YYYY-MM-DD format")
+			}
+			if endDate.Before(subscriptionDate(subscription.StartDate)) {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "end_date cannot be before start_date")
+			}
+			subscription.EndDate = &endDate
+		}
+	}
+	if request.AppointmentLocation != nil {
+		subscription.AppointmentLocation = &types.Point{
+			Lat: request.AppointmentLocation.Latitude,
+			Lng: request.AppointmentLocation.Longitude,
+		}
+	}
+	if request.UserPhoneNumber != nil {
+		subscription.UserPhoneNumber = *request.UserPhoneNumber
+	}
+	if request.Notes != nil {
+		subscription.Notes = *request.Notes
+	}
+
+	if request.Items != nil {
+		items, err := c.buildItems(tx, subscription.ID, request.Items)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.PickupSubscriptionItemRepository.DeleteBySubscriptionID(tx, subscription.ID.String()); err != nil {
+			c.Log.Warnf("Failed to delete pickup subscription items: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if err := c.PickupSubscriptionItemRepository.CreateBatch(tx, items); err != nil {
+			c.Log.Warnf("Failed to create pickup subscription items: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := c.PickupSubscriptionRepository.Update(tx, subscription); err != nil {
+		c.Log.Warnf("Failed to update pickup subscription: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return c.Get(ctx, &model.GetPickupSubscriptionRequest{ID: request.ID, ActorID: request.ActorID, ActorRole: request.ActorRole})
+}
+
+// Helper method to move a subscription between statuses
+func (c *PickupSubscriptionUsecase) changeStatus(ctx context.Context, request *model.PickupSubscriptionActionRequest, fromStatuses []string, toStatus string) (*entity.PickupSubscription, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	subscription := new(entity.PickupSubscription)
+	if err := c.PickupSubscriptionRepository.FindByIdForUpdate(tx, subscription, request.ID); err != nil {
+		c.Log.Warnf("Failed to find pickup subscription by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if err := checkSubscriptionAccess(subscription, request.ActorID, request.ActorRole, true); err != nil {
+		return nil, err
+	}
+
+	allowed := false
+	for _, status := range fromStatuses {
+		if subscription.Status == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Cannot change subscription from %s to %s", subscription.Status, toStatus))
+	}
+
+	subscription.Status = toStatus
+	if err := c.PickupSubscriptionRepository.Update(tx, subscription); err != nil {
+		c.Log.Warnf("Failed to update pickup subscription: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return subscription, nil
+}
+
+// Pause stops generating new requests, requests already generated stay booked
+func (c *PickupSubscriptionUsecase) Pause(ctx context.Context, request *model.PickupSubscriptionActionRequest) (*model.PickupSubscriptionResponse, error) {
+	if _, err := c.changeStatus(ctx, request, []string{"active"}, "paused"); err != nil {
+		return nil, err
+	}
+	return c.Get(ctx, &model.GetPickupSubscriptionRequest{ID: request.ID, ActorID: request.ActorID, ActorRole: request.ActorRole})
+}
+
+func (c *PickupSubscriptionUsecase) Resume(ctx context.Context, request *model.PickupSubscriptionActionRequest) (*model.PickupSubscriptionResponse, error) {
+	if _, err := c.changeStatus(ctx, request, []string{"paused"}, "active"); err != nil {
+		return nil, err
+	}
+	return c.Get(ctx, &model.GetPickupSubscriptionRequest{ID: request.ID, ActorID: request.ActorID, ActorRole: request.ActorRole})
+}
+
+// Cancel ends the subscription and cancels the upcoming requests it generated that are not yet being collected
+func (c *PickupSubscriptionUsecase) Cancel(ctx context.Context, request *model.PickupSubscriptionActionRequest) (*model.PickupSubscriptionResponse, error) {
+	subscription, err := c.changeStatus(ctx, request, []string{"active", "paused"}, "cancelled")
+	if err != nil {
+		return nil, err
+	}
+
+	c.cancelGeneratedRequests(ctx, subscription, subscriptionToday().AddDate(0, 0, 1))
+
+	return c.Get(ctx, &model.GetPickupSubscriptionRequest{ID: request.ID, ActorID: request.ActorID, ActorRole: request.ActorRole})
+}
+
+// Helper method to cancel the pending or assigned requests a subscription generated from a date on
+func (c *PickupSubscriptionUsecase) cancelGeneratedRequests(ctx context.Context, subscription *entity.PickupSubscription, from time.Time) {
+	tx := c.DB.WithContext(ctx)
+
+	occurrences, err := c.PickupSubscriptionOccurrenceRepository.FindFrom(tx, subscription.ID.String(), from.Format("2006-01-02"))
+	if err != nil {
+		c.Log.Warnf("Failed to find occurrences of subscription %s: %+v", subscription.ID, err)
+		return
+	}
+
+	for _, occurrence := range occurrences {
+		if occurrence.Status != "generated" || occurrence.RequestID == nil {
+			continue
+		}
+
+		wasteDropRequest := new(entity.WasteDropRequest)
+		if err := c.WasteDropRequestRepository.FindById(tx, wasteDropRequest, occurrence.RequestID.String()); err != nil {
+			c.Log.Warnf("Failed to find generated request %s: %+v", occurrence.RequestID, err)
+			continue
+		}
+		if wasteDropRequest.Status != "pending" && wasteDropRequest.Status != "assigned" {
+			continue
+		}
+
+		if _, err := c.WasteDropRequestUsecase.Update(ctx, &model.UpdateWasteDropRequest{
+			ID:         occurrence.RequestID.String(),
+			Status:     "cancelled",
+			Reason:     "Pickup subscription cancelled",
+			ReasonCode: "customer_cancelled",
+			ActorRole:  "system",
+		}); err != nil {
+			c.Log.Warnf("Failed to cancel generated request %s: %+v", occurrence.RequestID, err)
+		}
+	}
+}
+
+// Skip leaves out a single date. A request already generated for it is cancelled as if the customer cancelled it.
+func (c *PickupSubscriptionUsecase) Skip(ctx context.Context, request *model.SkipPickupSubscriptionRequest) (*model.PickupSubscriptionOccurrenceResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "date must be in YYYY-MM-DD format")
+	}
+	if date.Before(subscriptionToday()) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Past dates cannot be skipped")
+	}
+
+	subscription := new(entity.PickupSubscription)
+	if err := c.PickupSubscriptionRepository.FindByIdForUpdate(tx, subscription, request.ID); err != nil {
+		c.Log.Warnf("Failed to find pickup subscription by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if err := checkSubscriptionAccess(subscription, request.ActorID, request.ActorRole, true); err != nil {
+		return nil, err
+	}
+	if subscription.Status == "cancelled" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Cancelled subscriptions cannot be changed")
+	}
+	if !isSubscriptionDue(subscription, date) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "The subscription has no pickup on this date")
+	}
+
+	reason := request.Reason
+	if reason == "" {
+		reason = "Skipped by customer"
+	}
+
+	occurrence := &entity.PickupSubscriptionOccurrence{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		Date:           date,
+		Status:         "skipped",
+		Reason:         reason,
+	}
+	reserved, err := c.PickupSubscriptionOccurrenceRepository.Reserve(tx, occurrence)
+	if err != nil {
+		c.Log.Warnf("Failed to reserve occurrence: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if !reserved {
+		if err := c.PickupSubscriptionOccurrenceRepository.FindBySubscriptionAndDate(tx, occurrence, request.ID, request.Date); err != nil {
+			c.Log.Warnf("Failed to find occurrence: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		switch occurrence.Status {
+		case "skipped":
+			return converter.PickupSubscriptionOccurrenceToResponse(occurrence), nil
+		case "generating":
+			return nil, fiber.NewError(fiber.StatusConflict, "The request for this date is being generated, try again shortly")
+		case "generated":
+			if occurrence.RequestID != nil {
+				// Cancelling goes through the usual rules, so requests already being collected cannot be skipped
+				if _, err := c.WasteDropRequestUsecase.Update(ctx, &model.UpdateWasteDropRequest{
+					ID:         occurrence.RequestID.String(),
+					Status:     "cancelled",
+					Reason:     reason,
+					ReasonCode: "customer_cancelled",
+					ActorID:    request.ActorID,
+					ActorRole:  request.ActorRole,
+				}); err != nil {
+					c.Log.Warnf("Failed to cancel generated request %s: %+v", occurrence.RequestID, err)
+					return nil, err
+				}
+			}
+		}
+
+		occurrence.Status = "skipped"
+		occurrence.Reason = reason
+		if err := c.PickupSubscriptionOccurrenceRepository.UpdateResult(tx, occurrence); err != nil {
+			c.Log.Warnf("Failed to update occurrence: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PickupSubscriptionOccurrenceToResponse(occurrence), nil
+}
+
+// Generate materializes the drop requests of every active subscription due within the horizon and
+// returns how many were created. Each date is reserved first, so overlapping runs never duplicate a request.
+func (c *PickupSubscriptionUsecase) Generate(ctx context.Context) (int, error) {
+	subscriptions, err := c.PickupSubscriptionRepository.FindActive(c.DB.WithContext(ctx))
+	if err != nil {
+		c.Log.Warnf("Failed to find active pickup subscriptions: %+v", err)
+		return 0, err
+	}
+
+	today := subscriptionToday()
+	generated := 0
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+		for offset := 0; offset <= pickupSubscriptionHorizonDays; offset++ {
+			date := today.AddDate(0, 0, offset)
+			if !isSubscriptionDue(subscription, date) {
+				continue
+			}
+
+			// Today's window may already have started, the request would be rejected as in the past
+			if offset == 0 {
+				if past, err := timezone.IsDateTimeInPastFromParsed(date, subscription.StartTime.Format("15:04:05Z07:00")); err != nil || past {
+					continue
+				}
+			}
+
+			created, err := c.generateOccurrence(ctx, subscription, date)
+			if err != nil {
+				c.Log.Warnf("Failed to generate occurrence %s of subscription %s: %+v", date.Format("2006-01-02"), subscription.ID, err)
+				continue
+			}
+			if created {
+				generated++
+			}
+		}
+	}
+
+	return generated, nil
+}
+
+// Helper method to generate the request of one date, reporting whether a request was created
+func (c *PickupSubscriptionUsecase) generateOccurrence(ctx context.Context, subscription *entity.PickupSubscription, date time.Time) (bool, error) {
+	db := c.DB.WithContext(ctx)
+	dateStr := date.Format("2006-01-02")
+
+	occurrence := &entity.PickupSubscriptionOccurrence{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		Date:           date,
+		Status:         "generating",
+	}
+	reserved, err := c.PickupSubscriptionOccurrenceRepository.Reserve(db, occurrence)
+	if err != nil {
+		return false, err
+	}
+	if !reserved {
+		if err := c.PickupSubscriptionOccurrenceRepository.FindBySubscriptionAndDate(db, occurrence, subscription.ID.String(), dateStr); err != nil {
+			return false, err
+		}
+		if occurrence.Status != "generating" || time.Since(occurrence.UpdatedAt) < pickupSubscriptionStaleAfter {
+			return false, nil
+		}
+
+		// A run died between reserving and recording the result, the request may exist already
+		wasteDropRequest := new(entity.WasteDropRequest)
+		if err := c.WasteDropRequestRepository.FindBySubscriptionAndDate(db, wasteDropRequest, subscription.ID.String(), dateStr); err == nil {
+			occurrence.Status = "generated"
+			occurrence.RequestID = &wasteDropRequest.ID
+			return false, c.PickupSubscriptionOccurrenceRepository.UpdateResult(db, occurrence)
+		} else if err != gorm.ErrRecordNotFound {
+			return false, err
+		}
+		// Touch the occurrence so concurrent runs leave the retry to this one
+		if err := c.PickupSubscriptionOccurrenceRepository.UpdateResult(db, occurrence); err != nil {
+			return false, err
+		}
+	}
+
+	closed, reason, err := c.AppointmentSlotUsecase.IsClosedOn(db, subscription.WasteBankID.String(), dateStr)
+	if err != nil {
+		return false, err
+	}
+	if closed {
+		occurrence.Status = "skipped"
+		occurrence.Reason = "Waste bank closed"
+		if reason != "" {
+			occurrence.Reason += ": " + reason
+		}
+		return false, c.PickupSubscriptionOccurrenceRepository.UpdateResult(db, occurrence)
+	}
+
+	items := &model.WasteDropRequestItems{
+		WasteTypeIDs: make([]string, len(subscription.Items)),
+		Quantities:   make([]int64, len(subscription.Items)),
+	}
+	for i, item := range subscription.Items {
+		items.WasteTypeIDs[i] = item.WasteTypeID.String()
+		items.Quantities[i] = item.Quantity
+	}
+
+	request := &model.WasteDropRequestRequest{
+		DeliveryType:         "pickup",
+		CustomerID:           subscription.CustomerID.String(),
+		UserPhoneNumber:      subscription.UserPhoneNumber,
+		WasteBankID:          subscription.WasteBankID.String(),
+		AppointmentDate:      dateStr,
+		AppointmentStartTime: subscription.StartTime.Format("15:04:05Z07:00"),
+		AppointmentEndTime:   subscription.EndTime.Format("15:04:05Z07:00"),
+		Notes:                subscription.Notes,
+		Items:                items,
+		SubscriptionID:       subscription.ID.String(),
+	}
+	if subscription.AppointmentLocation != nil {
+		request.AppointmentLocation = &model.LocationRequest{
+			Latitude:  subscription.AppointmentLocation.Lat,
+			Longitude: subscription.AppointmentLocation.Lng,
+		}
+	}
+
+	response, err := c.WasteDropRequestUsecase.Create(ctx, request)
+	if err != nil {
+		occurrence.Status = "failed"
+		occurrence.Reason = err.Error()
+		return false, c.PickupSubscriptionOccurrenceRepository.UpdateResult(db, occurrence)
+	}
+
+	requestID, err := uuid.Parse(response.ID)
+	if err != nil {
+		return false, err
+	}
+	occurrence.Status = "generated"
+	occurrence.RequestID = &requestID
+	if err := c.PickupSubscriptionOccurrenceRepository.UpdateResult(db, occurrence); err != nil {
+		return false, err
+	}
+
+	// The subscription may have been cancelled while the request was being created
+	current := new(entity.PickupSubscription)
+	if err := c.PickupSubscriptionRepository.FindById(db, current, subscription.ID.String()); err == nil && current.Status == "cancelled" {
+		c.cancelGeneratedRequests(ctx, current, date)
+	}
+
+	return true, nil
+}
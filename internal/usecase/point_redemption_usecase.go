@@ -0,0 +1,561 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/pkg/timezone"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PointRedemptionUsecase struct {
+	DB                            *gorm.DB
+	Log                           *logrus.Logger
+	Validate                      *validator.Validate
+	PointRedemptionRepository     *repository.PointRedemptionRepository
+	PointConversionRateRepository *repository.PointConversionRateRepository
+	UserRepository                *repository.UserRepository
+	LedgerUsecase                 *LedgerUsecase
+}
+
+func NewPointRedemptionUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	pointRedemptionRepository *repository.PointRedemptionRepository,
+	pointConversionRateRepository *repository.PointConversionRateRepository,
+	userRepository *repository.UserRepository,
+	ledgerUsecase *LedgerUsecase,
+) *PointRedemptionUsecase {
+	return &PointRedemptionUsecase{
+		DB:                            db,
+		Log:                           log,
+		Validate:                      validate,
+		PointRedemptionRepository:     pointRedemptionRepository,
+		PointConversionRateRepository: pointConversionRateRepository,
+		UserRepository:                userRepository,
+		LedgerUsecase:                 ledgerUsecase,
+	}
+}
+
+func isWasteBankRole(role string) bool {
+	return role == "waste_bank_unit" || role == "waste_bank_central"
+}
+
+// Helper method to find the start of the current limit period in WIB
+func redemptionPeriodStart(now time.Time, period string) time.Time {
+	now = now.In(timezone.WIB)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, timezone.WIB)
+	switch period {
+	case "day":
+		return today
+	case "week":
+		// Weeks start on Monday
+		offset := (int(today.Weekday()) + 6) % 7
+		return today.AddDate(0, 0, -offset)
+	default:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, timezone.WIB)
+	}
+}
+
+// Helper method to check who may see or decide on a redemption
+func (u *PointRedemptionUsecase) checkActorAccess(redemption *entity.PointRedemption, actorID string, actorRole string) error {
+	switch {
+	case actorRole == "admin":
+		return nil
+	case actorRole == "customer":
+		if redemption.UserID.String() == actorID {
+			return nil
+		}
+	case isWasteBankRole(actorRole):
+		if redemption.WasteBankID != nil && redemption.WasteBankID.String() == actorID {
+			return nil
+		}
+	}
+	return fiber.NewError(fiber.StatusForbidden, "You are not allowed to access this redemption")
+}
+
+func (u *PointRedemptionUsecase) CreateRate(ctx context.Context, request *model.PointConversionRateRequest) (*model.PointConversionRateResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	// Waste banks may only set their own rate, admins may also set the default
+	if isWasteBankRole(request.ActorRole) {
+		request.WasteBankID = request.ActorID
+	}
+
+	rate := &entity.PointConversionRate{
+		RupiahPerPoint:    request.RupiahPerPoint,
+		MinPoints:         request.MinPoints,
+		MaxPoints:         request.MaxPoints,
+		PeriodLimitPoints: request.PeriodLimitPoints,
+		LimitPeriod:       request.LimitPeriod,
+		EffectiveFrom:     time.Now(),
+		IsActive:          true,
+	}
+	if rate.MinPoints == 0 {
+		rate.MinPoints = 1
+	}
+	if rate.LimitPeriod == "" {
+		rate.LimitPeriod = "month"
+	}
+	if rate.MaxPoints != nil && *rate.MaxPoints < rate.MinPoints {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "max_points must not be less than min_points")
+	}
+
+	if request.EffectiveFrom != "" {
+		effectiveFrom, err := time.ParseInLocation("2006-01-02", request.EffectiveFrom, timezone.WIB)
+		if err != nil {
+			u.Log.Warnf("Invalid effective date: %+v", err)
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid effective_from format, expected YYYY-MM-DD")
+		}
+		rate.EffectiveFrom = effectiveFrom
+	}
+
+	if request.WasteBankID != "" {
+		wasteBank := new(entity.User)
+		if err := u.UserRepository.FindById(tx, wasteBank, request.WasteBankID); err != nil {
+			u.Log.Warnf("Waste bank not found: %v", err)
+			return nil, fiber.NewError(fiber.StatusNotFound, "Waste bank not found")
+		}
+		if !isWasteBankRole(wasteBank.Role) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "User is not a waste bank")
+		}
+		rate.WasteBankID = &wasteBank.ID
+	}
+
+	if err := u.PointConversionRateRepository.Create(tx, rate); err != nil {
+		u.Log.Warnf("Failed to create point conversion rate: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Warnf("Commit error: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PointConversionRateToResponse(rate), nil
+}
+
+func (u *PointRedemptionUsecase) UpdateRate(ctx context.Context, request *model.UpdatePointConversionRateRequest) (*model.PointConversionRateResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	rate := new(entity.PointConversionRate)
+	if err := u.PointConversionRateRepository.FindById(tx, rate, request.ID); err != nil {
+		u.Log.Warnf("Point conversion rate not found: %v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	if isWasteBankRole(request.ActorRole) && (rate.WasteBankID == nil || rate.WasteBankID.String() != request.ActorID) {
+		return nil, fiber.NewError(fiber.StatusForbidden, "You are not allowed to change this rate")
+	}
+
+	// Rates are versioned, so only activation can change once created
+	if request.IsActive != nil {
+		rate.IsActive = *request.IsActive
+	}
+
+	if err := u.PointConversionRateRepository.Update(tx, rate); err != nil {
+		u.Log.Warnf("Update failed: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Warnf("Commit error: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PointConversionRateToResponse(rate), nil
+}
+
+func (u *PointRedemptionUsecase) SearchRates(ctx context.Context, request *model.SearchPointConversionRateRequest) ([]model.PointConversionRateResponse, int64, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.WithError(err).Warn("Invalid request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	rates, total, err := u.PointConversionRateRepository.Search(tx, request)
+	if err != nil {
+		u.Log.WithError(err).Warn("Search failed")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Commit failed")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.PointConversionRateResponse, len(rates))
+	for i, rate := range rates {
+		responses[i] = *converter.PointConversionRateToResponse(&rate)
+	}
+
+	return responses, total, nil
+}
+
+// Create requests a redemption at the waste bank's current rate and puts the points on hold
+func (u *PointRedemptionUsecase) Create(ctx context.Context, request *model.PointRedemptionRequest) (*model.PointRedemptionSimpleResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	wasteBank := new(entity.User)
+	if err := u.UserRepository.FindById(tx, wasteBank, request.WasteBankID); err != nil {
+		u.Log.Warnf("Waste bank not found: %v", err)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Waste bank not found")
+	}
+	if !isWasteBankRole(wasteBank.Role) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "User is not a waste bank")
+	}
+
+	// Lock the user so concurrent requests cannot hold the same points twice
+	user := new(entity.User)
+	if err := u.UserRepository.FindById(tx.Clauses(clause.Locking{Strength: "UPDATE"}), user, request.UserID); err != nil {
+		u.Log.Warnf("User not found: %v", err)
+		return nil, fiber.NewError(fiber.StatusNotFound, "User not found")
+	}
+
+	now := time.Now()
+	rate := new(entity.PointConversionRate)
+	if err := u.PointConversionRateRepository.FindEffective(tx, rate, request.WasteBankID, now); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "No conversion rate is configured for this waste bank")
+		}
+		u.Log.Warnf("Failed to find conversion rate: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if request.Points < rate.MinPoints {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Points are below the minimum redemption amount")
+	}
+	if rate.MaxPoints != nil && request.Points > *rate.MaxPoints {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Points are above the maximum redemption amount")
+	}
+
+	if rate.PeriodLimitPoints != nil {
+		used, err := u.PointRedemptionRepository.SumPointsSince(tx, request.UserID, redemptionPeriodStart(now, rate.LimitPeriod))
+		if err != nil {
+			u.Log.Warnf("Failed to sum redeemed points: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if used+request.Points > *rate.PeriodLimitPoints {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Redemption limit for this period has been reached")
+		}
+	}
+
+	if user.Points < request.Points {
+		u.Log.Warnf("Insufficient points: user_id=%s, points=%d, required=%d", request.UserID, user.Points, request.Points)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Insufficient points")
+	}
+
+	rupiahAmount := int64(math.Floor(float64(request.Points) * rate.RupiahPerPoint))
+	if rupiahAmount < 1 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Points are worth less than one rupiah")
+	}
+
+	redemption := &entity.PointRedemption{
+		UserID:         user.ID,
+		WasteBankID:    &wasteBank.ID,
+		RateID:         &rate.ID,
+		Points:         request.Points,
+		RupiahPerPoint: rate.RupiahPerPoint,
+		RupiahAmount:   rupiahAmount,
+		Status:         "pending",
+		Notes:          request.Notes,
+	}
+	if err := u.PointRedemptionRepository.Create(tx, redemption); err != nil {
+		u.Log.Warnf("Failed to create point redemption: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	hold, err := u.LedgerUsecase.Post(tx, &model.LedgerPostingRequest{
+		EntryType:     "point_redemption_hold",
+		ReferenceType: "point_redemption",
+		ReferenceID:   redemption.ID.String(),
+		Description:   "Points held for redemption",
+		Lines: []model.LedgerPostingLine{
+			{UserID: user.ID.String(), Asset: "points", Direction: "debit", Amount: redemption.Points},
+			{AccountCode: LedgerAccountPointRedemptionHolds, Asset: "points", Direction: "credit", Amount: redemption.Points},
+		},
+	})
+	if err != nil {
+		u.Log.Warnf("Failed to post point hold: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	redemption.HoldEntryID = &hold.ID
+	if err := u.PointRedemptionRepository.Update(tx, redemption); err != nil {
+		u.Log.Warnf("Failed to update point redemption: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Warnf("Commit error: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PointRedemptionToSimpleResponse(redemption), nil
+}
+
+// Helper method to load a pending redemption for a decision by the given actor
+func (u *PointRedemptionUsecase) findPendingForDecision(tx *gorm.DB, request *model.DecidePointRedemptionRequest) (*entity.PointRedemption, error) {
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	redemption := new(entity.PointRedemption)
+	if err := u.PointRedemptionRepository.FindByIdForUpdate(tx, redemption, request.ID); err != nil {
+		u.Log.Warnf("Point redemption not found: %v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := u.checkActorAccess(redemption, request.ActorID, request.ActorRole); err != nil {
+		return nil, err
+	}
+
+	if redemption.Status != "pending" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Redemption is not in pending status")
+	}
+
+	return redemption, nil
+}
+
+// Helper method to mark a redemption as decided and save it
+func (u *PointRedemptionUsecase) saveDecision(tx *gorm.DB, redemption *entity.PointRedemption, status string, request *model.DecidePointRedemptionRequest) error {
+	now := time.Now()
+	redemption.Status = status
+	redemption.Reason = request.Reason
+	redemption.DecidedAt = &now
+	if actorID, err := uuid.Parse(request.ActorID); err == nil {
+		redemption.DecidedByID = &actorID
+	}
+
+	if err := u.PointRedemptionRepository.Update(tx, redemption); err != nil {
+		u.Log.Warnf("Failed to update point redemption: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+// Approve settles the redemption: the held points are retired and the rupiah is credited to the customer
+func (u *PointRedemptionUsecase) Approve(ctx context.Context, request *model.DecidePointRedemptionRequest) (*model.PointRedemptionSimpleResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if request.ActorRole == "customer" {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Customers cannot approve redemptions")
+	}
+
+	redemption, err := u.findPendingForDecision(tx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Migrated requests were never put on hold, so their points come straight from the user
+	pointsSource := model.LedgerPostingLine{AccountCode: LedgerAccountPointRedemptionHolds, Asset: "points", Direction: "debit", Amount: redemption.Points}
+	if redemption.HoldEntryID == nil {
+		user := new(entity.User)
+		if err := u.UserRepository.FindById(tx.Clauses(clause.Locking{Strength: "UPDATE"}), user, redemption.UserID.String()); err != nil {
+			u.Log.Warnf("User not found: %v", err)
+			return nil, fiber.NewError(fiber.StatusNotFound, "User not found")
+		}
+		if user.Points < redemption.Points {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Insufficient points")
+		}
+		pointsSource = model.LedgerPostingLine{UserID: user.ID.String(), Asset: "points", Direction: "debit", Amount: redemption.Points}
+	}
+
+	posting := &model.LedgerPostingRequest{
+		EntryType:     "point_redemption",
+		ReferenceType: "point_redemption",
+		ReferenceID:   redemption.ID.String(),
+		Description:   "Points redeemed for balance",
+		Lines: []model.LedgerPostingLine{
+			pointsSource,
+			{AccountCode: LedgerAccountPointsIssuance, Asset: "points", Direction: "credit", Amount: redemption.Points},
+			{AccountCode: LedgerAccountPointConversionPayout, Asset: "rupiah", Direction: "debit", Amount: redemption.RupiahAmount},
+			{UserID: redemption.UserID.String(), Asset: "rupiah", Direction: "credit", Amount: redemption.RupiahAmount},
+		},
+	}
+	if _, err := u.LedgerUsecase.Post(tx, posting); err != nil {
+		u.Log.Warnf("Failed to post point redemption: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := u.saveDecision(tx, redemption, "approved", request); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Warnf("Commit error: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PointRedemptionToSimpleResponse(redemption), nil
+}
+
+func (u *PointRedemptionUsecase) Reject(ctx context.Context, request *model.DecidePointRedemptionRequest) (*model.PointRedemptionSimpleResponse, error) {
+	if request.ActorRole == "customer" {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Customers cannot reject redemptions")
+	}
+	if request.Reason == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Reason is required to reject a redemption")
+	}
+	return u.release(ctx, request, "rejected")
+}
+
+func (u *PointRedemptionUsecase) Cancel(ctx context.Context, request *model.DecidePointRedemptionRequest) (*model.PointRedemptionSimpleResponse, error) {
+	return u.release(ctx, request, "cancelled")
+}
+
+// Helper method to close a pending redemption and give the held points back
+func (u *PointRedemptionUsecase) release(ctx context.Context, request *model.DecidePointRedemptionRequest, status string) (*model.PointRedemptionSimpleResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	redemption, err := u.findPendingForDecision(tx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if redemption.HoldEntryID != nil {
+		posting := &model.LedgerPostingRequest{
+			EntryType:     "point_redemption_release",
+			ReferenceType: "point_redemption",
+			ReferenceID:   redemption.ID.String(),
+			Description:   "Held points returned, redemption " + status,
+			Lines: []model.LedgerPostingLine{
+				{AccountCode: LedgerAccountPointRedemptionHolds, Asset: "points", Direction: "debit", Amount: redemption.Points},
+				{UserID: redemption.UserID.String(), Asset: "points", Direction: "credit", Amount: redemption.Points},
+			},
+		}
+		if _, err := u.LedgerUsecase.Post(tx, posting); err != nil {
+			u.Log.Warnf("Failed to release point hold: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := u.saveDecision(tx, redemption, status, request); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Warnf("Commit error: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PointRedemptionToSimpleResponse(redemption), nil
+}
+
+func (u *PointRedemptionUsecase) Get(ctx context.Context, request *model.GetPointRedemptionRequest) (*model.PointRedemptionResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	redemption := new(entity.PointRedemption)
+	if err := u.PointRedemptionRepository.FindByIdWithRelations(tx, redemption, request.ID); err != nil {
+		u.Log.Warnf("Point redemption not found: %v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := u.checkActorAccess(redemption, request.ActorID, request.ActorRole); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Warnf("Commit error: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PointRedemptionToResponse(redemption), nil
+}
+
+func (u *PointRedemptionUsecase) Search(ctx context.Context, request *model.SearchPointRedemptionRequest) ([]model.PointRedemptionSimpleResponse, int64, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.WithError(err).Warn("Invalid request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	redemptions, total, err := u.PointRedemptionRepository.Search(tx, request)
+	if err != nil {
+		u.Log.WithError(err).Warn("Search failed")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Commit failed")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.PointRedemptionSimpleResponse, len(redemptions))
+	for i, redemption := range redemptions {
+		responses[i] = *converter.PointRedemptionToSimpleResponse(&redemption)
+	}
+
+	return responses, total, nil
+}
+
+func (u *PointRedemptionUsecase) Delete(ctx context.Context, id string) (*model.PointRedemptionSimpleResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	redemption := new(entity.PointRedemption)
+	if err := u.PointRedemptionRepository.FindById(tx, redemption, id); err != nil {
+		u.Log.Warnf("Point redemption not found: %v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	// Deleting a pending redemption would strand its held points
+	if redemption.Status == "pending" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Pending redemptions must be rejected or cancelled first")
+	}
+
+	if err := u.PointRedemptionRepository.SoftDelete(tx, redemption); err != nil {
+		u.Log.Warnf("Delete failed: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	redemption.IsDeleted = true
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Warnf("Commit error: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PointRedemptionToSimpleResponse(redemption), nil
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+	"gorm.io/gorm"
+)
+
+type RegionUsecase struct {
+	DB               *gorm.DB
+	Log              *logrus.Logger
+	Validate         *validator.Validate
+	RegionRepository *repository.RegionRepository
+}
+
+func NewRegionUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	regionRepository *repository.RegionRepository,
+) *RegionUsecase {
+	return &RegionUsecase{
+		DB:               db,
+		Log:              log,
+		Validate:         validate,
+		RegionRepository: regionRepository,
+	}
+}
+
+// Helper method to check an explicit region code, or resolve one from a location and free text address.
+// Returns nil when nothing matches, the region can be back-filled once the registry is complete.
+func resolveRegionCode(tx *gorm.DB, log *logrus.Logger, regions *repository.RegionRepository, code string, location *types.Point, province string, city string) (*string, error) {
+	if code != "" {
+		region := new(entity.Region)
+		if err := regions.FindByCode(tx, region, code); err != nil {
+			log.Warnf("Failed to find region by code: %+v", err)
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Unknown region code")
+		}
+		return &region.Code, nil
+	}
+
+	var lat, lng *float64
+	if location != nil {
+		lat, lng = &location.Lat, &location.Lng
+	}
+	regionCode, err := regions.ResolveCode(tx, lat, lng, province, city)
+	if err != nil {
+		log.Warnf("Failed to resolve region: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return regionCode, nil
+}
+
+func (c *RegionUsecase) Get(ctx context.Context, request *model.GetRegionRequest) (*model.RegionResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	region := new(entity.Region)
+	if err := c.RegionRepository.FindByCode(tx, region, request.Code); err != nil {
+		c.Log.Warnf("Failed to find region by code: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	return converter.RegionToResponse(region), nil
+}
+
+func (c *RegionUsecase) Search(ctx context.Context, request *model.SearchRegionRequest) ([]model.RegionResponse, int64, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	regions, total, err := c.RegionRepository.Search(tx, request)
+	if err != nil {
+		c.Log.Warnf("Failed to search regions: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.RegionResponse, len(regions))
+	for i, region := range regions {
+		responses[i] = *converter.RegionToResponse(&region)
+	}
+
+	return responses, total, nil
+}
+
+// Backfill sets the region of users from their location or address, then of requests from their
+// appointment location or the user they belong to. Dashboard rollups keep their regions until rebuilt.
+func (c *RegionUsecase) Backfill(ctx context.Context, request *model.BackfillRegionRequest) (*model.BackfillRegionResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// Without boundaries every location misses and records silently fall back to their address
+	boundaries, err := c.RegionRepository.CountWithBoundary(tx)
+	if err != nil {
+		c.Log.Warnf("Failed to count region boundaries: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if boundaries == 0 {
+		return nil, fiber.NewError(fiber.StatusConflict, "No region boundaries are loaded, import them before backfilling")
+	}
+
+	response := new(model.BackfillRegionResponse)
+	if response.Users, err = c.RegionRepository.BackfillUsers(tx, request.Overwrite); err != nil {
+		c.Log.Warnf("Failed to backfill user regions: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if response.WasteDropRequests, err = c.RegionRepository.BackfillWasteDropRequests(tx, request.Overwrite); err != nil {
+		c.Log.Warnf("Failed to backfill waste drop request regions: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if response.WasteTransferRequests, err = c.RegionRepository.BackfillWasteTransferRequests(tx, request.Overwrite); err != nil {
+		c.Log.Warnf("Failed to backfill waste transfer request regions: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return response, nil
+}
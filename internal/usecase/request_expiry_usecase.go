@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/pkg/timezone"
+	"gorm.io/gorm"
+)
+
+const (
+	// Used when neither the waste bank nor the server config sets an expiry window
+	defaultPendingExpiryHours = 48
+	// Requests handled per kind in one run, the rest wait for the next run
+	requestExpiryBatchSize = 200
+)
+
+type RequestExpiryUsecase struct {
+	DB                             *gorm.DB
+	Log                            *logrus.Logger
+	WasteDropRequestRepository     *repository.WasteDropRequestRepository
+	WasteTransferRequestRepository *repository.WasteTransferRequestRepository
+	WasteDropRequestUsecase        *WasteDropRequestUsecase
+	WasteTransferRequestUsecase    *WasteTransferRequestUsecase
+	PendingExpiryHours             int
+}
+
+func NewRequestExpiryUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	wasteDropRequestRepository *repository.WasteDropRequestRepository,
+	wasteTransferRequestRepository *repository.WasteTransferRequestRepository,
+	wasteDropRequestUsecase *WasteDropRequestUsecase,
+	wasteTransferRequestUsecase *WasteTransferRequestUsecase,
+	pendingExpiryHours int,
+) *RequestExpiryUsecase {
+	if pendingExpiryHours <= 0 {
+		pendingExpiryHours = defaultPendingExpiryHours
+	}
+	return &RequestExpiryUsecase{
+		DB:                             db,
+		Log:                            log,
+		WasteDropRequestRepository:     wasteDropRequestRepository,
+		WasteTransferRequestRepository: wasteTransferRequestRepository,
+		WasteDropRequestUsecase:        wasteDropRequestUsecase,
+		WasteTransferRequestUsecase:    wasteTransferRequestUsecase,
+		PendingExpiryHours:             pendingExpiryHours,
+	}
+}
+
+// Run expires unattended pending requests and cancels assigned requests whose appointment date passed,
+// returning how many requests were cancelled. Each request is cancelled in its own transaction.
+func (c *RequestExpiryUsecase) Run(ctx context.Context) (int, error) {
+	db := c.DB.WithContext(ctx)
+	today := time.Now().In(timezone.WIB).Format("2006-01-02")
+	cancelled := 0
+
+	ids, err := c.WasteDropRequestRepository.FindExpiredPending(db, c.PendingExpiryHours, requestExpiryBatchSize)
+	if err != nil {
+		c.Log.Warnf("Failed to find expired waste drop requests: %+v", err)
+		return cancelled, err
+	}
+	cancelled += c.cancelDropRequests(ctx, ids, "pending", "expired", "No response from the waste bank in time")
+
+	ids, err = c.WasteDropRequestRepository.FindOverdueAssigned(db, today, requestExpiryBatchSize)
+	if err != nil {
+		c.Log.Warnf("Failed to find overdue waste drop requests: %+v", err)
+		return cancelled, err
+	}
+	cancelled += c.cancelDropRequests(ctx, ids, "assigned", "no_show", "Appointment date passed without collection")
+
+	ids, err = c.WasteTransferRequestRepository.FindExpiredPending(db, c.PendingExpiryHours, requestExpiryBatchSize)
+	if err != nil {
+		c.Log.Warnf("Failed to find expired waste transfer requests: %+v", err)
+		return cancelled, err
+	}
+	cancelled += c.cancelTransferRequests(ctx, ids, "pending", "expired")
+
+	ids, err = c.WasteTransferRequestRepository.FindOverdueAssigned(db, today, requestExpiryBatchSize)
+	if err != nil {
+		c.Log.Warnf("Failed to find overdue waste transfer requests: %+v", err)
+		return cancelled, err
+	}
+	cancelled += c.cancelTransferRequests(ctx, ids, "assigned", "no_show")
+
+	return cancelled, nil
+}
+
+// Helper method to cancel drop requests still in the expected status once locked
+func (c *RequestExpiryUsecase) cancelDropRequests(ctx context.Context, ids []string, expectedStatus string, reasonCode string, reason string) int {
+	cancelled := 0
+	for _, id := range ids {
+		done := false
+		err := c.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			wasteDropRequest := new(entity.WasteDropRequest)
+			if err := c.WasteDropRequestRepository.FindByIdForUpdate(tx, wasteDropRequest, id); err != nil {
+				return err
+			}
+			// Someone acted on the request since it was found
+			if wasteDropRequest.Status != expectedStatus {
+				return nil
+			}
+			if err := c.WasteDropRequestUsecase.cancelRequest(tx, wasteDropRequest, reasonCode, reason, "", "system"); err != nil {
+				return err
+			}
+			done = true
+			return nil
+		})
+		if err != nil {
+			c.Log.Warnf("Failed to cancel waste drop request %s as %s: %+v", id, reasonCode, err)
+		} else if done {
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+// Helper method to cancel transfer requests still in the expected status once locked
+func (c *RequestExpiryUsecase) cancelTransferRequests(ctx context.Context, ids []string, expectedStatus string, reasonCode string) int {
+	cancelled := 0
+	for _, id := range ids {
+		done := false
+		err := c.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			wasteTransferRequest := new(entity.WasteTransferRequest)
+			if err := c.WasteTransferRequestRepository.FindByIdForUpdate(tx, wasteTransferRequest, id); err != nil {
+				return err
+			}
+			if wasteTransferRequest.Status != expectedStatus {
+				return nil
+			}
+			if err := c.WasteTransferRequestUsecase.cancelRequest(tx, wasteTransferRequest, reasonCode); err != nil {
+				return err
+			}
+			done = true
+			return nil
+		})
+		if err != nil {
+			c.Log.Warnf("Failed to cancel waste transfer request %s as %s: %+v", id, reasonCode, err)
+		} else if done {
+			cancelled++
+		}
+	}
+	return cancelled
+}
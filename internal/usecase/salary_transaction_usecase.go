@@ -12,6 +12,7 @@ import (
 	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
 	"github.com/wastetrack/wastetrack-backend/internal/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SalaryTransactionUsecase struct {
@@ -20,18 +21,26 @@ type SalaryTransactionUsecase struct {
 	Validate                    *validator.Validate
 	SalaryTransactionRepository *repository.SalaryTransactionRepository
 	UserRepository              *repository.UserRepository
+	LedgerUsecase               *LedgerUsecase
 }
 
-func NewSalaryTransactionUsecase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, salaryTransactionRepository *repository.SalaryTransactionRepository, userRepository *repository.UserRepository) *SalaryTransactionUsecase {
+func NewSalaryTransactionUsecase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, salaryTransactionRepository *repository.SalaryTransactionRepository, userRepository *repository.UserRepository, ledgerUsecase *LedgerUsecase) *SalaryTransactionUsecase {
 	return &SalaryTransactionUsecase{
 		DB:                          db,
 		Log:                         log,
 		Validate:                    validate,
 		SalaryTransactionRepository: salaryTransactionRepository,
 		UserRepository:              userRepository,
+		LedgerUsecase:               ledgerUsecase,
 	}
 }
 
+// Ledger entry type for each salary transaction type
+var salaryTransactionEntryTypes = map[string]string{
+	"salary":        "salary_payment",
+	"waste_payment": "transfer_payment",
+}
+
 func (u *SalaryTransactionUsecase) Create(ctx context.Context, request *model.SalaryTransactionRequest) (*model.SalaryTransactionSimpleResponse, error) {
 	tx := u.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
@@ -54,9 +63,9 @@ func (u *SalaryTransactionUsecase) Create(ctx context.Context, request *model.Sa
 		return nil, fiber.ErrBadRequest
 	}
 
-	// Check if sender exists
+	// Lock the sender so concurrent transfers cannot spend the same balance twice
 	sender := new(entity.User)
-	if err := u.UserRepository.FindById(tx, sender, request.SenderID); err != nil {
+	if err := u.UserRepository.FindById(tx.Clauses(clause.Locking{Strength: "UPDATE"}), sender, request.SenderID); err != nil {
 		u.Log.Warnf("Sender not found: %v", err)
 		return nil, fiber.NewError(fiber.StatusNotFound, "Sender not found")
 	}
@@ -74,22 +83,6 @@ func (u *SalaryTransactionUsecase) Create(ctx context.Context, request *model.Sa
 		return nil, fiber.NewError(fiber.StatusBadRequest, "Insufficient balance")
 	}
 
-	// Perform balance transfer
-	sender.Balance -= request.Amount
-	receiver.Balance += request.Amount
-
-	// Update sender balance
-	if err := u.UserRepository.Update(tx, sender); err != nil {
-		u.Log.Warnf("Failed to update sender balance: %+v", err)
-		return nil, fiber.ErrInternalServerError
-	}
-
-	// Update receiver balance
-	if err := u.UserRepository.Update(tx, receiver); err != nil {
-		u.Log.Warnf("Failed to update receiver balance: %+v", err)
-		return nil, fiber.ErrInternalServerError
-	}
-
 	salaryTransaction := &entity.SalaryTransaction{
 		SenderID:        senderID,
 		ReceiverID:      receiverID,
@@ -104,132 +97,23 @@ func (u *SalaryTransactionUsecase) Create(ctx context.Context, request *model.Sa
 		return nil, fiber.ErrInternalServerError
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		u.Log.Warnf("Commit error: %+v", err)
-		return nil, fiber.ErrInternalServerError
-	}
-
-	return converter.SalaryTransactionToSimpleResponse(salaryTransaction), nil
-}
-
-func (u *SalaryTransactionUsecase) CreatePointConversion(ctx context.Context, request *model.SalaryTransactionRequest) (*model.SalaryTransactionSimpleResponse, error) {
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
-
-	if err := u.Validate.Struct(request); err != nil {
-		u.Log.Warnf("Invalid request body: %+v", err)
-		return nil, fiber.ErrBadRequest
-	}
-	// Parse UUIDs
-	senderID, err := uuid.Parse(request.SenderID)
-	if err != nil {
-		u.Log.Warnf("Invalid sender ID: %v", err)
-		return nil, fiber.ErrBadRequest
-	}
-
-	receiverID, err := uuid.Parse(request.ReceiverID)
-	if err != nil {
-		u.Log.Warnf("Invalid receiver ID: %v", err)
-		return nil, fiber.ErrBadRequest
-	}
-
-	// Check if sender exists
-	sender := new(entity.User)
-	if err := u.UserRepository.FindById(tx, sender, request.SenderID); err != nil {
-		u.Log.Warnf("Sender not found: %v", err)
-		return nil, fiber.NewError(fiber.StatusNotFound, "Sender not found")
-	}
-
-	// Check if receiver exists
-	receiver := new(entity.User)
-	if err := u.UserRepository.FindById(tx, receiver, request.ReceiverID); err != nil {
-		u.Log.Warnf("Receiver not found: %v", err)
-		return nil, fiber.NewError(fiber.StatusNotFound, "Receiver not found")
-	}
-	// Check if sender has sufficient points
-	if sender.Points < request.Amount {
-		u.Log.Warnf("Insufficient points: sender_id=%s, points=%d, required=%d", request.SenderID, sender.Points, request.Amount)
-		return nil, fiber.NewError(fiber.StatusBadRequest, "Insufficient points")
-	}
-
-	salaryTransaction := &entity.SalaryTransaction{
-		SenderID:        senderID,
-		ReceiverID:      receiverID,
-		TransactionType: "point_conversion",
-		Amount:          request.Amount,
-		Status:          "pennding",
-		Notes:           request.Notes,
-	}
-	if err := u.SalaryTransactionRepository.Create(tx, salaryTransaction); err != nil {
-		u.Log.Warnf("Failed to create salary transaction: %+v", err)
-		return nil, fiber.ErrInternalServerError
-	}
-
-	if err := tx.Commit().Error; err != nil {
-		u.Log.Warnf("Commit error: %+v", err)
-		return nil, fiber.ErrInternalServerError
-	}
-
-	return converter.SalaryTransactionToSimpleResponse(salaryTransaction), nil
-}
-
-func (u *SalaryTransactionUsecase) CompletePointConversion(ctx context.Context, id string) (*model.SalaryTransactionSimpleResponse, error) {
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
-
-	// Find the existing salary transaction by ID
-	salaryTransaction := new(entity.SalaryTransaction)
-	if err := u.SalaryTransactionRepository.FindById(tx, salaryTransaction, id); err != nil {
-		u.Log.Warnf("Salary transaction not found: %v", err)
-		return nil, fiber.ErrNotFound
-	}
-
-	// Check if this is a point conversion transaction
-	if salaryTransaction.TransactionType != "point_conversion" {
-		u.Log.Warnf("Transaction is not a point conversion: transaction_id=%s, type=%s", id, salaryTransaction.TransactionType)
-		return nil, fiber.NewError(fiber.StatusBadRequest, "Transaction is not a point conversion")
+	// Perform balance transfer through the ledger
+	entryType, ok := salaryTransactionEntryTypes[request.TransactionType]
+	if !ok {
+		entryType = request.TransactionType
 	}
-
-	// Check if already completed
-	if salaryTransaction.Status == "completed" {
-		u.Log.Warnf("Transaction already completed: transaction_id=%s", id)
-		return nil, fiber.NewError(fiber.StatusBadRequest, "Transaction already completed")
-	}
-
-	// Check if transaction is in pending status
-	if salaryTransaction.Status != "pending" {
-		u.Log.Warnf("Transaction is not in pending status: transaction_id=%s, status=%s", id, salaryTransaction.Status)
-		return nil, fiber.NewError(fiber.StatusBadRequest, "Transaction is not in pending status")
-	}
-
-	// Get the sender (user who is converting points)
-	sender := new(entity.User)
-	if err := u.UserRepository.FindById(tx, sender, salaryTransaction.SenderID.String()); err != nil {
-		u.Log.Warnf("Sender not found: %v", err)
-		return nil, fiber.NewError(fiber.StatusNotFound, "Sender not found")
-	}
-
-	// Check if sender still has sufficient points
-	if sender.Points < salaryTransaction.Amount {
-		u.Log.Warnf("Insufficient points: sender_id=%s, points=%d, required=%d", salaryTransaction.SenderID.String(), sender.Points, salaryTransaction.Amount)
-		return nil, fiber.NewError(fiber.StatusBadRequest, "Insufficient points")
-	}
-
-	// Perform the point to balance conversion
-	sender.Points -= salaryTransaction.Amount
-	sender.Balance += salaryTransaction.Amount
-
-	// Update sender
-	if err := u.UserRepository.Update(tx, sender); err != nil {
-		u.Log.Warnf("Failed to update sender: %+v", err)
-		return nil, fiber.ErrInternalServerError
+	posting := &model.LedgerPostingRequest{
+		EntryType:     entryType,
+		ReferenceType: "salary_transaction",
+		ReferenceID:   salaryTransaction.ID.String(),
+		Description:   request.Notes,
+		Lines: []model.LedgerPostingLine{
+			{UserID: sender.ID.String(), Asset: "rupiah", Direction: "debit", Amount: request.Amount},
+			{UserID: receiver.ID.String(), Asset: "rupiah", Direction: "credit", Amount: request.Amount},
+		},
 	}
-
-	// Update transaction status to completed
-	salaryTransaction.Status = "completed"
-
-	if err := u.SalaryTransactionRepository.Update(tx, salaryTransaction); err != nil {
-		u.Log.Warnf("Failed to update salary transaction: %+v", err)
+	if _, err := u.LedgerUsecase.Post(tx, posting); err != nil {
+		u.Log.Warnf("Failed to post balance transfer: %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
@@ -274,6 +158,10 @@ func (u *SalaryTransactionUsecase) Update(ctx context.Context, request *model.Up
 		return nil, fiber.ErrNotFound
 	}
 
+	if err := u.checkNotPosted(tx, salaryTransaction); err != nil {
+		return nil, err
+	}
+
 	// Update transaction type if provided
 	if request.TransactionType != "" {
 		salaryTransaction.TransactionType = request.TransactionType
@@ -341,6 +229,10 @@ func (u *SalaryTransactionUsecase) Delete(ctx context.Context, id string) (*mode
 		return nil, fiber.ErrNotFound
 	}
 
+	if err := u.checkNotPosted(tx, salaryTransaction); err != nil {
+		return nil, err
+	}
+
 	if err := u.SalaryTransactionRepository.SoftDelete(tx, salaryTransaction); err != nil {
 		u.Log.Warnf("Delete failed: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -353,3 +245,18 @@ func (u *SalaryTransactionUsecase) Delete(ctx context.Context, id string) (*mode
 
 	return converter.SalaryTransactionToSimpleResponse(salaryTransaction), nil
 }
+
+// checkNotPosted rejects changes to a transaction whose balance transfer is already in the
+// ledger, since editing or deleting it would leave the journal out of step with the record
+func (u *SalaryTransactionUsecase) checkNotPosted(tx *gorm.DB, salaryTransaction *entity.SalaryTransaction) error {
+	entries, err := u.LedgerUsecase.LedgerEntryRepository.FindByReference(tx, "salary_transaction", salaryTransaction.ID.String())
+	if err != nil {
+		u.Log.Warnf("Failed to find ledger entries: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if len(entries) > 0 {
+		u.Log.Warnf("Salary transaction already posted to ledger: %s", salaryTransaction.ID)
+		return fiber.NewError(fiber.StatusConflict, "Salary transaction has already been posted and cannot be changed")
+	}
+	return nil
+}
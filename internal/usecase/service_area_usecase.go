@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+	"gorm.io/gorm"
+)
+
+// Limit on the polygons created from a single GeoJSON upload
+const maxServiceAreasPerUpload = 50
+
+type ServiceAreaUsecase struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	ServiceAreaRepository *repository.ServiceAreaRepository
+	UserRepository        *repository.UserRepository
+}
+
+func NewServiceAreaUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	serviceAreaRepository *repository.ServiceAreaRepository,
+	userRepository *repository.UserRepository,
+) *ServiceAreaUsecase {
+	return &ServiceAreaUsecase{
+		DB:                    db,
+		Log:                   log,
+		Validate:              validate,
+		ServiceAreaRepository: serviceAreaRepository,
+		UserRepository:        userRepository,
+	}
+}
+
+func isServiceAreaOwnerRole(role string) bool {
+	return isWasteBankRole(role) || role == "waste_collector_unit" || role == "waste_collector_central"
+}
+
+// Helper method to resolve whose service areas a request manages
+func serviceAreaOwnerID(actorID string, actorRole string, requested string) (string, error) {
+	switch {
+	case actorRole == "admin":
+		if requested == "" {
+			return "", fiber.NewError(fiber.StatusBadRequest, "user_id is required")
+		}
+		return requested, nil
+	case isServiceAreaOwnerRole(actorRole):
+		if requested != "" && requested != actorID {
+			return "", fiber.NewError(fiber.StatusForbidden, "You can only manage your own service areas")
+		}
+		return actorID, nil
+	}
+	return "", fiber.NewError(fiber.StatusForbidden, "Only waste banks and collectors have service areas")
+}
+
+// Helper method to parse and check the polygons of a GeoJSON document
+func (c *ServiceAreaUsecase) parseAreas(tx *gorm.DB, geoJSON []byte) ([]types.Polygon, error) {
+	areas, err := types.ParseGeoJSONPolygons(geoJSON)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if len(areas) == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "GeoJSON has no polygons")
+	}
+	if len(areas) > maxServiceAreasPerUpload {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("GeoJSON can have at most %d polygons", maxServiceAreasPerUpload))
+	}
+	for i, area := range areas {
+		valid, err := c.ServiceAreaRepository.IsValid(tx, area)
+		if err != nil {
+			c.Log.Warnf("Failed to validate service area: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if !valid {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Polygon %d is not a valid area, check for self intersections", i+1))
+		}
+	}
+	return areas, nil
+}
+
+// Create adds a service area for every polygon in the GeoJSON document
+func (c *ServiceAreaUsecase) Create(ctx context.Context, request *model.ServiceAreaRequest) ([]model.ServiceAreaResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	userID, err := serviceAreaOwnerID(request.ActorID, request.ActorRole, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	owner := new(entity.User)
+	if err := c.UserRepository.FindById(tx, owner, userID); err != nil {
+		c.Log.Warnf("Failed to find user by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if !isServiceAreaOwnerRole(owner.Role) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Only waste banks and collectors have service areas")
+	}
+
+	areas, err := c.parseAreas(tx, request.GeoJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAreas := make([]*entity.ServiceArea, len(areas))
+	for i, area := range areas {
+		name := request.Name
+		if len(areas) > 1 {
+			name = fmt.Sprintf("%s %d", request.Name, i+1)
+		}
+		serviceAreas[i] = &entity.ServiceArea{
+			UserID:   owner.ID,
+			Name:     name,
+			Area:     area,
+			IsActive: true,
+		}
+	}
+	if err := c.ServiceAreaRepository.CreateBatch(tx, serviceAreas); err != nil {
+		c.Log.Warnf("Failed to create service areas: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.ServiceAreaResponse, len(serviceAreas))
+	for i, serviceArea := range serviceAreas {
+		responses[i] = *converter.ServiceAreaToResponse(serviceArea)
+	}
+	return responses, nil
+}
+
+func (c *ServiceAreaUsecase) Search(ctx context.Context, request *model.SearchServiceAreaRequest) ([]model.ServiceAreaResponse, int64, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	serviceAreas, total, err := c.ServiceAreaRepository.Search(tx, request)
+	if err != nil {
+		c.Log.Warnf("Failed to search service areas: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.ServiceAreaResponse, len(serviceAreas))
+	for i, serviceArea := range serviceAreas {
+		responses[i] = *converter.ServiceAreaToResponse(&serviceArea)
+	}
+
+	return responses, total, nil
+}
+
+func (c *ServiceAreaUsecase) Update(ctx context.Context, request *model.UpdateServiceAreaRequest) (*model.ServiceAreaResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	serviceArea := new(entity.ServiceArea)
+	if err := c.ServiceAreaRepository.FindById(tx, serviceArea, request.ID); err != nil {
+		c.Log.Warnf("Failed to find service area by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if request.ActorRole != "admin" && serviceArea.UserID.String() != request.ActorID {
+		return nil, fiber.NewError(fiber.StatusForbidden, "You can only manage your own service areas")
+	}
+
+	if request.Name != "" {
+		serviceArea.Name = request.Name
+	}
+	if request.IsActive != nil {
+		serviceArea.IsActive = *request.IsActive
+	}
+	if len(request.GeoJSON) > 0 {
+		areas, err := c.parseAreas(tx, request.GeoJSON)
+		if err != nil {
+			return nil, err
+		}
+		if len(areas) != 1 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "GeoJSON must have exactly one polygon")
+		}
+		serviceArea.Area = areas[0]
+	}
+
+	if err := c.ServiceAreaRepository.Update(tx, serviceArea); err != nil {
+		c.Log.Warnf("Failed to update service area: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ServiceAreaToResponse(serviceArea), nil
+}
+
+func (c *ServiceAreaUsecase) Delete(ctx context.Context, request *model.DeleteServiceAreaRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	serviceArea := new(entity.ServiceArea)
+	if err := c.ServiceAreaRepository.FindById(tx, serviceArea, request.ID); err != nil {
+		c.Log.Warnf("Failed to find service area by ID: %+v", err)
+		return fiber.ErrNotFound
+	}
+	if request.ActorRole != "admin" && serviceArea.UserID.String() != request.ActorID {
+		return fiber.NewError(fiber.StatusForbidden, "You can only manage your own service areas")
+	}
+
+	if err := c.ServiceAreaRepository.Delete(tx, serviceArea); err != nil {
+		c.Log.Warnf("Failed to delete service area: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
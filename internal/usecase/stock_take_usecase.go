@@ -0,0 +1,491 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+type StockTakeUsecase struct {
+	DB                      *gorm.DB
+	Log                     *logrus.Logger
+	Validate                *validator.Validate
+	UserRepository          *repository.UserRepository
+	StorageRepository       *repository.StorageRepository
+	StorageItemRepository   *repository.StorageItemRepository
+	WasteTypeRepository     *repository.WasteTypeRepository
+	StockTakeRepository     *repository.StockTakeRepository
+	StockTakeLineRepository *repository.StockTakeLineRepository
+	StockMovementRepository *repository.StockMovementRepository
+}
+
+func NewStockTakeUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	userRepository *repository.UserRepository,
+	storageRepository *repository.StorageRepository,
+	storageItemRepository *repository.StorageItemRepository,
+	wasteTypeRepository *repository.WasteTypeRepository,
+	stockTakeRepository *repository.StockTakeRepository,
+	stockTakeLineRepository *repository.StockTakeLineRepository,
+	stockMovementRepository *repository.StockMovementRepository,
+) *StockTakeUsecase {
+	return &StockTakeUsecase{
+		DB:                      db,
+		Log:                     log,
+		Validate:                validate,
+		UserRepository:          userRepository,
+		StorageRepository:       storageRepository,
+		StorageItemRepository:   storageItemRepository,
+		WasteTypeRepository:     wasteTypeRepository,
+		StockTakeRepository:     stockTakeRepository,
+		StockTakeLineRepository: stockTakeLineRepository,
+		StockMovementRepository: stockMovementRepository,
+	}
+}
+
+func checkStorageOwner(storage *entity.Storage, actorID string, actorRole string) error {
+	if actorRole != "admin" && storage.UserID.String() != actorID {
+		return fiber.NewError(fiber.StatusForbidden, "You are not the owner of this storage")
+	}
+	return nil
+}
+
+// findOpenForUpdate locks a stock take of the actor that is still being counted
+func (c *StockTakeUsecase) findOpenForUpdate(tx *gorm.DB, id string, actorID string, actorRole string) (*entity.StockTake, error) {
+	stockTake := new(entity.StockTake)
+	if err := c.StockTakeRepository.FindByIdForUpdate(tx, stockTake, id); err != nil {
+		c.Log.Warnf("Failed to find stock take: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if err := checkStorageOwner(&stockTake.Storage, actorID, actorRole); err != nil {
+		return nil, err
+	}
+	if stockTake.Status != "open" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Stock take is already %s", stockTake.Status))
+	}
+	return stockTake, nil
+}
+
+// Open starts a count of a storage with a line for every waste type currently in stock
+func (c *StockTakeUsecase) Open(ctx context.Context, request *model.OpenStockTakeRequest) (*model.StockTakeResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	storage := new(entity.Storage)
+	if err := c.StorageRepository.FindById(tx, storage, request.StorageID); err != nil {
+		c.Log.Warnf("Failed to find storage: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if err := checkStorageOwner(storage, request.ActorID, request.ActorRole); err != nil {
+		return nil, err
+	}
+
+	hasOpen, err := c.StockTakeRepository.HasOpen(tx, request.StorageID)
+	if err != nil {
+		c.Log.Warnf("Failed to check open stock takes: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if hasOpen {
+		return nil, fiber.NewError(fiber.StatusConflict, "Storage already has an open stock take")
+	}
+
+	items, err := c.StorageItemRepository.FindByStorage(tx, storage.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to find storage items: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	stockTake := &entity.StockTake{
+		StorageID:  storage.ID,
+		Status:     "open",
+		Notes:      request.Notes,
+		OpenedByID: parseActorID(request.ActorID),
+	}
+	if err := c.StockTakeRepository.Create(tx, stockTake); err != nil {
+		c.Log.Warnf("Failed to create stock take: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	for wasteTypeID, item := range items {
+		line := &entity.StockTakeLine{
+			StockTakeID:     stockTake.ID,
+			WasteTypeID:     wasteTypeID,
+			SystemWeightKgs: item.WeightKgs,
+		}
+		if err := c.StockTakeLineRepository.Create(tx, line); err != nil {
+			c.Log.Warnf("Failed to create stock take line: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := c.StockTakeRepository.FindByIdWithLines(tx, stockTake, stockTake.ID.String()); err != nil {
+		c.Log.Warnf("Failed to reload stock take: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.StockTakeToResponse(stockTake), nil
+}
+
+// Get returns a stock take with its variance. While it is open the variance is against the live system stock,
+// once posted it is what was adjusted.
+func (c *StockTakeUsecase) Get(ctx context.Context, request *model.GetStockTakeRequest) (*model.StockTakeResponse, error) {
+	db := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	stockTake := new(entity.StockTake)
+	if err := c.StockTakeRepository.FindByIdWithLines(db, stockTake, request.ID); err != nil {
+		c.Log.Warnf("Failed to find stock take: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if err := checkStorageOwner(&stockTake.Storage, request.ActorID, request.ActorRole); err != nil {
+		return nil, err
+	}
+
+	if stockTake.Status == "open" {
+		items, err := c.StorageItemRepository.FindByStorage(db, stockTake.StorageID)
+		if err != nil {
+			c.Log.Warnf("Failed to find storage items: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		for i := range stockTake.Lines {
+			stockTake.Lines[i].SystemWeightKgs = 0
+			if item, ok := items[stockTake.Lines[i].WasteTypeID]; ok {
+				stockTake.Lines[i].SystemWeightKgs = item.WeightKgs
+			}
+		}
+	}
+
+	return converter.StockTakeToResponse(stockTake), nil
+}
+
+func (c *StockTakeUsecase) Search(ctx context.Context, request *model.SearchStockTakeRequest) ([]model.StockTakeSimpleResponse, int64, error) {
+	db := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	// Waste banks only see the stock takes of their own storages
+	if request.ActorRole != "admin" {
+		request.OwnerID = request.ActorID
+	}
+
+	stockTakes, total, err := c.StockTakeRepository.Search(db, request)
+	if err != nil {
+		c.Log.Warnf("Failed to search stock takes: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.StockTakeSimpleResponse, len(stockTakes))
+	for i, stockTake := range stockTakes {
+		responses[i] = *converter.StockTakeToSimpleResponse(&stockTake)
+	}
+
+	return responses, total, nil
+}
+
+// RecordCounts enters counted weights. A count can be corrected as long as the stock take is open.
+func (c *StockTakeUsecase) RecordCounts(ctx context.Context, request *model.RecordStockTakeCountsRequest) (*model.StockTakeResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	stockTake, err := c.findOpenForUpdate(tx, request.ID, request.ActorID, request.ActorRole)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := c.StockTakeLineRepository.FindByStockTake(tx, request.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to find stock take lines: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	linesByWasteType := make(map[uuid.UUID]*entity.StockTakeLine, len(lines))
+	for i := range lines {
+		linesByWasteType[lines[i].WasteTypeID] = &lines[i]
+	}
+
+	items, err := c.StorageItemRepository.FindByStorage(tx, stockTake.StorageID)
+	if err != nil {
+		c.Log.Warnf("Failed to find storage items: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	now := time.Now()
+	for _, count := range request.Lines {
+		wasteTypeID, _ := uuid.Parse(count.WasteTypeID)
+
+		line, ok := linesByWasteType[wasteTypeID]
+		if !ok {
+			// Found stock of a waste type the system does not know the storage holds
+			wasteType := new(entity.WasteType)
+			if err := c.WasteTypeRepository.FindById(tx, wasteType, count.WasteTypeID); err != nil {
+				c.Log.Warnf("Failed to find waste type: %+v", err)
+				return nil, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Waste type %s not found", count.WasteTypeID))
+			}
+			line = &entity.StockTakeLine{StockTakeID: stockTake.ID, WasteTypeID: wasteTypeID}
+			linesByWasteType[wasteTypeID] = line
+		}
+
+		line.SystemWeightKgs = 0
+		if item, ok := items[wasteTypeID]; ok {
+			line.SystemWeightKgs = item.WeightKgs
+		}
+		line.CountedWeightKgs = count.CountedWeightKgs
+		line.ShrinkageReason = nil
+		if count.ShrinkageReason != "" {
+			reason := count.ShrinkageReason
+			line.ShrinkageReason = &reason
+		}
+		line.Notes = count.Notes
+		line.CountedAt = &now
+
+		if err := c.StockTakeLineRepository.Update(tx, line); err != nil {
+			c.Log.Warnf("Failed to save stock take line: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := c.StockTakeRepository.FindByIdWithLines(tx, stockTake, request.ID); err != nil {
+		c.Log.Warnf("Failed to reload stock take: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.StockTakeToResponse(stockTake), nil
+}
+
+// Post adjusts the storage items by the variance between the counted and system weights frozen at count time,
+// so movements after the count are kept, and journals each adjustment referencing the stock take. Every line
+// has to be counted and every shortfall needs a shrinkage reason. Waste types that entered the storage after
+// the count was opened and were not counted are left as they are.
+func (c *StockTakeUsecase) Post(ctx context.Context, request *model.DecideStockTakeRequest) (*model.StockTakeResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	stockTake, err := c.findOpenForUpdate(tx, request.ID, request.ActorID, request.ActorRole)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := c.StockTakeLineRepository.FindByStockTake(tx, request.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to find stock take lines: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	items, err := c.StorageItemRepository.FindByStorageForUpdate(tx, stockTake.StorageID)
+	if err != nil {
+		c.Log.Warnf("Failed to lock storage items: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	actorID := parseActorID(request.ActorID)
+	for i := range lines {
+		line := &lines[i]
+		if line.CountedWeightKgs == nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Waste type %s has not been counted", line.WasteTypeID))
+		}
+		counted := *line.CountedWeightKgs
+
+		variance := counted - line.SystemWeightKgs
+		if variance < 0 && line.ShrinkageReason == nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("shrinkage_reason is required for waste type %s", line.WasteTypeID))
+		}
+
+		// The stock cannot go below zero if it was moved out since the count
+		item, inStock := items[line.WasteTypeID]
+		current := 0.0
+		if inStock {
+			current = item.WeightKgs
+		}
+		weight := math.Max(current+variance, 0)
+		adjustment := weight - current
+		if adjustment == 0 {
+			continue
+		}
+
+		notes := "Stock take"
+		if line.ShrinkageReason != nil {
+			notes = fmt.Sprintf("Stock take: %s", *line.ShrinkageReason)
+		}
+
+		if !inStock {
+			item = &entity.StorageItem{
+				StorageID:   stockTake.StorageID,
+				WasteTypeID: line.WasteTypeID,
+				WeightKgs:   weight,
+			}
+			if err := c.StorageItemRepository.Create(tx, item); err != nil {
+				c.Log.Warnf("Failed to create storage item: %+v", err)
+				return nil, fiber.ErrInternalServerError
+			}
+		} else {
+			item.WeightKgs = weight
+			item.UpdatedAt = time.Now()
+		}
+
+		if err := c.StockMovementRepository.Record(tx, item, "manual_adjustment", adjustment, "stock_take", &stockTake.ID, actorID, notes); err != nil {
+			c.Log.Warnf("Failed to record stock movement: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		if !inStock {
+			continue
+		}
+		// Like transfers, an item whose stock runs out is removed
+		if weight <= 0 {
+			if err := c.StorageItemRepository.Delete(tx, item); err != nil {
+				c.Log.Warnf("Failed to delete storage item: %+v", err)
+				return nil, fiber.ErrInternalServerError
+			}
+		} else if err := c.StorageItemRepository.Update(tx, item); err != nil {
+			c.Log.Warnf("Failed to update storage item: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	now := time.Now()
+	stockTake.Status = "posted"
+	stockTake.PostedByID = actorID
+	stockTake.PostedAt = &now
+	if err := c.StockTakeRepository.Update(tx, stockTake); err != nil {
+		c.Log.Warnf("Failed to post stock take: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.StockTakeRepository.FindByIdWithLines(tx, stockTake, request.ID); err != nil {
+		c.Log.Warnf("Failed to reload stock take: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.StockTakeToResponse(stockTake), nil
+}
+
+// Cancel discards an open stock take, storage items are not touched
+func (c *StockTakeUsecase) Cancel(ctx context.Context, request *model.DecideStockTakeRequest) (*model.StockTakeSimpleResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	stockTake, err := c.findOpenForUpdate(tx, request.ID, request.ActorID, request.ActorRole)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	stockTake.Status = "cancelled"
+	stockTake.CancelledAt = &now
+	if err := c.StockTakeRepository.Update(tx, stockTake); err != nil {
+		c.Log.Warnf("Failed to cancel stock take: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.StockTakeToSimpleResponse(stockTake), nil
+}
+
+// GetShrinkage reports the shrinkage found by posted stock takes per bank and per material
+func (c *StockTakeUsecase) GetShrinkage(ctx context.Context, request *model.StockTakeShrinkageRequest) (*model.StockTakeShrinkageResponse, error) {
+	db := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+	if err := validateMonthRange(request.StartMonth, request.EndMonth); err != nil {
+		return nil, err
+	}
+
+	// Waste banks only see their own shrinkage, admins see every bank unless they pick one
+	if request.ActorRole != "admin" {
+		request.WasteBankID = request.ActorID
+	}
+	if request.WasteBankID != "" {
+		wasteBank := new(entity.User)
+		if err := c.UserRepository.FindById(db, wasteBank, request.WasteBankID); err != nil {
+			c.Log.Warnf("Failed to find waste bank: %+v", err)
+			return nil, fiber.ErrNotFound
+		}
+		if !isWasteBankRole(wasteBank.Role) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "User is not a waste bank")
+		}
+	}
+
+	response := &model.StockTakeShrinkageResponse{
+		StartMonth: request.StartMonth,
+		EndMonth:   request.EndMonth,
+	}
+	var err error
+
+	if response.Banks, err = c.StockTakeRepository.GetShrinkageByBank(db, request.WasteBankID, request.StartMonth, request.EndMonth); err != nil {
+		c.Log.Warnf("Failed to get shrinkage by bank: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if response.Materials, err = c.StockTakeRepository.GetShrinkageByMaterial(db, request.WasteBankID, request.StartMonth, request.EndMonth); err != nil {
+		c.Log.Warnf("Failed to get shrinkage by material: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if response.Banks == nil {
+		response.Banks = []model.StockTakeShrinkageBank{}
+	}
+	if response.Materials == nil {
+		response.Materials = []model.StockTakeShrinkageByMaterial{}
+	}
+
+	return response, nil
+}
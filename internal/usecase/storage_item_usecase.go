@@ -22,6 +22,8 @@ type StorageItemUsecase struct {
 	StorageRepository     *repository.StorageRepository
 	StorageItemRepository *repository.StorageItemRepository
 	WasteTypeRepository   *repository.WasteTypeRepository
+	// Every weight change is journaled as a stock movement
+	StockMovementRepository *repository.StockMovementRepository
 }
 
 func NewStorageItemUsecase(
@@ -31,14 +33,16 @@ func NewStorageItemUsecase(
 	storageRepo *repository.StorageRepository,
 	storageItemRepo *repository.StorageItemRepository,
 	wasteTypeRepo *repository.WasteTypeRepository,
+	stockMovementRepo *repository.StockMovementRepository,
 ) *StorageItemUsecase {
 	return &StorageItemUsecase{
-		DB:                    db,
-		Log:                   log,
-		Validate:              validate,
-		StorageRepository:     storageRepo,
-		StorageItemRepository: storageItemRepo,
-		WasteTypeRepository:   wasteTypeRepo,
+		DB:                      db,
+		Log:                     log,
+		Validate:                validate,
+		StorageRepository:       storageRepo,
+		StorageItemRepository:   storageItemRepo,
+		WasteTypeRepository:     wasteTypeRepo,
+		StockMovementRepository: stockMovementRepo,
 	}
 }
 
@@ -86,8 +90,7 @@ func (c *StorageItemUsecase) Create(ctx context.Context, request *model.StorageI
 
 	// NEW: Check if storage item with this storage_id and waste_type_id combination already exists
 	var existingStorageItem entity.StorageItem
-	err = tx.Where("storage_id = ? AND waste_type_id = ?", storageID, wasteTypeID).
-		First(&existingStorageItem).Error
+	err = c.StorageItemRepository.FindByStorageAndWasteTypeForUpdate(tx, &existingStorageItem, storageID, wasteTypeID)
 
 	switch err {
 	case nil:
@@ -103,6 +106,11 @@ func (c *StorageItemUsecase) Create(ctx context.Context, request *model.StorageI
 			return nil, fiber.ErrInternalServerError
 		}
 
+		if err := c.StockMovementRepository.Record(tx, &existingStorageItem, "manual_adjustment", request.WeightKgs, "", nil, parseActorID(request.ActorID), request.Notes); err != nil {
+			c.Log.Warnf("Failed to record stock movement: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
 		if err := tx.Commit().Error; err != nil {
 			c.Log.Warnf("Failed to commit transaction: %+v", err)
 			return nil, fiber.ErrInternalServerError
@@ -131,6 +139,11 @@ func (c *StorageItemUsecase) Create(ctx context.Context, request *model.StorageI
 			return nil, fiber.ErrInternalServerError
 		}
 
+		if err := c.StockMovementRepository.Record(tx, storageItem, "manual_adjustment", request.WeightKgs, "", nil, parseActorID(request.ActorID), request.Notes); err != nil {
+			c.Log.Warnf("Failed to record stock movement: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
 		if err := tx.Commit().Error; err != nil {
 			c.Log.Warnf("Failed to commit transaction: %+v", err)
 			return nil, fiber.ErrInternalServerError
@@ -178,7 +191,7 @@ func (u *StorageItemUsecase) Update(ctx context.Context, request *model.UpdateSt
 		return nil, fiber.ErrNotFound
 	}
 	item := new(entity.StorageItem)
-	if err := u.StorageItemRepository.FindById(tx, item, request.ID); err != nil {
+	if err := u.StorageItemRepository.FindByIdForUpdate(tx, item, request.ID); err != nil {
 		u.Log.Warnf("Storage item not found: %v", err)
 		return nil, fiber.ErrNotFound
 	}
@@ -190,6 +203,7 @@ func (u *StorageItemUsecase) Update(ctx context.Context, request *model.UpdateSt
 		return nil, fiber.NewError(fiber.StatusBadRequest, "Weight must be greater than 0")
 	}
 
+	previousWeight := item.WeightKgs
 	if request.Weight != 0 {
 		item.WeightKgs = request.Weight
 	}
@@ -199,6 +213,13 @@ func (u *StorageItemUsecase) Update(ctx context.Context, request *model.UpdateSt
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if item.WeightKgs != previousWeight {
+		if err := u.StockMovementRepository.Record(tx, item, "manual_adjustment", item.WeightKgs-previousWeight, "", nil, parseActorID(request.UserID), request.Notes); err != nil {
+			u.Log.Warnf("Failed to record stock movement: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		u.Log.Warnf("Commit error: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -221,7 +242,7 @@ func (u *StorageItemUsecase) DeductFromStorage(ctx context.Context, request *mod
 		return nil, fiber.ErrNotFound
 	}
 	item := new(entity.StorageItem)
-	if err := u.StorageItemRepository.FindById(tx, item, request.ID); err != nil {
+	if err := u.StorageItemRepository.FindByIdForUpdate(tx, item, request.ID); err != nil {
 		u.Log.Warnf("Storage item not found: %v", err)
 		return nil, fiber.ErrNotFound
 	}
@@ -243,6 +264,15 @@ func (u *StorageItemUsecase) DeductFromStorage(ctx context.Context, request *mod
 		return nil, fiber.ErrInternalServerError
 	}
 
+	movementType := request.Reason
+	if movementType == "" {
+		movementType = "manual_adjustment"
+	}
+	if err := u.StockMovementRepository.Record(tx, item, movementType, -request.Weight, "", nil, parseActorID(request.UserID), request.Notes); err != nil {
+		u.Log.Warnf("Failed to record stock movement: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		u.Log.Warnf("Commit error: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -284,7 +314,7 @@ func (u *StorageItemUsecase) Delete(ctx context.Context, id string) (*model.Stor
 	defer tx.Rollback()
 
 	item := new(entity.StorageItem)
-	if err := u.StorageItemRepository.FindById(tx, item, id); err != nil {
+	if err := u.StorageItemRepository.FindByIdForUpdate(tx, item, id); err != nil {
 		u.Log.Warnf("Storage item not found: %v", err)
 		return nil, fiber.ErrNotFound
 	}
@@ -294,6 +324,16 @@ func (u *StorageItemUsecase) Delete(ctx context.Context, id string) (*model.Stor
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if item.WeightKgs != 0 {
+		// The movement leaves the waste type empty in this storage
+		emptied := *item
+		emptied.WeightKgs = 0
+		if err := u.StockMovementRepository.Record(tx, &emptied, "manual_adjustment", -item.WeightKgs, "", nil, nil, "Storage item deleted"); err != nil {
+			u.Log.Warnf("Failed to record stock movement: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		u.Log.Warnf("Commit error: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -301,3 +341,44 @@ func (u *StorageItemUsecase) Delete(ctx context.Context, id string) (*model.Stor
 
 	return converter.StorageItemToSimpleResponse(item), nil
 }
+
+// ListMovements returns the stock movement history of a storage item, to its owner and admins
+func (u *StorageItemUsecase) ListMovements(ctx context.Context, request *model.SearchStockMovementRequest) ([]model.StockMovementResponse, int64, error) {
+	db := u.DB.WithContext(ctx)
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.Warnf("Invalid request body: %+v", err)
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	item := new(entity.StorageItem)
+	if err := u.StorageItemRepository.FindById(db, item, request.StorageItemID); err != nil {
+		u.Log.Warnf("Storage item not found: %v", err)
+		return nil, 0, fiber.ErrNotFound
+	}
+	if request.ActorRole != "admin" && item.Storage.UserID.String() != request.ActorID {
+		return nil, 0, fiber.NewError(fiber.StatusForbidden, "You are not the owner of this storage")
+	}
+
+	movements, total, err := u.StockMovementRepository.FindByStorageAndWasteType(db, item.StorageID, item.WasteTypeID, request.Page, request.Size)
+	if err != nil {
+		u.Log.Warnf("Failed to find stock movements: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.StockMovementResponse, len(movements))
+	for i, movement := range movements {
+		responses[i] = *converter.StockMovementToResponse(&movement)
+	}
+
+	return responses, total, nil
+}
+
+// parseActorID returns nil for an empty or malformed actor ID, movements outlive the users who made them
+func parseActorID(id string) *uuid.UUID {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
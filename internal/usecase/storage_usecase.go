@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"math"
+	"sort"
 
 	"github.com/go-playground/validator"
 	"github.com/gofiber/fiber/v2"
@@ -14,22 +16,57 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// DefaultBulkDensityKgsPerM3 is used for waste types without a bulk density, roughly loose mixed recyclables
+	DefaultBulkDensityKgsPerM3 = 150.0
+	// Utilization a transfer suggestion brings an alerted storage back down to, lowered to three quarters of
+	// the alert threshold for storages alerting below it
+	capacityTargetPercent = 60.0
+)
+
 type StorageUsecase struct {
-	DB                *gorm.DB
-	Log               *logrus.Logger
-	Validate          *validator.Validate
-	StorageRepository *repository.StorageRepository
-	UserRepository    *repository.UserRepository
+	DB                            *gorm.DB
+	Log                           *logrus.Logger
+	Validate                      *validator.Validate
+	StorageRepository             *repository.StorageRepository
+	UserRepository                *repository.UserRepository
+	StorageItemRepository         *repository.StorageItemRepository
+	WasteBankPricedTypeRepository *repository.WasteBankPricedTypeRepository
 }
 
-func NewStorageUsecase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, storageRepository *repository.StorageRepository, userRepository *repository.UserRepository) *StorageUsecase {
+func NewStorageUsecase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, storageRepository *repository.StorageRepository, userRepository *repository.UserRepository, storageItemRepository *repository.StorageItemRepository, wasteBankPricedTypeRepository *repository.WasteBankPricedTypeRepository) *StorageUsecase {
 	return &StorageUsecase{
-		DB:                db,
-		Log:               log,
-		Validate:          validate,
-		StorageRepository: storageRepository,
-		UserRepository:    userRepository,
+		DB:                            db,
+		Log:                           log,
+		Validate:                      validate,
+		StorageRepository:             storageRepository,
+		UserRepository:                userRepository,
+		StorageItemRepository:         storageItemRepository,
+		WasteBankPricedTypeRepository: wasteBankPricedTypeRepository,
+	}
+}
+
+// bulkDensity returns the density of a waste type and whether the default had to be used
+func bulkDensity(wasteType *entity.WasteType) (float64, bool) {
+	if wasteType.BulkDensityKgsPerM3 == nil || *wasteType.BulkDensityKgsPerM3 <= 0 {
+		return DefaultBulkDensityKgsPerM3, true
+	}
+	return *wasteType.BulkDensityKgsPerM3, false
+}
+
+func storageCapacity(storage *entity.Storage) float64 {
+	return storage.Length * storage.Width * storage.Height
+}
+
+// fillUtilization derives the percentage and flags from the capacity and used volume
+func fillUtilization(response *model.StorageUtilizationResponse) {
+	if response.CapacityM3 <= 0 {
+		return
 	}
+	percent := response.UsedVolumeM3 / response.CapacityM3 * 100
+	response.UtilizationPercent = &percent
+	response.IsAboveAlert = percent >= response.CapacityAlertPercent
+	response.IsOverCapacity = percent > 100
 }
 
 func (u *StorageUsecase) Create(ctx context.Context, request *model.StorageRequest) (*model.StorageSimpleResponse, error) {
@@ -53,6 +90,8 @@ func (u *StorageUsecase) Create(ctx context.Context, request *model.StorageReque
 		Width:                 request.Width,
 		Height:                request.Height,
 		IsForRecycledMaterial: request.IsForRecycledMaterial,
+		CapacityAlertPercent:  request.CapacityAlertPercent,
+		RejectOverflow:        request.RejectOverflow,
 	}
 
 	if err := u.StorageRepository.Create(tx, storage); err != nil {
@@ -116,6 +155,12 @@ func (u *StorageUsecase) Update(ctx context.Context, request *model.UpdateStorag
 	if request.IsForRecycledMaterial != nil {
 		storage.IsForRecycledMaterial = *request.IsForRecycledMaterial
 	}
+	if request.CapacityAlertPercent != nil {
+		storage.CapacityAlertPercent = *request.CapacityAlertPercent
+	}
+	if request.RejectOverflow != nil {
+		storage.RejectOverflow = *request.RejectOverflow
+	}
 
 	if err := u.StorageRepository.Update(tx, storage); err != nil {
 		u.Log.Warnf("Update failed: %+v", err)
@@ -180,3 +225,141 @@ func (u *StorageUsecase) Delete(ctx context.Context, id string) (*model.StorageS
 
 	return converter.StorageToSimpleResponse(storage), nil
 }
+
+// GetUtilization breaks down the estimated volume of a storage per waste type. When the storage is at or above its
+// alert threshold it suggests the stock to move out with an outbound transfer, largest volumes first.
+func (u *StorageUsecase) GetUtilization(ctx context.Context, request *model.GetStorageUtilizationRequest) (*model.StorageUtilizationResponse, error) {
+	db := u.DB.WithContext(ctx)
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	storage := new(entity.Storage)
+	if err := u.StorageRepository.FindById(db, storage, request.ID); err != nil {
+		u.Log.Warnf("Storage not found: %v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if err := checkStorageOwner(storage, request.ActorID, request.ActorRole); err != nil {
+		return nil, err
+	}
+
+	items, err := u.StorageItemRepository.FindInStockWithWasteType(db, storage.ID)
+	if err != nil {
+		u.Log.Warnf("Failed to find storage items: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.StorageUtilizationResponse{
+		StorageID:            storage.ID.String(),
+		UserID:               storage.UserID.String(),
+		CapacityM3:           storageCapacity(storage),
+		CapacityAlertPercent: storage.CapacityAlertPercent,
+		Items:                make([]model.StorageItemVolume, len(items)),
+	}
+	for i, item := range items {
+		density, isDefault := bulkDensity(&item.WasteType)
+		response.Items[i] = model.StorageItemVolume{
+			WasteTypeID:         item.WasteTypeID.String(),
+			WasteTypeName:       item.WasteType.Name,
+			WeightKgs:           item.WeightKgs,
+			BulkDensityKgsPerM3: density,
+			IsDefaultDensity:    isDefault,
+			VolumeM3:            item.WeightKgs / density,
+		}
+		response.UsedVolumeM3 += response.Items[i].VolumeM3
+	}
+	sort.Slice(response.Items, func(i, j int) bool {
+		return response.Items[i].VolumeM3 > response.Items[j].VolumeM3
+	})
+	fillUtilization(response)
+
+	if response.IsAboveAlert {
+		if response.TransferSuggestion, err = u.suggestTransfer(db, storage, response); err != nil {
+			u.Log.Warnf("Failed to suggest transfer: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	return response, nil
+}
+
+// suggestTransfer picks the stock that frees enough volume to get back to the target utilization. Offering prices
+// start from the owner's own prices for the waste types, zero when the owner has none.
+func (u *StorageUsecase) suggestTransfer(db *gorm.DB, storage *entity.Storage, utilization *model.StorageUtilizationResponse) (*model.StorageTransferSuggestion, error) {
+	target := min(capacityTargetPercent, utilization.CapacityAlertPercent*0.75)
+	suggestion := &model.StorageTransferSuggestion{
+		SourceUserID:   storage.UserID.String(),
+		TargetPercent:  target,
+		VolumeToFreeM3: utilization.UsedVolumeM3 - utilization.CapacityM3*target/100,
+		Items: model.WasteTransferRequestItems{
+			WasteTypeIDs:         []string{},
+			OfferingWeights:      []float64{},
+			OfferingPricesPerKgs: []int64{},
+		},
+	}
+
+	remaining := suggestion.VolumeToFreeM3
+	for _, item := range utilization.Items {
+		if remaining <= 0 {
+			break
+		}
+		volume := math.Min(item.VolumeM3, remaining)
+		// Whole kilograms, rounded up so the target is reached but never more than is in stock
+		weight := math.Min(math.Ceil(volume*item.BulkDensityKgsPerM3), item.WeightKgs)
+		suggestion.Items.WasteTypeIDs = append(suggestion.Items.WasteTypeIDs, item.WasteTypeID)
+		suggestion.Items.OfferingWeights = append(suggestion.Items.OfferingWeights, weight)
+		remaining -= volume
+	}
+
+	prices, err := u.WasteBankPricedTypeRepository.FindByWasteBanksAndTypes(db, []string{suggestion.SourceUserID}, suggestion.Items.WasteTypeIDs)
+	if err != nil {
+		return nil, err
+	}
+	priceByType := make(map[string]int64, len(prices))
+	for _, price := range prices {
+		priceByType[price.WasteTypeID.String()] = price.CustomPricePerKgs
+	}
+	for _, wasteTypeID := range suggestion.Items.WasteTypeIDs {
+		suggestion.Items.OfferingPricesPerKgs = append(suggestion.Items.OfferingPricesPerKgs, priceByType[wasteTypeID])
+	}
+
+	return suggestion, nil
+}
+
+// SearchUtilization lists the utilization of storages without the per waste type breakdown. With AboveAlert it
+// lists the storages that need an outbound transfer.
+func (u *StorageUsecase) SearchUtilization(ctx context.Context, request *model.SearchStorageUtilizationRequest) ([]model.StorageUtilizationResponse, int64, error) {
+	db := u.DB.WithContext(ctx)
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.Warnf("Invalid request body: %+v", err)
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	// Owners only see their own storages
+	if request.ActorRole != "admin" {
+		request.UserID = request.ActorID
+	}
+
+	rows, total, err := u.StorageRepository.SearchUtilization(db, request, DefaultBulkDensityKgsPerM3)
+	if err != nil {
+		u.Log.Warnf("Failed to search storage utilization: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.StorageUtilizationResponse, len(rows))
+	for i, row := range rows {
+		responses[i] = model.StorageUtilizationResponse{
+			StorageID:            row.StorageID,
+			UserID:               row.UserID,
+			CapacityM3:           row.CapacityM3,
+			UsedVolumeM3:         row.UsedVolumeM3,
+			CapacityAlertPercent: row.CapacityAlertPercent,
+		}
+		fillUtilization(&responses[i])
+	}
+
+	return responses, total, nil
+}
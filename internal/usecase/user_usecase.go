@@ -29,6 +29,7 @@ type UserUseCase struct {
 	IndustryRepository            *repository.IndustryRepository
 	CollectorManagementRepository *repository.CollectorManagementRepository
 	StorageRepository             *repository.StorageRepository
+	RegionRepository              *repository.RegionRepository
 	JWTHelper                     *helper.JWTHelper
 	EmailHelper                   *helper.EmailHelper
 	BaseURL                       string
@@ -45,6 +46,7 @@ func NewUserUseCase(
 	industryRepository *repository.IndustryRepository,
 	collectorManagementRepository *repository.CollectorManagementRepository,
 	storageRepository *repository.StorageRepository,
+	regionRepository *repository.RegionRepository,
 	jwtHelper *helper.JWTHelper,
 	emailHelper *helper.EmailHelper,
 	baseURL string,
@@ -60,6 +62,7 @@ func NewUserUseCase(
 		IndustryRepository:            industryRepository,
 		CollectorManagementRepository: collectorManagementRepository,
 		StorageRepository:             storageRepository,
+		RegionRepository:              regionRepository,
 		JWTHelper:                     jwtHelper,
 		EmailHelper:                   emailHelper,
 		BaseURL:                       baseURL,
@@ -118,6 +121,10 @@ func (c *UserUseCase) Register(ctx context.Context, request *model.RegisterUserR
 		return nil, fiber.NewError(fiber.StatusBadRequest, "You must agree to the terms and conditions")
 	}
 	isAcceptingCustomer := getIsAcceptingCustomer(request.IsAcceptingCustomer)
+	regionCode, err := resolveRegionCode(tx, c.Log, c.RegionRepository, request.RegionCode, location, request.Province, request.City)
+	if err != nil {
+		return nil, err
+	}
 	user := &entity.User{
 		Username:               request.Username,
 		Email:                  request.Email,
@@ -128,6 +135,7 @@ func (c *UserUseCase) Register(ctx context.Context, request *model.RegisterUserR
 		Address:                request.Address,
 		City:                   request.City,
 		Province:               request.Province,
+		RegionCode:             regionCode,
 		IsEmailVerified:        false,
 		IsAcceptingCustomer:    isAcceptingCustomer,
 		EmailVerificationToken: verificationToken,
@@ -622,6 +630,14 @@ func (c *UserUseCase) Update(ctx context.Context, request *model.UpdateUserReque
 	if request.IsAcceptingCustomer != nil {
 		user.IsAcceptingCustomer = *request.IsAcceptingCustomer
 	}
+	// An explicit region wins, otherwise a changed address is resolved again
+	if request.RegionCode != "" || request.City != "" || request.Province != "" {
+		regionCode, err := resolveRegionCode(tx, c.Log, c.RegionRepository, request.RegionCode, user.Location, user.Province, user.City)
+		if err != nil {
+			return nil, err
+		}
+		user.RegionCode = regionCode
+	}
 
 	// Update the user in database
 	if err := c.UserRepository.Update(tx, user); err != nil {
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/pkg/timezone"
+	"gorm.io/gorm"
+)
+
+type WasteBankDashboardUsecase struct {
+	DB                         *gorm.DB
+	Log                        *logrus.Logger
+	Validate                   *validator.Validate
+	UserRepository             *repository.UserRepository
+	DailyWasteRollupRepository *repository.DailyWasteRollupRepository
+	StorageItemRepository      *repository.StorageItemRepository
+	WasteDropRequestRepository *repository.WasteDropRequestRepository
+}
+
+func NewWasteBankDashboardUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	userRepository *repository.UserRepository,
+	dailyWasteRollupRepository *repository.DailyWasteRollupRepository,
+	storageItemRepository *repository.StorageItemRepository,
+	wasteDropRequestRepository *repository.WasteDropRequestRepository,
+) *WasteBankDashboardUsecase {
+	return &WasteBankDashboardUsecase{
+		DB:                         db,
+		Log:                        log,
+		Validate:                   validate,
+		UserRepository:             userRepository,
+		DailyWasteRollupRepository: dailyWasteRollupRepository,
+		StorageItemRepository:      storageItemRepository,
+		WasteDropRequestRepository: wasteDropRequestRepository,
+	}
+}
+
+// Get builds the operational dashboard of a waste bank. Inbound and outbound weights come from the daily rollups.
+func (c *WasteBankDashboardUsecase) Get(ctx context.Context, request *model.WasteBankDashboardRequest) (*model.WasteBankDashboardResponse, error) {
+	db := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+	if err := validateMonthRange(request.StartMonth, request.EndMonth); err != nil {
+		return nil, err
+	}
+
+	// Waste banks only see their own dashboard, admins pick one
+	if request.ActorRole != "admin" {
+		request.WasteBankID = request.ActorID
+	}
+	if request.WasteBankID == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "waste_bank_id is required")
+	}
+
+	wasteBank := new(entity.User)
+	if err := c.UserRepository.FindById(db, wasteBank, request.WasteBankID); err != nil {
+		c.Log.Warnf("Failed to find waste bank: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if wasteBank.Role != "waste_bank_unit" && wasteBank.Role != "waste_bank_central" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "User is not a waste bank")
+	}
+
+	response := &model.WasteBankDashboardResponse{WasteBankID: request.WasteBankID}
+	var err error
+
+	if response.Inbound, err = c.DailyWasteRollupRepository.GetInboundByWasteType(db, request.WasteBankID, request.StartMonth, request.EndMonth); err != nil {
+		c.Log.Warnf("Failed to get inbound weight: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if response.Stock.Items, err = c.StorageItemRepository.GetStockValue(db, request.WasteBankID); err != nil {
+		c.Log.Warnf("Failed to get storage stock: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	for _, item := range response.Stock.Items {
+		response.Stock.TotalWeight += item.WeightKgs
+		response.Stock.TotalValue += item.Value
+	}
+
+	if response.TopCustomers, err = c.WasteDropRequestRepository.GetTopCustomers(db, request.WasteBankID, request.StartMonth, request.EndMonth, 5); err != nil {
+		c.Log.Warnf("Failed to get top customers: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if response.Collectors, err = c.WasteDropRequestRepository.GetCollectorProductivity(db, request.WasteBankID, request.StartMonth, request.EndMonth); err != nil {
+		c.Log.Warnf("Failed to get collector productivity: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	backlog, err := c.WasteDropRequestRepository.GetBacklog(db, request.WasteBankID, time.Now().In(timezone.WIB).Format("2006-01-02"))
+	if err != nil {
+		c.Log.Warnf("Failed to get request backlog: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	response.Backlog = *backlog
+
+	if response.Outbound, err = c.DailyWasteRollupRepository.GetOutboundByMonth(db, request.WasteBankID, request.StartMonth, request.EndMonth); err != nil {
+		c.Log.Warnf("Failed to get outbound transfers: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return response, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-playground/validator"
 	"github.com/gofiber/fiber/v2"
@@ -11,6 +12,7 @@ import (
 	"github.com/wastetrack/wastetrack-backend/internal/model"
 	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
 	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/pkg/timezone"
 	"gorm.io/gorm"
 )
 
@@ -20,6 +22,7 @@ type WasteBankPricedTypeUsecase struct {
 	Validate                      *validator.Validate
 	WasteBankPricedTypeRepository *repository.WasteBankPricedTypeRepository
 	WasteTypeRepository           *repository.WasteTypeRepository
+	PriceVersionRepository        *repository.WasteBankPriceVersionRepository
 }
 
 func NewWasteBankPricedTypeUsecase(
@@ -28,13 +31,95 @@ func NewWasteBankPricedTypeUsecase(
 	validate *validator.Validate,
 	wasteBankPricedTypeRepo *repository.WasteBankPricedTypeRepository,
 	wasteTypeRepo *repository.WasteTypeRepository,
+	priceVersionRepo *repository.WasteBankPriceVersionRepository,
 ) *WasteBankPricedTypeUsecase {
 	return &WasteBankPricedTypeUsecase{
 		DB: db, Log: log, Validate: validate,
 		WasteBankPricedTypeRepository: wasteBankPricedTypeRepo,
 		WasteTypeRepository:           wasteTypeRepo,
+		PriceVersionRepository:        priceVersionRepo,
 	}
 }
+
+// Helper method to parse an effective date, either a plain date in WIB or RFC3339. Empty or today's date means now.
+func parseEffectiveFrom(value string) (time.Time, error) {
+	now := time.Now()
+	if value == "" || value == now.In(timezone.WIB).Format("2006-01-02") {
+		return now, nil
+	}
+	effectiveFrom, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		effectiveFrom, err = time.ParseInLocation("2006-01-02", value, timezone.WIB)
+		if err != nil {
+			return time.Time{}, fiber.NewError(fiber.StatusBadRequest, "Invalid effective_from format, expected YYYY-MM-DD or RFC3339")
+		}
+	}
+	// Prices that already applied cannot be rewritten
+	if effectiveFrom.Before(now) {
+		return time.Time{}, fiber.NewError(fiber.StatusBadRequest, "effective_from cannot be in the past")
+	}
+	return effectiveFrom, nil
+}
+
+// Helper method to add a price version to a priced type. The version in effect at that time is
+// closed, and the new one runs until the next scheduled version if there is one.
+func (uc *WasteBankPricedTypeUsecase) addPriceVersion(tx *gorm.DB, wpt *entity.WasteBankPricedType, price int64, effectiveFrom time.Time, actorID string) (*entity.WasteBankPriceVersion, error) {
+	now := time.Now()
+	wasteBankID, wasteTypeID := wpt.WasteBankID.String(), wpt.WasteTypeID.String()
+
+	covering := new(entity.WasteBankPriceVersion)
+	err := uc.PriceVersionRepository.FindEffective(tx, covering, wasteBankID, wasteTypeID, effectiveFrom)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		uc.Log.Warnf("Failed to find price version: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if err == nil {
+		if covering.EffectiveFrom.Equal(effectiveFrom) {
+			if !covering.EffectiveFrom.After(now) {
+				return nil, fiber.NewError(fiber.StatusConflict, "A price version already started at this time")
+			}
+			// Rescheduling the same start replaces the scheduled price
+			covering.PricePerKgs = price
+			if err := uc.PriceVersionRepository.Update(tx, covering); err != nil {
+				uc.Log.Warnf("Failed to update price version: %+v", err)
+				return nil, fiber.ErrInternalServerError
+			}
+			return covering, nil
+		}
+
+		covering.EffectiveTo = &effectiveFrom
+		if err := uc.PriceVersionRepository.Update(tx, covering); err != nil {
+			uc.Log.Warnf("Failed to close price version: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	version := &entity.WasteBankPriceVersion{
+		PricedTypeID:  &wpt.ID,
+		WasteBankID:   wpt.WasteBankID,
+		WasteTypeID:   wpt.WasteTypeID,
+		PricePerKgs:   price,
+		EffectiveFrom: effectiveFrom,
+	}
+	if createdBy, err := uuid.Parse(actorID); err == nil {
+		version.CreatedByID = &createdBy
+	}
+
+	next := new(entity.WasteBankPriceVersion)
+	if err := uc.PriceVersionRepository.FindNextAfter(tx, next, wasteBankID, wasteTypeID, effectiveFrom); err == nil {
+		version.EffectiveTo = &next.EffectiveFrom
+	} else if err != gorm.ErrRecordNotFound {
+		uc.Log.Warnf("Failed to find next price version: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := uc.PriceVersionRepository.Create(tx, version); err != nil {
+		uc.Log.Warnf("Failed to create price version: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return version, nil
+}
 func (uc *WasteBankPricedTypeUsecase) CreateBatch(ctx context.Context, requests []model.WasteBankPricedTypeRequest) ([]*model.WasteBankPricedTypeResponse, error) {
 	tx := uc.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
@@ -59,10 +144,17 @@ func (uc *WasteBankPricedTypeUsecase) CreateBatch(ctx context.Context, requests
 	}
 
 	var entities []*entity.WasteBankPricedType
-	for _, req := range requests {
+	effectiveFroms := make([]time.Time, len(requests))
+	for i, req := range requests {
 		wasteBankID := uuid.MustParse(req.WasteBankID)
 		wasteTypeID := uuid.MustParse(req.WasteTypeID)
 
+		effectiveFrom, err := parseEffectiveFrom(req.EffectiveFrom)
+		if err != nil {
+			return nil, err
+		}
+		effectiveFroms[i] = effectiveFrom
+
 		exists, err := uc.WasteBankPricedTypeRepository.ExistsByBankAndType(tx, wasteBankID, wasteTypeID)
 		if err != nil {
 			uc.Log.Warn("Failed to check unique constraint: ", err)
@@ -72,11 +164,15 @@ func (uc *WasteBankPricedTypeUsecase) CreateBatch(ctx context.Context, requests
 			return nil, fiber.NewError(fiber.StatusConflict, "Duplicate waste_type_id found for waste bank: "+wasteTypeID.String())
 		}
 
-		entities = append(entities, &entity.WasteBankPricedType{
-			WasteBankID:       wasteBankID,
-			WasteTypeID:       wasteTypeID,
-			CustomPricePerKgs: req.CustomPricePerKgs,
-		})
+		wpt := &entity.WasteBankPricedType{
+			WasteBankID: wasteBankID,
+			WasteTypeID: wasteTypeID,
+		}
+		// A scheduled price only becomes the current one when it starts
+		if !effectiveFrom.After(time.Now()) {
+			wpt.CustomPricePerKgs = req.CustomPricePerKgs
+		}
+		entities = append(entities, wpt)
 	}
 
 	if err := uc.WasteBankPricedTypeRepository.CreateBatch(tx, entities); err != nil {
@@ -84,6 +180,12 @@ func (uc *WasteBankPricedTypeUsecase) CreateBatch(ctx context.Context, requests
 		return nil, fiber.ErrInternalServerError
 	}
 
+	for i, wpt := range entities {
+		if _, err := uc.addPriceVersion(tx, wpt, requests[i].CustomPricePerKgs, effectiveFroms[i], requests[i].WasteBankID); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		uc.Log.Error("Failed to commit batch insert: ", err)
 		return nil, fiber.ErrInternalServerError
@@ -127,10 +229,18 @@ func (uc *WasteBankPricedTypeUsecase) Create(ctx context.Context, request *model
 		return nil, fiber.NewError(fiber.StatusConflict, "Waste type already priced by this waste bank")
 	}
 
+	effectiveFrom, err := parseEffectiveFrom(request.EffectiveFrom)
+	if err != nil {
+		return nil, err
+	}
+
 	wpt := &entity.WasteBankPricedType{
-		WasteBankID:       uuid.MustParse(request.WasteBankID),
-		WasteTypeID:       uuid.MustParse(request.WasteTypeID),
-		CustomPricePerKgs: request.CustomPricePerKgs,
+		WasteBankID: uuid.MustParse(request.WasteBankID),
+		WasteTypeID: uuid.MustParse(request.WasteTypeID),
+	}
+	// A scheduled price only becomes the current one when it starts
+	if !effectiveFrom.After(time.Now()) {
+		wpt.CustomPricePerKgs = request.CustomPricePerKgs
 	}
 
 	if err := uc.WasteBankPricedTypeRepository.Create(tx, wpt); err != nil {
@@ -138,6 +248,10 @@ func (uc *WasteBankPricedTypeUsecase) Create(ctx context.Context, request *model
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if _, err := uc.addPriceVersion(tx, wpt, request.CustomPricePerKgs, effectiveFrom, request.WasteBankID); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		uc.Log.Error("Failed to commit transaction: ", err)
 		return nil, fiber.ErrInternalServerError
@@ -179,10 +293,21 @@ func (uc *WasteBankPricedTypeUsecase) Update(ctx context.Context, request *model
 		return nil, fiber.ErrNotFound
 	}
 
-	wpt.CustomPricePerKgs = request.CustomPricePerKgs
+	effectiveFrom, err := parseEffectiveFrom(request.EffectiveFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prices are never overwritten, the change becomes a new version
+	if _, err := uc.addPriceVersion(tx, wpt, request.CustomPricePerKgs, effectiveFrom, request.ActorID); err != nil {
+		return nil, err
+	}
 
-	if err := uc.WasteBankPricedTypeRepository.Update(tx, wpt); err != nil {
-		return nil, fiber.ErrInternalServerError
+	if !effectiveFrom.After(time.Now()) {
+		wpt.CustomPricePerKgs = request.CustomPricePerKgs
+		if err := uc.WasteBankPricedTypeRepository.Update(tx, wpt); err != nil {
+			return nil, fiber.ErrInternalServerError
+		}
 	}
 
 	if err := tx.Commit().Error; err != nil {
@@ -205,6 +330,12 @@ func (uc *WasteBankPricedTypeUsecase) Delete(ctx context.Context, request *model
 		return nil, fiber.ErrNotFound
 	}
 
+	// Versions stay for audits, but stop applying from now on
+	if err := uc.PriceVersionRepository.CloseOpenVersions(tx, wpt.ID.String(), time.Now()); err != nil {
+		uc.Log.Warnf("Failed to close price versions: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := uc.WasteBankPricedTypeRepository.Delete(tx, wpt); err != nil {
 		return nil, fiber.ErrInternalServerError
 	}
@@ -239,3 +370,49 @@ func (c *WasteBankPricedTypeUsecase) Search(ctx context.Context, request *model.
 	}
 	return responses, total, nil
 }
+
+func (uc *WasteBankPricedTypeUsecase) SearchHistory(ctx context.Context, request *model.SearchWasteBankPriceHistoryRequest) ([]model.WasteBankPriceVersionResponse, int64, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Warnf("Invalid request body")
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	var at *time.Time
+	if request.At != "" {
+		date, err := time.ParseInLocation("2006-01-02", request.At, timezone.WIB)
+		if err != nil {
+			return nil, 0, fiber.NewError(fiber.StatusBadRequest, "Invalid at format, expected YYYY-MM-DD")
+		}
+		at = &date
+	}
+
+	versions, total, err := uc.PriceVersionRepository.Search(tx, request, at)
+	if err != nil {
+		uc.Log.WithError(err).Warnf("Failed to search price history")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		uc.Log.WithError(err).Error("Failed to commit transaction")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.WasteBankPriceVersionResponse, len(versions))
+	for i, version := range versions {
+		responses[i] = *converter.WasteBankPriceVersionToResponse(&version)
+	}
+	return responses, total, nil
+}
+
+// SyncCurrentPrices applies scheduled price versions that have started
+func (uc *WasteBankPricedTypeUsecase) SyncCurrentPrices(ctx context.Context) (int64, error) {
+	updated, err := uc.PriceVersionRepository.SyncCurrentPrices(uc.DB.WithContext(ctx))
+	if err != nil {
+		uc.Log.Warnf("Failed to sync current prices: %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+	return updated, nil
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+type WasteBankPromotionUsecase struct {
+	DB                           *gorm.DB
+	Log                          *logrus.Logger
+	Validate                     *validator.Validate
+	WasteBankPromotionRepository *repository.WasteBankPromotionRepository
+	WasteBankRepository          *repository.WasteBankRepository
+	WasteTypeRepository          *repository.WasteTypeRepository
+}
+
+func NewWasteBankPromotionUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	wasteBankPromotionRepository *repository.WasteBankPromotionRepository,
+	wasteBankRepository *repository.WasteBankRepository,
+	wasteTypeRepository *repository.WasteTypeRepository,
+) *WasteBankPromotionUsecase {
+	return &WasteBankPromotionUsecase{
+		DB:                           db,
+		Log:                          log,
+		Validate:                     validate,
+		WasteBankPromotionRepository: wasteBankPromotionRepository,
+		WasteBankRepository:          wasteBankRepository,
+		WasteTypeRepository:          wasteTypeRepository,
+	}
+}
+
+// Helper method to resolve which waste bank a promotion request is for
+func promotionBankID(actorID string, actorRole string, requested string) (string, error) {
+	switch {
+	case actorRole == "admin":
+		if requested == "" {
+			return "", fiber.NewError(fiber.StatusBadRequest, "waste_bank_id is required")
+		}
+		return requested, nil
+	case isWasteBankRole(actorRole):
+		if requested != "" && requested != actorID {
+			return "", fiber.NewError(fiber.StatusForbidden, "You can only manage your own promotions")
+		}
+		return actorID, nil
+	}
+	return "", fiber.NewError(fiber.StatusForbidden, "You can only manage your own promotions")
+}
+
+func (c *WasteBankPromotionUsecase) Create(ctx context.Context, request *model.WasteBankPromotionRequest) (*model.WasteBankPromotionResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	wasteBankID, err := promotionBankID(request.ActorID, request.ActorRole, request.WasteBankID)
+	if err != nil {
+		return nil, err
+	}
+	bankUUID, err := uuid.Parse(wasteBankID)
+	if err != nil {
+		c.Log.Warnf("Invalid waste bank ID: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	wasteBank := new(entity.WasteBankProfile)
+	if err := c.WasteBankRepository.FindByUserIDNoPreload(tx, wasteBank, wasteBankID); err != nil {
+		c.Log.Warnf("Failed to find waste bank profile: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	promotion := &entity.WasteBankPromotion{
+		WasteBankID:  bankUUID,
+		Name:         request.Name,
+		BonusPercent: request.BonusPercent,
+		StartsAt:     time.Now(),
+		IsActive:     true,
+	}
+
+	if request.WasteTypeID != "" {
+		wasteType := new(entity.WasteType)
+		if err := c.WasteTypeRepository.FindById(tx, wasteType, request.WasteTypeID); err != nil {
+			c.Log.Warnf("Failed to find waste type by ID: %+v", err)
+			return nil, fiber.ErrNotFound
+		}
+		promotion.WasteTypeID = &wasteType.ID
+	}
+
+	if request.StartsAt != "" {
+		startsAt, err := time.Parse(time.RFC3339, request.StartsAt)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "starts_at must be in RFC3339 format")
+		}
+		promotion.StartsAt = startsAt
+	}
+	if request.EndsAt != "" {
+		endsAt, err := time.Parse(time.RFC3339, request.EndsAt)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "ends_at must be in RFC3339 format")
+		}
+		if !endsAt.After(promotion.StartsAt) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "ends_at must be after starts_at")
+		}
+		promotion.EndsAt = &endsAt
+	}
+
+	if err := c.WasteBankPromotionRepository.Create(tx, promotion); err != nil {
+		c.Log.Warnf("Failed to create promotion: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.WasteBankPromotionToResponse(promotion), nil
+}
+
+func (c *WasteBankPromotionUsecase) Search(ctx context.Context, request *model.SearchWasteBankPromotionRequest) ([]model.WasteBankPromotionResponse, int64, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, 0, fiber.ErrBadRequest
+	}
+
+	promotions, total, err := c.WasteBankPromotionRepository.Search(tx, request)
+	if err != nil {
+		c.Log.Warnf("Failed to search promotions: %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.WasteBankPromotionResponse, len(promotions))
+	for i, promotion := range promotions {
+		responses[i] = *converter.WasteBankPromotionToResponse(&promotion)
+	}
+
+	return responses, total, nil
+}
+
+// Deactivate ends a promotion early, quotes made while it ran keep their bonus
+func (c *WasteBankPromotionUsecase) Deactivate(ctx context.Context, request *model.DeactivateWasteBankPromotionRequest) (*model.WasteBankPromotionResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	promotion := new(entity.WasteBankPromotion)
+	if err := c.WasteBankPromotionRepository.FindById(tx, promotion, request.ID); err != nil {
+		c.Log.Warnf("Failed to find promotion by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if request.ActorRole != "admin" && promotion.WasteBankID.String() != request.ActorID {
+		return nil, fiber.NewError(fiber.StatusForbidden, "You can only manage your own promotions")
+	}
+
+	promotion.IsActive = false
+	if err := c.WasteBankPromotionRepository.Update(tx, promotion); err != nil {
+		c.Log.Warnf("Failed to deactivate promotion: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.WasteBankPromotionToResponse(promotion), nil
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/pkg/timezone"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRecommendationRadiusMeters = 10000
+	// Nearest banks considered before ranking
+	maxRecommendationCandidates = 100
+
+	// Maximum score of each ranking factor, adding up to 100
+	recommendationDistanceWeight  = 25
+	recommendationAcceptingWeight = 15
+	recommendationCoverageWeight  = 25
+	recommendationPayoutWeight    = 20
+	recommendationOpeningWeight   = 5
+	recommendationCapacityWeight  = 10
+)
+
+type WasteBankRecommendationUsecase struct {
+	DB                              *gorm.DB
+	Log                             *logrus.Logger
+	Validate                        *validator.Validate
+	WasteBankRepository             *repository.WasteBankRepository
+	WasteBankPriceVersionRepository *repository.WasteBankPriceVersionRepository
+	UserRepository                  *repository.UserRepository
+	AppointmentSlotUsecase          *AppointmentSlotUsecase
+}
+
+func NewWasteBankRecommendationUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	wasteBankRepository *repository.WasteBankRepository,
+	wasteBankPriceVersionRepository *repository.WasteBankPriceVersionRepository,
+	userRepository *repository.UserRepository,
+	appointmentSlotUsecase *AppointmentSlotUsecase,
+) *WasteBankRecommendationUsecase {
+	return &WasteBankRecommendationUsecase{
+		DB:                              db,
+		Log:                             log,
+		Validate:                        validate,
+		WasteBankRepository:             wasteBankRepository,
+		WasteBankPriceVersionRepository: wasteBankPriceVersionRepository,
+		UserRepository:                  userRepository,
+		AppointmentSlotUsecase:          appointmentSlotUsecase,
+	}
+}
+
+// Recommend ranks the waste banks around the customer for the waste they want to hand over
+func (c *WasteBankRecommendationUsecase) Recommend(ctx context.Context, request *model.SearchWasteBankRecommendationRequest) ([]model.WasteBankRecommendationResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if len(request.Weights) > 0 && len(request.Weights) != len(request.WasteTypeIDs) {
+		c.Log.Warnf("WasteTypeIDs and Weights arrays must have same length")
+		return nil, fiber.ErrBadRequest
+	}
+	weights := make(map[string]float64, len(request.WasteTypeIDs))
+	for i, wasteTypeID := range request.WasteTypeIDs {
+		if _, err := uuid.Parse(wasteTypeID); err != nil {
+			c.Log.Warnf("Invalid waste type ID: %+v", err)
+			return nil, fiber.ErrBadRequest
+		}
+		weights[wasteTypeID] = 1
+		if len(request.Weights) > 0 {
+			weights[wasteTypeID] = request.Weights[i]
+		}
+	}
+
+	lat, lng := request.Latitude, request.Longitude
+	if lat == nil || lng == nil {
+		customer := new(entity.User)
+		if err := c.UserRepository.FindById(tx, customer, request.ActorID); err != nil {
+			c.Log.Warnf("Failed to find customer by ID: %+v", err)
+			return nil, fiber.ErrNotFound
+		}
+		if customer.Location == nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "latitude and longitude are required when no location is saved")
+		}
+		lat, lng = &customer.Location.Lat, &customer.Location.Lng
+	}
+
+	today := time.Now().In(timezone.WIB).Format("2006-01-02")
+	date := today
+	if request.Date != "" {
+		if _, err := time.Parse("2006-01-02", request.Date); err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "date must be in YYYY-MM-DD format")
+		}
+		if request.Date < today {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "date cannot be in the past")
+		}
+		date = request.Date
+	}
+
+	radiusMeters := request.RadiusMeters
+	if radiusMeters == 0 {
+		radiusMeters = defaultRecommendationRadiusMeters
+	}
+
+	wasteBanks, err := c.WasteBankRepository.FindNearby(tx, *lat, *lng, radiusMeters, maxRecommendationCandidates)
+	if err != nil {
+		c.Log.Warnf("Failed to find nearby waste banks: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if len(wasteBanks) == 0 {
+		return []model.WasteBankRecommendationResponse{}, nil
+	}
+
+	wasteBankIDs := make([]string, len(wasteBanks))
+	for i, wasteBank := range wasteBanks {
+		wasteBankIDs[i] = wasteBank.UserID.String()
+	}
+	// Estimated from the price versions a drop booked now would be priced from
+	priceVersions, err := c.WasteBankPriceVersionRepository.FindEffectiveByBanksAndTypes(tx, wasteBankIDs, request.WasteTypeIDs, time.Now())
+	if err != nil {
+		c.Log.Warnf("Failed to find waste bank prices: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	prices := make(map[string]map[string]int64, len(wasteBanks))
+	for _, priceVersion := range priceVersions {
+		bankID := priceVersion.WasteBankID.String()
+		if prices[bankID] == nil {
+			prices[bankID] = make(map[string]int64)
+		}
+		prices[bankID][priceVersion.WasteTypeID.String()] = priceVersion.PricePerKgs
+	}
+
+	openingHoursByBank, remainingByBank, err := c.AppointmentSlotUsecase.remainingCapacities(tx, wasteBanks, date, request.DeliveryType)
+	if err != nil {
+		c.Log.Warnf("Failed to find slot capacity of waste banks: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.WasteBankRecommendationResponse, len(wasteBanks))
+	var maxPayout int64
+	maxRemaining := 0
+	for i := range wasteBanks {
+		wasteBank := &wasteBanks[i]
+		bankID := wasteBank.UserID.String()
+
+		factors := model.WasteBankRecommendationFactors{
+			IsAcceptingCustomer: wasteBank.User.IsAcceptingCustomer,
+			RequestedTypes:      len(request.WasteTypeIDs),
+			MissingWasteTypeIDs: []string{},
+			IsOpen:              true,
+		}
+		if wasteBank.Distance != nil {
+			factors.DistanceKm = *wasteBank.Distance / 1000
+		}
+
+		var payout int64
+		for _, wasteTypeID := range request.WasteTypeIDs {
+			price, ok := prices[bankID][wasteTypeID]
+			if !ok {
+				factors.MissingWasteTypeIDs = append(factors.MissingWasteTypeIDs, wasteTypeID)
+				continue
+			}
+			factors.CoveredTypes++
+			payout += int64(weights[wasteTypeID] * float64(price))
+		}
+		if request.DeliveryType == "pickup" {
+			factors.PickupFee = pickupFee(wasteBank, &factors.DistanceKm)
+		}
+		factors.EstimatedPayout = max(payout-factors.PickupFee, 0)
+
+		if hours, ok := openingHoursByBank[bankID]; ok {
+			factors.IsOpen = !hours.IsClosed
+			if factors.IsOpen {
+				factors.OpenTime = formatClockMinute(hours.Open)
+				factors.CloseTime = formatClockMinute(hours.Close)
+			}
+		}
+		if remaining, ok := remainingByBank[bankID]; ok {
+			factors.RemainingSlots = &remaining
+			maxRemaining = max(maxRemaining, remaining)
+		}
+
+		maxPayout = max(maxPayout, factors.EstimatedPayout)
+
+		responses[i] = model.WasteBankRecommendationResponse{
+			WasteBank: converter.UserToResponse(&wasteBank.User),
+			Factors:   factors,
+		}
+	}
+
+	// Payout and capacity are scored against the best bank around
+	for i := range responses {
+		factors := &responses[i].Factors
+		factors.DistanceScore = recommendationDistanceWeight * math.Max(1-factors.DistanceKm*1000/float64(radiusMeters), 0)
+		if factors.IsAcceptingCustomer {
+			factors.AcceptingScore = recommendationAcceptingWeight
+		}
+		factors.CoverageScore = recommendationCoverageWeight * float64(factors.CoveredTypes) / float64(factors.RequestedTypes)
+		if maxPayout > 0 {
+			factors.PayoutScore = recommendationPayoutWeight * float64(factors.EstimatedPayout) / float64(maxPayout)
+		}
+		if factors.IsOpen {
+			factors.OpeningScore = recommendationOpeningWeight
+		}
+		// Banks without opening hours take bookings at any time
+		if factors.RemainingSlots == nil {
+			factors.CapacityScore = recommendationCapacityWeight
+		} else if maxRemaining > 0 {
+			factors.CapacityScore = recommendationCapacityWeight * float64(*factors.RemainingSlots) / float64(maxRemaining)
+		}
+
+		score := factors.DistanceScore + factors.AcceptingScore + factors.CoverageScore +
+			factors.PayoutScore + factors.OpeningScore + factors.CapacityScore
+		responses[i].Score = math.Round(score*100) / 100
+	}
+
+	sort.SliceStable(responses, func(i, j int) bool {
+		if responses[i].Score != responses[j].Score {
+			return responses[i].Score > responses[j].Score
+		}
+		return responses[i].Factors.DistanceKm < responses[j].Factors.DistanceKm
+	})
+
+	size := request.Size
+	if size > len(responses) {
+		size = len(responses)
+	}
+	responses = responses[:size]
+	for i := range responses {
+		responses[i].Rank = i + 1
+	}
+
+	return responses, nil
+}
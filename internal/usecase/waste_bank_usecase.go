@@ -158,6 +158,58 @@ func (c *WasteBankUseCase) Update(ctx context.Context, request *model.UpdateWast
 		wasteBank.CloseTime = types.NewTimeOnly(closeTime)
 	}
 
+	if request.AutoAssignCollectors != nil {
+		wasteBank.AutoAssignCollectors = *request.AutoAssignCollectors
+	}
+
+	if request.AutoAssignMaxDistanceKm != nil {
+		if *request.AutoAssignMaxDistanceKm == 0 {
+			wasteBank.AutoAssignMaxDistanceKm = nil
+		} else {
+			wasteBank.AutoAssignMaxDistanceKm = request.AutoAssignMaxDistanceKm
+		}
+	}
+
+	if request.SlotDurationMinutes != nil {
+		wasteBank.SlotDurationMinutes = *request.SlotDurationMinutes
+	}
+
+	if request.DropoffSlotCapacity != nil {
+		wasteBank.DropoffSlotCapacity = *request.DropoffSlotCapacity
+	}
+
+	if request.PickupSlotCapacity != nil {
+		wasteBank.PickupSlotCapacity = *request.PickupSlotCapacity
+	}
+
+	if request.SlotOverbookingPolicy != nil {
+		wasteBank.SlotOverbookingPolicy = *request.SlotOverbookingPolicy
+	}
+
+	if request.PendingExpiryHours != nil {
+		if *request.PendingExpiryHours == 0 {
+			wasteBank.PendingExpiryHours = nil
+		} else {
+			wasteBank.PendingExpiryHours = request.PendingExpiryHours
+		}
+	}
+
+	if request.PickupBaseFee != nil {
+		wasteBank.PickupBaseFee = *request.PickupBaseFee
+	}
+
+	if request.PickupFeePerKm != nil {
+		wasteBank.PickupFeePerKm = *request.PickupFeePerKm
+	}
+
+	if request.PickupFreeRadiusKm != nil {
+		wasteBank.PickupFreeRadiusKm = *request.PickupFreeRadiusKm
+	}
+
+	if request.RejectOutsideServiceArea != nil {
+		wasteBank.RejectOutsideServiceArea = *request.RejectOutsideServiceArea
+	}
+
 	if err := c.WasteBankRepository.Update(tx, wasteBank); err != nil {
 		c.Log.Warnf("Failed to update waste bank: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/wastetrack/wastetrack-backend/internal/entity"
+	"github.com/wastetrack/wastetrack-backend/internal/model"
+	"github.com/wastetrack/wastetrack-backend/internal/model/converter"
+	"github.com/wastetrack/wastetrack-backend/internal/repository"
+	"github.com/wastetrack/wastetrack-backend/internal/types"
+	"gorm.io/gorm"
+)
+
+// How long a quote can be booked with
+const wasteDropQuoteValidity = 30 * time.Minute
+
+// How far a booking may be from the quoted appointment location before the quoted pickup fee no longer applies
+const wasteDropQuoteLocationToleranceMeters = 100.0
+
+type WasteDropQuoteUsecase struct {
+	DB                              *gorm.DB
+	Log                             *logrus.Logger
+	Validate                        *validator.Validate
+	WasteDropQuoteRepository        *repository.WasteDropQuoteRepository
+	WasteDropQuoteItemRepository    *repository.WasteDropQuoteItemRepository
+	WasteBankPriceVersionRepository *repository.WasteBankPriceVersionRepository
+	WasteBankPromotionRepository    *repository.WasteBankPromotionRepository
+	WasteBankRepository             *repository.WasteBankRepository
+	WasteTypeRepository             *repository.WasteTypeRepository
+	UserRepository                  *repository.UserRepository
+}
+
+func NewWasteDropQuoteUsecase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	wasteDropQuoteRepository *repository.WasteDropQuoteRepository,
+	wasteDropQuoteItemRepository *repository.WasteDropQuoteItemRepository,
+	wasteBankPriceVersionRepository *repository.WasteBankPriceVersionRepository,
+	wasteBankPromotionRepository *repository.WasteBankPromotionRepository,
+	wasteBankRepository *repository.WasteBankRepository,
+	wasteTypeRepository *repository.WasteTypeRepository,
+	userRepository *repository.UserRepository,
+) *WasteDropQuoteUsecase {
+	return &WasteDropQuoteUsecase{
+		DB:                              db,
+		Log:                             log,
+		Validate:                        validate,
+		WasteDropQuoteRepository:        wasteDropQuoteRepository,
+		WasteDropQuoteItemRepository:    wasteDropQuoteItemRepository,
+		WasteBankPriceVersionRepository: wasteBankPriceVersionRepository,
+		WasteBankPromotionRepository:    wasteBankPromotionRepository,
+		WasteBankRepository:             wasteBankRepository,
+		WasteTypeRepository:             wasteTypeRepository,
+		UserRepository:                  userRepository,
+	}
+}
+
+// pickupFee charges the base fee plus the per km fee for every started km beyond the free radius
+func pickupFee(wasteBank *entity.WasteBankProfile, distanceKm *float64) int64 {
+	fee := wasteBank.PickupBaseFee
+	if distanceKm != nil && *distanceKm > wasteBank.PickupFreeRadiusKm {
+		fee += int64(math.Ceil(*distanceKm-wasteBank.PickupFreeRadiusKm)) * wasteBank.PickupFeePerKm
+	}
+	return fee
+}
+
+func (c *WasteDropQuoteUsecase) Create(ctx context.Context, request *model.WasteDropQuoteRequest) (*model.WasteDropQuoteResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if len(request.Items.WasteTypeIDs) != len(request.Items.Weights) {
+		c.Log.Warnf("WasteTypeIDs and Weights arrays must have same length")
+		return nil, fiber.ErrBadRequest
+	}
+
+	// Customers quote for themselves, only admins may quote on someone's behalf
+	if request.ActorRole == "customer" {
+		request.CustomerID = request.ActorID
+	}
+	customerID, err := uuid.Parse(request.CustomerID)
+	if err != nil {
+		c.Log.Warnf("Invalid customer ID: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+	wasteBankID, err := uuid.Parse(request.WasteBankID)
+	if err != nil {
+		c.Log.Warnf("Invalid waste bank ID: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if request.DeliveryType == "pickup" && request.AppointmentLocation == nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "appointment_location is required for pickups")
+	}
+
+	wasteBank := new(entity.WasteBankProfile)
+	if err := c.WasteBankRepository.FindByUserIDNoPreload(tx, wasteBank, request.WasteBankID); err != nil {
+		c.Log.Warnf("Failed to find waste bank profile: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	quote := &entity.WasteDropQuote{
+		CustomerID:   customerID,
+		WasteBankID:  wasteBankID,
+		DeliveryType: request.DeliveryType,
+		ExpiresAt:    time.Now().Add(wasteDropQuoteValidity),
+	}
+
+	now := time.Now()
+	seen := make(map[uuid.UUID]bool)
+	items := make([]*entity.WasteDropQuoteItem, len(request.Items.WasteTypeIDs))
+	for i, wasteTypeIDStr := range request.Items.WasteTypeIDs {
+		wasteType := new(entity.WasteType)
+		if err := c.WasteTypeRepository.FindById(tx, wasteType, wasteTypeIDStr); err != nil {
+			c.Log.Warnf("Failed to find waste type by ID %s: %+v", wasteTypeIDStr, err)
+			return nil, fiber.ErrNotFound
+		}
+		if seen[wasteType.ID] {
+			c.Log.Warnf("Duplicate waste type ID: %s", wasteTypeIDStr)
+			return nil, fiber.ErrBadRequest
+		}
+		seen[wasteType.ID] = true
+
+		// Quoted from the same price versions the drop request items are priced from
+		priceVersion := new(entity.WasteBankPriceVersion)
+		if err := c.WasteBankPriceVersionRepository.FindEffective(tx, priceVersion, request.WasteBankID, wasteTypeIDStr, now); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Waste bank does not accept waste type %s", wasteTypeIDStr))
+			}
+			c.Log.Warnf("Failed to find price for waste type %s: %+v", wasteTypeIDStr, err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		weight := request.Items.Weights[i]
+		item := &entity.WasteDropQuoteItem{
+			WasteTypeID:       wasteType.ID,
+			EstimatedWeight:   weight,
+			PricePerKgs:       priceVersion.PricePerKgs,
+			PriceVersionID:    &priceVersion.ID,
+			QuotedPricePerKgs: priceVersion.PricePerKgs,
+		}
+
+		promotion := new(entity.WasteBankPromotion)
+		err := c.WasteBankPromotionRepository.FindBestActive(tx, promotion, request.WasteBankID, wasteTypeIDStr, now)
+		if err == nil {
+			item.PromotionID = &promotion.ID
+			item.BonusPercent = promotion.BonusPercent
+			item.QuotedPricePerKgs += int64(float64(item.PricePerKgs) * promotion.BonusPercent / 100)
+		} else if err != gorm.ErrRecordNotFound {
+			c.Log.Warnf("Failed to find promotion for waste type %s: %+v", wasteTypeIDStr, err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		item.Subtotal = int64(weight * float64(item.QuotedPricePerKgs))
+		quote.ItemsTotal += item.Subtotal
+		quote.PromotionBonus += item.Subtotal - int64(weight*float64(item.PricePerKgs))
+		items[i] = item
+	}
+
+	if request.AppointmentLocation != nil {
+		quote.AppointmentLocation = &types.Point{
+			Lat: request.AppointmentLocation.Latitude,
+			Lng: request.AppointmentLocation.Longitude,
+		}
+		meters, err := c.UserRepository.DistanceTo(tx, request.WasteBankID, request.AppointmentLocation.Latitude, request.AppointmentLocation.Longitude)
+		if err != nil {
+			c.Log.Warnf("Failed to measure distance to waste bank: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if meters != nil {
+			distanceKm := *meters / 1000
+			quote.DistanceKm = &distanceKm
+		}
+	}
+
+	if quote.DeliveryType == "pickup" {
+		quote.PickupFee = pickupFee(wasteBank, quote.DistanceKm)
+	}
+	quote.TotalPrice = max(quote.ItemsTotal-quote.PickupFee, 0)
+
+	if err := c.WasteDropQuoteRepository.Create(tx, quote); err != nil {
+		c.Log.Warnf("Failed to create quote: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	for _, item := range items {
+		item.QuoteID = quote.ID
+	}
+	if err := c.WasteDropQuoteItemRepository.CreateBatch(tx, items); err != nil {
+		c.Log.Warnf("Failed to create quote items: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	quote.Items = make([]entity.WasteDropQuoteItem, len(items))
+	for i, item := range items {
+		quote.Items[i] = *item
+	}
+	return converter.WasteDropQuoteToResponse(quote), nil
+}
+
+func (c *WasteDropQuoteUsecase) Get(ctx context.Context, request *model.GetWasteDropQuoteRequest) (*model.WasteDropQuoteResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	quote := new(entity.WasteDropQuote)
+	if err := c.WasteDropQuoteRepository.FindByIdWithItems(tx, quote, request.ID); err != nil {
+		c.Log.Warnf("Failed to find quote by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	if request.ActorRole != "admin" && quote.CustomerID.String() != request.ActorID && quote.WasteBankID.String() != request.ActorID {
+		return nil, fiber.NewError(fiber.StatusForbidden, "You are not allowed to access this quote")
+	}
+
+	return converter.WasteDropQuoteToResponse(quote), nil
+}
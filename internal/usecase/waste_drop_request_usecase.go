@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/go-playground/validator"
@@ -18,20 +20,46 @@ import (
 )
 
 type WasteDropRequestUsecase struct {
-	DB                             *gorm.DB
-	Log                            *logrus.Logger
-	Validate                       *validator.Validate
-	WasteDropRequestRepository     *repository.WasteDropRequestRepository
-	UserRepository                 *repository.UserRepository
-	WasteTypeRepository            *repository.WasteTypeRepository
-	WasteDropRequestItemRepository *repository.WasteDropRequestItemRepository
-	WasteBankPricedTypeRepository  *repository.WasteBankPricedTypeRepository
-	CustomerRepository             *repository.CustomerRepository
-	WasteBankRepository            *repository.WasteBankRepository
-	WasteCollectorRepository       *repository.WasteCollectorRepository
+	DB                              *gorm.DB
+	Log                             *logrus.Logger
+	Validate                        *validator.Validate
+	WasteDropRequestRepository      *repository.WasteDropRequestRepository
+	UserRepository                  *repository.UserRepository
+	WasteTypeRepository             *repository.WasteTypeRepository
+	WasteDropRequestItemRepository  *repository.WasteDropRequestItemRepository
+	WasteBankPricedTypeRepository   *repository.WasteBankPricedTypeRepository
+	WasteBankPriceVersionRepository *repository.WasteBankPriceVersionRepository
+	CustomerRepository              *repository.CustomerRepository
+	WasteBankRepository             *repository.WasteBankRepository
+	WasteCollectorRepository        *repository.WasteCollectorRepository
 	// NEW: Add storage repositories
-	StorageRepository     *repository.StorageRepository
-	StorageItemRepository *repository.StorageItemRepository
+	StorageRepository                       *repository.StorageRepository
+	StorageItemRepository                   *repository.StorageItemRepository
+	WasteDropRequestStatusHistoryRepository *repository.WasteDropRequestStatusHistoryRepository
+	ImpactFactorRepository                  *repository.ImpactFactorRepository
+	LedgerUsecase                           *LedgerUsecase
+	CollectorAssignmentUsecase              *CollectorAssignmentUsecase
+	AppointmentSlotUsecase                  *AppointmentSlotUsecase
+	WasteDropQuoteRepository                *repository.WasteDropQuoteRepository
+	ServiceAreaRepository                   *repository.ServiceAreaRepository
+	RegionRepository                        *repository.RegionRepository
+	DailyWasteRollupRepository              *repository.DailyWasteRollupRepository
+	StockMovementRepository                 *repository.StockMovementRepository
+}
+
+// wasteDropRequestTransitions lists the statuses a request may move to from its current status.
+// Completed and cancelled requests are final.
+var wasteDropRequestTransitions = map[string][]string{
+	"pending":    {"assigned", "completed", "cancelled"},
+	"assigned":   {"collecting", "cancelled"},
+	"collecting": {"completed", "cancelled"},
+}
+
+// itemGradeMultipliers scales the price of a completed item by its quality grade
+var itemGradeMultipliers = map[string]float64{
+	"A": 1.0,
+	"B": 0.8,
+	"C": 0.6,
 }
 
 func NewWasteDropRequestUsecase(
@@ -43,27 +71,179 @@ func NewWasteDropRequestUsecase(
 	wasteTypeRepository *repository.WasteTypeRepository,
 	wasteDropRequestItemRepository *repository.WasteDropRequestItemRepository,
 	wasteBankPricedTypeRepository *repository.WasteBankPricedTypeRepository,
+	wasteBankPriceVersionRepository *repository.WasteBankPriceVersionRepository,
 	customerRepository *repository.CustomerRepository,
 	wasteBankRepository *repository.WasteBankRepository,
 	wasteCollectorRepository *repository.WasteCollectorRepository,
 	storageRepository *repository.StorageRepository,
 	storageItemRepository *repository.StorageItemRepository,
+	wasteDropRequestStatusHistoryRepository *repository.WasteDropRequestStatusHistoryRepository,
+	impactFactorRepository *repository.ImpactFactorRepository,
+	ledgerUsecase *LedgerUsecase,
+	collectorAssignmentUsecase *CollectorAssignmentUsecase,
+	appointmentSlotUsecase *AppointmentSlotUsecase,
+	wasteDropQuoteRepository *repository.WasteDropQuoteRepository,
+	serviceAreaRepository *repository.ServiceAreaRepository,
+	regionRepository *repository.RegionRepository,
+	dailyWasteRollupRepository *repository.DailyWasteRollupRepository,
+	stockMovementRepository *repository.StockMovementRepository,
 ) *WasteDropRequestUsecase {
 	return &WasteDropRequestUsecase{
-		DB:                             db,
-		Log:                            log,
-		Validate:                       validate,
-		WasteDropRequestRepository:     wasteDropRequestRepository,
-		UserRepository:                 userRepository,
-		WasteTypeRepository:            wasteTypeRepository,
-		WasteDropRequestItemRepository: wasteDropRequestItemRepository,
-		WasteBankPricedTypeRepository:  wasteBankPricedTypeRepository,
-		CustomerRepository:             customerRepository,
-		WasteBankRepository:            wasteBankRepository,
-		WasteCollectorRepository:       wasteCollectorRepository,
-		StorageRepository:              storageRepository,
-		StorageItemRepository:          storageItemRepository,
+		DB:                                      db,
+		Log:                                     log,
+		Validate:                                validate,
+		WasteDropRequestRepository:              wasteDropRequestRepository,
+		UserRepository:                          userRepository,
+		WasteTypeRepository:                     wasteTypeRepository,
+		WasteDropRequestItemRepository:          wasteDropRequestItemRepository,
+		WasteBankPricedTypeRepository:           wasteBankPricedTypeRepository,
+		WasteBankPriceVersionRepository:         wasteBankPriceVersionRepository,
+		CustomerRepository:                      customerRepository,
+		WasteBankRepository:                     wasteBankRepository,
+		WasteCollectorRepository:                wasteCollectorRepository,
+		StorageRepository:                       storageRepository,
+		StorageItemRepository:                   storageItemRepository,
+		WasteDropRequestStatusHistoryRepository: wasteDropRequestStatusHistoryRepository,
+		ImpactFactorRepository:                  impactFactorRepository,
+		LedgerUsecase:                           ledgerUsecase,
+		CollectorAssignmentUsecase:              collectorAssignmentUsecase,
+		AppointmentSlotUsecase:                  appointmentSlotUsecase,
+		WasteDropQuoteRepository:                wasteDropQuoteRepository,
+		ServiceAreaRepository:                   serviceAreaRepository,
+		RegionRepository:                        regionRepository,
+		DailyWasteRollupRepository:              dailyWasteRollupRepository,
+		StockMovementRepository:                 stockMovementRepository,
+	}
+}
+
+// Helper method to check that the actor is a party of the request
+func (c *WasteDropRequestUsecase) checkActorAccess(wasteDropRequest *entity.WasteDropRequest, actorID string, actorRole string) error {
+	switch actorRole {
+	case "admin", "system":
+		return nil
+	case "customer":
+		if wasteDropRequest.CustomerID.String() == actorID {
+			return nil
+		}
+	case "waste_collector_unit", "waste_collector_central":
+		if wasteDropRequest.AssignedCollectorID != nil && wasteDropRequest.AssignedCollectorID.String() == actorID {
+			return nil
+		}
+	case "waste_bank_unit", "waste_bank_central":
+		if wasteDropRequest.WasteBankID != nil && wasteDropRequest.WasteBankID.String() == actorID {
+			return nil
+		}
 	}
+	return fiber.NewError(fiber.StatusForbidden, "You are not allowed to access this request")
+}
+
+// Helper method to validate a status change against the transition table and the actor's role
+func (c *WasteDropRequestUsecase) validateStatusTransition(wasteDropRequest *entity.WasteDropRequest, toStatus string, actorID string, actorRole string) error {
+	fromStatus := wasteDropRequest.Status
+
+	allowed := false
+	for _, next := range wasteDropRequestTransitions[fromStatus] {
+		if next == toStatus {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Cannot change status from %s to %s", fromStatus, toStatus))
+	}
+
+	// Waitlisted requests hold no slot yet, so they can only be cancelled until one frees up
+	if wasteDropRequest.IsWaitlisted && toStatus != "cancelled" {
+		return fiber.NewError(fiber.StatusConflict, "Waitlisted requests can only be cancelled until they get a slot")
+	}
+
+	// Pickups must be collected before they can be completed, only drop-offs complete straight from pending
+	if toStatus == "completed" && fromStatus == "pending" && wasteDropRequest.DeliveryType != "dropoff" {
+		return fiber.NewError(fiber.StatusBadRequest, "Pickup requests must be collected before they can be completed")
+	}
+
+	if err := c.checkActorAccess(wasteDropRequest, actorID, actorRole); err != nil {
+		return err
+	}
+
+	switch actorRole {
+	case "customer":
+		if toStatus != "cancelled" || fromStatus != "pending" {
+			return fiber.NewError(fiber.StatusForbidden, "Customers can only cancel pending requests")
+		}
+	case "waste_collector_unit", "waste_collector_central":
+		// Completion weighs the waste and pays the customer, that stays with the waste bank
+		if toStatus != "collecting" {
+			return fiber.NewError(fiber.StatusForbidden, "Collectors can only start collecting their assigned requests")
+		}
+	}
+
+	return nil
+}
+
+// Helper method to pick the cancellation reason code for the actor. Customers always cancel as
+// customer_cancelled, banks reject or report a no-show, and expired is left to the expiry job.
+func cancellationReasonCode(actorRole string, requested string) (string, error) {
+	switch actorRole {
+	case "customer":
+		if requested != "" && requested != "customer_cancelled" {
+			return "", fiber.NewError(fiber.StatusForbidden, "Customers can only cancel with reason code customer_cancelled")
+		}
+		return "customer_cancelled", nil
+	case "waste_bank_unit", "waste_bank_central":
+		if requested == "" {
+			return "bank_rejected", nil
+		}
+		if requested != "bank_rejected" && requested != "no_show" {
+			return "", fiber.NewError(fiber.StatusForbidden, "Waste banks can only cancel with reason code bank_rejected or no_show")
+		}
+	}
+	return requested, nil
+}
+
+// Helper method to cancel a request inside the caller's transaction, recording why and freeing its slot
+func (c *WasteDropRequestUsecase) cancelRequest(tx *gorm.DB, wasteDropRequest *entity.WasteDropRequest, reasonCode string, reason string, actorID string, actorRole string) error {
+	fromStatus := wasteDropRequest.Status
+	now := time.Now()
+	wasteDropRequest.Status = "cancelled"
+	wasteDropRequest.CancellationReasonCode = &reasonCode
+	wasteDropRequest.CancelledAt = &now
+
+	if err := c.recordStatusHistory(tx, wasteDropRequest.ID, fromStatus, "cancelled", actorID, actorRole, reason, nil); err != nil {
+		return err
+	}
+	if err := c.WasteDropRequestRepository.Update(tx, wasteDropRequest); err != nil {
+		return err
+	}
+	return c.AppointmentSlotUsecase.ReleaseSlot(tx, wasteDropRequest)
+}
+
+// Helper method to record a status change in the request history
+func (c *WasteDropRequestUsecase) recordStatusHistory(tx *gorm.DB, requestID uuid.UUID, fromStatus string, toStatus string, actorID string, actorRole string, reason string, location *model.LocationRequest) error {
+	history := &entity.WasteDropRequestStatusHistory{
+		RequestID: requestID,
+		ToStatus:  toStatus,
+		ActorRole: actorRole,
+		Reason:    reason,
+	}
+	if fromStatus != "" {
+		history.FromStatus = &fromStatus
+	}
+	if actorID != "" {
+		id, err := uuid.Parse(actorID)
+		if err != nil {
+			return err
+		}
+		history.ActorID = &id
+	}
+	if location != nil {
+		history.Location = &types.Point{
+			Lat: location.Latitude,
+			Lng: location.Longitude,
+		}
+	}
+
+	return c.WasteDropRequestStatusHistoryRepository.Create(tx, history)
 }
 
 // NEW: Helper method to find or create storage for waste bank
@@ -109,6 +289,77 @@ func (c *WasteDropRequestUsecase) findOrCreateWasteBankStorage(tx *gorm.DB, wast
 	return storage, nil
 }
 
+// Helper method to estimate the storage utilization once the handed over items are stored. Storages that reject
+// overflow refuse the drop, otherwise a warning is returned from the alert threshold on.
+func (c *WasteDropRequestUsecase) checkStorageCapacity(tx *gorm.DB, storage *entity.Storage, items []entity.WasteDropRequestItem) (*model.StorageCapacityWarning, error) {
+	capacity := storageCapacity(storage)
+	if capacity <= 0 {
+		return nil, nil
+	}
+
+	wasteTypeIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		if item.VerifiedWeight > 0 {
+			wasteTypeIDs = append(wasteTypeIDs, item.WasteTypeID)
+		}
+	}
+	if len(wasteTypeIDs) == 0 {
+		return nil, nil
+	}
+
+	wasteTypes, err := c.WasteTypeRepository.FindByIds(tx, wasteTypeIDs)
+	if err != nil {
+		c.Log.Warnf("Failed to find waste types: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	densities := make(map[uuid.UUID]float64, len(wasteTypes))
+	for i := range wasteTypes {
+		densities[wasteTypes[i].ID], _ = bulkDensity(&wasteTypes[i])
+	}
+
+	before, err := c.StorageRepository.GetUsedVolume(tx, storage.ID, DefaultBulkDensityKgsPerM3)
+	if err != nil {
+		c.Log.Warnf("Failed to get storage volume: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	after := before
+	for _, item := range items {
+		if item.VerifiedWeight <= 0 {
+			continue
+		}
+		density, ok := densities[item.WasteTypeID]
+		if !ok {
+			density = DefaultBulkDensityKgsPerM3
+		}
+		after += item.VerifiedWeight / density
+	}
+
+	percent := after / capacity * 100
+	if percent > 100 && storage.RejectOverflow {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+			"Storage would be at %.0f%% of its %.1f m3 capacity, transfer stock out before completing this drop", percent, capacity))
+	}
+	if percent < storage.CapacityAlertPercent {
+		return nil, nil
+	}
+
+	warning := &model.StorageCapacityWarning{
+		StorageID:            storage.ID.String(),
+		CapacityM3:           capacity,
+		VolumeBeforeM3:       before,
+		VolumeAfterM3:        after,
+		UtilizationPercent:   percent,
+		CapacityAlertPercent: storage.CapacityAlertPercent,
+		IsOverCapacity:       percent > 100,
+		Message:              fmt.Sprintf("Storage is at %.0f%% of its capacity, consider an outbound waste transfer", percent),
+	}
+	if warning.IsOverCapacity {
+		warning.Message = fmt.Sprintf("Storage is over capacity at %.0f%%, create an outbound waste transfer", percent)
+	}
+	c.Log.Warnf("Storage %s is at %.1f%% of its capacity after drop completion", storage.ID.String(), percent)
+	return warning, nil
+}
+
 // NEW: Helper method to add items to storage
 func (c *WasteDropRequestUsecase) addItemsToStorage(tx *gorm.DB, storageID uuid.UUID, items []entity.WasteDropRequestItem) error {
 	c.Log.Infof("Adding %d items to storage ID: %s", len(items), storageID.String())
@@ -121,8 +372,7 @@ func (c *WasteDropRequestUsecase) addItemsToStorage(tx *gorm.DB, storageID uuid.
 
 		// Check if storage item already exists for this waste type
 		var existingStorageItem entity.StorageItem
-		err := tx.Where("storage_id = ? AND waste_type_id = ?", storageID, item.WasteTypeID).
-			First(&existingStorageItem).Error
+		err := c.StorageItemRepository.FindByStorageAndWasteTypeForUpdate(tx, &existingStorageItem, storageID, item.WasteTypeID)
 
 		if err == nil {
 			// Storage item exists, add to existing weight
@@ -136,6 +386,11 @@ func (c *WasteDropRequestUsecase) addItemsToStorage(tx *gorm.DB, storageID uuid.
 				c.Log.Warnf("Failed to update existing storage item: %+v", err)
 				return err
 			}
+
+			if err := c.StockMovementRepository.Record(tx, &existingStorageItem, "drop_inbound", item.VerifiedWeight, "waste_drop_request", &item.RequestID, nil, ""); err != nil {
+				c.Log.Warnf("Failed to record stock movement: %+v", err)
+				return err
+			}
 		} else if err == gorm.ErrRecordNotFound {
 			// Storage item doesn't exist, create new one
 			c.Log.Infof("Creating new storage item for waste type %s with weight %f kg",
@@ -153,6 +408,11 @@ func (c *WasteDropRequestUsecase) addItemsToStorage(tx *gorm.DB, storageID uuid.
 				c.Log.Warnf("Failed to create new storage item: %+v", err)
 				return err
 			}
+
+			if err := c.StockMovementRepository.Record(tx, newStorageItem, "drop_inbound", item.VerifiedWeight, "waste_drop_request", &item.RequestID, nil, ""); err != nil {
+				c.Log.Warnf("Failed to record stock movement: %+v", err)
+				return err
+			}
 		} else {
 			// Database error
 			c.Log.Warnf("Database error while checking storage item: %+v", err)
@@ -164,6 +424,43 @@ func (c *WasteDropRequestUsecase) addItemsToStorage(tx *gorm.DB, storageID uuid.
 	return nil
 }
 
+// Helper method to lock a quote for booking and check it was made for this request
+func (c *WasteDropRequestUsecase) lockQuote(tx *gorm.DB, quoteID string, customerID uuid.UUID, wasteBankID *uuid.UUID, deliveryType string, location *model.LocationRequest) (*entity.WasteDropQuote, error) {
+	quote := new(entity.WasteDropQuote)
+	if err := c.WasteDropQuoteRepository.FindByIdForUpdate(tx, quote, quoteID); err != nil {
+		c.Log.Warnf("Failed to find quote by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+	if quote.CustomerID != customerID {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Quote belongs to another customer")
+	}
+	if wasteBankID == nil || quote.WasteBankID != *wasteBankID || quote.DeliveryType != deliveryType {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Quote was made for another waste bank or delivery type")
+	}
+	if quote.RequestID != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, "Quote has already been used")
+	}
+	if time.Now().After(quote.ExpiresAt) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Quote has expired")
+	}
+
+	// The quoted pickup fee depends on the distance, so the booking must be at the quoted location
+	if (quote.AppointmentLocation == nil) != (location == nil) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Quote was made for another appointment location")
+	}
+	if location != nil {
+		distance, err := c.WasteDropQuoteRepository.DistanceTo(tx, quoteID, location.Latitude, location.Longitude)
+		if err != nil {
+			c.Log.Warnf("Failed to compare quote location: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if distance == nil || *distance > wasteDropQuoteLocationToleranceMeters {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Quote was made for another appointment location")
+		}
+	}
+	return quote, nil
+}
+
 func (c *WasteDropRequestUsecase) Create(ctx context.Context, request *model.WasteDropRequestRequest) (*model.WasteDropRequestSimpleResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
@@ -186,6 +483,16 @@ func (c *WasteDropRequestUsecase) Create(ctx context.Context, request *model.Was
 		return nil, fiber.ErrBadRequest
 	}
 
+	var subscriptionID *uuid.UUID
+	if request.SubscriptionID != "" {
+		id, err := uuid.Parse(request.SubscriptionID)
+		if err != nil {
+			c.Log.Warnf("Invalid subscription ID: %+v", err)
+			return nil, fiber.ErrBadRequest
+		}
+		subscriptionID = &id
+	}
+
 	var wasteBankID *uuid.UUID
 	if request.WasteBankID != "" {
 		id, err := uuid.Parse(request.WasteBankID)
@@ -314,6 +621,25 @@ func (c *WasteDropRequestUsecase) Create(ctx context.Context, request *model.Was
 		}
 	}
 
+	// A quote locks in its prices and pickup fee for the waste types it covers
+	var quote *entity.WasteDropQuote
+	quotedItems := make(map[uuid.UUID]*entity.WasteDropQuoteItem)
+	if request.QuoteID != "" {
+		quote, err = c.lockQuote(tx, request.QuoteID, customerID, wasteBankID, request.DeliveryType, request.AppointmentLocation)
+		if err != nil {
+			c.Log.Warnf("Cannot book with quote %s: %+v", request.QuoteID, err)
+			return nil, err
+		}
+		for i := range quote.Items {
+			quotedItems[quote.Items[i].WasteTypeID] = &quote.Items[i]
+		}
+		for _, wasteTypeID := range wasteTypeIDs {
+			if quotedItems[wasteTypeID] == nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Waste type %s is not in the quote", wasteTypeID.String()))
+			}
+		}
+	}
+
 	wasteDropRequest := &entity.WasteDropRequest{
 		DeliveryType:         request.DeliveryType,
 		CustomerID:           customerID,
@@ -326,6 +652,7 @@ func (c *WasteDropRequestUsecase) Create(ctx context.Context, request *model.Was
 		AppointmentStartTime: appointmentStartTime,
 		AppointmentEndTime:   appointmentEndTime,
 		Notes:                request.Notes,
+		SubscriptionID:       subscriptionID,
 	}
 
 	// Handle appointment location if provided
@@ -336,6 +663,50 @@ func (c *WasteDropRequestUsecase) Create(ctx context.Context, request *model.Was
 		}
 	}
 
+	// Pickups outside every service area of the waste bank are flagged, or rejected when the bank asks for it
+	if wasteDropRequest.DeliveryType == "pickup" && wasteBankID != nil && wasteDropRequest.AppointmentLocation != nil {
+		hasAreas, covered, err := c.ServiceAreaRepository.Covers(tx, wasteBankID.String(), wasteDropRequest.AppointmentLocation.Lat, wasteDropRequest.AppointmentLocation.Lng)
+		if err != nil {
+			c.Log.Warnf("Failed to check service areas: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if hasAreas && !covered {
+			wasteBank := new(entity.WasteBankProfile)
+			if err := c.WasteBankRepository.FindByUserIDNoPreload(tx, wasteBank, wasteBankID.String()); err != nil {
+				c.Log.Warnf("Failed to find waste bank profile: %+v", err)
+				return nil, fiber.ErrNotFound
+			}
+			if wasteBank.RejectOutsideServiceArea {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Pickup location is outside the waste bank's service area")
+			}
+			wasteDropRequest.IsOutsideServiceArea = true
+		}
+	}
+
+	// The region of the appointment location, or of the customer without one
+	wasteDropRequest.RegionCode = customer.RegionCode
+	if wasteDropRequest.AppointmentLocation != nil {
+		regionCode, err := resolveRegionCode(tx, c.Log, c.RegionRepository, "", wasteDropRequest.AppointmentLocation, "", "")
+		if err != nil {
+			return nil, err
+		}
+		if regionCode != nil {
+			wasteDropRequest.RegionCode = regionCode
+		}
+	}
+
+	if quote != nil {
+		wasteDropRequest.QuoteID = &quote.ID
+		wasteDropRequest.PickupFee = quote.PickupFee
+		wasteDropRequest.TotalPrice = quote.TotalPrice
+	}
+
+	// Check opening hours and slot capacity, a full slot may waitlist the request
+	if err := c.AppointmentSlotUsecase.Book(tx, wasteDropRequest); err != nil {
+		c.Log.Warnf("Failed to book appointment slot: %+v", err)
+		return nil, err
+	}
+
 	if err := c.WasteDropRequestRepository.Create(tx, wasteDropRequest); err != nil {
 		c.Log.Warnf("Failed to create waste drop request: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -346,26 +717,26 @@ func (c *WasteDropRequestUsecase) Create(ctx context.Context, request *model.Was
 	for i, wasteTypeID := range wasteTypeIDs {
 		var pricePerKg int64 = 0
 
-		// Get price from WasteBankPricedType if waste bank is assigned
-		if wasteBankID != nil {
-			searchReq := &model.SearchWasteBankPricedTypeRequest{
-				WasteBankID: wasteBankID.String(),
-				WasteTypeID: wasteTypeID.String(),
-				Page:        1,
-				Size:        1,
-			}
-
-			pricedTypes, _, err := c.WasteBankPricedTypeRepository.Search(tx, searchReq)
-			if err != nil {
-				c.Log.Warnf("Failed to find price for waste bank %s and type %s: %+v",
-					wasteBankID.String(), wasteTypeID.String(), err)
-				// Continue with zero price if price not found
-			} else if len(pricedTypes) > 0 {
-				pricePerKg = pricedTypes[0].CustomPricePerKgs
+		var priceVersionID *uuid.UUID
+
+		// Lock in the quoted price, or the price version in effect now if waste bank is assigned
+		if quotedItem := quotedItems[wasteTypeID]; quotedItem != nil {
+			pricePerKg = quotedItem.QuotedPricePerKgs
+			priceVersionID = quotedItem.PriceVersionID
+		} else if wasteBankID != nil {
+			priceVersion := new(entity.WasteBankPriceVersion)
+			err := c.WasteBankPriceVersionRepository.FindEffective(tx, priceVersion, wasteBankID.String(), wasteTypeID.String(), time.Now())
+			if err == nil {
+				pricePerKg = priceVersion.PricePerKgs
+				priceVersionID = &priceVersion.ID
 				c.Log.Infof("Found price per kg: %d for waste type: %s", pricePerKg, wasteTypeID.String())
-			} else {
+			} else if err == gorm.ErrRecordNotFound {
 				c.Log.Warnf("No price found for waste bank %s and type %s, using default price 0",
 					wasteBankID.String(), wasteTypeID.String())
+			} else {
+				c.Log.Warnf("Failed to find price for waste bank %s and type %s: %+v",
+					wasteBankID.String(), wasteTypeID.String(), err)
+				// Continue with zero price if price not found
 			}
 		}
 
@@ -374,6 +745,7 @@ func (c *WasteDropRequestUsecase) Create(ctx context.Context, request *model.Was
 			WasteTypeID:         wasteTypeID,
 			Quantity:            request.Items.Quantities[i],
 			VerifiedPricePerKgs: pricePerKg,
+			PriceVersionID:      priceVersionID,
 			VerifiedWeight:      0.0, // Initial values
 			VerifiedSubtotal:    0,   // Initial values
 		}
@@ -384,6 +756,27 @@ func (c *WasteDropRequestUsecase) Create(ctx context.Context, request *model.Was
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if quote != nil {
+		if err := c.WasteDropQuoteRepository.MarkUsed(tx, quote.ID.String(), wasteDropRequest.ID.String()); err != nil {
+			c.Log.Warnf("Failed to mark quote as used: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := c.recordStatusHistory(tx, wasteDropRequest.ID, "", wasteDropRequest.Status, customerID.String(), customer.Role, "", request.AppointmentLocation); err != nil {
+		c.Log.Warnf("Failed to record status history: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// A failed auto-assignment leaves the request pending for the sweep instead of failing its creation
+	if wasteDropRequest.DeliveryType == "pickup" && wasteDropRequest.WasteBankID != nil {
+		tx.SavePoint("auto_assign")
+		if _, err := c.CollectorAssignmentUsecase.AutoAssign(tx, wasteDropRequest, AssignmentTriggerCreate); err != nil {
+			c.Log.Warnf("Failed to auto-assign collector: %+v", err)
+			tx.RollbackTo("auto_assign")
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed to commit transaction: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -393,7 +786,7 @@ func (c *WasteDropRequestUsecase) Create(ctx context.Context, request *model.Was
 }
 
 // Helper method to update customer profile with environmental impact
-func (c *WasteDropRequestUsecase) updateCustomerProfile(tx *gorm.DB, customerID uuid.UUID, totalWeight float64, itemCount int64) error {
+func (c *WasteDropRequestUsecase) updateCustomerProfile(tx *gorm.DB, customerID uuid.UUID, requestID uuid.UUID, itemCount int64) error {
 	// Find or create customer profile
 	customerProfile := &entity.CustomerProfile{}
 	err := c.CustomerRepository.FindByUserID(tx, customerProfile, customerID.String())
@@ -404,6 +797,7 @@ func (c *WasteDropRequestUsecase) updateCustomerProfile(tx *gorm.DB, customerID
 				UserID:        customerID,
 				CarbonDeficit: 0,
 				WaterSaved:    0,
+				EnergySaved:   0,
 				BagsStored:    0,
 				Trees:         0,
 			}
@@ -415,18 +809,18 @@ func (c *WasteDropRequestUsecase) updateCustomerProfile(tx *gorm.DB, customerID
 		}
 	}
 
-	// Calculate environmental impact based on waste weight
-	// These are example calculations - adjust based on your environmental impact formulas
-	carbonReduction := int64(totalWeight * 2.5) // 2.5kg CO2 reduction per kg waste
-	waterSaved := int64(totalWeight * 1000)     // 1 liter per gram of waste
-	bagsStored := itemCount                     // Each item represents a bag
-	treesSaved := int64(totalWeight / 10)       // 1 tree saved per 10kg of waste
+	// Environmental impact uses the factor of each item's waste type on its verified weight
+	impact, err := c.ImpactFactorRepository.SumImpactForRequest(tx, requestID.String())
+	if err != nil {
+		return err
+	}
 
 	// Update profile with accumulated values
-	customerProfile.CarbonDeficit += carbonReduction
-	customerProfile.WaterSaved += waterSaved
-	customerProfile.BagsStored += bagsStored
-	customerProfile.Trees += treesSaved
+	customerProfile.CarbonDeficit += int64(impact.Co2e)
+	customerProfile.WaterSaved += int64(impact.WaterLiters)
+	customerProfile.EnergySaved += int64(impact.EnergyKwh)
+	customerProfile.BagsStored += itemCount // Each item represents a bag
+	customerProfile.Trees += int64(impact.Trees)
 
 	return c.CustomerRepository.Update(tx, customerProfile)
 }
@@ -550,14 +944,36 @@ func (c *WasteDropRequestUsecase) Update(ctx context.Context, request *model.Upd
 		return nil, fiber.ErrNotFound
 	}
 
+	// Completion credits points and stock, so it only goes through Complete
+	if request.Status == "completed" {
+		c.Log.Warn("Cannot complete waste drop request through update")
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Use the complete endpoint to complete a request")
+	}
+
+	fromStatus := wasteDropRequest.Status
+
 	// Update fields if provided
 	if request.DeliveryType != "" {
 		wasteDropRequest.DeliveryType = request.DeliveryType
 	}
-	if request.Status != "" {
-		wasteDropRequest.Status = request.Status
-	}
 	if request.AssignedCollectorID != "" {
+		if fromStatus != "pending" && fromStatus != "assigned" {
+			c.Log.Warnf("Cannot assign collector to request with status %s", fromStatus)
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Collectors can only be assigned to pending or assigned requests")
+		}
+		if wasteDropRequest.IsWaitlisted {
+			c.Log.Warnf("Cannot assign collector to waitlisted request %s", wasteDropRequest.ID)
+			return nil, fiber.NewError(fiber.StatusConflict, "Waitlisted requests are assigned once they get a slot")
+		}
+		if request.ActorRole != "admin" && request.ActorRole != "system" && request.ActorRole != "waste_bank_unit" && request.ActorRole != "waste_bank_central" {
+			c.Log.Warnf("Role %s cannot assign collectors", request.ActorRole)
+			return nil, fiber.NewError(fiber.StatusForbidden, "Only waste banks can assign collectors")
+		}
+		if err := c.checkActorAccess(wasteDropRequest, request.ActorID, request.ActorRole); err != nil {
+			c.Log.Warnf("Actor %s cannot assign collector: %+v", request.ActorID, err)
+			return nil, err
+		}
+
 		collectorID, err := uuid.Parse(request.AssignedCollectorID)
 		if err != nil {
 			c.Log.Warnf("Invalid collector ID: %+v", err)
@@ -572,6 +988,41 @@ func (c *WasteDropRequestUsecase) Update(ctx context.Context, request *model.Upd
 		}
 
 		wasteDropRequest.AssignedCollectorID = &collectorID
+		wasteDropRequest.AssignedCollector = collector
+	}
+
+	// Re-sending "assigned" with a new collector is a reassignment, not a transition
+	reassigned := request.Status == "assigned" && fromStatus == "assigned" && request.AssignedCollectorID != ""
+	if request.Status != "" && !reassigned {
+		if err := c.validateStatusTransition(wasteDropRequest, request.Status, request.ActorID, request.ActorRole); err != nil {
+			c.Log.Warnf("Invalid status transition for request %s: %+v", wasteDropRequest.ID, err)
+			return nil, err
+		}
+		if request.Status == "assigned" && wasteDropRequest.AssignedCollectorID == nil {
+			c.Log.Warn("Cannot assign request without collector")
+			return nil, fiber.NewError(fiber.StatusBadRequest, "A collector is required to assign a request")
+		}
+		if request.Status == "cancelled" {
+			reasonCode, err := cancellationReasonCode(request.ActorRole, request.ReasonCode)
+			if err != nil {
+				c.Log.Warnf("Invalid cancellation reason code for request %s: %+v", wasteDropRequest.ID, err)
+				return nil, err
+			}
+			now := time.Now()
+			if reasonCode != "" {
+				wasteDropRequest.CancellationReasonCode = &reasonCode
+			}
+			wasteDropRequest.CancelledAt = &now
+		}
+		wasteDropRequest.Status = request.Status
+	}
+
+	// History only records transitions, a reassignment keeps the status as it is
+	if wasteDropRequest.Status != fromStatus {
+		if err := c.recordStatusHistory(tx, wasteDropRequest.ID, fromStatus, wasteDropRequest.Status, request.ActorID, request.ActorRole, request.Reason, request.Location); err != nil {
+			c.Log.Warnf("Failed to record status history: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
 	}
 
 	if err := c.WasteDropRequestRepository.Update(tx, wasteDropRequest); err != nil {
@@ -579,6 +1030,13 @@ func (c *WasteDropRequestUsecase) Update(ctx context.Context, request *model.Upd
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if wasteDropRequest.Status == "cancelled" && fromStatus != "cancelled" {
+		if err := c.AppointmentSlotUsecase.ReleaseSlot(tx, wasteDropRequest); err != nil {
+			c.Log.Warnf("Failed to release appointment slot: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed to commit transaction: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -587,6 +1045,31 @@ func (c *WasteDropRequestUsecase) Update(ctx context.Context, request *model.Upd
 	return converter.WasteDropRequestToSimpleResponse(wasteDropRequest), nil
 }
 
+// Helper method to price a handed over item from its weight, grade and deductions at position idx.
+// Contamination and moisture deductions are capped at the whole subtotal.
+func applyItemCompletion(item *entity.WasteDropRequestItem, items *model.CompleteWasteDropRequestItems, idx int) {
+	grade := "A"
+	if len(items.Grades) > 0 {
+		grade = items.Grades[idx]
+	}
+	var contamination, moisture float64
+	if len(items.ContaminationPercents) > 0 {
+		contamination = items.ContaminationPercents[idx]
+	}
+	if len(items.MoisturePercents) > 0 {
+		moisture = items.MoisturePercents[idx]
+	}
+	deduction := math.Min(contamination+moisture, 100)
+
+	item.VerifiedWeight = items.Weights[idx]
+	item.Grade = grade
+	item.GradeMultiplier = itemGradeMultipliers[grade]
+	item.ContaminationPercent = contamination
+	item.MoisturePercent = moisture
+	item.GrossSubtotal = int64(item.VerifiedWeight * float64(item.VerifiedPricePerKgs))
+	item.VerifiedSubtotal = int64(float64(item.GrossSubtotal) * item.GradeMultiplier * (100 - deduction) / 100)
+}
+
 func (c *WasteDropRequestUsecase) Complete(ctx context.Context, request *model.CompleteWasteDropRequest) (*model.WasteDropRequestSimpleResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
@@ -596,14 +1079,18 @@ func (c *WasteDropRequestUsecase) Complete(ctx context.Context, request *model.C
 		return nil, fiber.ErrBadRequest
 	}
 
-	if len(request.Items.WasteTypeIDs) != len(request.Items.Weights) {
-		c.Log.Warnf("WasteTypeIDs and Weights arrays must have same length")
+	items := request.Items
+	if len(items.WasteTypeIDs) != len(items.Weights) ||
+		(len(items.Grades) > 0 && len(items.Grades) != len(items.WasteTypeIDs)) ||
+		(len(items.ContaminationPercents) > 0 && len(items.ContaminationPercents) != len(items.WasteTypeIDs)) ||
+		(len(items.MoisturePercents) > 0 && len(items.MoisturePercents) != len(items.WasteTypeIDs)) {
+		c.Log.Warnf("Item arrays must have the same length as WasteTypeIDs")
 		return nil, fiber.ErrBadRequest
 	}
 
-	// Find waste drop request
+	// Lock the request so concurrent completions cannot credit it twice
 	wasteDropRequest := new(entity.WasteDropRequest)
-	if err := c.WasteDropRequestRepository.FindByID(tx, wasteDropRequest, request.ID); err != nil {
+	if err := c.WasteDropRequestRepository.FindByIdForUpdate(tx, wasteDropRequest, request.ID); err != nil {
 		c.Log.Warnf("Failed to find waste drop request by ID: %+v", err)
 		return nil, fiber.ErrNotFound
 	}
@@ -613,63 +1100,107 @@ func (c *WasteDropRequestUsecase) Complete(ctx context.Context, request *model.C
 		return nil, fiber.ErrBadRequest
 	}
 
-	// Parse waste type IDs and build map
-	weightMap := make(map[uuid.UUID]float64)
-	for i, idStr := range request.Items.WasteTypeIDs {
+	if err := c.validateStatusTransition(wasteDropRequest, "completed", request.ActorID, request.ActorRole); err != nil {
+		c.Log.Warnf("Invalid status transition for request %s: %+v", wasteDropRequest.ID, err)
+		return nil, err
+	}
+	fromStatus := wasteDropRequest.Status
+
+	// Parse waste type IDs and map them to their position in the item arrays
+	indexMap := make(map[uuid.UUID]int)
+	wasteTypeIDs := make([]uuid.UUID, len(items.WasteTypeIDs))
+	for i, idStr := range items.WasteTypeIDs {
 		id, err := uuid.Parse(idStr)
 		if err != nil {
 			c.Log.Warnf("Invalid waste type ID: %+v", err)
 			return nil, fiber.ErrBadRequest
 		}
-		weightMap[id] = request.Items.Weights[i]
+		if _, exists := indexMap[id]; exists {
+			c.Log.Warnf("Duplicate waste type ID: %s", idStr)
+			return nil, fiber.ErrBadRequest
+		}
+		indexMap[id] = i
+		wasteTypeIDs[i] = id
 	}
 
 	// Get existing items
 	var existingItems []entity.WasteDropRequestItem
-	if err := tx.Where("request_id = ?", wasteDropRequest.ID).Find(&existingItems).Error; err != nil {
+	if err := tx.Where("request_id = ? AND is_deleted = ?", wasteDropRequest.ID, false).Find(&existingItems).Error; err != nil {
 		c.Log.Warnf("Failed to find waste drop request items: %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
-	if len(existingItems) == 0 {
-		c.Log.Warnf("No waste drop request items found for request ID: %s", wasteDropRequest.ID)
-		return nil, fiber.ErrNotFound
-	}
-
-	var totalVerifiedPrice int64
-	var totalVerifiedWeight float64
-
-	// NEW: Calculate subtotal using price_per_kgs from the request item and verified weight
+	// Requested types that were not handed over are zeroed
+	requested := make(map[uuid.UUID]bool, len(existingItems))
 	for i := range existingItems {
-		weight, exists := weightMap[existingItems[i].WasteTypeID]
-		if !exists {
-			c.Log.Warnf("Weight not provided for waste type ID: %s", existingItems[i].WasteTypeID)
-			return nil, fiber.ErrBadRequest
+		requested[existingItems[i].WasteTypeID] = true
+		if idx, exists := indexMap[existingItems[i].WasteTypeID]; exists {
+			applyItemCompletion(&existingItems[i], items, idx)
+		} else {
+			c.Log.Infof("Waste type %s was not handed over, zeroing item", existingItems[i].WasteTypeID.String())
+			existingItems[i].VerifiedWeight = 0
+			existingItems[i].GrossSubtotal = 0
+			existingItems[i].VerifiedSubtotal = 0
 		}
 
-		// NEW: Use the price_per_kgs stored in the request item (set during creation)
-		pricePerKg := existingItems[i].VerifiedPricePerKgs
-		subtotal := int64(weight * float64(pricePerKg))
+		if err := tx.Save(&existingItems[i]).Error; err != nil {
+			c.Log.Warnf("Failed to update item: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
 
-		c.Log.Infof("Calculating subtotal for waste type %s: weight=%f, price_per_kg=%d, subtotal=%d",
-			existingItems[i].WasteTypeID.String(), weight, pricePerKg, subtotal)
+	// Types handed over on top of the request are priced at completion time
+	for i, wasteTypeID := range wasteTypeIDs {
+		if requested[wasteTypeID] {
+			continue
+		}
+
+		priceVersion := new(entity.WasteBankPriceVersion)
+		if err := c.WasteBankPriceVersionRepository.FindEffective(tx, priceVersion, wasteDropRequest.WasteBankID.String(), wasteTypeID.String(), time.Now()); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.Log.Warnf("Waste bank %s has no price for added waste type %s", wasteDropRequest.WasteBankID.String(), wasteTypeID.String())
+				return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Waste bank does not accept waste type %s", wasteTypeID.String()))
+			}
+			c.Log.Warnf("Failed to find price for added waste type %s: %+v", wasteTypeID.String(), err)
+			return nil, fiber.ErrInternalServerError
+		}
 
-		existingItems[i].VerifiedWeight = weight
-		existingItems[i].VerifiedSubtotal = subtotal
+		item := entity.WasteDropRequestItem{
+			RequestID:           wasteDropRequest.ID,
+			WasteTypeID:         wasteTypeID,
+			VerifiedPricePerKgs: priceVersion.PricePerKgs,
+			PriceVersionID:      &priceVersion.ID,
+			IsAddedAtCompletion: true,
+		}
+		applyItemCompletion(&item, items, i)
 
-		if err := tx.Save(&existingItems[i]).Error; err != nil {
-			c.Log.Warnf("Failed to update item: %+v", err)
+		if err := c.WasteDropRequestItemRepository.Create(tx, &item); err != nil {
+			c.Log.Warnf("Failed to create added item: %+v", err)
 			return nil, fiber.ErrInternalServerError
 		}
+		existingItems = append(existingItems, item)
+	}
 
-		totalVerifiedPrice += subtotal
-		totalVerifiedWeight += weight
+	var totalVerifiedPrice int64
+	var totalVerifiedWeight float64
+	var handedOverCount int64
+	for _, item := range existingItems {
+		c.Log.Infof("Calculated subtotal for waste type %s: weight=%f, price_per_kg=%d, grade=%s, gross=%d, subtotal=%d",
+			item.WasteTypeID.String(), item.VerifiedWeight, item.VerifiedPricePerKgs, item.Grade, item.GrossSubtotal, item.VerifiedSubtotal)
+		totalVerifiedPrice += item.VerifiedSubtotal
+		totalVerifiedWeight += item.VerifiedWeight
+		if item.VerifiedWeight > 0 {
+			handedOverCount++
+		}
 	}
 
-	if len(request.Items.WasteTypeIDs) != len(existingItems) {
-		c.Log.Warnf("Mismatch between request items (%d) and DB items (%d)",
-			len(request.Items.WasteTypeIDs), len(existingItems))
-		return nil, fiber.ErrBadRequest
+	// The pickup fee locked in by the quote comes out of the payout
+	totalVerifiedPrice = max(totalVerifiedPrice-wasteDropRequest.PickupFee, 0)
+
+	// Keep the quoted total so the customer can compare it with the payout
+	if wasteDropRequest.EstimatedTotalPrice == nil {
+		estimatedTotalPrice := wasteDropRequest.TotalPrice
+		wasteDropRequest.EstimatedTotalPrice = &estimatedTotalPrice
 	}
 
 	// Update main request
@@ -681,18 +1212,27 @@ func (c *WasteDropRequestUsecase) Complete(ctx context.Context, request *model.C
 		return nil, fiber.ErrInternalServerError
 	}
 
-	// Add totalVerifiedPrice to user's points
-	user := &entity.User{}
-	if err := tx.First(user, "id = ?", wasteDropRequest.CustomerID).Error; err != nil {
-		c.Log.Warnf("Failed to find user for point update: %+v", err)
+	if err := c.recordStatusHistory(tx, wasteDropRequest.ID, fromStatus, wasteDropRequest.Status, request.ActorID, request.ActorRole, request.Reason, request.Location); err != nil {
+		c.Log.Warnf("Failed to record status history: %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
-	user.Points += totalVerifiedPrice
-
-	if err := c.UserRepository.Update(tx, user); err != nil {
-		c.Log.Warnf("Failed to update user points: %+v", err)
-		return nil, fiber.ErrInternalServerError
+	// Credit totalVerifiedPrice to the customer's points through the ledger
+	if totalVerifiedPrice > 0 {
+		posting := &model.LedgerPostingRequest{
+			EntryType:     "drop_completion",
+			ReferenceType: "waste_drop_request",
+			ReferenceID:   wasteDropRequest.ID.String(),
+			Description:   "Points earned from waste drop request",
+			Lines: []model.LedgerPostingLine{
+				{AccountCode: LedgerAccountPointsIssuance, Asset: "points", Direction: "debit", Amount: totalVerifiedPrice},
+				{UserID: wasteDropRequest.CustomerID.String(), Asset: "points", Direction: "credit", Amount: totalVerifiedPrice},
+			},
+		}
+		if _, err := c.LedgerUsecase.Post(tx, posting); err != nil {
+			c.Log.Warnf("Failed to post user points: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
 	}
 
 	// NEW: Add items to waste bank storage
@@ -705,6 +1245,11 @@ func (c *WasteDropRequestUsecase) Complete(ctx context.Context, request *model.C
 		return nil, fiber.ErrInternalServerError
 	}
 
+	capacityWarning, err := c.checkStorageCapacity(tx, storage, existingItems)
+	if err != nil {
+		return nil, err
+	}
+
 	// Add all verified items to storage
 	if err := c.addItemsToStorage(tx, storage.ID, existingItems); err != nil {
 		c.Log.Warnf("Failed to add items to storage: %+v", err)
@@ -714,7 +1259,7 @@ func (c *WasteDropRequestUsecase) Complete(ctx context.Context, request *model.C
 	c.Log.Infof("Successfully added %d items to storage ID: %s", len(existingItems), storage.ID.String())
 
 	// Update related profiles
-	if err := c.updateCustomerProfile(tx, wasteDropRequest.CustomerID, totalVerifiedWeight, int64(len(existingItems))); err != nil {
+	if err := c.updateCustomerProfile(tx, wasteDropRequest.CustomerID, wasteDropRequest.ID, handedOverCount); err != nil {
 		c.Log.Warnf("Failed to update customer profile: %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
@@ -733,13 +1278,120 @@ func (c *WasteDropRequestUsecase) Complete(ctx context.Context, request *model.C
 		}
 	}
 
+	if err := c.DailyWasteRollupRepository.RefreshForWasteDropRequest(tx, wasteDropRequest.ID); err != nil {
+		c.Log.Warnf("Failed to refresh daily waste rollups: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed to commit transaction: %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
 	c.Log.Infof("Successfully completed waste drop request with storage integration")
-	return converter.WasteDropRequestToSimpleResponse(wasteDropRequest), nil
+	response := converter.WasteDropRequestToSimpleResponse(wasteDropRequest)
+	response.StorageCapacityWarning = capacityWarning
+	return response, nil
+}
+
+func (c *WasteDropRequestUsecase) GetHistory(ctx context.Context, request *model.GetWasteDropRequestHistory) ([]model.WasteDropRequestStatusHistoryResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	wasteDropRequest := new(entity.WasteDropRequest)
+	if err := c.WasteDropRequestRepository.FindByID(tx, wasteDropRequest, request.ID); err != nil {
+		c.Log.Warnf("Failed to find waste drop request by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	if request.ActorRole != "government" {
+		if err := c.checkActorAccess(wasteDropRequest, request.ActorID, request.ActorRole); err != nil {
+			c.Log.Warnf("Actor %s cannot view history of request %s", request.ActorID, request.ID)
+			return nil, err
+		}
+	}
+
+	histories, err := c.WasteDropRequestStatusHistoryRepository.FindByRequestID(tx, request.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to find status history: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.WasteDropRequestStatusHistoryResponse, len(histories))
+	for i, history := range histories {
+		responses[i] = *converter.WasteDropRequestStatusHistoryToResponse(&history)
+	}
+
+	return responses, nil
+}
+
+// GetBreakdown shows the per item pricing behind a request's payout next to the quoted total
+func (c *WasteDropRequestUsecase) GetBreakdown(ctx context.Context, request *model.GetWasteDropRequestBreakdown) (*model.WasteDropRequestBreakdownResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	wasteDropRequest := new(entity.WasteDropRequest)
+	if err := c.WasteDropRequestRepository.FindByID(tx, wasteDropRequest, request.ID); err != nil {
+		c.Log.Warnf("Failed to find waste drop request by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	if request.ActorRole != "government" {
+		if err := c.checkActorAccess(wasteDropRequest, request.ActorID, request.ActorRole); err != nil {
+			c.Log.Warnf("Actor %s cannot view breakdown of request %s", request.ActorID, request.ID)
+			return nil, err
+		}
+	}
+
+	items, err := c.WasteDropRequestItemRepository.FindByDropFormID(tx, wasteDropRequest.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to find waste drop request items: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Until completion the quoted total is still held in TotalPrice
+	estimatedTotalPrice := wasteDropRequest.TotalPrice
+	if wasteDropRequest.EstimatedTotalPrice != nil {
+		estimatedTotalPrice = *wasteDropRequest.EstimatedTotalPrice
+	}
+
+	responses := make([]model.WasteDropRequestItemSimpleResponse, 0, len(items))
+	for i := range items {
+		if items[i].IsDeleted {
+			continue
+		}
+		responses = append(responses, *converter.WasteDropRequestItemToSimpleResponse(&items[i]))
+	}
+
+	return &model.WasteDropRequestBreakdownResponse{
+		RequestID:           wasteDropRequest.ID.String(),
+		Status:              wasteDropRequest.Status,
+		EstimatedTotalPrice: estimatedTotalPrice,
+		PickupFee:           wasteDropRequest.PickupFee,
+		TotalPrice:          wasteDropRequest.TotalPrice,
+		Difference:          wasteDropRequest.TotalPrice - estimatedTotalPrice,
+		Items:               responses,
+	}, nil
 }
 
 func (c *WasteDropRequestUsecase) Search(ctx context.Context, request *model.SearchWasteDropRequest) ([]model.WasteDropRequestSimpleResponse, int64, error) {
@@ -811,6 +1463,20 @@ func (c *WasteDropRequestUsecase) Delete(ctx context.Context, request *model.Del
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if wasteDropRequest.Status != "cancelled" && wasteDropRequest.Status != "completed" {
+		if err := c.AppointmentSlotUsecase.ReleaseSlot(tx, wasteDropRequest); err != nil {
+			c.Log.Warnf("Failed to release appointment slot: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if wasteDropRequest.Status == "completed" {
+		if err := c.DailyWasteRollupRepository.RefreshForWasteDropRequest(tx, wasteDropRequest.ID); err != nil {
+			c.Log.Warnf("Failed to refresh daily waste rollups: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed to commit transaction: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -35,6 +35,9 @@ type WasteTransferRequestUsecase struct {
 	IndustryRepository          *repository.IndustryRepository
 	WasteBankRepository         *repository.WasteBankRepository
 	SalaryTransactionRepository *repository.SalaryTransactionRepository
+	RegionRepository            *repository.RegionRepository
+	DailyWasteRollupRepository  *repository.DailyWasteRollupRepository
+	StockMovementRepository     *repository.StockMovementRepository
 }
 
 func NewWasteTransferRequestUsecase(
@@ -50,6 +53,9 @@ func NewWasteTransferRequestUsecase(
 	industryRepository *repository.IndustryRepository,
 	wasteBankRepository *repository.WasteBankRepository,
 	salaryTransactionRepository *repository.SalaryTransactionRepository,
+	regionRepository *repository.RegionRepository,
+	dailyWasteRollupRepository *repository.DailyWasteRollupRepository,
+	stockMovementRepository *repository.StockMovementRepository,
 ) *WasteTransferRequestUsecase {
 	return &WasteTransferRequestUsecase{
 		DB:                                  db,
@@ -64,6 +70,9 @@ func NewWasteTransferRequestUsecase(
 		IndustryRepository:                  industryRepository,
 		WasteBankRepository:                 wasteBankRepository,
 		SalaryTransactionRepository:         salaryTransactionRepository,
+		RegionRepository:                    regionRepository,
+		DailyWasteRollupRepository:          dailyWasteRollupRepository,
+		StockMovementRepository:             stockMovementRepository,
 	}
 }
 
@@ -236,6 +245,18 @@ func (c *WasteTransferRequestUsecase) Create(ctx context.Context, request *model
 		}
 	}
 
+	// The region of the appointment location, or of the source user without one
+	wasteTransferRequest.RegionCode = sourceUser.RegionCode
+	if wasteTransferRequest.AppointmentLocation != nil {
+		regionCode, err := resolveRegionCode(tx, c.Log, c.RegionRepository, "", wasteTransferRequest.AppointmentLocation, "", "")
+		if err != nil {
+			return nil, err
+		}
+		if regionCode != nil {
+			wasteTransferRequest.RegionCode = regionCode
+		}
+	}
+
 	if err := c.WasteTransferRequestRepository.Create(tx, wasteTransferRequest); err != nil {
 		c.Log.Warnf("Failed to create waste transfer request: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -501,8 +522,9 @@ func (c *WasteTransferRequestUsecase) Update(ctx context.Context, request *model
 		return nil, fiber.ErrBadRequest
 	}
 
+	// Lock the request so concurrent cancellations cannot release its reserved stock twice
 	wasteTransferRequest := new(entity.WasteTransferRequest)
-	if err := c.WasteTransferRequestRepository.FindByID(tx, wasteTransferRequest, request.ID); err != nil {
+	if err := c.WasteTransferRequestRepository.FindByIdForUpdate(tx, wasteTransferRequest, request.ID); err != nil {
 		c.Log.Warnf("Failed to find waste transfer request by ID: %+v", err)
 		return nil, fiber.ErrNotFound
 	}
@@ -510,6 +532,21 @@ func (c *WasteTransferRequestUsecase) Update(ctx context.Context, request *model
 	// Store original status for comparison
 	originalStatus := wasteTransferRequest.Status
 
+	cancelling := request.Status == "cancelled" || request.Status == "rejected"
+	var reasonCode string
+	if cancelling {
+		if originalStatus != "pending" && originalStatus != "assigned" && originalStatus != "collecting" {
+			c.Log.Warnf("Cannot cancel waste transfer request %s in status %s", wasteTransferRequest.ID, originalStatus)
+			return nil, fiber.NewError(fiber.StatusConflict, "Only pending, assigned or collecting requests can be cancelled")
+		}
+		code, err := transferCancellationReasonCode(wasteTransferRequest, request.ActorID, request.ActorRole, request.ReasonCode)
+		if err != nil {
+			c.Log.Warnf("Invalid cancellation reason for request %s: %+v", wasteTransferRequest.ID, err)
+			return nil, err
+		}
+		reasonCode = code
+	}
+
 	// Update fields if provided
 	if request.FormType != "" {
 		wasteTransferRequest.FormType = request.FormType
@@ -543,44 +580,19 @@ func (c *WasteTransferRequestUsecase) Update(ctx context.Context, request *model
 	}
 
 	// FLOATING LOSS: Handle status change to cancelled/rejected
-	if request.Status != "" && (request.Status == "cancelled" || request.Status == "rejected") {
+	if cancelling {
 		// Only release reserved stock if transitioning FROM assigned/collecting status
 		if originalStatus == "assigned" || originalStatus == "collecting" {
 			c.Log.Infof("Releasing reserved stock due to status change from %s to %s", originalStatus, request.Status)
-
-			// Parse UUID for finding items
-			transferFormUUID, err := uuid.Parse(request.ID)
-			if err != nil {
-				c.Log.Warnf("Invalid transfer form ID: %+v", err)
-				return nil, fiber.ErrBadRequest
-			}
-
-			// Get current items to release their accepted weights
-			currentItems, err := c.WasteTransferItemOfferingRepository.FindByTransferFormID(tx, transferFormUUID)
-			if err != nil {
-				c.Log.Warnf("Failed to find waste transfer items: %+v", err)
-				return nil, fiber.ErrInternalServerError
-			}
-
-			// Find source storage
-			sourceStorage, err := c.findOrCreateRawMaterialStorage(tx, wasteTransferRequest.SourceUserID)
-			if err != nil {
-				c.Log.Warnf("Failed to find source storage: %+v", err)
+			if err := c.releaseReservedStock(tx, wasteTransferRequest); err != nil {
+				c.Log.Warnf("Failed to release reserved stock: %+v", err)
 				return nil, fiber.ErrInternalServerError
 			}
-
-			// Release reserved stock by adding back accepted weights
-			for _, item := range currentItems {
-				if item.AcceptedWeight > 0 {
-					if err := c.addBackWeightToStorage(tx, sourceStorage.ID, item.WasteTypeID, item.AcceptedWeight); err != nil {
-						c.Log.Warnf("Failed to release reserved stock: %+v", err)
-						return nil, fiber.ErrInternalServerError
-					}
-				}
-			}
-
-			c.Log.Infof("Successfully released reserved stock for cancelled/rejected transfer")
 		}
+
+		now := time.Now()
+		wasteTransferRequest.CancellationReasonCode = &reasonCode
+		wasteTransferRequest.CancelledAt = &now
 	}
 
 	if err := c.WasteTransferRequestRepository.Update(tx, wasteTransferRequest); err != nil {
@@ -733,6 +745,11 @@ func (c *WasteTransferRequestUsecase) CompleteRequest(ctx context.Context, reque
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if err := c.DailyWasteRollupRepository.RefreshForWasteTransferRequest(tx, wasteTransferRequest.ID); err != nil {
+		c.Log.Warnf("Failed to refresh daily waste rollups: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed to commit transaction: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -742,6 +759,125 @@ func (c *WasteTransferRequestUsecase) CompleteRequest(ctx context.Context, reque
 	return converter.WasteTransferRequestToSimpleResponse(wasteTransferRequest), nil
 }
 
+// RecycleRequest records how much recycled material the destination industry got out of each verified item
+// of a completed transfer and takes it out of the industry's raw material stock. Reporting again overwrites the
+// previous weights and moves only the difference.
+func (c *WasteTransferRequestUsecase) RecycleRequest(ctx context.Context, request *model.RecycleWasteTransferRequest) (*model.WasteTransferRequestSimpleResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if len(request.Items.WasteTypeIDs) != len(request.Items.Weights) {
+		c.Log.Warnf("WasteTypeIDs and Weights arrays must have same length")
+		return nil, fiber.ErrBadRequest
+	}
+
+	transferFormUUID, err := uuid.Parse(request.ID)
+	if err != nil {
+		c.Log.Warnf("Invalid transfer request ID: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	// Lock the request so concurrent reports cannot overwrite each other's recycled weights
+	wasteTransferRequest := new(entity.WasteTransferRequest)
+	if err := c.WasteTransferRequestRepository.FindByIdForUpdate(tx, wasteTransferRequest, request.ID); err != nil {
+		c.Log.Warnf("Failed to find waste transfer request by ID: %+v", err)
+		return nil, fiber.ErrNotFound
+	}
+
+	if request.ActorRole != "admin" && wasteTransferRequest.DestinationUserID.String() != request.ActorID {
+		return nil, fiber.ErrForbidden
+	}
+	if wasteTransferRequest.FormType != "industry_request" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Only industry transfers can be recycled")
+	}
+	if wasteTransferRequest.Status != "completed" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Can only recycle completed requests")
+	}
+
+	currentItems, err := c.WasteTransferItemOfferingRepository.FindByTransferFormID(tx, transferFormUUID)
+	if err != nil {
+		c.Log.Warnf("Failed to find waste transfer items: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	wasteTypeWeights := make(map[uuid.UUID]float64)
+	for i, wasteTypeIDStr := range request.Items.WasteTypeIDs {
+		wasteTypeID, err := uuid.Parse(wasteTypeIDStr)
+		if err != nil {
+			c.Log.Warnf("Invalid waste type ID: %+v", err)
+			return nil, fiber.ErrBadRequest
+		}
+		if request.Items.Weights[i] < 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("Weight at index %d must be non-negative", i))
+		}
+		wasteTypeWeights[wasteTypeID] = request.Items.Weights[i]
+	}
+
+	// Recycling consumes the raw material the transfer delivered into the industry's storage
+	destinationStorage, err := c.findOrCreateRawMaterialStorage(tx, wasteTransferRequest.DestinationUserID)
+	if err != nil {
+		c.Log.Warnf("Failed to find destination storage: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	now := time.Now().In(timezone.WIB)
+	var recycledDelta float64
+	updatedItemsCount := 0
+
+	for i := range currentItems {
+		recycledWeight, exists := wasteTypeWeights[currentItems[i].WasteTypeID]
+		if !exists {
+			continue
+		}
+		if recycledWeight > currentItems[i].VerifiedWeight {
+			return nil, fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("Recycled weight of waste type %s exceeds its verified weight", currentItems[i].WasteTypeID))
+		}
+
+		itemDelta := recycledWeight - currentItems[i].RecycledWeight
+		if itemDelta != 0 {
+			if err := c.consumeRecycledStock(tx, destinationStorage.ID, currentItems[i].WasteTypeID, itemDelta, wasteTransferRequest.ID, parseActorID(request.ActorID)); err != nil {
+				return nil, err
+			}
+		}
+
+		recycledDelta += itemDelta
+		currentItems[i].RecycledWeight = recycledWeight
+		currentItems[i].RecycledAt = &now
+
+		if err := c.WasteTransferItemOfferingRepository.UpdateRecycledWeight(tx, &currentItems[i]); err != nil {
+			c.Log.Warnf("Failed to update waste transfer item recycled weight: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		updatedItemsCount++
+	}
+
+	if updatedItemsCount != len(wasteTypeWeights) {
+		return nil, fiber.NewError(fiber.StatusBadRequest,
+			"Some waste types not found in this transfer request")
+	}
+
+	if recycledDelta != 0 {
+		if err := c.updateIndustryProfile(tx, wasteTransferRequest.DestinationUserID, 0, recycledDelta); err != nil {
+			c.Log.Warnf("Failed to update industry profile: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.WasteTransferRequestToSimpleResponse(wasteTransferRequest), nil
+}
+
 func (c *WasteTransferRequestUsecase) Search(ctx context.Context, request *model.SearchWasteTransferRequest) ([]model.WasteTransferRequestSimpleResponse, int64, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
@@ -821,6 +957,13 @@ func (c *WasteTransferRequestUsecase) Delete(ctx context.Context, request *model
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if wasteTransferRequest.Status == "completed" {
+		if err := c.DailyWasteRollupRepository.RefreshForWasteTransferRequest(tx, wasteTransferRequest.ID); err != nil {
+			c.Log.Warnf("Failed to refresh daily waste rollups: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed to commit transaction: %+v", err)
 		return nil, fiber.ErrInternalServerError
@@ -906,8 +1049,7 @@ func (c *WasteTransferRequestUsecase) subtractFromSourceStorage(tx *gorm.DB, sto
 
 		// Check if storage item exists for this waste type
 		var existingStorageItem entity.StorageItem
-		err := tx.Where("storage_id = ? AND waste_type_id = ?", storageID, item.WasteTypeID).
-			First(&existingStorageItem).Error
+		err := c.StorageItemRepository.FindByStorageAndWasteTypeForUpdate(tx, &existingStorageItem, storageID, item.WasteTypeID)
 
 		if err == nil {
 			// Storage item exists, subtract from existing weight
@@ -922,6 +1064,11 @@ func (c *WasteTransferRequestUsecase) subtractFromSourceStorage(tx *gorm.DB, sto
 			existingStorageItem.WeightKgs -= item.AcceptedWeight
 			existingStorageItem.UpdatedAt = time.Now()
 
+			if err := c.StockMovementRepository.Record(tx, &existingStorageItem, "transfer_reservation", -item.AcceptedWeight, "waste_transfer_request", &item.TransferFormID, nil, ""); err != nil {
+				c.Log.Warnf("Failed to record stock movement: %+v", err)
+				return err
+			}
+
 			// If weight becomes zero or negative, delete the storage item
 			if existingStorageItem.WeightKgs <= 0 {
 				c.Log.Infof("Deleting storage item for waste type %s as weight is now %f kg",
@@ -963,8 +1110,7 @@ func (c *WasteTransferRequestUsecase) addToDestinationStorage(tx *gorm.DB, stora
 
 		// Check if storage item already exists for this waste type
 		var existingStorageItem entity.StorageItem
-		err := tx.Where("storage_id = ? AND waste_type_id = ?", storageID, item.WasteTypeID).
-			First(&existingStorageItem).Error
+		err := c.StorageItemRepository.FindByStorageAndWasteTypeForUpdate(tx, &existingStorageItem, storageID, item.WasteTypeID)
 
 		if err == nil {
 			// Storage item exists, add to existing weight
@@ -978,6 +1124,11 @@ func (c *WasteTransferRequestUsecase) addToDestinationStorage(tx *gorm.DB, stora
 				c.Log.Warnf("Failed to update existing storage item: %+v", err)
 				return err
 			}
+
+			if err := c.StockMovementRepository.Record(tx, &existingStorageItem, "transfer_inbound", item.VerifiedWeight, "waste_transfer_request", &item.TransferFormID, nil, ""); err != nil {
+				c.Log.Warnf("Failed to record stock movement: %+v", err)
+				return err
+			}
 		} else if err == gorm.ErrRecordNotFound {
 			// Storage item doesn't exist, create new one
 			c.Log.Infof("Creating new storage item for waste type %s with weight %f kg",
@@ -995,6 +1146,11 @@ func (c *WasteTransferRequestUsecase) addToDestinationStorage(tx *gorm.DB, stora
 				c.Log.Warnf("Failed to create new storage item: %+v", err)
 				return err
 			}
+
+			if err := c.StockMovementRepository.Record(tx, newStorageItem, "transfer_inbound", item.VerifiedWeight, "waste_transfer_request", &item.TransferFormID, nil, ""); err != nil {
+				c.Log.Warnf("Failed to record stock movement: %+v", err)
+				return err
+			}
 		} else {
 			// Database error
 			c.Log.Warnf("Database error while checking storage item: %+v", err)
@@ -1006,6 +1162,51 @@ func (c *WasteTransferRequestUsecase) addToDestinationStorage(tx *gorm.DB, stora
 	return nil
 }
 
+// consumeRecycledStock takes the recycled weight out of the industry's raw material stock. A negative weight
+// gives stock back when a report is revised down.
+func (c *WasteTransferRequestUsecase) consumeRecycledStock(tx *gorm.DB, storageID uuid.UUID, wasteTypeID uuid.UUID, weight float64, transferRequestID uuid.UUID, actorID *uuid.UUID) error {
+	item := new(entity.StorageItem)
+	err := c.StorageItemRepository.FindByStorageAndWasteTypeForUpdate(tx, item, storageID, wasteTypeID)
+	if err == gorm.ErrRecordNotFound {
+		if weight > 0 {
+			return fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("Waste type %s not available in storage", wasteTypeID))
+		}
+		item = &entity.StorageItem{
+			StorageID:   storageID,
+			WasteTypeID: wasteTypeID,
+		}
+	} else if err != nil {
+		c.Log.Warnf("Failed to find storage item: %+v", err)
+		return fiber.ErrInternalServerError
+	} else if item.WeightKgs < weight {
+		return fiber.NewError(fiber.StatusBadRequest,
+			fmt.Sprintf("Insufficient stock for waste type %s: available %f kg, recycled %f kg",
+				wasteTypeID, item.WeightKgs, weight))
+	}
+
+	item.WeightKgs -= weight
+	item.UpdatedAt = time.Now()
+
+	if item.ID == uuid.Nil {
+		err = c.StorageItemRepository.Create(tx, item)
+	} else if item.WeightKgs <= 0 {
+		err = c.StorageItemRepository.Delete(tx, item)
+	} else {
+		err = c.StorageItemRepository.Update(tx, item)
+	}
+	if err != nil {
+		c.Log.Warnf("Failed to update storage item: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.StockMovementRepository.Record(tx, item, "recycling_consumption", -weight, "waste_transfer_request", &transferRequestID, actorID, ""); err != nil {
+		c.Log.Warnf("Failed to record stock movement: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
 func (c *WasteTransferRequestUsecase) updateIndustryProfile(tx *gorm.DB, userID uuid.UUID, wasteWeight float64, recycledWeight float64) error {
 	c.Log.Infof("Updating industry profile for user ID: %s with waste weight: %f, recycled weight: %f",
 		userID.String(), wasteWeight, recycledWeight)
@@ -1089,7 +1290,73 @@ func (c *WasteTransferRequestUsecase) updateWasteBankProfileTransfer(tx *gorm.DB
 	return nil
 }
 
-func (c *WasteTransferRequestUsecase) addBackWeightToStorage(tx *gorm.DB, storageID uuid.UUID, wasteTypeID uuid.UUID, weight float64) error {
+// Helper method to return the accepted weights reserved at assignment to the source storage
+func (c *WasteTransferRequestUsecase) releaseReservedStock(tx *gorm.DB, wasteTransferRequest *entity.WasteTransferRequest) error {
+	currentItems, err := c.WasteTransferItemOfferingRepository.FindByTransferFormID(tx, wasteTransferRequest.ID)
+	if err != nil {
+		return err
+	}
+
+	sourceStorage, err := c.findOrCreateRawMaterialStorage(tx, wasteTransferRequest.SourceUserID)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range currentItems {
+		if item.AcceptedWeight > 0 {
+			if err := c.addBackWeightToStorage(tx, sourceStorage.ID, item.WasteTypeID, item.AcceptedWeight, wasteTransferRequest.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	c.Log.Infof("Successfully released reserved stock for transfer request %s", wasteTransferRequest.ID)
+	return nil
+}
+
+// Helper method to cancel a request inside the caller's transaction, releasing stock reserved for it
+// Helper method to pick the cancellation reason code for the actor. The source withdraws its own
+// request as customer_cancelled, the destination rejects or reports a no-show, and expired is left
+// to the expiry job.
+func transferCancellationReasonCode(wasteTransferRequest *entity.WasteTransferRequest, actorID string, actorRole string, requested string) (string, error) {
+	switch {
+	case actorRole == "admin":
+		if requested == "" {
+			return "bank_rejected", nil
+		}
+		return requested, nil
+	case actorID == wasteTransferRequest.SourceUserID.String():
+		if requested != "" && requested != "customer_cancelled" {
+			return "", fiber.NewError(fiber.StatusForbidden, "The source can only cancel with reason code customer_cancelled")
+		}
+		return "customer_cancelled", nil
+	case actorID == wasteTransferRequest.DestinationUserID.String():
+		if requested == "" {
+			return "bank_rejected", nil
+		}
+		if requested != "bank_rejected" && requested != "no_show" {
+			return "", fiber.NewError(fiber.StatusForbidden, "The destination can only cancel with reason code bank_rejected or no_show")
+		}
+		return requested, nil
+	}
+	return "", fiber.NewError(fiber.StatusForbidden, "Only the source or destination can cancel this request")
+}
+
+func (c *WasteTransferRequestUsecase) cancelRequest(tx *gorm.DB, wasteTransferRequest *entity.WasteTransferRequest, reasonCode string) error {
+	if wasteTransferRequest.Status == "assigned" || wasteTransferRequest.Status == "collecting" {
+		if err := c.releaseReservedStock(tx, wasteTransferRequest); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	wasteTransferRequest.Status = "cancelled"
+	wasteTransferRequest.CancellationReasonCode = &reasonCode
+	wasteTransferRequest.CancelledAt = &now
+	return c.WasteTransferRequestRepository.Update(tx, wasteTransferRequest)
+}
+
+func (c *WasteTransferRequestUsecase) addBackWeightToStorage(tx *gorm.DB, storageID uuid.UUID, wasteTypeID uuid.UUID, weight float64, transferRequestID uuid.UUID) error {
 	if weight <= 0 {
 		return nil // No weight to add back
 	}
@@ -1098,8 +1365,7 @@ func (c *WasteTransferRequestUsecase) addBackWeightToStorage(tx *gorm.DB, storag
 
 	// Check if storage item exists for this waste type
 	var existingStorageItem entity.StorageItem
-	err := tx.Where("storage_id = ? AND waste_type_id = ?", storageID, wasteTypeID).
-		First(&existingStorageItem).Error
+	err := c.StorageItemRepository.FindByStorageAndWasteTypeForUpdate(tx, &existingStorageItem, storageID, wasteTypeID)
 
 	if err == nil {
 		// Storage item exists, add to existing weight
@@ -1110,6 +1376,11 @@ func (c *WasteTransferRequestUsecase) addBackWeightToStorage(tx *gorm.DB, storag
 			c.Log.Warnf("Failed to update existing storage item: %+v", err)
 			return err
 		}
+
+		if err := c.StockMovementRepository.Record(tx, &existingStorageItem, "transfer_release", weight, "waste_transfer_request", &transferRequestID, nil, ""); err != nil {
+			c.Log.Warnf("Failed to record stock movement: %+v", err)
+			return err
+		}
 	} else if err == gorm.ErrRecordNotFound {
 		// Storage item doesn't exist, create new one
 		newStorageItem := &entity.StorageItem{
@@ -1124,6 +1395,11 @@ func (c *WasteTransferRequestUsecase) addBackWeightToStorage(tx *gorm.DB, storag
 			c.Log.Warnf("Failed to create new storage item: %+v", err)
 			return err
 		}
+
+		if err := c.StockMovementRepository.Record(tx, newStorageItem, "transfer_release", weight, "waste_transfer_request", &transferRequestID, nil, ""); err != nil {
+			c.Log.Warnf("Failed to record stock movement: %+v", err)
+			return err
+		}
 	} else {
 		// Database error
 		c.Log.Warnf("Database error while checking storage item: %+v", err)
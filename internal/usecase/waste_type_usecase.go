@@ -48,9 +48,10 @@ func (u *WasteTypeUsecase) Create(ctx context.Context, request *model.WasteTypeR
 	}
 
 	wasteType := &entity.WasteType{
-		Name:        request.Name,
-		Description: request.Description,
-		CategoryID:  category.ID,
+		Name:                request.Name,
+		Description:         request.Description,
+		CategoryID:          category.ID,
+		BulkDensityKgsPerM3: request.BulkDensityKgsPerM3,
 	}
 
 	if err := u.WasteTypeRepository.Create(tx, wasteType); err != nil {
@@ -115,6 +116,9 @@ func (u *WasteTypeUsecase) Update(ctx context.Context, request *model.UpdateWast
 	if request.Description != "" {
 		wasteType.Description = request.Description
 	}
+	if request.BulkDensityKgsPerM3 != nil {
+		wasteType.BulkDensityKgsPerM3 = request.BulkDensityKgsPerM3
+	}
 
 	if err := u.WasteTypeRepository.Update(tx, wasteType); err != nil {
 		u.Log.Warnf("Update failed: %+v", err)
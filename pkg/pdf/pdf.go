@@ -0,0 +1,114 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page geometry in points, A4 portrait
+const (
+	pageWidth  = 595
+	pageHeight = 842
+	margin     = 40
+)
+
+// Document is a plain text PDF written with the built in Courier fonts, so nothing has to be embedded and
+// columns padded with spaces line up. Lines flow onto a new page when the current one is full.
+type Document struct {
+	FontSize float64
+	pages    []*bytes.Buffer
+	y        float64
+}
+
+func NewDocument() *Document {
+	return &Document{FontSize: 9}
+}
+
+func (d *Document) lineHeight() float64 {
+	return d.FontSize * 1.4
+}
+
+// AddPage starts a new page
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, new(bytes.Buffer))
+	d.y = pageHeight - margin
+}
+
+// Line writes a line of text, in bold when asked
+func (d *Document) Line(text string, bold bool) {
+	if len(d.pages) == 0 || d.y-d.lineHeight() < margin {
+		d.AddPage()
+	}
+	d.y -= d.lineHeight()
+
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %.1f Tf %d %.1f Td (%s) Tj ET\n", font, d.FontSize, margin, d.y, escape(text))
+}
+
+// Blank leaves an empty line
+func (d *Document) Blank() {
+	d.Line("", false)
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// Objects 1-4 are the catalog, the page tree and the two fonts, pages and their content follow in pairs
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 6+i*2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// escape quotes a string for a PDF literal, characters outside Latin-1 are replaced
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 255:
+			b.WriteByte('?')
+		case r > 127:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}